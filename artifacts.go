@@ -0,0 +1,287 @@
+// Package main implements multi-artifact releases for the Maven plugin.
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// ArtifactConfig describes one artifact of a multi-artifact release.
+// Empty fields inherit the top-level configuration.
+type ArtifactConfig struct {
+	GroupID    string
+	ArtifactID string
+	PomPath    string
+	Profiles   []string
+	Repository string
+	// Module selects a module of the reactor POM to deploy on its own.
+	Module string
+}
+
+// parseArtifacts parses the artifacts list from the raw configuration.
+func parseArtifacts(raw map[string]any) []ArtifactConfig {
+	var entries []map[string]any
+	switch v := raw["artifacts"].(type) {
+	case []map[string]any:
+		entries = v
+	case []any:
+		for _, item := range v {
+			if entry, ok := item.(map[string]any); ok {
+				entries = append(entries, entry)
+			} else {
+				// Keep the position so validation can report the bad entry.
+				entries = append(entries, nil)
+			}
+		}
+	}
+
+	artifacts := make([]ArtifactConfig, 0, len(entries))
+	for _, entry := range entries {
+		parser := helpers.NewConfigParser(entry)
+		artifacts = append(artifacts, ArtifactConfig{
+			GroupID:    parser.GetString("group_id", "", ""),
+			ArtifactID: parser.GetString("artifact_id", "", ""),
+			PomPath:    parser.GetString("pom_path", "", ""),
+			Profiles:   parser.GetStringSlice("profiles", nil),
+			Repository: parser.GetString("repository", "", ""),
+			Module:     parser.GetString("module", "", ""),
+		})
+	}
+	return artifacts
+}
+
+// artifactConfigs returns one configuration per artifact to deploy. Without an
+// artifacts list the configuration itself is the only artifact.
+func (cfg *Config) artifactConfigs() []*Config {
+	if len(cfg.Artifacts) == 0 {
+		return []*Config{cfg}
+	}
+
+	configs := make([]*Config, 0, len(cfg.Artifacts))
+	for _, artifact := range cfg.Artifacts {
+		c := *cfg
+		c.Artifacts = nil
+		if artifact.GroupID != "" {
+			c.GroupID = artifact.GroupID
+		}
+		if artifact.ArtifactID != "" {
+			c.ArtifactID = artifact.ArtifactID
+		}
+		if artifact.PomPath != "" {
+			c.PomPath = artifact.PomPath
+		}
+		if artifact.Profiles != nil {
+			c.Profiles = artifact.Profiles
+		}
+		if artifact.Repository != "" {
+			c.Repository = artifact.Repository
+			c.redirectDeploy = true
+		}
+		c.Module = artifact.Module
+		configs = append(configs, &c)
+	}
+	return configs
+}
+
+// forEachArtifact runs fn for every artifact configuration, on up to workers
+// goroutines when parallel is set. Artifacts built from the same POM share
+// the project's target directory and POM, so they still run one after the
+// other. Sequential runs stop at the first failure; artifacts that were not
+// attempted have a nil response.
+func forEachArtifact(configs []*Config, parallel bool, workers int, fn func(*Config) *plugin.ExecuteResponse) []*plugin.ExecuteResponse {
+	results := make([]*plugin.ExecuteResponse, len(configs))
+
+	if parallel {
+		var projects [][]int
+		seen := map[string]int{}
+		for i, c := range configs {
+			pomPath := filepath.Clean(c.PomPath)
+			j, ok := seen[pomPath]
+			if !ok {
+				j = len(projects)
+				seen[pomPath] = j
+				projects = append(projects, nil)
+			}
+			projects[j] = append(projects[j], i)
+		}
+		runPool(len(projects), workers, func(j int) {
+			for _, i := range projects[j] {
+				results[i] = fn(configs[i])
+			}
+		})
+		return results
+	}
+
+	for i, c := range configs {
+		results[i] = fn(c)
+		if !results[i].Success {
+			break
+		}
+	}
+	return results
+}
+
+// aggregateArtifacts combines per-artifact responses into a single response.
+// message is a format string receiving the number of artifacts.
+func aggregateArtifacts(configs []*Config, results []*plugin.ExecuteResponse, version, message string) *plugin.ExecuteResponse {
+	entries := make([]map[string]any, 0, len(results))
+	var failures []string
+	for i, resp := range results {
+		c := configs[i]
+		entry := map[string]any{}
+		if resp == nil {
+			entry["group_id"] = c.GroupID
+			entry["artifact_id"] = c.ArtifactID
+			entry["skipped"] = true
+			entries = append(entries, entry)
+			continue
+		}
+		for k, v := range resp.Outputs {
+			entry[k] = v
+		}
+		entry["group_id"] = c.GroupID
+		entry["artifact_id"] = c.ArtifactID
+		entry["success"] = resp.Success
+		if !resp.Success {
+			entry["error"] = resp.Error
+			failures = append(failures, fmt.Sprintf("%s:%s: %s", c.GroupID, c.ArtifactID, resp.Error))
+		}
+		entries = append(entries, entry)
+	}
+
+	outputs := map[string]any{
+		"version":   version,
+		"artifacts": entries,
+	}
+
+	if len(failures) > 0 {
+		return &plugin.ExecuteResponse{
+			Success: false,
+			Error:   fmt.Sprintf("%d of %d Maven artifacts failed:\n%s", len(failures), len(results), strings.Join(failures, "\n")),
+			Outputs: outputs,
+		}
+	}
+
+	return &plugin.ExecuteResponse{
+		Success: true,
+		Message: fmt.Sprintf(message, len(results)),
+		Outputs: outputs,
+	}
+}
+
+// deployArtifacts deploys every configured artifact and aggregates the results.
+func (p *MavenPlugin) deployArtifacts(ctx context.Context, cfg *Config, releaseCtx plugin.ReleaseContext, dryRun bool) (*plugin.ExecuteResponse, error) {
+	configs := cfg.deployConfigs()
+	if cfg.stage != nil {
+		for i, c := range configs {
+			c.stage = cfg.stage.artifacts[i]
+		}
+	}
+	results := forEachArtifact(configs, cfg.Parallel, cfg.MaxParallel, func(c *Config) *plugin.ExecuteResponse {
+		resp, err := p.deploy(ctx, c, releaseCtx, dryRun)
+		if err != nil {
+			return &plugin.ExecuteResponse{Success: false, Error: err.Error()}
+		}
+		return resp
+	})
+
+	message := "Deployed %d Maven artifacts"
+	if dryRun {
+		message = "Would deploy %d Maven artifacts"
+	}
+	return aggregateArtifacts(configs, results, releaseCtx.Version, message), nil
+}
+
+// rollbackArtifacts rolls back every configured artifact and aggregates the results.
+// All artifacts are attempted even when one of them fails.
+func (p *MavenPlugin) rollbackArtifacts(ctx context.Context, cfg *Config, releaseCtx plugin.ReleaseContext, dryRun bool) (*plugin.ExecuteResponse, error) {
+	configs := cfg.deployConfigs()
+	results := make([]*plugin.ExecuteResponse, len(configs))
+	for i, c := range configs {
+		resp, err := p.rollback(ctx, c, releaseCtx, dryRun)
+		if err != nil {
+			resp = &plugin.ExecuteResponse{Success: false, Error: err.Error()}
+		}
+		results[i] = resp
+	}
+
+	message := "Rolled back %d Maven artifacts"
+	if dryRun {
+		message = "Would roll back %d Maven artifacts"
+	}
+	return aggregateArtifacts(configs, results, releaseCtx.Version, message), nil
+}
+
+// validateArtifacts validates the artifacts list, checking the effective
+// configuration of each entry after inheriting top-level values.
+func validateArtifacts(vb *helpers.ValidationBuilder, config map[string]any, base *Config) {
+	if raw, ok := config["artifacts"]; ok {
+		switch raw.(type) {
+		case []any, []map[string]any:
+		default:
+			vb.AddError("artifacts", "artifacts must be a list of artifact objects")
+			return
+		}
+	}
+
+	if items, ok := config["artifacts"].([]any); ok {
+		for i, item := range items {
+			if _, ok := item.(map[string]any); !ok {
+				vb.AddError(fmt.Sprintf("artifacts[%d]", i), "artifact entry must be an object")
+			}
+		}
+	}
+
+	if len(base.Artifacts) == 0 {
+		vb.AddError("artifacts", "artifacts must list at least one artifact")
+		return
+	}
+
+	seen := map[string]int{}
+	for i, c := range base.artifactConfigs() {
+		field := func(name string) string { return fmt.Sprintf("artifacts[%d].%s", i, name) }
+
+		if err := validateMavenCoordinate(c.GroupID, "group_id"); err != nil {
+			vb.AddError(field("group_id"), err.Error())
+		}
+		if err := validateMavenCoordinate(c.ArtifactID, "artifact_id"); err != nil {
+			vb.AddError(field("artifact_id"), err.Error())
+		}
+
+		if err := validatePath(c.PomPath); err != nil {
+			vb.AddError(field("pom_path"), err.Error())
+		} else if c.CheckPOM {
+			if err := checkPOMFile(c.PomPath); err != nil {
+				vb.AddError(field("pom_path"), err.Error())
+			}
+		}
+
+		if err := validatePath(c.Module); err != nil {
+			vb.AddError(field("module"), err.Error())
+		}
+
+		for _, profile := range c.Profiles {
+			if err := validateProfile(profile); err != nil {
+				vb.AddError(field("profiles"), fmt.Sprintf("invalid profile '%s': %s", profile, err.Error()))
+			}
+		}
+
+		if c.Repository != base.Repository {
+			if err := validateRepositoryURL(c.Repository, base.networkPolicy()); err != nil {
+				vb.AddError(field("repository"), err.Error())
+			}
+		}
+
+		key := c.GroupID + ":" + c.ArtifactID
+		if j, dup := seen[key]; dup {
+			vb.AddError(field("artifact_id"), fmt.Sprintf("duplicate artifact %s (also artifacts[%d])", key, j))
+		} else {
+			seen[key] = i
+		}
+	}
+}
@@ -0,0 +1,387 @@
+// Package main provides tests for multi-artifact releases.
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func multiArtifactConfig() map[string]any {
+	return map[string]any{
+		"group_id": "com.example",
+		"profiles": []any{"release"},
+		"artifacts": []any{
+			map[string]any{"artifact_id": "core", "pom_path": "core/pom.xml"},
+			map[string]any{"artifact_id": "client", "pom_path": "client/pom.xml", "profiles": []any{"sign"}},
+			map[string]any{"group_id": "org.example", "artifact_id": "tools", "repository": "http://localhost:8081/repository/tools"},
+		},
+	}
+}
+
+func TestArtifactConfigs(t *testing.T) {
+	p := &MavenPlugin{}
+	cfg := p.parseConfig(multiArtifactConfig())
+
+	configs := cfg.artifactConfigs()
+	if len(configs) != 3 {
+		t.Fatalf("expected 3 artifact configs, got %d", len(configs))
+	}
+
+	tests := []struct {
+		groupID    string
+		artifactID string
+		pomPath    string
+		profiles   string
+		repository string
+	}{
+		{"com.example", "core", "core/pom.xml", "release", ""},
+		{"com.example", "client", "client/pom.xml", "sign", ""},
+		{"org.example", "tools", "pom.xml", "release", "http://localhost:8081/repository/tools"},
+	}
+	for i, tt := range tests {
+		c := configs[i]
+		if c.GroupID != tt.groupID || c.ArtifactID != tt.artifactID || c.PomPath != tt.pomPath ||
+			strings.Join(c.Profiles, ",") != tt.profiles || c.Repository != tt.repository {
+			t.Errorf("artifact %d: got %s:%s pom=%s profiles=%v repository=%s", i, c.GroupID, c.ArtifactID, c.PomPath, c.Profiles, c.Repository)
+		}
+		if len(c.Artifacts) != 0 {
+			t.Errorf("artifact %d: expected no nested artifacts", i)
+		}
+	}
+
+	single := p.parseConfig(map[string]any{"group_id": "com.example", "artifact_id": "my-app"})
+	if configs := single.artifactConfigs(); len(configs) != 1 || configs[0] != single {
+		t.Error("expected a single-artifact config to deploy itself")
+	}
+}
+
+func TestExecuteMultiArtifact(t *testing.T) {
+	for _, parallel := range []bool{false, true} {
+		name := "sequential"
+		if parallel {
+			name = "parallel"
+		}
+		t.Run(name, func(t *testing.T) {
+			mockExec := &MockCommandExecutor{}
+			p := &MavenPlugin{executor: mockExec}
+
+			config := multiArtifactConfig()
+			config["parallel"] = parallel
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook:    plugin.HookPostPublish,
+				Config:  config,
+				Context: plugin.ReleaseContext{Version: "1.2.0"},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !resp.Success {
+				t.Fatalf("expected success, got error: %s", resp.Error)
+			}
+			if resp.Message != "Deployed 3 Maven artifacts" {
+				t.Errorf("unexpected message: %s", resp.Message)
+			}
+			if len(mockExec.Calls) != 3 {
+				t.Fatalf("expected 3 deploys, got %d", len(mockExec.Calls))
+			}
+
+			var poms []string
+			for _, call := range mockExec.Calls {
+				poms = append(poms, call.Args[2])
+			}
+			if !parallel && strings.Join(poms, " ") != "core/pom.xml client/pom.xml pom.xml" {
+				t.Errorf("expected sequential deploys in order, got %v", poms)
+			}
+
+			artifacts, ok := resp.Outputs["artifacts"].([]map[string]any)
+			if !ok || len(artifacts) != 3 {
+				t.Fatalf("expected 3 artifact results, got %v", resp.Outputs["artifacts"])
+			}
+			for _, artifact := range artifacts {
+				if artifact["success"] != true || artifact["version"] != "1.2.0" {
+					t.Errorf("unexpected artifact result: %v", artifact)
+				}
+			}
+		})
+	}
+}
+
+func TestExecuteMultiArtifactFailure(t *testing.T) {
+	tests := []struct {
+		name      string
+		parallel  bool
+		wantCalls int
+	}{
+		{name: "sequential stops at first failure", wantCalls: 2},
+		{name: "parallel attempts every artifact", parallel: true, wantCalls: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockExec := &MockCommandExecutor{
+				RunFunc: func(_ context.Context, _ string, args ...string) ([]byte, error) {
+					if args[2] == "client/pom.xml" {
+						return []byte("401 Unauthorized"), errors.New("exit status 1")
+					}
+					return []byte("[INFO] BUILD SUCCESS"), nil
+				},
+			}
+			p := &MavenPlugin{executor: mockExec}
+
+			config := multiArtifactConfig()
+			config["parallel"] = tt.parallel
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook:    plugin.HookPostPublish,
+				Config:  config,
+				Context: plugin.ReleaseContext{Version: "1.2.0"},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Success {
+				t.Fatal("expected failure")
+			}
+			if !strings.Contains(resp.Error, "1 of 3 Maven artifacts failed") || !strings.Contains(resp.Error, "com.example:client") {
+				t.Errorf("unexpected error: %s", resp.Error)
+			}
+			if len(mockExec.Calls) != tt.wantCalls {
+				t.Errorf("expected %d deploys, got %d", tt.wantCalls, len(mockExec.Calls))
+			}
+
+			artifacts := resp.Outputs["artifacts"].([]map[string]any)
+			if artifacts[0]["success"] != true || artifacts[1]["success"] != false {
+				t.Errorf("unexpected artifact results: %v", artifacts)
+			}
+			if !tt.parallel && artifacts[2]["skipped"] != true {
+				t.Errorf("expected the remaining artifact to be skipped, got %v", artifacts[2])
+			}
+		})
+	}
+}
+
+func TestForEachArtifactSharedPOM(t *testing.T) {
+	configs := []*Config{
+		{ArtifactID: "core", PomPath: "core/pom.xml"},
+		{ArtifactID: "core_2.13", PomPath: "./core/pom.xml"},
+		{ArtifactID: "client", PomPath: "client/pom.xml"},
+	}
+
+	var mu sync.Mutex
+	running := map[string]bool{}
+	var overlaps []string
+	results := forEachArtifact(configs, true, 3, func(c *Config) *plugin.ExecuteResponse {
+		pom := strings.TrimPrefix(c.PomPath, "./")
+		mu.Lock()
+		if running[pom] {
+			overlaps = append(overlaps, c.ArtifactID)
+		}
+		running[pom] = true
+		mu.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+
+		mu.Lock()
+		running[pom] = false
+		mu.Unlock()
+		return &plugin.ExecuteResponse{Success: true}
+	})
+
+	for i, resp := range results {
+		if resp == nil || !resp.Success {
+			t.Errorf("expected artifact %d to be deployed, got %+v", i, resp)
+		}
+	}
+	if len(overlaps) > 0 {
+		t.Errorf("expected artifacts of the same POM to run one after the other, %v overlapped", overlaps)
+	}
+}
+
+func TestExecuteMultiArtifactDryRun(t *testing.T) {
+	mockExec := &MockCommandExecutor{}
+	p := &MavenPlugin{executor: mockExec}
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:    plugin.HookPostPublish,
+		Config:  multiArtifactConfig(),
+		Context: plugin.ReleaseContext{Version: "1.2.0"},
+		DryRun:  true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success || resp.Message != "Would deploy 3 Maven artifacts" {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	if len(mockExec.Calls) != 0 {
+		t.Errorf("expected no commands in dry run, got %d", len(mockExec.Calls))
+	}
+
+	artifacts := resp.Outputs["artifacts"].([]map[string]any)
+	command, _ := artifacts[1]["command"].(string)
+	if !strings.Contains(command, "-f client/pom.xml") || !strings.Contains(command, "-P sign") {
+		t.Errorf("unexpected command for client artifact: %s", command)
+	}
+}
+
+func TestExecutePerModuleProfiles(t *testing.T) {
+	mockExec := &MockCommandExecutor{}
+	p := &MavenPlugin{executor: mockExec}
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id": "com.example",
+			"profiles": []any{"sign"},
+			"artifacts": []any{
+				map[string]any{"artifact_id": "core", "module": "core"},
+				map[string]any{"artifact_id": "test-fixtures", "module": "test-fixtures", "profiles": []any{}},
+			},
+		},
+		Context: plugin.ReleaseContext{Version: "1.2.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+	if len(mockExec.Calls) != 2 {
+		t.Fatalf("expected 2 deploys, got %d", len(mockExec.Calls))
+	}
+
+	core := strings.Join(mockExec.Calls[0].Args, " ")
+	if !strings.Contains(core, "-f pom.xml -pl core") || !strings.Contains(core, "-P sign") {
+		t.Errorf("expected core to deploy with the signing profile, got %s", core)
+	}
+	fixtures := strings.Join(mockExec.Calls[1].Args, " ")
+	if !strings.Contains(fixtures, "-pl test-fixtures") || strings.Contains(fixtures, "-P") {
+		t.Errorf("expected test-fixtures to deploy without profiles, got %s", fixtures)
+	}
+}
+
+func TestValidateArtifacts(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    map[string]any
+		wantField string
+	}{
+		{
+			name:   "valid artifacts without top-level coordinates",
+			config: map[string]any{"artifacts": []any{map[string]any{"group_id": "com.example", "artifact_id": "core"}}},
+		},
+		{
+			name:   "valid artifacts inheriting group_id",
+			config: multiArtifactConfig(),
+		},
+		{
+			name:      "missing artifact_id",
+			config:    map[string]any{"group_id": "com.example", "artifacts": []any{map[string]any{"pom_path": "core/pom.xml"}}},
+			wantField: "artifacts[0].artifact_id",
+		},
+		{
+			name: "duplicate artifact",
+			config: map[string]any{"group_id": "com.example", "artifacts": []any{
+				map[string]any{"artifact_id": "core"},
+				map[string]any{"artifact_id": "core", "pom_path": "other/pom.xml"},
+			}},
+			wantField: "artifacts[1].artifact_id",
+		},
+		{
+			name:      "pom path traversal",
+			config:    map[string]any{"group_id": "com.example", "artifacts": []any{map[string]any{"artifact_id": "core", "pom_path": "../pom.xml"}}},
+			wantField: "artifacts[0].pom_path",
+		},
+		{
+			name:      "invalid profile",
+			config:    map[string]any{"group_id": "com.example", "artifacts": []any{map[string]any{"artifact_id": "core", "profiles": []any{"bad profile"}}}},
+			wantField: "artifacts[0].profiles",
+		},
+		{
+			name:      "insecure repository",
+			config:    map[string]any{"group_id": "com.example", "artifacts": []any{map[string]any{"artifact_id": "core", "repository": "http://example.com/maven"}}},
+			wantField: "artifacts[0].repository",
+		},
+		{
+			name:      "module path traversal",
+			config:    map[string]any{"group_id": "com.example", "artifacts": []any{map[string]any{"artifact_id": "core", "module": "../core"}}},
+			wantField: "artifacts[0].module",
+		},
+		{
+			name:      "entry not an object",
+			config:    map[string]any{"group_id": "com.example", "artifacts": []any{"core"}},
+			wantField: "artifacts[0]",
+		},
+		{
+			name:      "empty list",
+			config:    map[string]any{"artifacts": []any{}},
+			wantField: "artifacts",
+		},
+		{
+			name:      "not a list",
+			config:    map[string]any{"group_id": "com.example", "artifact_id": "my-app", "artifacts": "core"},
+			wantField: "artifacts",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &MavenPlugin{}
+			resp, err := p.Validate(context.Background(), tt.config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantField == "" {
+				if !resp.Valid {
+					t.Errorf("expected valid config, got errors: %v", resp.Errors)
+				}
+				return
+			}
+			found := false
+			for _, e := range resp.Errors {
+				if e.Field == tt.wantField {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("expected error on field %q, got %v", tt.wantField, resp.Errors)
+			}
+		})
+	}
+}
+
+func TestRollbackMultiArtifact(t *testing.T) {
+	p := &MavenPlugin{}
+	config := multiArtifactConfig()
+	config["repository"] = "http://localhost:8081/repository/maven-releases"
+	config["repository_type"] = repositoryTypeNexus
+	config["rollback_on_error"] = true
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:    plugin.HookOnError,
+		Config:  config,
+		Context: plugin.ReleaseContext{Version: "1.2.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+
+	artifacts := resp.Outputs["artifacts"].([]map[string]any)
+	if len(artifacts) != 3 {
+		t.Fatalf("expected 3 artifact results, got %d", len(artifacts))
+	}
+	for _, artifact := range artifacts {
+		if artifact["rolled_back"] != false {
+			t.Errorf("expected nothing to be rolled back without a recorded deploy, got %v", artifact)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+// Package main implements the append-only audit log of deploy operations.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// Audit record results.
+const (
+	auditResultSuccess = "success"
+	auditResultFailure = "failure"
+)
+
+// actorEnvVars lists the environment variables identifying who triggered a
+// release, checked in order: CI systems first, then the local user.
+var actorEnvVars = []string{
+	"GITHUB_ACTOR",
+	"GITLAB_USER_LOGIN",
+	"BUILDKITE_BUILD_CREATOR",
+	"CIRCLE_USERNAME",
+	"BUILD_USER_ID",
+	"USER",
+	"USERNAME",
+}
+
+// auditMu serializes appends to audit logs within the process so parallel
+// artifact deploys keep the hash chain intact.
+var auditMu sync.Mutex
+
+// auditRecord is one entry of the audit log. Each record carries the hash of
+// the previous record, so removing or editing an entry breaks the chain.
+type auditRecord struct {
+	Timestamp      string            `json:"timestamp"`
+	GroupID        string            `json:"group_id"`
+	ArtifactID     string            `json:"artifact_id"`
+	Version        string            `json:"version"`
+	Repository     string            `json:"repository,omitempty"`
+	Actor          string            `json:"actor,omitempty"`
+	RepositoryUser string            `json:"repository_user,omitempty"`
+	Tag            string            `json:"tag,omitempty"`
+	Commit         string            `json:"commit,omitempty"`
+	DryRun         bool              `json:"dry_run"`
+	Result         string            `json:"result"`
+	Error          string            `json:"error,omitempty"`
+	Digests        map[string]string `json:"digests,omitempty"`
+	PrevHash       string            `json:"prev_hash"`
+	Hash           string            `json:"hash"`
+}
+
+// releaseActor returns the identity that triggered the release.
+func releaseActor() string {
+	for _, name := range actorEnvVars {
+		if actor := os.Getenv(name); actor != "" {
+			return actor
+		}
+	}
+	return ""
+}
+
+// newAuditRecord describes the outcome of a deploy.
+func newAuditRecord(cfg *Config, releaseCtx plugin.ReleaseContext, dryRun bool, resp *plugin.ExecuteResponse, now time.Time) auditRecord {
+	rec := auditRecord{
+		Timestamp:      now.UTC().Format(time.RFC3339),
+		GroupID:        cfg.GroupID,
+		ArtifactID:     cfg.ArtifactID,
+		Version:        releaseCtx.Version,
+		Repository:     redactURL(cfg.targetRepository(releaseCtx.Version)),
+		Actor:          releaseActor(),
+		RepositoryUser: cfg.Username,
+		Tag:            releaseCtx.TagName,
+		Commit:         releaseCtx.CommitSHA,
+		DryRun:         dryRun,
+		Result:         auditResultFailure,
+	}
+	if resp != nil && resp.Success {
+		rec.Result = auditResultSuccess
+		for _, artifact := range cfg.deployedArtifacts(releaseCtx.Version) {
+			if rec.Digests == nil {
+				rec.Digests = map[string]string{}
+			}
+			rec.Digests[artifact.File] = "sha256:" + artifact.SHA256
+		}
+	} else if resp != nil {
+		rec.Error = resp.Error
+	}
+	return rec
+}
+
+// sealAuditRecord links the record to the previous one and sets its hash.
+func sealAuditRecord(rec *auditRecord, prevHash string) error {
+	rec.PrevHash = prevHash
+	rec.Hash = ""
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(data)
+	rec.Hash = hex.EncodeToString(sum[:])
+	return nil
+}
+
+// lastAuditHash returns the hash of the last record of an audit log, or ""
+// when the log is empty or missing.
+func lastAuditHash(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	var last []byte
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10<<20)
+	for scanner.Scan() {
+		if line := bytes.TrimSpace(scanner.Bytes()); len(line) > 0 {
+			last = append(last[:0], line...)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	if last == nil {
+		return "", nil
+	}
+	var rec auditRecord
+	if err := json.Unmarshal(last, &rec); err != nil {
+		return "", fmt.Errorf("last audit record is not valid JSON: %w", err)
+	}
+	return rec.Hash, nil
+}
+
+// checkAuditLog verifies that the audit log can be appended to, so a deploy
+// is not published without its record.
+func checkAuditLog(path string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// appendAuditRecord seals the record against the log and appends it as a
+// JSON line.
+func appendAuditRecord(path string, rec *auditRecord) error {
+	auditMu.Lock()
+	defer auditMu.Unlock()
+
+	prevHash, err := lastAuditHash(path)
+	if err != nil {
+		return err
+	}
+	if err := sealAuditRecord(rec, prevHash); err != nil {
+		return err
+	}
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// audit records the outcome of a deploy in the audit log and, when enabled,
+// in the outputs. A record that cannot be written fails the response.
+func (cfg *Config) audit(releaseCtx plugin.ReleaseContext, dryRun bool, resp *plugin.ExecuteResponse) *plugin.ExecuteResponse {
+	rec := newAuditRecord(cfg, releaseCtx, dryRun, resp, time.Now())
+	if cfg.AuditLog != "" {
+		if err := appendAuditRecord(cfg.AuditLog, &rec); err != nil {
+			msg := fmt.Sprintf("failed to write audit log: %v", err)
+			if resp == nil {
+				return &plugin.ExecuteResponse{Success: false, Error: msg}
+			}
+			if resp.Success {
+				resp.Error = msg
+			} else {
+				resp.Error += "; " + msg
+			}
+			resp.Success = false
+			return resp
+		}
+	} else {
+		_ = sealAuditRecord(&rec, "")
+	}
+	if cfg.AuditOutput && resp != nil {
+		if resp.Outputs == nil {
+			resp.Outputs = map[string]any{}
+		}
+		resp.Outputs["audit"] = rec
+	}
+	return resp
+}
@@ -0,0 +1,135 @@
+// Package main provides tests for the deploy audit log.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// readAuditLog reads the records of an audit log and checks the hash chain.
+func readAuditLog(t *testing.T, path string) []auditRecord {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var records []auditRecord
+	prevHash := ""
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var rec auditRecord
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("invalid audit record %q: %v", line, err)
+		}
+		check := rec
+		if err := sealAuditRecord(&check, prevHash); err != nil {
+			t.Fatal(err)
+		}
+		if rec.PrevHash != prevHash || rec.Hash != check.Hash {
+			t.Errorf("broken hash chain at %q", line)
+		}
+		prevHash = rec.Hash
+		records = append(records, rec)
+	}
+	return records
+}
+
+func TestExecuteAuditLog(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+	if err := os.WriteFile("pom.xml", []byte(`<project><artifactId>my-app</artifactId></project>`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	writeBuildOutput(t, dir, map[string]int{"my-app-1.0.0.jar": 10})
+	t.Setenv("GITHUB_ACTOR", "release-bot")
+
+	logPath := filepath.Join(dir, "audit.jsonl")
+	config := map[string]any{
+		"group_id":     "com.example",
+		"artifact_id":  "my-app",
+		"repository":   "http://localhost:8081/repository/maven-releases",
+		"username":     "deployer",
+		"password":     "secret",
+		"audit_log":    logPath,
+		"audit_output": true,
+	}
+	execute := func(mockExec *MockCommandExecutor, dryRun bool) *plugin.ExecuteResponse {
+		t.Helper()
+		resp, err := (&MavenPlugin{executor: mockExec}).Execute(context.Background(), plugin.ExecuteRequest{
+			Hook:    plugin.HookPostPublish,
+			Config:  config,
+			Context: plugin.ReleaseContext{Version: "1.0.0", TagName: "v1.0.0", CommitSHA: "abc123"},
+			DryRun:  dryRun,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return resp
+	}
+
+	if resp := execute(&MockCommandExecutor{}, true); !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+	resp := execute(&MockCommandExecutor{}, false)
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+	if rec, ok := resp.Outputs["audit"].(auditRecord); !ok || rec.Hash == "" {
+		t.Errorf("expected the audit record in outputs, got %v", resp.Outputs["audit"])
+	}
+	failing := &MockCommandExecutor{
+		RunFunc: func(context.Context, string, ...string) ([]byte, error) {
+			return []byte("401 Unauthorized"), errors.New("exit status 1")
+		},
+	}
+	if resp := execute(failing, false); resp.Success {
+		t.Fatal("expected the failing deploy to fail")
+	}
+
+	records := readAuditLog(t, logPath)
+	if len(records) != 3 {
+		t.Fatalf("expected 3 audit records, got %d", len(records))
+	}
+	if !records[0].DryRun || records[1].DryRun {
+		t.Errorf("unexpected dry-run flags: %+v", records)
+	}
+	deploy := records[1]
+	if deploy.Result != auditResultSuccess || deploy.Actor != "release-bot" || deploy.RepositoryUser != "deployer" ||
+		deploy.Tag != "v1.0.0" || deploy.Commit != "abc123" || deploy.Repository != "http://localhost:8081/repository/maven-releases" {
+		t.Errorf("unexpected deploy record: %+v", deploy)
+	}
+	if !strings.HasPrefix(deploy.Digests["my-app-1.0.0.jar"], "sha256:") {
+		t.Errorf("expected the jar digest, got %v", deploy.Digests)
+	}
+	if records[2].Result != auditResultFailure || !strings.Contains(records[2].Error, "Maven deploy failed") {
+		t.Errorf("unexpected failure record: %+v", records[2])
+	}
+}
+
+func TestExecuteAuditLogNotWritable(t *testing.T) {
+	mockExec := &MockCommandExecutor{}
+	resp, err := (&MavenPlugin{executor: mockExec}).Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":    "com.example",
+			"artifact_id": "my-app",
+			"audit_log":   filepath.Join(t.TempDir(), "missing", "audit.jsonl"),
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Success || !strings.Contains(resp.Error, "audit log is not writable") {
+		t.Errorf("expected an audit log error, got %+v", resp)
+	}
+	if len(mockExec.Calls) != 0 {
+		t.Errorf("expected no deploy without an audit log, got %d calls", len(mockExec.Calls))
+	}
+}
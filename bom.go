@@ -0,0 +1,181 @@
+// Package main implements updating downstream BOMs and version catalogs after a release.
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// versionPropertyPattern restricts BOM property and catalog key names.
+var versionPropertyPattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+
+// BOMUpdate describes a version property to bump in a downstream file.
+type BOMUpdate struct {
+	// Path is a POM (.xml) or Gradle version catalog (.toml) file.
+	Path string
+	// Property is the POM property or [versions] key holding the artifact version.
+	Property string
+}
+
+// bomUpdateResult reports the outcome of a single BOM update.
+type bomUpdateResult struct {
+	Path     string `json:"path"`
+	Property string `json:"property"`
+	Previous string `json:"previous"`
+	Version  string `json:"version"`
+	Changed  bool   `json:"changed"`
+}
+
+// parseBOMUpdates parses the bom_updates list from the raw configuration.
+func parseBOMUpdates(raw map[string]any) []BOMUpdate {
+	items, ok := raw["bom_updates"].([]any)
+	if !ok {
+		return nil
+	}
+	updates := make([]BOMUpdate, 0, len(items))
+	for _, item := range items {
+		entry, _ := item.(map[string]any)
+		parser := helpers.NewConfigParser(entry)
+		updates = append(updates, BOMUpdate{
+			Path:     parser.GetString("path", "", ""),
+			Property: parser.GetString("property", "", ""),
+		})
+	}
+	return updates
+}
+
+// validateBOMUpdate validates a single BOM update entry.
+func validateBOMUpdate(update BOMUpdate) (string, error) {
+	if update.Path == "" {
+		return "path", fmt.Errorf("path is required")
+	}
+	if err := validatePath(update.Path); err != nil {
+		return "path", err
+	}
+	if ext := filepath.Ext(update.Path); ext != ".xml" && ext != ".toml" {
+		return "path", fmt.Errorf("unsupported file type %q: must be a POM (.xml) or version catalog (.toml)", ext)
+	}
+	if !versionPropertyPattern.MatchString(update.Property) {
+		return "property", fmt.Errorf("property must be a POM property or catalog version key")
+	}
+	return "", nil
+}
+
+// updatePOMProperty sets every <property> element in a POM to version and
+// returns the updated content and the previous value.
+func updatePOMProperty(content []byte, property, version string) ([]byte, string, error) {
+	pattern := regexp.MustCompile(`(<` + regexp.QuoteMeta(property) + `>)\s*([^<]*?)\s*(</` + regexp.QuoteMeta(property) + `>)`)
+	match := pattern.FindSubmatch(content)
+	if match == nil {
+		return nil, "", fmt.Errorf("property <%s> not found", property)
+	}
+	updated := pattern.ReplaceAll(content, []byte("${1}"+strings.ReplaceAll(version, "$", "$$")+"${3}"))
+	return updated, string(match[2]), nil
+}
+
+// updateCatalogVersion sets a key of the [versions] table in a Gradle version
+// catalog to version and returns the updated content and the previous value.
+func updateCatalogVersion(content []byte, key, version string) ([]byte, string, error) {
+	text := string(content)
+	section := regexp.MustCompile(`(?m)^\s*\[versions\]\s*$`).FindStringIndex(text)
+	if section == nil {
+		return nil, "", fmt.Errorf("version catalog has no [versions] table")
+	}
+	start := section[1]
+	end := len(text)
+	if next := regexp.MustCompile(`(?m)^\s*\[`).FindStringIndex(text[start:]); next != nil {
+		end = start + next[0]
+	}
+
+	pattern := regexp.MustCompile(`(?m)^(\s*"?` + regexp.QuoteMeta(key) + `"?\s*=\s*")([^"]*)(")`)
+	table := text[start:end]
+	match := pattern.FindStringSubmatch(table)
+	if match == nil {
+		return nil, "", fmt.Errorf("version %q not found in [versions]", key)
+	}
+	table = pattern.ReplaceAllString(table, "${1}"+strings.ReplaceAll(version, "$", "$$")+"${3}")
+	return []byte(text[:start] + table + text[end:]), match[2], nil
+}
+
+// applyBOMUpdate updates the version in one file. The file is only written
+// when the version changes and dryRun is false.
+func applyBOMUpdate(update BOMUpdate, version string, dryRun bool) (*bomUpdateResult, error) {
+	content, err := os.ReadFile(update.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", update.Path, err)
+	}
+
+	var updated []byte
+	var previous string
+	if filepath.Ext(update.Path) == ".toml" {
+		updated, previous, err = updateCatalogVersion(content, update.Property, version)
+	} else {
+		updated, previous, err = updatePOMProperty(content, update.Property, version)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", update.Path, err)
+	}
+
+	result := &bomUpdateResult{
+		Path:     update.Path,
+		Property: update.Property,
+		Previous: previous,
+		Version:  version,
+		Changed:  previous != version,
+	}
+	if result.Changed && !dryRun {
+		info, err := os.Stat(update.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat %s: %w", update.Path, err)
+		}
+		if err := os.WriteFile(update.Path, updated, info.Mode().Perm()); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", update.Path, err)
+		}
+	}
+	return result, nil
+}
+
+// updateBOMs bumps the released version in every configured BOM or version
+// catalog and reports the modified files for a follow-up pull request.
+func (p *MavenPlugin) updateBOMs(cfg *Config, releaseCtx plugin.ReleaseContext, dryRun bool) (*plugin.ExecuteResponse, error) {
+	var results []*bomUpdateResult
+	var updatedFiles []string
+	for _, update := range cfg.BOMUpdates {
+		if _, err := validateBOMUpdate(update); err != nil {
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   fmt.Sprintf("invalid bom update: %v", err),
+			}, nil
+		}
+		result, err := applyBOMUpdate(update, releaseCtx.Version, dryRun)
+		if err != nil {
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   fmt.Sprintf("BOM update failed: %v", err),
+			}, nil
+		}
+		results = append(results, result)
+		if result.Changed {
+			updatedFiles = append(updatedFiles, result.Path)
+		}
+	}
+
+	message := fmt.Sprintf("Updated %d BOM files to %s", len(updatedFiles), releaseCtx.Version)
+	if dryRun {
+		message = fmt.Sprintf("Would update %d BOM files to %s", len(updatedFiles), releaseCtx.Version)
+	}
+	return &plugin.ExecuteResponse{
+		Success: true,
+		Message: message,
+		Outputs: map[string]any{
+			"bom_updates":   results,
+			"updated_files": updatedFiles,
+		},
+	}, nil
+}
@@ -0,0 +1,158 @@
+// Package main provides tests for downstream BOM updates.
+package main
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+const sampleBOM = `<project>
+  <modelVersion>4.0.0</modelVersion>
+  <properties>
+    <my-app.version>1.0.0</my-app.version>
+    <other.version>3.2.1</other.version>
+  </properties>
+</project>
+`
+
+const sampleCatalog = `[versions]
+other = "3.2.1"
+my-app = "1.0.0"
+
+[libraries]
+my-app = { module = "com.example:my-app", version.ref = "my-app" }
+`
+
+func TestUpdatePOMProperty(t *testing.T) {
+	updated, previous, err := updatePOMProperty([]byte(sampleBOM), "my-app.version", "1.1.0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if previous != "1.0.0" {
+		t.Errorf("expected previous 1.0.0, got %s", previous)
+	}
+	if want := strings.Replace(sampleBOM, "<my-app.version>1.0.0<", "<my-app.version>1.1.0<", 1); string(updated) != want {
+		t.Errorf("unexpected content:\n%s", updated)
+	}
+
+	if _, _, err := updatePOMProperty([]byte(sampleBOM), "missing.version", "1.1.0"); err == nil {
+		t.Error("expected error for a missing property")
+	}
+}
+
+func TestUpdateCatalogVersion(t *testing.T) {
+	updated, previous, err := updateCatalogVersion([]byte(sampleCatalog), "my-app", "1.1.0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if previous != "1.0.0" {
+		t.Errorf("expected previous 1.0.0, got %s", previous)
+	}
+	if want := strings.Replace(sampleCatalog, `my-app = "1.0.0"`, `my-app = "1.1.0"`, 1); string(updated) != want {
+		t.Errorf("unexpected content:\n%s", updated)
+	}
+
+	if _, _, err := updateCatalogVersion([]byte(sampleCatalog), "missing", "1.1.0"); err == nil {
+		t.Error("expected error for a missing version key")
+	}
+	if _, _, err := updateCatalogVersion([]byte("[libraries]\n"), "my-app", "1.1.0"); err == nil {
+		t.Error("expected error for a catalog without [versions]")
+	}
+}
+
+func TestExecuteOnSuccessBOMUpdates(t *testing.T) {
+	chdir(t, t.TempDir())
+	files := map[string]string{"platform/pom.xml": sampleBOM, "gradle/libs.versions.toml": sampleCatalog}
+	for path, content := range files {
+		if err := os.MkdirAll(strings.Split(path, "/")[0], 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	config := map[string]any{
+		"group_id":    "com.example",
+		"artifact_id": "my-app",
+		"bom_updates": []any{
+			map[string]any{"path": "platform/pom.xml", "property": "my-app.version"},
+			map[string]any{"path": "gradle/libs.versions.toml", "property": "my-app"},
+		},
+	}
+
+	for _, dryRun := range []bool{true, false} {
+		p := &MavenPlugin{}
+		resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+			Hook:    plugin.HookOnSuccess,
+			Config:  config,
+			Context: plugin.ReleaseContext{Version: "1.1.0"},
+			DryRun:  dryRun,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !resp.Success {
+			t.Fatalf("expected success, got error: %s", resp.Error)
+		}
+
+		updated, _ := resp.Outputs["updated_files"].([]string)
+		if strings.Join(updated, ",") != "platform/pom.xml,gradle/libs.versions.toml" {
+			t.Errorf("dry run %v: unexpected updated files %v", dryRun, updated)
+		}
+
+		bom, _ := os.ReadFile("platform/pom.xml")
+		if got := strings.Contains(string(bom), "<my-app.version>1.1.0</my-app.version>"); got == dryRun {
+			t.Errorf("dry run %v: unexpected BOM content:\n%s", dryRun, bom)
+		}
+	}
+}
+
+func TestValidateBOMUpdates(t *testing.T) {
+	tests := []struct {
+		name      string
+		update    any
+		wantField string
+	}{
+		{name: "valid pom", update: map[string]any{"path": "platform/pom.xml", "property": "my-app.version"}},
+		{name: "valid catalog", update: map[string]any{"path": "gradle/libs.versions.toml", "property": "my-app"}},
+		{name: "missing path", update: map[string]any{"property": "my-app.version"}, wantField: "bom_updates[0].path"},
+		{name: "unsupported file", update: map[string]any{"path": "gradle.properties", "property": "my-app"}, wantField: "bom_updates[0].path"},
+		{name: "path traversal", update: map[string]any{"path": "../platform/pom.xml", "property": "my-app"}, wantField: "bom_updates[0].path"},
+		{name: "invalid property", update: map[string]any{"path": "platform/pom.xml", "property": "<my-app>"}, wantField: "bom_updates[0].property"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &MavenPlugin{}
+			resp, err := p.Validate(context.Background(), map[string]any{
+				"group_id":    "com.example",
+				"artifact_id": "my-app",
+				"bom_updates": []any{tt.update},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantField == "" {
+				if !resp.Valid {
+					t.Errorf("expected valid config, got errors: %v", resp.Errors)
+				}
+				return
+			}
+			found := false
+			for _, e := range resp.Errors {
+				if e.Field == tt.wantField {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("expected error on field %q, got %v", tt.wantField, resp.Errors)
+			}
+		})
+	}
+}
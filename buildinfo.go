@@ -0,0 +1,141 @@
+// Package main implements the build metadata and the protocol handshake of the plugin.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime/debug"
+	"strconv"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// Build metadata set at link time, for example
+// -ldflags "-X main.version=v2.1.0 -X main.commit=$(git rev-parse HEAD) -X main.date=2026-01-02T15:04:05Z".
+var (
+	version string
+	commit  string
+	date    string
+)
+
+// defaultVersion is the plugin version reported when the build sets none.
+const defaultVersion = "2.0.0"
+
+// sdkModule is the module path of the plugin SDK.
+const sdkModule = "github.com/relicta-tech/relicta-plugin-sdk"
+
+// protocolVersionsEnv lists the plugin protocol versions the host accepts.
+const protocolVersionsEnv = "PLUGIN_PROTOCOL_VERSIONS"
+
+// BuildInfo describes the plugin binary.
+type BuildInfo struct {
+	Version         string `json:"version"`
+	Commit          string `json:"commit,omitempty"`
+	Date            string `json:"date,omitempty"`
+	SDKVersion      string `json:"sdk_version,omitempty"`
+	ProtocolVersion int    `json:"protocol_version"`
+}
+
+// currentBuildInfo returns the metadata of the running binary. Values not set
+// at link time fall back to the VCS stamp of go build.
+func currentBuildInfo() BuildInfo {
+	info := BuildInfo{
+		Version:         strings.TrimPrefix(version, "v"),
+		Commit:          commit,
+		Date:            date,
+		ProtocolVersion: plugin.ProtocolVersion,
+	}
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, s := range bi.Settings {
+			switch {
+			case s.Key == "vcs.revision" && info.Commit == "":
+				info.Commit = s.Value
+			case s.Key == "vcs.time" && info.Date == "":
+				info.Date = s.Value
+			}
+		}
+		for _, dep := range bi.Deps {
+			if dep.Path == sdkModule {
+				info.SDKVersion = dep.Version
+			}
+		}
+	}
+	if info.Version == "" {
+		info.Version = defaultVersion
+	}
+	return info
+}
+
+// String returns the metadata as printed by --version.
+func (b BuildInfo) String() string {
+	s := "maven plugin " + b.Version
+	if b.Commit != "" {
+		s += " (commit " + b.Commit
+		if b.Date != "" {
+			s += ", built " + b.Date
+		}
+		s += ")"
+	}
+	s += fmt.Sprintf(", plugin protocol %d", b.ProtocolVersion)
+	if b.SDKVersion != "" {
+		s += ", relicta-plugin-sdk " + b.SDKVersion
+	}
+	return s
+}
+
+// schema returns the metadata as the JSON of the x-build annotation.
+func (b BuildInfo) schema() string {
+	data, err := json.Marshal(b)
+	if err != nil {
+		return "{}"
+	}
+	return string(data)
+}
+
+// checkHostProtocol checks that the host accepts the protocol version of the
+// SDK the plugin was built with. versions is the comma-separated list the
+// host passes in PLUGIN_PROTOCOL_VERSIONS; hosts that pass none predate the
+// negotiation and are accepted.
+func checkHostProtocol(versions string) error {
+	if strings.TrimSpace(versions) == "" {
+		return nil
+	}
+	var accepted []int
+	for _, v := range strings.Split(versions, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil {
+			return fmt.Errorf("host sent an invalid %s %q", protocolVersionsEnv, versions)
+		}
+		if n == plugin.ProtocolVersion {
+			return nil
+		}
+		accepted = append(accepted, n)
+	}
+
+	lowest, highest := accepted[0], accepted[0]
+	for _, n := range accepted[1:] {
+		lowest, highest = min(lowest, n), max(highest, n)
+	}
+	switch {
+	case highest < plugin.ProtocolVersion:
+		return fmt.Errorf("the Relicta host is too old: it supports plugin protocol %s, this plugin requires protocol %d; upgrade Relicta or use an older release of the maven plugin",
+			versions, plugin.ProtocolVersion)
+	case lowest > plugin.ProtocolVersion:
+		return fmt.Errorf("the Relicta host is too new: it requires plugin protocol %s, this plugin speaks protocol %d; upgrade the maven plugin",
+			versions, plugin.ProtocolVersion)
+	default:
+		return fmt.Errorf("the Relicta host supports plugin protocol %s but not protocol %d of this plugin",
+			versions, plugin.ProtocolVersion)
+	}
+}
+
+// checkHostCookie checks the magic cookie the host passes to its plugins. A
+// different value is a host of another major release.
+func checkHostCookie(value string) error {
+	if value == "" || value == plugin.MagicCookieValue {
+		return nil
+	}
+	return fmt.Errorf("the Relicta host is incompatible: it sent %s=%q, this plugin expects %q",
+		plugin.MagicCookieKey, value, plugin.MagicCookieValue)
+}
@@ -0,0 +1,79 @@
+// Package main provides tests for the build metadata and the protocol handshake.
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestCheckHostProtocol(t *testing.T) {
+	tests := []struct {
+		name     string
+		versions string
+		errMsg   string
+	}{
+		{name: "not negotiated", versions: ""},
+		{name: "supported", versions: "1"},
+		{name: "supported among others", versions: "2, 1"},
+		{name: "host too new", versions: "2,3", errMsg: "host is too new"},
+		{name: "host too old", versions: "0", errMsg: "host is too old"},
+		{name: "invalid", versions: "one", errMsg: "invalid PLUGIN_PROTOCOL_VERSIONS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkHostProtocol(tt.versions)
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("expected error containing %q, got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestCheckHostCookie(t *testing.T) {
+	if err := checkHostCookie(""); err != nil {
+		t.Errorf("unexpected error without a cookie: %v", err)
+	}
+	if err := checkHostCookie(plugin.MagicCookieValue); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := checkHostCookie("relicta-v2"); err == nil || !strings.Contains(err.Error(), "incompatible") {
+		t.Errorf("expected an incompatible host error, got %v", err)
+	}
+}
+
+func TestGetInfoBuildMetadata(t *testing.T) {
+	oldVersion, oldCommit, oldDate := version, commit, date
+	t.Cleanup(func() { version, commit, date = oldVersion, oldCommit, oldDate })
+	version, commit, date = "v2.3.1", "abc1234", "2026-01-02T15:04:05Z"
+
+	info := (&MavenPlugin{}).GetInfo()
+	if info.Version != "2.3.1" {
+		t.Errorf("expected version 2.3.1, got %q", info.Version)
+	}
+	var schema struct {
+		Build BuildInfo `json:"x-build"`
+	}
+	if err := json.Unmarshal([]byte(info.ConfigSchema), &schema); err != nil {
+		t.Fatalf("config schema is not valid JSON: %v", err)
+	}
+	want := BuildInfo{Version: "2.3.1", Commit: "abc1234", Date: "2026-01-02T15:04:05Z", ProtocolVersion: plugin.ProtocolVersion}
+	schema.Build.SDKVersion = ""
+	if schema.Build != want {
+		t.Errorf("expected build metadata %+v, got %+v", want, schema.Build)
+	}
+
+	version = ""
+	if got := currentBuildInfo().Version; got != defaultVersion {
+		t.Errorf("expected the default version %s, got %q", defaultVersion, got)
+	}
+}
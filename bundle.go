@@ -0,0 +1,65 @@
+// Package main implements writing the Central bundle for manual upload.
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// centralBundleProblems lists what keeps a bundle from passing Central
+// validation: every file needs a signature, and jar projects must publish
+// sources and javadoc jars.
+func centralBundleProblems(files []deployFile, artifactID, version, packaging string) []string {
+	present := make(map[string]bool, len(files))
+	for _, f := range files {
+		present[f.Remote] = true
+	}
+
+	var problems []string
+	for _, f := range files {
+		if strings.HasSuffix(f.Remote, ".asc") || isChecksumFile(f.Remote) {
+			continue
+		}
+		if !present[f.Remote+".asc"] {
+			problems = append(problems, fmt.Sprintf("missing signature %s.asc", filepath.Base(f.Remote)))
+		}
+	}
+
+	if packaging == "" || packaging == "jar" {
+		dir := filepath.Dir(files[0].Remote)
+		base := artifactID + "-" + mavenVersion(version)
+		for _, classifier := range []string{"sources", "javadoc"} {
+			name := base + "-" + classifier + ".jar"
+			if !present[dir+"/"+name] {
+				problems = append(problems, fmt.Sprintf("missing %s jar %s", classifier, name))
+			}
+		}
+	}
+	return problems
+}
+
+// writeCentralBundle writes the Central bundle of the files to path instead of
+// uploading it. Bundles that Central would reject are not written.
+func writeCentralBundle(path string, files []deployFile, artifactID, version, packaging string) error {
+	if problems := centralBundleProblems(files, artifactID, version, packaging); len(problems) > 0 {
+		slices.Sort(problems)
+		return fmt.Errorf("the bundle would not pass Central validation:\n%s\nSign the artifacts (maven-gpg-plugin) and attach sources and javadoc jars (maven-source-plugin, maven-javadoc-plugin)", strings.Join(problems, "\n"))
+	}
+
+	bundle, err := centralBundle(files)
+	if err != nil {
+		return fmt.Errorf("failed to create bundle: %w", err)
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create bundle directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, bundle, 0o644); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+// Package main provides tests for writing the Central bundle.
+package main
+
+import (
+	"archive/zip"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestCentralBundleProblems(t *testing.T) {
+	dir := "com/example/my-app/1.0.0/"
+	files := []deployFile{
+		{Remote: dir + "my-app-1.0.0.pom"},
+		{Remote: dir + "my-app-1.0.0.pom.asc"},
+		{Remote: dir + "my-app-1.0.0.pom.md5"},
+		{Remote: dir + "my-app-1.0.0.jar"},
+		{Remote: dir + "my-app-1.0.0-sources.jar"},
+		{Remote: dir + "my-app-1.0.0-sources.jar.asc"},
+	}
+
+	problems := centralBundleProblems(files, "my-app", "1.0.0", "jar")
+	want := []string{"missing signature my-app-1.0.0.jar.asc", "missing javadoc jar my-app-1.0.0-javadoc.jar"}
+	if strings.Join(problems, ";") != strings.Join(want, ";") {
+		t.Errorf("expected %v, got %v", want, problems)
+	}
+
+	if problems := centralBundleProblems(files[:3], "my-app", "1.0.0", "pom"); len(problems) != 0 {
+		t.Errorf("expected a signed pom project to pass, got %v", problems)
+	}
+}
+
+func TestExecuteBundlePath(t *testing.T) {
+	writeHTTPDeployProject(t)
+	config := map[string]any{
+		"group_id":          "com.example",
+		"artifact_id":       "my-app",
+		"deployer":          "http",
+		"bundle_path":       "dist/bundle.zip",
+		"central_pom_check": "off",
+	}
+	execute := func() *plugin.ExecuteResponse {
+		t.Helper()
+		p := &MavenPlugin{}
+		resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+			Hook:    plugin.HookPostPublish,
+			Config:  config,
+			Context: plugin.ReleaseContext{Version: "1.0.0"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return resp
+	}
+
+	resp := execute()
+	if resp.Success || !strings.Contains(resp.Error, "would not pass Central validation") || !strings.Contains(resp.Error, "missing signature my-app-1.0.0.pom.asc") {
+		t.Fatalf("expected a Central validation error, got %+v", resp)
+	}
+	if fileExists(filepath.Join("dist", "bundle.zip")) {
+		t.Fatal("expected no bundle to be written")
+	}
+
+	for _, name := range []string{"my-app-1.0.0.pom.asc", "my-app-1.0.0-sources.jar.asc", "my-app-1.0.0-javadoc.jar.asc"} {
+		if err := os.WriteFile(filepath.Join("target", name), []byte("signature"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeJar(t, filepath.Join("target", "my-app-1.0.0-javadoc.jar"), "index.html")
+
+	resp = execute()
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+	if resp.Outputs["bundle_path"] != "dist/bundle.zip" {
+		t.Errorf("unexpected outputs: %v", resp.Outputs)
+	}
+
+	zr, err := zip.OpenReader(filepath.Join("dist", "bundle.zip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer zr.Close()
+	names := map[string]bool{}
+	for _, f := range zr.File {
+		names[f.Name] = true
+	}
+	for _, name := range []string{"my-app-1.0.0.pom", "my-app-1.0.0.pom.asc", "my-app-1.0.0.jar.sha1", "my-app-1.0.0-javadoc.jar.md5"} {
+		if !names["com/example/my-app/1.0.0/"+name] {
+			t.Errorf("expected %s in the bundle, got %v", name, names)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+// Package main implements the canary consumer build run after a deploy.
+package main
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// canaryRepositoryID is the server ID of the deploy repository in the canary project.
+const canaryRepositoryID = "relicta-canary"
+
+// canarySource is the single class compiled by the canary project.
+const canarySource = `package canary;
+
+public class Canary {
+}
+`
+
+// canaryDependency is the <dependency> of the canary project.
+type canaryDependency struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	Type       string `xml:"type,omitempty"`
+}
+
+// canaryRepository is a <repository> of the canary project.
+type canaryRepository struct {
+	ID        string `xml:"id"`
+	URL       string `xml:"url"`
+	Releases  bool   `xml:"releases>enabled"`
+	Snapshots bool   `xml:"snapshots>enabled"`
+}
+
+// canaryProject is the POM of the throwaway consumer project.
+type canaryProject struct {
+	XMLName      xml.Name           `xml:"project"`
+	Xmlns        string             `xml:"xmlns,attr"`
+	ModelVersion string             `xml:"modelVersion"`
+	GroupID      string             `xml:"groupId"`
+	ArtifactID   string             `xml:"artifactId"`
+	Version      string             `xml:"version"`
+	Dependencies []canaryDependency `xml:"dependencies>dependency"`
+	Repositories []canaryRepository `xml:"repositories>repository"`
+}
+
+// canaryDependencyType returns the dependency type for a packaging; packagings
+// producing a jar use the default type.
+func canaryDependencyType(packaging string) string {
+	switch packaging {
+	case "", "jar", "bundle", "maven-plugin", "eclipse-plugin":
+		return ""
+	}
+	return packaging
+}
+
+// renderCanaryPOM renders the POM of a project depending on the released GAV
+// and resolving it from the deploy repository.
+func renderCanaryPOM(dependency canaryDependency, repoURL string) ([]byte, error) {
+	doc := canaryProject{
+		Xmlns:        "http://maven.apache.org/POM/4.0.0",
+		ModelVersion: "4.0.0",
+		GroupID:      "dev.relicta.canary",
+		ArtifactID:   "canary",
+		Version:      "1.0.0",
+		Dependencies: []canaryDependency{dependency},
+		Repositories: []canaryRepository{{ID: canaryRepositoryID, URL: repoURL, Releases: true, Snapshots: true}},
+	}
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to render canary POM: %w", err)
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+// targetCredentials returns the credentials of the repository the version is
+// deployed to. Snapshot credentials fall back to the release credentials.
+func (cfg *Config) targetCredentials(version string) (string, string) {
+	if strings.HasSuffix(version, "-SNAPSHOT") && cfg.SnapshotRepository != "" && (cfg.SnapshotUsername != "" || cfg.SnapshotPassword != "") {
+		return cfg.SnapshotUsername, cfg.SnapshotPassword
+	}
+	return cfg.Username, cfg.Password
+}
+
+// consumerSettings writes a settings file holding the deploy repository
+// credentials under the given server ID, merged into the user's settings file
+// when one is configured. It returns no path when there is nothing to write.
+func (cfg *Config) consumerSettings(id, version string) (string, func(), error) {
+	username, password := cfg.targetCredentials(version)
+	if username == "" && password == "" && cfg.userSettings() == "" {
+		return "", func() {}, nil
+	}
+	var servers []settingsServer
+	if username != "" || password != "" {
+		servers = append(servers, settingsServer{ID: id, Username: username, Password: password, Configuration: cfg.serverConfiguration()})
+	}
+	data, err := cfg.resolveSettings(servers)
+	if err != nil {
+		return "", nil, err
+	}
+	return writeSettings(data)
+}
+
+// canaryBuild compiles a throwaway project depending on the released GAV
+// against the deploy repository, with an empty local repository so every
+// dependency is resolved from the remote repositories.
+func (p *MavenPlugin) canaryBuild(ctx context.Context, cfg *Config, version string) error {
+	version = mavenVersion(version)
+	dir, err := os.MkdirTemp("", "relicta-maven-canary-*")
+	if err != nil {
+		return fmt.Errorf("failed to create canary project: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	dependency := canaryDependency{
+		GroupID:    cfg.GroupID,
+		ArtifactID: cfg.ArtifactID,
+		Version:    version,
+		Type:       canaryDependencyType(cfg.packaging()),
+	}
+	pom, err := renderCanaryPOM(dependency, cfg.targetRepository(version))
+	if err != nil {
+		return err
+	}
+	sourceDir := filepath.Join(dir, "src", "main", "java", "canary")
+	if err := os.MkdirAll(sourceDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create canary project: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(sourceDir, "Canary.java"), []byte(canarySource), 0o600); err != nil {
+		return fmt.Errorf("failed to create canary project: %w", err)
+	}
+	pomPath := filepath.Join(dir, "pom.xml")
+	if err := os.WriteFile(pomPath, pom, 0o600); err != nil {
+		return fmt.Errorf("failed to create canary project: %w", err)
+	}
+
+	args := []string{"-B", "-f", pomPath, "-Dmaven.repo.local=" + filepath.Join(dir, "repository")}
+	args = append(args, cfg.isolationArgs(false)...)
+	settingsPath, cleanup, err := cfg.consumerSettings(canaryRepositoryID, version)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	if settingsPath != "" {
+		args = append(args, "-s", settingsPath)
+	}
+	args = append(args, "compile")
+
+	output, err := p.runMavenCommand(ctx, cfg, args...)
+	if err != nil {
+		return fmt.Errorf("consumer of %s:%s:%s does not build: %s\nOutput: %s",
+			cfg.GroupID, cfg.ArtifactID, version, describeExecError(cfg.mavenCommand(), err), cfg.errorOutput(output))
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+// Package main provides tests for the canary consumer build.
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestRenderCanaryPOM(t *testing.T) {
+	data, err := renderCanaryPOM(canaryDependency{GroupID: "com.example", ArtifactID: "my-bom", Version: "1.0.0", Type: canaryDependencyType("pom")}, "http://localhost:8081/repository/maven-releases")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pom, err := parsePOM(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatalf("canary POM does not parse: %v", err)
+	}
+	if pom.ArtifactID != "canary" {
+		t.Errorf("unexpected canary POM:\n%s", data)
+	}
+	for _, want := range []string{
+		"<artifactId>my-bom</artifactId>",
+		"<type>pom</type>",
+		"<id>relicta-canary</id>",
+		"<url>http://localhost:8081/repository/maven-releases</url>",
+		"<snapshots>\n        <enabled>true</enabled>",
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected %q in canary POM:\n%s", want, data)
+		}
+	}
+
+	if typ := canaryDependencyType("jar"); typ != "" {
+		t.Errorf("expected the default type for jars, got %q", typ)
+	}
+}
+
+func TestExecuteCanaryBuild(t *testing.T) {
+	config := map[string]any{
+		"group_id":     "com.example",
+		"artifact_id":  "my-app",
+		"repository":   "http://localhost:8081/repository/maven-releases",
+		"username":     "deployer",
+		"password":     "secret",
+		"canary_build": true,
+	}
+
+	run := func(t *testing.T, canaryErr error) (*plugin.ExecuteResponse, []string, string, string) {
+		t.Helper()
+		var canaryArgs []string
+		var pom, settings string
+		mockExec := &MockCommandExecutor{
+			RunFunc: func(_ context.Context, _ string, args ...string) ([]byte, error) {
+				if !slices.Contains(args, "compile") {
+					return []byte("[INFO] BUILD SUCCESS"), nil
+				}
+				canaryArgs = args
+				for i, arg := range args {
+					switch {
+					case arg == "-f" && i+1 < len(args):
+						data, _ := os.ReadFile(args[i+1])
+						pom = string(data)
+					case arg == "-s" && i+1 < len(args):
+						data, _ := os.ReadFile(args[i+1])
+						settings = string(data)
+					}
+				}
+				if canaryErr != nil {
+					return []byte("[ERROR] Could not resolve dependencies"), canaryErr
+				}
+				return []byte("[INFO] BUILD SUCCESS"), nil
+			},
+		}
+		p := &MavenPlugin{executor: mockExec}
+		resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+			Hook:    plugin.HookPostPublish,
+			Config:  config,
+			Context: plugin.ReleaseContext{Version: "1.0.0"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return resp, canaryArgs, pom, settings
+	}
+
+	t.Run("passed", func(t *testing.T) {
+		resp, args, pom, settings := run(t, nil)
+		if !resp.Success {
+			t.Fatalf("expected success, got error: %s", resp.Error)
+		}
+		if resp.Outputs["canary_build"] != true {
+			t.Errorf("expected the canary build in outputs, got %v", resp.Outputs)
+		}
+		if !strings.Contains(strings.Join(args, " "), "-Dmaven.repo.local=") {
+			t.Errorf("expected an empty local repository, got %v", args)
+		}
+		if !strings.Contains(pom, "<version>1.0.0</version>") || !strings.Contains(pom, "<url>http://localhost:8081/repository/maven-releases</url>") {
+			t.Errorf("unexpected canary POM:\n%s", pom)
+		}
+		if !strings.Contains(settings, "<id>relicta-canary</id>") || !strings.Contains(settings, "<username>deployer</username>") {
+			t.Errorf("expected the deploy credentials for the canary repository, got:\n%s", settings)
+		}
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		resp, _, _, _ := run(t, errors.New("exit status 1"))
+		if resp.Success || !strings.Contains(resp.Error, "canary build failed") || !strings.Contains(resp.Error, "Could not resolve dependencies") {
+			t.Errorf("expected a canary build failure, got %+v", resp)
+		}
+	})
+}
+
+func TestValidateCanaryBuild(t *testing.T) {
+	p := &MavenPlugin{}
+	resp, err := p.Validate(context.Background(), map[string]any{
+		"group_id":     "com.example",
+		"artifact_id":  "my-app",
+		"canary_build": true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	found := false
+	for _, e := range resp.Errors {
+		if e.Field == "canary_build" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected an error for canary_build without a repository, got %v", resp.Errors)
+	}
+}
@@ -0,0 +1,105 @@
+// Package main implements checking POMs against the Maven Central requirements.
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// inheritedPOMElements are the elements Maven Central requires besides the
+// name. Unlike the name, they may be inherited from a parent POM.
+var inheritedPOMElements = []struct {
+	name string
+	has  func(*pomModel) bool
+}{
+	{"description", func(m *pomModel) bool { return strings.TrimSpace(m.Description) != "" }},
+	{"url", func(m *pomModel) bool { return strings.TrimSpace(m.URL) != "" }},
+	{"licenses", func(m *pomModel) bool {
+		for _, license := range m.Licenses {
+			if strings.TrimSpace(license.Name) != "" {
+				return true
+			}
+		}
+		return false
+	}},
+	{"developers", func(m *pomModel) bool {
+		for _, developer := range m.Developers {
+			if strings.TrimSpace(developer.Name) != "" || strings.TrimSpace(developer.ID) != "" {
+				return true
+			}
+		}
+		return false
+	}},
+	{"scm/connection", func(m *pomModel) bool { return m.SCM != nil && strings.TrimSpace(m.SCM.Connection) != "" }},
+	{"scm/developerConnection", func(m *pomModel) bool { return m.SCM != nil && strings.TrimSpace(m.SCM.DeveloperConnection) != "" }},
+	{"scm/url", func(m *pomModel) bool { return m.SCM != nil && strings.TrimSpace(m.SCM.URL) != "" }},
+}
+
+// chainHas reports whether any POM of the chain satisfies has.
+func chainHas(chain []*pomModel, has func(*pomModel) bool) bool {
+	for _, model := range chain {
+		if has(model) {
+			return true
+		}
+	}
+	return false
+}
+
+// centralPOMProblems lists the elements Maven Central requires that are
+// missing from a POM. The chain holds the POM followed by its parents; the
+// name is not inherited, everything else may come from a parent. When the
+// chain ends in a parent that is not available locally, inherited elements
+// cannot be checked and are assumed present.
+func centralPOMProblems(chain []*pomModel) []string {
+	var problems []string
+	if strings.TrimSpace(chain[0].Name) == "" {
+		problems = append(problems, "name")
+	}
+	if chain[len(chain)-1].Parent.ArtifactID != "" {
+		return problems
+	}
+	for _, element := range inheritedPOMElements {
+		if !chainHas(chain, element.has) {
+			problems = append(problems, element.name)
+		}
+	}
+	return problems
+}
+
+// publishedPOMChain reads a published POM with its local parents. A flattened
+// POM is read on its own since it already contains the inherited elements.
+func publishedPOMChain(path string) ([]*pomModel, error) {
+	if filepath.Base(path) == ".flattened-pom.xml" {
+		pom, err := readPOM(path)
+		if err != nil {
+			return nil, err
+		}
+		return []*pomModel{pom}, nil
+	}
+	return readPOMChain(path)
+}
+
+// checkCentralPOMs checks the POM published by every deployed module against
+// the Maven Central requirements. Elements supplied by pom_metadata are
+// injected at deploy time and not reported. Modules whose POM cannot be read
+// are skipped; Maven reports those itself.
+func (cfg *Config) checkCentralPOMs() error {
+	var problems []string
+	for _, module := range cfg.deployedModules() {
+		chain, err := publishedPOMChain(module.deployedPOM())
+		if err != nil || len(chain) == 0 {
+			continue
+		}
+
+		missing := slices.DeleteFunc(centralPOMProblems(chain), cfg.POMMetadata.provides)
+		if len(missing) > 0 {
+			problems = append(problems, fmt.Sprintf("%s:%s is missing %s", module.GroupID, module.ArtifactID, strings.Join(missing, ", ")))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("POM does not meet the Maven Central requirements: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
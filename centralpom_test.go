@@ -0,0 +1,144 @@
+// Package main provides tests for the Maven Central POM requirements check.
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// completePOMMetadata holds every element Maven Central requires besides the name.
+const completePOMMetadata = `<description>An example</description>
+<url>https://example.com/my-app</url>
+<licenses><license><name>Apache-2.0</name><url>https://www.apache.org/licenses/LICENSE-2.0</url></license></licenses>
+<developers><developer><id>jdoe</id><name>J. Doe</name></developer></developers>
+<scm>
+  <connection>scm:git:https://github.com/example/my-app.git</connection>
+  <developerConnection>scm:git:ssh://git@github.com/example/my-app.git</developerConnection>
+  <url>https://github.com/example/my-app</url>
+</scm>`
+
+func TestCentralPOMProblems(t *testing.T) {
+	tests := []struct {
+		name string
+		poms map[string]string
+		want string
+	}{
+		{
+			name: "complete",
+			poms: map[string]string{"pom.xml": `<project><artifactId>my-app</artifactId><name>My App</name>` + completePOMMetadata + `</project>`},
+		},
+		{
+			name: "empty",
+			poms: map[string]string{"pom.xml": `<project><artifactId>my-app</artifactId></project>`},
+			want: "name, description, url, licenses, developers, scm/connection, scm/developerConnection, scm/url",
+		},
+		{
+			name: "inherited from local parent",
+			poms: map[string]string{
+				"pom.xml":        `<project><artifactId>parent</artifactId>` + completePOMMetadata + `</project>`,
+				"my-app/pom.xml": `<project><parent><artifactId>parent</artifactId></parent><artifactId>my-app</artifactId><name>My App</name></project>`,
+			},
+		},
+		{
+			name: "name is not inherited",
+			poms: map[string]string{
+				"pom.xml":        `<project><artifactId>parent</artifactId><name>Parent</name>` + completePOMMetadata + `</project>`,
+				"my-app/pom.xml": `<project><parent><artifactId>parent</artifactId></parent><artifactId>my-app</artifactId></project>`,
+			},
+			want: "name",
+		},
+		{
+			name: "parent not available locally",
+			poms: map[string]string{
+				"my-app/pom.xml": `<project><parent><artifactId>external-parent</artifactId></parent><artifactId>my-app</artifactId><name>My App</name><scm><url>https://github.com/example/my-app</url></scm></project>`,
+			},
+		},
+		{
+			name: "incomplete scm and unnamed license",
+			poms: map[string]string{"pom.xml": `<project><artifactId>my-app</artifactId><name>My App</name><description>d</description><url>https://example.com</url>
+<licenses><license><url>https://example.com/license</url></license></licenses><developers><developer><name>J. Doe</name></developer></developers>
+<scm><url>https://github.com/example/my-app</url></scm></project>`},
+			want: "licenses, scm/connection, scm/developerConnection",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.poms {
+				path := filepath.Join(dir, filepath.FromSlash(name))
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			pomPath := filepath.Join(dir, "my-app", "pom.xml")
+			if _, ok := tt.poms["my-app/pom.xml"]; !ok {
+				pomPath = filepath.Join(dir, "pom.xml")
+			}
+
+			chain, err := readPOMChain(pomPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := strings.Join(centralPOMProblems(chain), ", "); got != tt.want {
+				t.Errorf("expected missing %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestExecuteCentralPOMCheck(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+	if err := os.WriteFile("pom.xml", []byte(`<project><artifactId>my-app</artifactId><version>1.0.0</version></project>`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	config := map[string]any{
+		"group_id":    "com.example",
+		"artifact_id": "my-app",
+		"repository":  "https://repo1.maven.org/maven2",
+		"dns_policy":  "skip_dns",
+	}
+	execute := func() *plugin.ExecuteResponse {
+		t.Helper()
+		resp, err := (&MavenPlugin{executor: &MockCommandExecutor{}}).Execute(context.Background(), plugin.ExecuteRequest{
+			Hook:    plugin.HookPostPublish,
+			Config:  config,
+			Context: plugin.ReleaseContext{Version: "1.0.0"},
+			DryRun:  true,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return resp
+	}
+
+	resp := execute()
+	if resp.Success || !strings.Contains(resp.Error, "com.example:my-app is missing name, description") {
+		t.Fatalf("expected missing elements to be listed, got %+v", resp)
+	}
+
+	// The flattened POM is published and checked instead of the project POM.
+	if err := os.WriteFile(".flattened-pom.xml", []byte(`<project><artifactId>my-app</artifactId><name>My App</name>`+completePOMMetadata+`</project>`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if resp := execute(); !resp.Success {
+		t.Fatalf("expected the flattened POM to pass, got %s", resp.Error)
+	}
+
+	config["repository"] = "http://localhost:8081/repository/maven-releases"
+	if err := os.Remove(".flattened-pom.xml"); err != nil {
+		t.Fatal(err)
+	}
+	if resp := execute(); !resp.Success {
+		t.Errorf("expected no check outside Maven Central, got %s", resp.Error)
+	}
+}
@@ -0,0 +1,218 @@
+// Package main implements verification of uploaded files against remote checksums.
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// remoteChecksums lists the checksum files tried for each uploaded file, strongest first.
+var remoteChecksums = []string{".sha512", ".sha256", ".sha1"}
+
+// checksumAlgorithmNames maps the checksums option values to the Maven
+// Resolver algorithm names.
+var checksumAlgorithmNames = map[string]string{
+	"md5":    "MD5",
+	"sha1":   "SHA-1",
+	"sha256": "SHA-256",
+	"sha512": "SHA-512",
+}
+
+// normalizeChecksum returns the option form of an algorithm name, e.g.
+// sha256 for SHA-256.
+func normalizeChecksum(name string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(name), "-", ""))
+}
+
+// validateChecksums validates the checksums option. Maven Central requires
+// the MD5 and SHA-1 checksums of every file.
+func validateChecksums(names []string, central bool) error {
+	seen := map[string]bool{}
+	for _, name := range names {
+		algorithm := normalizeChecksum(name)
+		if _, ok := checksumAlgorithmNames[algorithm]; !ok {
+			return fmt.Errorf("unknown checksum algorithm %q, expected md5, sha1, sha256 or sha512", name)
+		}
+		seen[algorithm] = true
+	}
+	if central && len(names) > 0 && (!seen["md5"] || !seen["sha1"]) {
+		return fmt.Errorf("checksums must include md5 and sha1, which Maven Central requires")
+	}
+	return nil
+}
+
+// checksumExtensions returns the checksum file extensions published for
+// each file: the configured checksums, or the given defaults.
+func (cfg *Config) checksumExtensions(defaults []string) []string {
+	if len(cfg.Checksums) == 0 {
+		return defaults
+	}
+	exts := make([]string, 0, len(cfg.Checksums))
+	for _, name := range cfg.Checksums {
+		exts = append(exts, "."+normalizeChecksum(name))
+	}
+	return exts
+}
+
+// checksumArgs returns the Maven Resolver property selecting the checksums
+// generated and uploaded by the deploy (Maven 3.9 or newer).
+func checksumArgs(cfg *Config) []string {
+	if len(cfg.Checksums) == 0 {
+		return nil
+	}
+	algorithms := make([]string, 0, len(cfg.Checksums))
+	for _, name := range cfg.Checksums {
+		algorithms = append(algorithms, checksumAlgorithmNames[normalizeChecksum(name)])
+	}
+	return []string{"-Daether.checksums.algorithms=" + strings.Join(algorithms, ",")}
+}
+
+// maxChecksumSize bounds the size of downloaded checksum files.
+const maxChecksumSize = 1 << 10
+
+// hashFile returns the hex digest of a local file.
+func hashFile(path string, newHash func() hash.Hash) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := newHash()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// remoteDigest returns the digest of a remote file from its checksum files,
+// falling back to hashing the downloaded file when no checksum is published.
+func remoteDigest(ctx context.Context, client *repositoryClient, relPath string) (string, func() hash.Hash, error) {
+	for _, ext := range remoteChecksums {
+		body, err := client.fetchFile(ctx, relPath+ext)
+		if errors.Is(err, errComponentNotFound) {
+			continue
+		}
+		if err != nil {
+			return "", nil, err
+		}
+		data, err := io.ReadAll(io.LimitReader(body, maxChecksumSize))
+		body.Close()
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to read %s%s: %w", relPath, ext, err)
+		}
+		fields := strings.Fields(string(data))
+		if len(fields) == 0 {
+			return "", nil, fmt.Errorf("%s%s is empty", relPath, ext)
+		}
+		return strings.ToLower(fields[0]), checksumAlgorithms[ext], nil
+	}
+
+	body, err := client.fetchFile(ctx, relPath)
+	if err != nil {
+		return "", nil, err
+	}
+	defer body.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, body); err != nil {
+		return "", nil, fmt.Errorf("failed to download %s: %w", relPath, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), sha256.New, nil
+}
+
+// requiredDigests returns the configured checksums of a remote file. A
+// missing checksum means the repository did not accept it.
+func requiredDigests(ctx context.Context, client *repositoryClient, relPath string, exts []string) (map[string]string, error) {
+	digests := make(map[string]string, len(exts))
+	for _, ext := range exts {
+		body, err := client.fetchFile(ctx, relPath+ext)
+		if errors.Is(err, errComponentNotFound) {
+			return nil, fmt.Errorf("the repository has no %s checksum for %s", ext, filepath.Base(relPath))
+		}
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(io.LimitReader(body, maxChecksumSize))
+		body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s%s: %w", relPath, ext, err)
+		}
+		fields := strings.Fields(string(data))
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("%s%s is empty", relPath, ext)
+		}
+		digests[ext] = strings.ToLower(fields[0])
+	}
+	return digests, nil
+}
+
+// verifyRemoteChecksums compares the built files of the release with the
+// files in the repository and returns the names of the verified files. With
+// the checksums option every configured checksum must be in the repository.
+func (p *MavenPlugin) verifyRemoteChecksums(ctx context.Context, cfg *Config, version string) ([]string, error) {
+	out, err := cfg.readBuildOutput(version)
+	if err != nil {
+		return nil, err
+	}
+
+	client := newRepositoryClient(p.getHTTPClient(), cfg.targetRepository(version), cfg.Username, cfg.Password)
+	base := cfg.ArtifactID + "-" + mavenVersion(version)
+	var verified []string
+	for _, path := range out.Files {
+		name := filepath.Base(path)
+		if isChecksumFile(name) {
+			continue
+		}
+		// Files are built under the finalName but deployed as artifactId-version.
+		remoteName := base + strings.TrimPrefix(name, out.FinalName)
+		relPath := gavPath(cfg.GroupID, cfg.ArtifactID, mavenVersion(version)) + "/" + remoteName
+
+		if len(cfg.Checksums) > 0 {
+			digests, err := requiredDigests(ctx, client, relPath, cfg.checksumExtensions(nil))
+			if errors.Is(err, errComponentNotFound) {
+				return verified, fmt.Errorf("%s was not found in the repository", remoteName)
+			}
+			if err != nil {
+				return verified, err
+			}
+			for _, ext := range cfg.checksumExtensions(nil) {
+				actual, err := hashFile(path, checksumAlgorithms[ext])
+				if err != nil {
+					return verified, fmt.Errorf("failed to hash %s: %w", name, err)
+				}
+				if actual != digests[ext] {
+					return verified, fmt.Errorf("%s checksum mismatch for %s: repository has %s, local file has %s", ext, remoteName, digests[ext], actual)
+				}
+			}
+			verified = append(verified, remoteName)
+			continue
+		}
+
+		expected, newHash, err := remoteDigest(ctx, client, relPath)
+		if errors.Is(err, errComponentNotFound) {
+			return verified, fmt.Errorf("%s was not found in the repository", remoteName)
+		}
+		if err != nil {
+			return verified, err
+		}
+		actual, err := hashFile(path, newHash)
+		if err != nil {
+			return verified, fmt.Errorf("failed to hash %s: %w", name, err)
+		}
+		if actual != expected {
+			return verified, fmt.Errorf("checksum mismatch for %s: repository has %s, local file has %s", remoteName, expected, actual)
+		}
+		verified = append(verified, remoteName)
+	}
+	if len(verified) == 0 {
+		return nil, fmt.Errorf("no built files found to verify")
+	}
+	return verified, nil
+}
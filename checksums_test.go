@@ -0,0 +1,193 @@
+// Package main provides tests for remote checksum verification.
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestExecuteVerifyChecksums(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+	target := filepath.Join(dir, "target")
+	if err := os.MkdirAll(target, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{
+		"my-app-1.0.0.jar":         "main",
+		"my-app-1.0.0-sources.jar": "sources",
+	} {
+		if err := os.WriteFile(filepath.Join(target, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sha512Sum := sha512.Sum512([]byte("main"))
+	remote := map[string]string{
+		"/repo/com/example/my-app/1.0.0/my-app-1.0.0.jar.sha512":  hex.EncodeToString(sha512Sum[:]) + "  my-app-1.0.0.jar",
+		"/repo/com/example/my-app/1.0.0/my-app-1.0.0-sources.jar": "sources",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := remote[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	config := map[string]any{
+		"group_id":         "com.example",
+		"artifact_id":      "my-app",
+		"repository":       server.URL + "/repo",
+		"verify_checksums": true,
+	}
+	execute := func() *plugin.ExecuteResponse {
+		t.Helper()
+		p := &MavenPlugin{executor: &MockCommandExecutor{}, httpClient: server.Client()}
+		resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+			Hook:    plugin.HookPostPublish,
+			Config:  config,
+			Context: plugin.ReleaseContext{Version: "1.0.0"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return resp
+	}
+
+	resp := execute()
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+	if verified, _ := resp.Outputs["verified_files"].([]string); len(verified) != 2 {
+		t.Errorf("expected 2 verified files, got %v", resp.Outputs["verified_files"])
+	}
+
+	truncated := sha256.Sum256([]byte("sourc"))
+	remote["/repo/com/example/my-app/1.0.0/my-app-1.0.0-sources.jar.sha256"] = hex.EncodeToString(truncated[:])
+	resp = execute()
+	if resp.Success || !strings.Contains(resp.Error, "checksum mismatch for my-app-1.0.0-sources.jar") {
+		t.Errorf("expected a checksum mismatch, got %+v", resp)
+	}
+
+	delete(remote, "/repo/com/example/my-app/1.0.0/my-app-1.0.0-sources.jar.sha256")
+	delete(remote, "/repo/com/example/my-app/1.0.0/my-app-1.0.0.jar.sha512")
+	resp = execute()
+	if resp.Success || !strings.Contains(resp.Error, "my-app-1.0.0.jar was not found") {
+		t.Errorf("expected a missing file error, got %+v", resp)
+	}
+}
+
+func TestValidateChecksums(t *testing.T) {
+	tests := []struct {
+		name    string
+		names   []string
+		central bool
+		wantErr bool
+	}{
+		{name: "defaults"},
+		{name: "strong only", names: []string{"sha256", "SHA-512"}},
+		{name: "unknown", names: []string{"sha3"}, wantErr: true},
+		{name: "central without md5", names: []string{"sha1", "sha512"}, central: true, wantErr: true},
+		{name: "central", names: []string{"md5", "sha1", "sha512"}, central: true},
+		{name: "central defaults", central: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateChecksums(tt.names, tt.central); (err != nil) != tt.wantErr {
+				t.Errorf("validateChecksums() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestChecksumArgs(t *testing.T) {
+	cfg := &Config{Checksums: []string{"sha512", "SHA-256"}}
+	args := checksumArgs(cfg)
+	if len(args) != 1 || args[0] != "-Daether.checksums.algorithms=SHA-512,SHA-256" {
+		t.Errorf("unexpected args %v", args)
+	}
+	if exts := cfg.checksumExtensions(deployChecksums); strings.Join(exts, ",") != ".sha512,.sha256" {
+		t.Errorf("unexpected extensions %v", exts)
+	}
+	if exts := (&Config{}).checksumExtensions(requiredChecksums); strings.Join(exts, ",") != ".md5,.sha1" {
+		t.Errorf("unexpected default extensions %v", exts)
+	}
+	if args := checksumArgs(&Config{}); args != nil {
+		t.Errorf("expected no args, got %v", args)
+	}
+}
+
+func TestExecuteVerifyConfiguredChecksums(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+	target := filepath.Join(dir, "target")
+	if err := os.MkdirAll(target, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(target, "my-app-1.0.0.jar"), []byte("main"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	sha256Sum := sha256.Sum256([]byte("main"))
+	sha512Sum := sha512.Sum512([]byte("main"))
+	remote := map[string]string{
+		"/repo/com/example/my-app/1.0.0/my-app-1.0.0.jar.sha512": hex.EncodeToString(sha512Sum[:]),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := remote[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	execute := func() (*plugin.ExecuteResponse, *MockCommandExecutor) {
+		t.Helper()
+		mockExec := &MockCommandExecutor{}
+		p := &MavenPlugin{executor: mockExec, httpClient: server.Client()}
+		resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+			Hook: plugin.HookPostPublish,
+			Config: map[string]any{
+				"group_id":         "com.example",
+				"artifact_id":      "my-app",
+				"repository":       server.URL + "/repo",
+				"verify_checksums": true,
+				"checksums":        []any{"sha256", "sha512"},
+			},
+			Context: plugin.ReleaseContext{Version: "1.0.0"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return resp, mockExec
+	}
+
+	resp, mockExec := execute()
+	if !strings.Contains(strings.Join(mockExec.Calls[0].Args, " "), "-Daether.checksums.algorithms=SHA-256,SHA-512") {
+		t.Errorf("expected the checksum algorithms in %v", mockExec.Calls[0].Args)
+	}
+	if resp.Success || !strings.Contains(resp.Error, "the repository has no .sha256 checksum for my-app-1.0.0.jar") {
+		t.Fatalf("expected a missing checksum error, got %+v", resp)
+	}
+
+	remote["/repo/com/example/my-app/1.0.0/my-app-1.0.0.jar.sha256"] = hex.EncodeToString(sha256Sum[:])
+	if resp, _ = execute(); !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+}
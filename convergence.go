@@ -0,0 +1,88 @@
+// Package main implements the dependency convergence check of the release.
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// convergenceErrorPattern matches the header of a conflict the
+// dependencyConvergence rule reports:
+// "Dependency convergence error for org.slf4j:slf4j-api:jar:1.7.36 paths to dependency are:".
+var convergenceErrorPattern = regexp.MustCompile(`Dependency convergence error for ([^:\s]+):([^:\s]+):\S+ paths to dependency are:`)
+
+// dependencyPathPattern matches an entry of a dependency path, e.g.
+// "    +-org.slf4j:slf4j-api:jar:2.0.7:compile".
+var dependencyPathPattern = regexp.MustCompile(`^\s*\+-(\S+)`)
+
+// dependencyScopes are the scopes the enforcer may append to a coordinate.
+var dependencyScopes = map[string]bool{"compile": true, "provided": true, "runtime": true, "test": true, "system": true, "import": true}
+
+// coordinateVersion returns the version of a groupId:artifactId:type[:classifier]:version[:scope] coordinate.
+func coordinateVersion(coordinate string) string {
+	parts := strings.Split(coordinate, ":")
+	if len(parts) > 4 && dependencyScopes[parts[len(parts)-1]] {
+		parts = parts[:len(parts)-1]
+	}
+	return parts[len(parts)-1]
+}
+
+// convergenceConflicts returns the dependencies the dependencyConvergence
+// rule reported with the versions their paths resolve, e.g.
+// "org.slf4j:slf4j-api (1.7.36, 2.0.7)". Modules reporting the same
+// dependency are merged.
+func convergenceConflicts(output string) []string {
+	var keys []string
+	versions := map[string][]string{}
+	current := ""
+	for _, line := range strings.Split(ansiPattern.ReplaceAllString(output, ""), "\n") {
+		line = strings.TrimRight(line, "\r ")
+		if m := convergenceErrorPattern.FindStringSubmatch(line); m != nil {
+			current = m[1] + ":" + m[2]
+			if _, ok := versions[current]; !ok {
+				keys = append(keys, current)
+				versions[current] = nil
+			}
+			continue
+		}
+		if current == "" {
+			continue
+		}
+		m := dependencyPathPattern.FindStringSubmatch(line)
+		switch {
+		case m != nil:
+			if strings.HasPrefix(m[1], current+":") {
+				version := coordinateVersion(m[1])
+				if !slices.Contains(versions[current], version) {
+					versions[current] = append(versions[current], version)
+				}
+			}
+		case strings.TrimSpace(line) != "and":
+			current = ""
+		}
+	}
+
+	conflicts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		conflicts = append(conflicts, fmt.Sprintf("%s (%s)", key, strings.Join(versions[key], ", ")))
+	}
+	return conflicts
+}
+
+// checkDependencyConvergence runs the Maven Enforcer dependencyConvergence
+// rule over the reactor. The reactor fails at the end so that the conflicts
+// of every module are reported.
+func (p *MavenPlugin) checkDependencyConvergence(ctx context.Context, cfg *Config) error {
+	args := append(enforcerArgs(cfg, "dependencyConvergence"), "--fail-at-end")
+	output, err := p.runMavenCommand(ctx, cfg, args...)
+	if err == nil {
+		return nil
+	}
+	if conflicts := convergenceConflicts(string(output)); len(conflicts) > 0 {
+		return fmt.Errorf("dependencies resolve to diverging versions: %s", strings.Join(conflicts, "; "))
+	}
+	return fmt.Errorf("dependencyConvergence failed: %s\nOutput: %s", describeExecError(cfg.mavenCommand(), err), cfg.errorOutput(output))
+}
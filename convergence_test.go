@@ -0,0 +1,153 @@
+// Package main provides tests for the dependency convergence check of the release.
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// convergenceOutput is the enforcer output for slf4j-api and jackson-core
+// diverging in two modules.
+const convergenceOutput = `[INFO] --- enforcer:3.5.0:enforce (default-cli) @ core ---
+[ERROR] Rule 0: org.apache.maven.enforcer.rules.dependency.DependencyConvergence failed with message:
+Failed while enforcing releasability.
+
+Dependency convergence error for org.slf4j:slf4j-api:jar:1.7.36 paths to dependency are:
++-com.example:core:jar:1.0.0
+  +-org.slf4j:slf4j-api:jar:1.7.36:compile
+and
++-com.example:core:jar:1.0.0
+  +-ch.qos.logback:logback-classic:jar:1.4.14:compile
+    +-org.slf4j:slf4j-api:jar:2.0.7:compile
+
+Dependency convergence error for com.fasterxml.jackson.core:jackson-core:jar:2.15.2 paths to dependency are:
++-com.example:core:jar:1.0.0
+  +-com.fasterxml.jackson.core:jackson-databind:jar:2.17.0:compile
+    +-com.fasterxml.jackson.core:jackson-core:jar:2.17.0:compile
+and
++-com.example:core:jar:1.0.0
+  +-com.example.lib:client:jar:2.1:compile
+    +-com.fasterxml.jackson.core:jackson-core:jar:tests:2.15.2:compile
+
+[INFO] --- enforcer:3.5.0:enforce (default-cli) @ web ---
+[ERROR] Rule 0: org.apache.maven.enforcer.rules.dependency.DependencyConvergence failed with message:
+Dependency convergence error for org.slf4j:slf4j-api:jar:1.7.36 paths to dependency are:
++-com.example:web:war:1.0.0
+  +-org.slf4j:slf4j-api:jar:1.7.36
+and
++-com.example:web:war:1.0.0
+  +-org.slf4j:jul-to-slf4j:jar:2.0.9
+    +-org.slf4j:slf4j-api:jar:2.0.9
+`
+
+func TestConvergenceConflicts(t *testing.T) {
+	got := strings.Join(convergenceConflicts(convergenceOutput), "; ")
+	want := "org.slf4j:slf4j-api (1.7.36, 2.0.7, 2.0.9); com.fasterxml.jackson.core:jackson-core (2.17.0, 2.15.2)"
+	if got != want {
+		t.Errorf("convergenceConflicts() = %q, want %q", got, want)
+	}
+
+	if conflicts := convergenceConflicts("[ERROR] Failed to execute goal: could not resolve dependencies"); len(conflicts) != 0 {
+		t.Errorf("expected no conflicts, got %v", conflicts)
+	}
+}
+
+func TestExecuteDependencyConvergenceCheck(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+	if err := os.WriteFile("pom.xml", []byte(`<project><artifactId>my-app</artifactId><version>1.0.0</version></project>`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	const conflicts = "dependency convergence check: dependencies resolve to diverging versions: org.slf4j:slf4j-api (1.7.36, 2.0.7, 2.0.9); com.fasterxml.jackson.core:jackson-core (2.17.0, 2.15.2)"
+	tests := []struct {
+		name        string
+		policy      string
+		output      string
+		dryRun      bool
+		wantErr     string
+		wantWarning string
+		wantRun     bool
+	}{
+		{name: "off"},
+		{name: "converged", policy: "fail", wantRun: true},
+		{name: "fail", policy: "fail", output: convergenceOutput, wantErr: conflicts, wantRun: true},
+		{name: "warn", policy: "warn", output: convergenceOutput, wantWarning: conflicts, wantRun: true},
+		{name: "enforcer error", policy: "fail", output: "[ERROR] Failed to execute goal: could not resolve dependencies", wantErr: "dependency convergence check: dependencyConvergence failed", wantRun: true},
+		{name: "dry run", policy: "fail", output: convergenceOutput, dryRun: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var enforced bool
+			mockExec := &MockCommandExecutor{
+				RunFunc: func(ctx context.Context, name string, args ...string) ([]byte, error) {
+					if strings.Contains(strings.Join(args, " "), enforcerPlugin+":enforce -Denforcer.rules=dependencyConvergence --fail-at-end") {
+						enforced = true
+						if tt.output != "" {
+							return []byte(tt.output), errors.New("exit status 1")
+						}
+					}
+					return []byte("[INFO] BUILD SUCCESS"), nil
+				},
+			}
+			config := map[string]any{
+				"group_id":    "com.example",
+				"artifact_id": "my-app",
+				"repository":  "http://localhost:8081/repository/maven-releases",
+			}
+			if tt.policy != "" {
+				config["dependency_convergence_check"] = tt.policy
+			}
+			resp, err := (&MavenPlugin{executor: mockExec}).Execute(context.Background(), plugin.ExecuteRequest{
+				Hook:    plugin.HookPostPublish,
+				Config:  config,
+				Context: plugin.ReleaseContext{Version: "1.0.0"},
+				DryRun:  tt.dryRun,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if enforced != tt.wantRun {
+				t.Errorf("expected enforcer run %v, got %v", tt.wantRun, enforced)
+			}
+			if tt.wantErr != "" {
+				if resp.Success || !strings.HasPrefix(resp.Error, tt.wantErr) {
+					t.Errorf("expected error %q, got %q", tt.wantErr, resp.Error)
+				}
+				return
+			}
+			if !resp.Success {
+				t.Fatalf("expected success, got error: %s", resp.Error)
+			}
+			warnings, _ := resp.Outputs["warnings"].([]string)
+			if tt.wantWarning != "" && (len(warnings) != 1 || warnings[0] != tt.wantWarning) {
+				t.Errorf("expected warning %q, got %v", tt.wantWarning, warnings)
+			}
+		})
+	}
+}
+
+func TestValidateDependencyConvergenceCheck(t *testing.T) {
+	resp, err := (&MavenPlugin{}).Validate(context.Background(), map[string]any{
+		"group_id":                     "com.example",
+		"artifact_id":                  "my-app",
+		"repository":                   "http://localhost:8081/repository/maven-releases",
+		"deployer":                     "http",
+		"dependency_convergence_check": "fail",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, e := range resp.Errors {
+		if e.Field == "dependency_convergence_check" && strings.Contains(e.Message, "requires the maven deployer") {
+			return
+		}
+	}
+	t.Errorf("expected a maven deployer error, got %v", resp.Errors)
+}
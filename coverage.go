@@ -0,0 +1,133 @@
+// Package main implements the code coverage gate before publishing.
+package main
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// jacocoPlugin is the JaCoCo Maven plugin used when the gate runs the build.
+const jacocoPlugin = "org.jacoco:jacoco-maven-plugin:0.8.12"
+
+// defaultCoverageReport is where the JaCoCo report goal writes its XML report.
+const defaultCoverageReport = "target/site/jacoco/jacoco.xml"
+
+// jacocoCounter is a coverage counter of a JaCoCo XML report.
+type jacocoCounter struct {
+	Type    string `xml:"type,attr"`
+	Missed  int    `xml:"missed,attr"`
+	Covered int    `xml:"covered,attr"`
+}
+
+// jacocoReport is the root of a JaCoCo XML report. Only the counters that
+// summarize the whole report are read.
+type jacocoReport struct {
+	XMLName  xml.Name        `xml:"report"`
+	Counters []jacocoCounter `xml:"counter"`
+}
+
+// coverageResult holds the coverage percentages of a report.
+type coverageResult struct {
+	Line   float64 `json:"line"`
+	Branch float64 `json:"branch"`
+}
+
+// parseCoverageReport parses a JaCoCo XML report into coverage percentages.
+// A counter without any lines or branches counts as fully covered.
+func parseCoverageReport(r io.Reader) (*coverageResult, error) {
+	var report jacocoReport
+	decoder := xml.NewDecoder(io.LimitReader(r, maxPOMSize))
+	// JaCoCo reports reference an external DTD that must not be fetched.
+	decoder.Strict = false
+	if err := decoder.Decode(&report); err != nil {
+		return nil, fmt.Errorf("failed to parse JaCoCo report: %w", err)
+	}
+
+	result := &coverageResult{Line: 100, Branch: 100}
+	for _, c := range report.Counters {
+		total := c.Missed + c.Covered
+		if total == 0 {
+			continue
+		}
+		percent := float64(c.Covered) * 100 / float64(total)
+		switch c.Type {
+		case "LINE":
+			result.Line = percent
+		case "BRANCH":
+			result.Branch = percent
+		}
+	}
+	return result, nil
+}
+
+// coverageReportPath returns the JaCoCo report path, relative to the POM directory by default.
+func (cfg *Config) coverageReportPath() string {
+	if cfg.CoverageReport != "" {
+		return cfg.CoverageReport
+	}
+	return filepath.Join(filepath.Dir(cfg.PomPath), filepath.FromSlash(defaultCoverageReport))
+}
+
+// coverageArgs returns the Maven arguments that run the tests with JaCoCo and
+// write the XML report.
+func coverageArgs(cfg *Config) []string {
+	args := []string{"-f", cfg.PomPath}
+	if path := cfg.userSettings(); path != "" {
+		args = append(args, "-s", path)
+	}
+	args = append(args, cfg.isolationArgs(true)...)
+	return append(args,
+		jacocoPlugin+":prepare-agent",
+		"verify",
+		jacocoPlugin+":report",
+	)
+}
+
+// checkCoverage enforces the configured coverage thresholds, running the
+// build with JaCoCo first when requested.
+func (p *MavenPlugin) checkCoverage(ctx context.Context, cfg *Config) (*coverageResult, error) {
+	if cfg.CoverageRun {
+		if err := validatePath(cfg.PomPath); err != nil {
+			return nil, fmt.Errorf("invalid pom_path: %w", err)
+		}
+		output, err := p.runMavenCommand(ctx, cfg, coverageArgs(cfg)...)
+		if err != nil {
+			return nil, fmt.Errorf("coverage build failed: %s\nOutput: %s", describeExecError(cfg.mavenCommand(), err), cfg.errorOutput(output))
+		}
+	}
+
+	f, err := os.Open(cfg.coverageReportPath())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open coverage report: %w", err)
+	}
+	defer f.Close()
+
+	result, err := parseCoverageReport(f)
+	if err != nil {
+		return nil, err
+	}
+	if result.Line < cfg.CoverageMinLine {
+		return result, fmt.Errorf("line coverage %.1f%% is below the minimum of %.1f%%", result.Line, cfg.CoverageMinLine)
+	}
+	if result.Branch < cfg.CoverageMinBranch {
+		return result, fmt.Errorf("branch coverage %.1f%% is below the minimum of %.1f%%", result.Branch, cfg.CoverageMinBranch)
+	}
+	return result, nil
+}
+
+// coverageGate reports whether a coverage threshold is configured.
+func (cfg *Config) coverageGate() bool {
+	return cfg.CoverageMinLine > 0 || cfg.CoverageMinBranch > 0
+}
+
+// validateCoverageThreshold validates a coverage percentage.
+func validateCoverageThreshold(value float64) error {
+	if value < 0 || value > 100 {
+		return fmt.Errorf("coverage threshold must be between 0 and 100")
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+// Package main provides tests for the coverage gate.
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// jacocoXML returns a JaCoCo report with the given line and branch counters.
+// The package-level counter must not be mistaken for the report totals.
+func jacocoXML(lineMissed, lineCovered, branchMissed, branchCovered int) string {
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<!DOCTYPE report PUBLIC "-//JACOCO//DTD Report 1.1//EN" "report.dtd">
+<report name="my-app">
+  <package name="com/example">
+    <counter type="LINE" missed="99" covered="1"/>
+  </package>
+  <counter type="INSTRUCTION" missed="10" covered="90"/>
+  <counter type="BRANCH" missed="%d" covered="%d"/>
+  <counter type="LINE" missed="%d" covered="%d"/>
+</report>`, branchMissed, branchCovered, lineMissed, lineCovered)
+}
+
+func TestParseCoverageReport(t *testing.T) {
+	result, err := parseCoverageReport(strings.NewReader(jacocoXML(20, 80, 3, 1)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Line != 80 || result.Branch != 25 {
+		t.Errorf("expected 80%% lines and 25%% branches, got %+v", result)
+	}
+
+	empty, err := parseCoverageReport(strings.NewReader(`<report name="empty"/>`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if empty.Line != 100 || empty.Branch != 100 {
+		t.Errorf("expected full coverage without counters, got %+v", empty)
+	}
+
+	if _, err := parseCoverageReport(strings.NewReader(`<project/>`)); err == nil {
+		t.Error("expected an error for a non-JaCoCo document")
+	}
+}
+
+func TestExecuteCoverageGate(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+	report := filepath.Join(dir, "target", "site", "jacoco", "jacoco.xml")
+	if err := os.MkdirAll(filepath.Dir(report), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	config := map[string]any{
+		"group_id":          "com.example",
+		"artifact_id":       "my-app",
+		"repository":        "http://localhost:8081/repository/maven-releases",
+		"coverage_min_line": 75,
+		"coverage_run":      true,
+	}
+
+	t.Run("above threshold", func(t *testing.T) {
+		mockExec := &MockCommandExecutor{
+			RunFunc: func(_ context.Context, _ string, args ...string) ([]byte, error) {
+				if strings.Contains(strings.Join(args, " "), "jacoco-maven-plugin") {
+					return nil, os.WriteFile(report, []byte(jacocoXML(20, 80, 0, 0)), 0o600)
+				}
+				return []byte("[INFO] BUILD SUCCESS"), nil
+			},
+		}
+		p := &MavenPlugin{executor: mockExec}
+
+		resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+			Hook:    plugin.HookPostPublish,
+			Config:  config,
+			Context: plugin.ReleaseContext{Version: "1.0.0"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !resp.Success {
+			t.Fatalf("expected success, got error: %s", resp.Error)
+		}
+		if len(mockExec.Calls) != 2 || mockExec.Calls[1].Args[0] != "deploy" {
+			t.Errorf("expected the coverage build before the deploy, got %v", mockExec.Calls)
+		}
+		if coverage, _ := resp.Outputs["coverage"].(*coverageResult); coverage == nil || coverage.Line != 80 {
+			t.Errorf("unexpected coverage output: %v", resp.Outputs["coverage"])
+		}
+	})
+
+	t.Run("below threshold", func(t *testing.T) {
+		if err := os.WriteFile(report, []byte(jacocoXML(30, 70, 0, 0)), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		below := map[string]any{}
+		for k, v := range config {
+			below[k] = v
+		}
+		delete(below, "coverage_run")
+
+		mockExec := &MockCommandExecutor{}
+		p := &MavenPlugin{executor: mockExec}
+		resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+			Hook:    plugin.HookPostPublish,
+			Config:  below,
+			Context: plugin.ReleaseContext{Version: "1.0.0"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Success || !strings.Contains(resp.Error, "line coverage 70.0% is below the minimum of 75.0%") {
+			t.Errorf("expected the coverage gate to block the release, got %+v", resp)
+		}
+		if len(mockExec.Calls) != 0 {
+			t.Errorf("expected no deploy, got %d calls", len(mockExec.Calls))
+		}
+	})
+
+	t.Run("dry run without report", func(t *testing.T) {
+		if err := os.Remove(report); err != nil {
+			t.Fatal(err)
+		}
+		mockExec := &MockCommandExecutor{}
+		p := &MavenPlugin{executor: mockExec}
+		resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+			Hook:    plugin.HookPostPublish,
+			Config:  config,
+			Context: plugin.ReleaseContext{Version: "1.0.0"},
+			DryRun:  true,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		warnings, _ := resp.Outputs["warnings"].([]string)
+		if !resp.Success || len(warnings) == 0 || !strings.HasPrefix(warnings[0], "coverage gate") {
+			t.Errorf("expected a coverage warning in dry run, got %+v", resp)
+		}
+		if len(mockExec.Calls) != 0 {
+			t.Errorf("expected no commands in dry run, got %d", len(mockExec.Calls))
+		}
+	})
+}
+
+func TestValidateCoverageThreshold(t *testing.T) {
+	for _, v := range []float64{0, 50.5, 100} {
+		if err := validateCoverageThreshold(v); err != nil {
+			t.Errorf("unexpected error for %v: %v", v, err)
+		}
+	}
+	for _, v := range []float64{-1, 100.1, math.Inf(1)} {
+		if err := validateCoverageThreshold(v); err == nil {
+			t.Errorf("expected error for %v", v)
+		}
+	}
+}
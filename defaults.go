@@ -0,0 +1,81 @@
+// Package main implements shared configuration defaults for the repositories of an organization.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+)
+
+// defaultsFile is the repository-level file supplying default configuration
+// when defaults_file is not set.
+const defaultsFile = ".relicta/maven.json"
+
+// maxDefaultsSize bounds the defaults file read.
+const maxDefaultsSize = 1 << 20
+
+// readDefaults reads the JSON object of a defaults file.
+func readDefaults(path string) (map[string]any, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var defaults map[string]any
+	if err := json.NewDecoder(io.LimitReader(f, maxDefaultsSize)).Decode(&defaults); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if defaults == nil {
+		return nil, fmt.Errorf("%s must contain a JSON object", path)
+	}
+	return defaults, nil
+}
+
+// mergeDefaults returns the configuration with the defaults filled in. Values
+// of the configuration take precedence; objects present in both are merged
+// key by key, while lists and scalars replace the default.
+func mergeDefaults(defaults, raw map[string]any) map[string]any {
+	merged := make(map[string]any, len(defaults)+len(raw))
+	for k, v := range defaults {
+		merged[k] = v
+	}
+	for k, v := range raw {
+		base, baseIsMap := merged[k].(map[string]any)
+		override, overrideIsMap := v.(map[string]any)
+		if baseIsMap && overrideIsMap {
+			merged[k] = mergeDefaults(base, override)
+			continue
+		}
+		merged[k] = v
+	}
+	return merged
+}
+
+// withDefaults applies the defaults file named by defaults_file, or the
+// repository's .relicta/maven.json when it exists, to the raw configuration.
+// The merge happens before templates are expanded so shared defaults can use
+// them too. A missing defaults_file is an error; a missing .relicta/maven.json
+// is not.
+func withDefaults(raw map[string]any) (map[string]any, error) {
+	path, explicit := raw["defaults_file"].(string)
+	if !explicit || path == "" {
+		path, explicit = defaultsFile, false
+	}
+	if err := validatePath(path); err != nil {
+		return nil, fmt.Errorf("invalid defaults_file: %w", err)
+	}
+
+	defaults, err := readDefaults(path)
+	if errors.Is(err, fs.ErrNotExist) && !explicit {
+		return raw, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	delete(defaults, "defaults_file")
+	return mergeDefaults(defaults, raw), nil
+}
@@ -0,0 +1,180 @@
+// Package main provides tests for shared configuration defaults.
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// writeDefaults writes a defaults file in the current directory.
+func writeDefaults(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMergeDefaults(t *testing.T) {
+	defaults := map[string]any{
+		"group_id":   "com.example",
+		"skip_tests": true,
+		"profiles":   []any{"release", "sign"},
+		"properties": map[string]any{"gpg.keyname": "ABC", "maven.javadoc.skip": "true"},
+	}
+	raw := map[string]any{
+		"artifact_id": "my-app",
+		"skip_tests":  false,
+		"profiles":    []any{"release"},
+		"properties":  map[string]any{"maven.javadoc.skip": "false"},
+	}
+
+	merged := mergeDefaults(defaults, raw)
+	want := map[string]any{
+		"group_id":    "com.example",
+		"artifact_id": "my-app",
+		"skip_tests":  false,
+		"profiles":    []any{"release"},
+		"properties":  map[string]any{"gpg.keyname": "ABC", "maven.javadoc.skip": "false"},
+	}
+	if !reflect.DeepEqual(merged, want) {
+		t.Errorf("mergeDefaults() = %v, want %v", merged, want)
+	}
+	if _, ok := defaults["artifact_id"]; ok {
+		t.Error("mergeDefaults modified the defaults")
+	}
+}
+
+func TestWithDefaults(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		raw     map[string]any
+		want    map[string]any
+		wantErr string
+	}{
+		{
+			name: "no defaults file",
+			raw:  map[string]any{"group_id": "com.example"},
+			want: map[string]any{"group_id": "com.example"},
+		},
+		{
+			name:  "repository defaults",
+			files: map[string]string{".relicta/maven.json": `{"group_id": "org.example", "repository_id": "nexus"}`},
+			raw:   map[string]any{"group_id": "com.example"},
+			want:  map[string]any{"group_id": "com.example", "repository_id": "nexus"},
+		},
+		{
+			name:  "explicit defaults file",
+			files: map[string]string{"ci/maven-defaults.json": `{"repository_id": "nexus"}`},
+			raw:   map[string]any{"defaults_file": "ci/maven-defaults.json"},
+			want:  map[string]any{"defaults_file": "ci/maven-defaults.json", "repository_id": "nexus"},
+		},
+		{
+			name:    "explicit defaults file missing",
+			raw:     map[string]any{"defaults_file": "ci/maven-defaults.json"},
+			wantErr: "no such file",
+		},
+		{
+			name:    "invalid JSON",
+			files:   map[string]string{".relicta/maven.json": `group_id: com.example`},
+			wantErr: "failed to parse .relicta/maven.json",
+		},
+		{
+			name:    "path traversal",
+			raw:     map[string]any{"defaults_file": "../defaults.json"},
+			wantErr: "path traversal",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chdir(t, t.TempDir())
+			for path, content := range tt.files {
+				writeDefaults(t, path, content)
+			}
+
+			got, err := withDefaults(tt.raw)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("withDefaults() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecuteWithDefaults(t *testing.T) {
+	chdir(t, t.TempDir())
+	writeDefaults(t, ".relicta/maven.json", `{
+  "repository": "http://localhost:8081/repository/maven-releases",
+  "repository_id": "releases",
+  "skip_tests": true,
+  "properties": {"release.tag": "{{tag}}"}
+}`)
+
+	mockExec := &MockCommandExecutor{}
+	p := &MavenPlugin{executor: mockExec}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":    "com.example",
+			"artifact_id": "my-app",
+			"skip_tests":  false,
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0", TagName: "v1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+
+	args := strings.Join(mockExec.Calls[0].Args, " ")
+	if !strings.Contains(args, "-DaltReleaseDeploymentRepository=releases::http://localhost:8081/repository/maven-releases") {
+		t.Errorf("expected the repository from the defaults, got %s", args)
+	}
+	if !strings.Contains(args, "-Drelease.tag=v1.0.0") {
+		t.Errorf("expected the templated property from the defaults, got %s", args)
+	}
+	if strings.Contains(args, "-DskipTests") {
+		t.Errorf("expected skip_tests to be overridden, got %s", args)
+	}
+}
+
+func TestValidateDefaultsFile(t *testing.T) {
+	chdir(t, t.TempDir())
+	resp, err := (&MavenPlugin{}).Validate(context.Background(), map[string]any{
+		"group_id":      "com.example",
+		"artifact_id":   "my-app",
+		"defaults_file": "missing.json",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	found := false
+	for _, e := range resp.Errors {
+		if e.Field == "defaults_file" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a defaults_file error, got %v", resp.Errors)
+	}
+}
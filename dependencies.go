@@ -0,0 +1,250 @@
+// Package main implements the dependency diff between releases for release notes.
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// defaultTagPrefix is prepended to versions to form release tag names.
+const defaultTagPrefix = "v"
+
+// propertyReferencePattern matches ${...} property references in POM values.
+var propertyReferencePattern = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// dependencyChange describes a dependency whose version differs between releases.
+type dependencyChange struct {
+	Key  string `json:"key"`
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+}
+
+// dependencyDiff groups the dependency changes between two releases.
+type dependencyDiff struct {
+	Added      []dependencyChange `json:"added"`
+	Removed    []dependencyChange `json:"removed"`
+	Upgraded   []dependencyChange `json:"upgraded"`
+	Downgraded []dependencyChange `json:"downgraded"`
+}
+
+// empty reports whether the diff contains no changes.
+func (d *dependencyDiff) empty() bool {
+	return len(d.Added)+len(d.Removed)+len(d.Upgraded)+len(d.Downgraded) == 0
+}
+
+// interpolate resolves property references in a POM value. Unknown
+// properties are left as they are.
+func (m *pomModel) interpolate(value string) string {
+	for range 10 { // Bound nested references.
+		resolved := propertyReferencePattern.ReplaceAllStringFunc(value, func(ref string) string {
+			name := ref[2 : len(ref)-1]
+			switch name {
+			case "project.version", "pom.version", "version":
+				if m.Version != "" {
+					return m.Version
+				}
+			case "project.groupId", "pom.groupId":
+				if m.GroupID != "" {
+					return m.GroupID
+				}
+			}
+			if v, ok := m.Properties[name]; ok {
+				return v
+			}
+			return ref
+		})
+		if resolved == value {
+			break
+		}
+		value = resolved
+	}
+	return value
+}
+
+// dependencyVersions returns the declared dependency versions of a POM keyed by
+// groupId:artifactId[:classifier]. Dependencies without a version take it from
+// dependencyManagement.
+func (m *pomModel) dependencyVersions() map[string]string {
+	key := func(d pomDependency) string {
+		k := m.interpolate(d.GroupID) + ":" + m.interpolate(d.ArtifactID)
+		if d.Classifier != "" {
+			k += ":" + m.interpolate(d.Classifier)
+		}
+		return k
+	}
+
+	managed := map[string]string{}
+	for _, d := range m.ManagedDependencies {
+		managed[key(d)] = m.interpolate(d.Version)
+	}
+
+	versions := map[string]string{}
+	for _, d := range m.Dependencies {
+		k := key(d)
+		if d.Version != "" {
+			versions[k] = m.interpolate(d.Version)
+		} else {
+			versions[k] = managed[k]
+		}
+	}
+	// Managed dependencies such as imported BOMs are part of the published POM too.
+	for k, v := range managed {
+		if _, ok := versions[k]; !ok {
+			versions[k] = v
+		}
+	}
+	return versions
+}
+
+// diffDependencies compares dependency versions between two releases.
+func diffDependencies(previous, current map[string]string) *dependencyDiff {
+	diff := &dependencyDiff{}
+	for k, to := range current {
+		from, ok := previous[k]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, dependencyChange{Key: k, To: to})
+		case from == to:
+		case compareMavenVersions(to, from) < 0:
+			diff.Downgraded = append(diff.Downgraded, dependencyChange{Key: k, From: from, To: to})
+		default:
+			diff.Upgraded = append(diff.Upgraded, dependencyChange{Key: k, From: from, To: to})
+		}
+	}
+	for k, from := range previous {
+		if _, ok := current[k]; !ok {
+			diff.Removed = append(diff.Removed, dependencyChange{Key: k, From: from})
+		}
+	}
+
+	for _, changes := range [][]dependencyChange{diff.Added, diff.Removed, diff.Upgraded, diff.Downgraded} {
+		sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	}
+	return diff
+}
+
+// markdown renders the diff as a "Dependency updates" release notes section.
+func (d *dependencyDiff) markdown() string {
+	if d.empty() {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("### Dependency updates\n\n")
+	for _, c := range d.Upgraded {
+		fmt.Fprintf(&b, "- Upgraded `%s` from %s to %s\n", c.Key, c.From, c.To)
+	}
+	for _, c := range d.Downgraded {
+		fmt.Fprintf(&b, "- Downgraded `%s` from %s to %s\n", c.Key, c.From, c.To)
+	}
+	for _, c := range d.Added {
+		fmt.Fprintf(&b, "- Added `%s` %s\n", c.Key, c.To)
+	}
+	for _, c := range d.Removed {
+		fmt.Fprintf(&b, "- Removed `%s` %s\n", c.Key, c.From)
+	}
+	return b.String()
+}
+
+// previousTag returns the tag of the previous release. Without a configured
+// tag_prefix, the prefix is taken from the current tag name.
+func previousTag(cfg *Config, releaseCtx plugin.ReleaseContext) string {
+	prefix := cfg.TagPrefix
+	if prefix == "" {
+		prefix = defaultTagPrefix
+		if releaseCtx.Version != "" && strings.HasSuffix(releaseCtx.TagName, releaseCtx.Version) {
+			prefix = strings.TrimSuffix(releaseCtx.TagName, releaseCtx.Version)
+		}
+	}
+	return prefix + releaseCtx.PreviousVersion
+}
+
+// previousPOM reads the POM at path as it was at the given git revision. It
+// returns nil when the file did not exist in that revision.
+func (p *MavenPlugin) previousPOM(ctx context.Context, revision, path string) (*pomModel, error) {
+	spec := revision + ":./" + filepath.ToSlash(filepath.Clean(path))
+	output, err := p.getExecutor().Run(ctx, "git", "show", spec)
+	if err != nil {
+		if bytes.Contains(output, []byte("does not exist")) || bytes.Contains(output, []byte("exists on disk, but not in")) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("git show %s failed: %w: %s", spec, err, strings.TrimSpace(string(output)))
+	}
+	return parsePOM(bytes.NewReader(output))
+}
+
+// dependencyUpdates computes the dependency changes since the previous release
+// for the release notes.
+func (p *MavenPlugin) dependencyUpdates(ctx context.Context, cfg *Config, releaseCtx plugin.ReleaseContext) (*plugin.ExecuteResponse, error) {
+	if releaseCtx.PreviousVersion == "" {
+		return &plugin.ExecuteResponse{
+			Success: true,
+			Message: "No previous release to compare dependencies with",
+		}, nil
+	}
+	revision := previousTag(cfg, releaseCtx)
+
+	previous := map[string]string{}
+	current := map[string]string{}
+	seen := map[string]bool{}
+	for _, c := range cfg.artifactConfigs() {
+		if seen[c.PomPath] {
+			continue
+		}
+		seen[c.PomPath] = true
+
+		if err := validatePath(c.PomPath); err != nil {
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   fmt.Sprintf("invalid pom_path: %v", err),
+			}, nil
+		}
+
+		pom, err := readPOM(c.PomPath)
+		if err != nil {
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   err.Error(),
+			}, nil
+		}
+		for k, v := range pom.dependencyVersions() {
+			current[k] = v
+		}
+
+		oldPOM, err := p.previousPOM(ctx, revision, c.PomPath)
+		if err != nil {
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   fmt.Sprintf("failed to read POM from %s: %v", revision, err),
+			}, nil
+		}
+		if oldPOM != nil {
+			for k, v := range oldPOM.dependencyVersions() {
+				previous[k] = v
+			}
+		}
+	}
+
+	diff := diffDependencies(previous, current)
+	outputs := map[string]any{
+		"previous_version":   releaseCtx.PreviousVersion,
+		"dependency_changes": diff,
+	}
+	if notes := diff.markdown(); notes != "" {
+		outputs["dependency_notes"] = notes
+	}
+
+	return &plugin.ExecuteResponse{
+		Success: true,
+		Message: fmt.Sprintf("Found %d dependency changes since %s",
+			len(diff.Added)+len(diff.Removed)+len(diff.Upgraded)+len(diff.Downgraded), revision),
+		Outputs: outputs,
+	}, nil
+}
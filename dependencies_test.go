@@ -0,0 +1,255 @@
+// Package main provides tests for the dependency diff between releases.
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+const previousDependenciesPOM = `<project>
+  <modelVersion>4.0.0</modelVersion>
+  <version>1.0.0</version>
+  <properties>
+    <jackson.version>2.15.0</jackson.version>
+  </properties>
+  <dependencies>
+    <dependency>
+      <groupId>com.fasterxml.jackson.core</groupId>
+      <artifactId>jackson-databind</artifactId>
+      <version>${jackson.version}</version>
+    </dependency>
+    <dependency>
+      <groupId>commons-io</groupId>
+      <artifactId>commons-io</artifactId>
+      <version>2.15.0</version>
+    </dependency>
+    <dependency>
+      <groupId>org.slf4j</groupId>
+      <artifactId>slf4j-api</artifactId>
+      <version>2.0.9</version>
+    </dependency>
+  </dependencies>
+</project>`
+
+const currentDependenciesPOM = `<project>
+  <modelVersion>4.0.0</modelVersion>
+  <version>1.1.0</version>
+  <properties>
+    <jackson.version>2.17.1</jackson.version>
+  </properties>
+  <dependencyManagement>
+    <dependencies>
+      <dependency>
+        <groupId>org.junit</groupId>
+        <artifactId>junit-bom</artifactId>
+        <version>5.10.2</version>
+      </dependency>
+      <dependency>
+        <groupId>org.slf4j</groupId>
+        <artifactId>slf4j-api</artifactId>
+        <version>2.0.7</version>
+      </dependency>
+    </dependencies>
+  </dependencyManagement>
+  <dependencies>
+    <dependency>
+      <groupId>com.fasterxml.jackson.core</groupId>
+      <artifactId>jackson-databind</artifactId>
+      <version>${jackson.version}</version>
+    </dependency>
+    <dependency>
+      <groupId>org.slf4j</groupId>
+      <artifactId>slf4j-api</artifactId>
+    </dependency>
+    <dependency>
+      <groupId>com.example</groupId>
+      <artifactId>shared</artifactId>
+      <version>${project.version}</version>
+    </dependency>
+  </dependencies>
+</project>`
+
+func TestDependencyVersions(t *testing.T) {
+	pom, err := parsePOM(strings.NewReader(currentDependenciesPOM))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	versions := pom.dependencyVersions()
+	want := map[string]string{
+		"com.fasterxml.jackson.core:jackson-databind": "2.17.1",
+		"org.slf4j:slf4j-api":                         "2.0.7",
+		"com.example:shared":                          "1.1.0",
+		"org.junit:junit-bom":                         "5.10.2",
+	}
+	if len(versions) != len(want) {
+		t.Errorf("expected %d dependencies, got %v", len(want), versions)
+	}
+	for k, v := range want {
+		if versions[k] != v {
+			t.Errorf("%s: expected %s, got %s", k, v, versions[k])
+		}
+	}
+}
+
+func TestDiffDependencies(t *testing.T) {
+	previous := map[string]string{"a:a": "1.0", "b:b": "2.0", "c:c": "3.0", "d:d": "1.0"}
+	current := map[string]string{"a:a": "1.1", "b:b": "1.9", "c:c": "3.0", "e:e": "1.0"}
+
+	diff := diffDependencies(previous, current)
+	if len(diff.Upgraded) != 1 || diff.Upgraded[0] != (dependencyChange{Key: "a:a", From: "1.0", To: "1.1"}) {
+		t.Errorf("unexpected upgrades: %v", diff.Upgraded)
+	}
+	if len(diff.Downgraded) != 1 || diff.Downgraded[0].Key != "b:b" {
+		t.Errorf("unexpected downgrades: %v", diff.Downgraded)
+	}
+	if len(diff.Added) != 1 || diff.Added[0].Key != "e:e" {
+		t.Errorf("unexpected additions: %v", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].Key != "d:d" {
+		t.Errorf("unexpected removals: %v", diff.Removed)
+	}
+
+	notes := diff.markdown()
+	for _, want := range []string{
+		"### Dependency updates",
+		"- Upgraded `a:a` from 1.0 to 1.1",
+		"- Downgraded `b:b` from 2.0 to 1.9",
+		"- Added `e:e` 1.0",
+		"- Removed `d:d` 1.0",
+	} {
+		if !strings.Contains(notes, want) {
+			t.Errorf("expected notes to contain %q, got:\n%s", want, notes)
+		}
+	}
+
+	if notes := diffDependencies(previous, previous).markdown(); notes != "" {
+		t.Errorf("expected no notes without changes, got %q", notes)
+	}
+}
+
+func TestPreviousTag(t *testing.T) {
+	tests := []struct {
+		name      string
+		tagPrefix string
+		tagName   string
+		want      string
+	}{
+		{name: "derived from tag", tagName: "v1.1.0", want: "v1.0.0"},
+		{name: "derived empty prefix", tagName: "1.1.0", want: "1.0.0"},
+		{name: "derived custom prefix", tagName: "my-app-1.1.0", want: "my-app-1.0.0"},
+		{name: "default", want: "v1.0.0"},
+		{name: "configured", tagPrefix: "release/", tagName: "v1.1.0", want: "release/1.0.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := previousTag(&Config{TagPrefix: tt.tagPrefix}, plugin.ReleaseContext{
+				Version:         "1.1.0",
+				PreviousVersion: "1.0.0",
+				TagName:         tt.tagName,
+			})
+			if got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestExecutePreNotesDependencyDiff(t *testing.T) {
+	chdir(t, t.TempDir())
+	if err := os.WriteFile("pom.xml", []byte(currentDependenciesPOM), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	mockExec := &MockCommandExecutor{
+		RunFunc: func(_ context.Context, name string, args ...string) ([]byte, error) {
+			if name == "git" && strings.Join(args, " ") == "show v1.0.0:./pom.xml" {
+				return []byte(previousDependenciesPOM), nil
+			}
+			return []byte("fatal: bad revision"), errors.New("exit status 128")
+		},
+	}
+	p := &MavenPlugin{executor: mockExec}
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPreNotes,
+		Config: map[string]any{
+			"group_id":        "com.example",
+			"artifact_id":     "my-app",
+			"dependency_diff": true,
+		},
+		Context: plugin.ReleaseContext{Version: "1.1.0", PreviousVersion: "1.0.0", TagName: "v1.1.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+
+	diff, ok := resp.Outputs["dependency_changes"].(*dependencyDiff)
+	if !ok {
+		t.Fatalf("expected dependency changes, got %v", resp.Outputs["dependency_changes"])
+	}
+	if len(diff.Upgraded) != 1 || len(diff.Downgraded) != 1 || len(diff.Added) != 2 || len(diff.Removed) != 1 {
+		t.Errorf("unexpected diff: %+v", diff)
+	}
+	if notes, _ := resp.Outputs["dependency_notes"].(string); !strings.Contains(notes, "jackson-databind` from 2.15.0 to 2.17.1") {
+		t.Errorf("unexpected notes: %s", notes)
+	}
+}
+
+func TestExecutePreNotesDependencyDiffErrors(t *testing.T) {
+	chdir(t, t.TempDir())
+	if err := os.WriteFile("pom.xml", []byte(currentDependenciesPOM), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		output      string
+		previous    string
+		wantSuccess bool
+		wantAdded   int
+	}{
+		{name: "no previous release", wantSuccess: true},
+		{name: "pom added since previous release", previous: "1.0.0", output: "fatal: path 'pom.xml' exists on disk, but not in 'v1.0.0'", wantSuccess: true, wantAdded: 4},
+		{name: "unknown tag", previous: "1.0.0", output: "fatal: invalid object name 'v1.0.0'."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockExec := &MockCommandExecutor{
+				RunFunc: func(_ context.Context, _ string, _ ...string) ([]byte, error) {
+					return []byte(tt.output), errors.New("exit status 128")
+				},
+			}
+			p := &MavenPlugin{executor: mockExec}
+
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook: plugin.HookPreNotes,
+				Config: map[string]any{
+					"group_id":        "com.example",
+					"artifact_id":     "my-app",
+					"dependency_diff": true,
+				},
+				Context: plugin.ReleaseContext{Version: "1.1.0", PreviousVersion: tt.previous},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Success != tt.wantSuccess {
+				t.Fatalf("expected success=%v, got %+v", tt.wantSuccess, resp)
+			}
+			if diff, ok := resp.Outputs["dependency_changes"].(*dependencyDiff); ok && len(diff.Added) != tt.wantAdded {
+				t.Errorf("expected %d added dependencies, got %+v", tt.wantAdded, diff)
+			}
+		})
+	}
+}
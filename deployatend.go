@@ -0,0 +1,98 @@
+// Package main implements deploying a multi-module reactor at the end of the build.
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// deployAtEndMinVersion is the first maven-deploy-plugin release whose
+// deployAtEnd works in parallel builds (MDEPLOY-193).
+const deployAtEndMinVersion = "3.0.0"
+
+// deployAtEndArgs returns the arguments deferring install and deploy until
+// every module of the reactor has been built.
+func deployAtEndArgs(cfg *Config) []string {
+	var args []string
+	if cfg.InstallAtEnd {
+		args = append(args, "-DinstallAtEnd=true")
+	}
+	if cfg.DeployAtEnd {
+		args = append(args, "-DdeployAtEnd=true")
+	}
+	return args
+}
+
+// failureModeArg returns the extra argument that keeps the build going after
+// a module fails, or "".
+func failureModeArg(extraArgs []string) string {
+	for _, arg := range extraArgs {
+		switch arg {
+		case "-fae", "--fail-at-end", "-fn", "--fail-never":
+			return arg
+		}
+	}
+	return ""
+}
+
+// parallelBuild reports whether the extra arguments build modules in parallel.
+func parallelBuild(extraArgs []string) bool {
+	for _, arg := range extraArgs {
+		if arg == "--threads" || strings.HasPrefix(arg, "--threads=") || strings.HasPrefix(arg, "-T") {
+			return true
+		}
+	}
+	return false
+}
+
+// deployPluginVersion returns the maven-deploy-plugin version declared by the
+// POM or its local parents, or "" when it is not declared.
+func deployPluginVersion(chain []*pomModel) string {
+	for _, pom := range chain {
+		for _, plugins := range [][]pomPlugin{pom.BuildPlugins, pom.ManagedPlugins} {
+			for _, plugin := range plugins {
+				if plugin.ArtifactID == deployPluginArtifactID && plugin.Version != "" {
+					return resolvePOMValue(plugin.Version, chain)
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// resolvePOMValue resolves a ${property} value against the properties of the chain.
+func resolvePOMValue(value string, chain []*pomModel) string {
+	value = strings.TrimSpace(value)
+	name, ok := strings.CutPrefix(value, "${")
+	if !ok || !strings.HasSuffix(name, "}") {
+		return value
+	}
+	name = strings.TrimSuffix(name, "}")
+	for _, pom := range chain {
+		if v, ok := pom.Properties[name]; ok {
+			return strings.TrimSpace(v)
+		}
+	}
+	return value
+}
+
+// checkDeployAtEnd checks that deployAtEnd holds back the whole reactor in a
+// parallel build, which requires a recent maven-deploy-plugin. It returns a
+// warning when the plugin version is not declared in a local POM.
+func (cfg *Config) checkDeployAtEnd() (string, error) {
+	if !cfg.DeployAtEnd || !parallelBuild(cfg.ExtraArgs) {
+		return "", nil
+	}
+	chain, err := readPOMChain(cfg.projectPOM())
+	if err != nil {
+		return "", nil
+	}
+	version := deployPluginVersion(chain)
+	switch {
+	case version == "" || strings.Contains(version, "${"):
+		return fmt.Sprintf("deploy_at_end in a parallel build requires %s %s or newer; pin its version to be sure", deployPluginArtifactID, deployAtEndMinVersion), nil
+	case compareMavenVersions(version, deployAtEndMinVersion) < 0:
+		return "", fmt.Errorf("deploy_at_end in a parallel build requires %s %s or newer, found %s", deployPluginArtifactID, deployAtEndMinVersion, version)
+	}
+	return "", nil
+}
@@ -0,0 +1,146 @@
+// Package main provides tests for deploying a reactor at the end of the build.
+package main
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestParallelBuild(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{args: []string{"-T", "4"}, want: true},
+		{args: []string{"-T1C"}, want: true},
+		{args: []string{"--threads=2"}, want: true},
+		{args: []string{"-U", "-Dfoo=-T"}},
+		{},
+	}
+	for _, tt := range tests {
+		if got := parallelBuild(tt.args); got != tt.want {
+			t.Errorf("parallelBuild(%v) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestCheckDeployAtEnd(t *testing.T) {
+	deployPlugin := func(version string) string {
+		return `<project><artifactId>my-app</artifactId>
+  <properties><deploy.version>3.1.1</deploy.version></properties>
+  <build><pluginManagement><plugins><plugin><artifactId>maven-deploy-plugin</artifactId><version>` + version + `</version></plugin></plugins></pluginManagement></build>
+</project>`
+	}
+	tests := []struct {
+		name        string
+		pom         string
+		extraArgs   []string
+		wantWarning bool
+		errMsg      string
+	}{
+		{name: "sequential build", pom: deployPlugin("2.8.2")},
+		{name: "old plugin", pom: deployPlugin("2.8.2"), extraArgs: []string{"-T", "4"}, errMsg: "requires maven-deploy-plugin 3.0.0 or newer, found 2.8.2"},
+		{name: "plugin from property", pom: deployPlugin("${deploy.version}"), extraArgs: []string{"-T", "4"}},
+		{name: "plugin not pinned", pom: `<project><artifactId>my-app</artifactId></project>`, extraArgs: []string{"-T1C"}, wantWarning: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chdir(t, t.TempDir())
+			if err := os.WriteFile("pom.xml", []byte(tt.pom), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			cfg := &Config{PomPath: "pom.xml", DeployAtEnd: true, ExtraArgs: tt.extraArgs}
+
+			warning, err := cfg.checkDeployAtEnd()
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Errorf("expected error containing %q, got %v", tt.errMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if (warning != "") != tt.wantWarning {
+				t.Errorf("unexpected warning %q", warning)
+			}
+		})
+	}
+}
+
+func TestExecuteDeployAtEnd(t *testing.T) {
+	mockExec := &MockCommandExecutor{}
+	p := &MavenPlugin{executor: mockExec}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":       "com.example",
+			"artifact_id":    "my-app",
+			"repository":     "http://localhost:8081/repository/maven-releases",
+			"deploy_at_end":  true,
+			"install_at_end": true,
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+	args := strings.Join(mockExec.Calls[0].Args, " ")
+	if !strings.Contains(args, "-DinstallAtEnd=true -DdeployAtEnd=true") {
+		t.Errorf("expected the at-end flags, got %s", args)
+	}
+}
+
+func TestValidateDeployAtEnd(t *testing.T) {
+	tests := []struct {
+		name   string
+		config map[string]any
+		errMsg string
+	}{
+		{name: "valid", config: map[string]any{"extra_args": []any{"-T", "4"}}},
+		{name: "fail at end", config: map[string]any{"extra_args": []any{"--fail-at-end"}}, errMsg: "cannot be combined with --fail-at-end"},
+		{name: "failure strategy", config: map[string]any{"failure_strategy": "fail-never"}, errMsg: "cannot be combined with failure_strategy fail-never"},
+		{name: "http deployer", config: map[string]any{"deployer": "http"}, errMsg: "requires the maven deployer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]any{
+				"group_id":      "com.example",
+				"artifact_id":   "my-app",
+				"repository":    "http://localhost:8081/repository/maven-releases",
+				"deploy_at_end": true,
+			}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+			resp, err := (&MavenPlugin{}).Validate(context.Background(), config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var messages []string
+			for _, e := range resp.Errors {
+				if e.Field == "deploy_at_end" {
+					messages = append(messages, e.Message)
+				}
+			}
+			if tt.errMsg == "" {
+				if len(messages) > 0 {
+					t.Errorf("unexpected errors: %v", messages)
+				}
+				return
+			}
+			if !strings.Contains(strings.Join(messages, "\n"), tt.errMsg) {
+				t.Errorf("expected error containing %q, got %v", tt.errMsg, messages)
+			}
+		})
+	}
+}
@@ -0,0 +1,251 @@
+// Package main implements describing every artifact file a deploy published.
+package main
+
+import (
+	"crypto/sha256"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// deployedArtifact is a file published for a module of the release.
+type deployedArtifact struct {
+	GroupID    string
+	ArtifactID string
+	Version    string
+	Packaging  string
+	Classifier string
+	Extension  string
+	// File is the name of the file in the repository.
+	File   string
+	Size   int64
+	SHA256 string
+}
+
+// output returns the artifact as a plugin output entry.
+func (a deployedArtifact) output() map[string]any {
+	return map[string]any{
+		"group_id":    a.GroupID,
+		"artifact_id": a.ArtifactID,
+		"version":     a.Version,
+		"packaging":   a.Packaging,
+		"classifier":  a.Classifier,
+		"extension":   a.Extension,
+		"file":        a.File,
+		"size":        a.Size,
+		"sha256":      a.SHA256,
+	}
+}
+
+// deployedModules returns a configuration per module published by the deploy:
+// the configured module, or every project of the reactor. Aggregators are
+// left out when they are skipped.
+func (cfg *Config) deployedModules() []*Config {
+	if cfg.Module != "" {
+		return []*Config{cfg}
+	}
+	modules, err := readReactor(cfg.PomPath)
+	if err != nil || len(modules) < 2 {
+		return []*Config{cfg}
+	}
+
+	var skipped []string
+	if cfg.SkipAggregators {
+		skipped = aggregatorModules(modules)
+	}
+	var configs []*Config
+	for i, m := range modules {
+		if slices.Contains(skipped, m.key()) {
+			continue
+		}
+		if i == 0 {
+			configs = append(configs, cfg)
+			continue
+		}
+		c := *cfg
+		c.Module = m.Dir
+		c.ArtifactID = m.POM.ArtifactID
+		if groupID, _, _ := strings.Cut(m.key(), ":"); groupID != "" {
+			c.GroupID = groupID
+		}
+		configs = append(configs, &c)
+	}
+	return configs
+}
+
+// deployedArtifacts describes the POM and built files published for every
+// module of the release. Signatures and checksums are not listed, and files
+// missing from the build directory are left out.
+func (cfg *Config) deployedArtifacts(version string) []deployedArtifact {
+	version = mavenVersion(version)
+	var artifacts []deployedArtifact
+	for _, c := range cfg.deployedModules() {
+		packaging := c.packaging()
+		if packaging == "" {
+			packaging = defaultPackaging
+		}
+		base := c.ArtifactID + "-" + version
+		describe := func(path, classifier, extension string) {
+			info, err := os.Stat(path)
+			if err != nil {
+				return
+			}
+			digest, err := hashFile(path, sha256.New)
+			if err != nil {
+				return
+			}
+			file := base
+			if classifier != "" {
+				file += "-" + classifier
+			}
+			artifacts = append(artifacts, deployedArtifact{
+				GroupID:    c.GroupID,
+				ArtifactID: c.ArtifactID,
+				Version:    version,
+				Packaging:  packaging,
+				Classifier: classifier,
+				Extension:  extension,
+				File:       file + "." + extension,
+				Size:       info.Size(),
+				SHA256:     digest,
+			})
+		}
+
+		describe(c.deployedPOM(), "", "pom")
+		out, err := c.readBuildOutput(version)
+		if err != nil {
+			continue
+		}
+		for _, path := range out.Files {
+			name := filepath.Base(path)
+			if isChecksumFile(name) || strings.HasSuffix(name, ".asc") || strings.HasSuffix(name, ".pom") {
+				continue
+			}
+			rest := strings.TrimPrefix(name, out.FinalName)
+			classifier, extension, _ := strings.Cut(strings.TrimPrefix(rest, "-"), ".")
+			if strings.HasPrefix(rest, ".") {
+				classifier, extension = "", strings.TrimPrefix(rest, ".")
+			}
+			describe(path, classifier, extension)
+		}
+	}
+	return artifacts
+}
+
+// moduleStatus describes a reactor project and whether the deploy publishes it.
+type moduleStatus struct {
+	GroupID    string `json:"group_id"`
+	ArtifactID string `json:"artifact_id"`
+	Version    string `json:"version"`
+	Packaging  string `json:"packaging"`
+	Deployed   bool   `json:"deployed"`
+	SkipReason string `json:"skip_reason,omitempty"`
+}
+
+// moduleStatuses lists every project of a multi-module reactor with its
+// resolved version and whether the deploy publishes it, or nil for a
+// single-project build. Versions that cannot be resolved from the POMs and
+// the command line are reported as the release version.
+func (cfg *Config) moduleStatuses(version string) []moduleStatus {
+	modules, err := readReactor(cfg.PomPath)
+	if err != nil || len(modules) < 2 {
+		return nil
+	}
+
+	var aggregators []string
+	if cfg.SkipAggregators {
+		aggregators = aggregatorModules(modules)
+	}
+	commandLine := cfg.commandLineProperties()
+	selected := path.Clean(filepath.ToSlash(cfg.Module))
+
+	statuses := make([]moduleStatus, 0, len(modules))
+	for _, m := range modules {
+		groupID, artifactID, _ := strings.Cut(m.key(), ":")
+		status := moduleStatus{
+			GroupID:    groupID,
+			ArtifactID: artifactID,
+			Version:    mavenVersion(version),
+			Packaging:  m.POM.Packaging,
+			Deployed:   true,
+		}
+		if status.Packaging == "" {
+			status.Packaging = defaultPackaging
+		}
+
+		c := *cfg
+		c.Module = m.Dir
+		chain, err := readPOMChain(c.projectPOM())
+		if err == nil {
+			if v := resolveModuleVersion(chain, commandLine); v != "" {
+				status.Version = v
+			}
+		}
+
+		switch {
+		case cfg.Module != "" && m.Dir != selected:
+			status.SkipReason = "not the selected module " + cfg.Module
+		case slices.Contains(aggregators, m.key()):
+			status.SkipReason = "aggregator-only module skipped by skip_aggregators"
+		case err == nil:
+			status.SkipReason = deploySkipReason(chain, commandLine)
+		}
+		status.Deployed = status.SkipReason == ""
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// resolveModuleVersion returns the version of the project described by
+// chain, inherited from its parent when not declared, with properties set on
+// the command line taking precedence over the POMs. It returns "" when the
+// version cannot be resolved.
+func resolveModuleVersion(chain []*pomModel, commandLine map[string]string) string {
+	version := strings.TrimSpace(chain[0].Version)
+	if version == "" {
+		version = strings.TrimSpace(chain[0].Parent.Version)
+	}
+	if name, ok := strings.CutPrefix(version, "${"); ok && strings.HasSuffix(name, "}") {
+		if value, ok := commandLine[strings.TrimSuffix(name, "}")]; ok {
+			version = value
+		}
+	}
+	version = resolvePOMValue(version, chain)
+	if strings.Contains(version, "${") {
+		return ""
+	}
+	return version
+}
+
+// builtFiles returns the paths of the files built for every module of the
+// release, relative to the workspace, so later plugins can upload, hash or
+// sign them without running Maven again. Paths outside the workspace stay
+// absolute.
+func (cfg *Config) builtFiles(version string) []string {
+	workspace, err := os.Getwd()
+	if err != nil {
+		return nil
+	}
+	var files []string
+	for _, c := range cfg.deployedModules() {
+		out, err := c.readBuildOutput(version)
+		if err != nil {
+			continue
+		}
+		for _, file := range out.Files {
+			abs, err := filepath.Abs(file)
+			if err != nil {
+				continue
+			}
+			if rel, err := filepath.Rel(workspace, abs); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				file = rel
+			} else {
+				file = abs
+			}
+			files = append(files, filepath.ToSlash(file))
+		}
+	}
+	return files
+}
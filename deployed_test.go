@@ -0,0 +1,212 @@
+// Package main provides tests for the deployed artifacts outputs.
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestDeployedArtifacts(t *testing.T) {
+	root := writeReactor(t, sampleReactor)
+	chdir(t, root)
+	writeBuildOutput(t, "core", map[string]int{
+		"my-app-1.0.0.jar":         300,
+		"my-app-1.0.0-sources.jar": 100,
+		"my-app-1.0.0.jar.asc":     10,
+		"my-app-1.0.0.jar.sha1":    40,
+	})
+	writeBuildOutput(t, filepath.Join("examples", "basic"), map[string]int{
+		"my-app-example-basic-1.0.0-dist.tar.gz": 50,
+	})
+
+	cfg := &Config{GroupID: "com.example", ArtifactID: "my-app-aggregator", PomPath: "pom.xml", SkipAggregators: true}
+	artifacts := cfg.deployedArtifacts("v1.0.0")
+
+	var files []string
+	for _, a := range artifacts {
+		files = append(files, a.GroupID+":"+a.ArtifactID+":"+a.Packaging+":"+a.Classifier+":"+a.Extension+":"+a.File)
+	}
+	want := []string{
+		"com.example:my-app-parent:pom::pom:my-app-parent-1.0.0.pom",
+		"com.example:my-app:jar::pom:my-app-1.0.0.pom",
+		"com.example:my-app:jar:sources:jar:my-app-1.0.0-sources.jar",
+		"com.example:my-app:jar::jar:my-app-1.0.0.jar",
+		"com.example:my-app-example-basic:jar::pom:my-app-example-basic-1.0.0.pom",
+	}
+	if len(files) != len(want) {
+		t.Fatalf("expected %v, got %v", want, files)
+	}
+	for i := range want {
+		if files[i] != want[i] {
+			t.Errorf("artifact %d: expected %s, got %s", i, want[i], files[i])
+		}
+	}
+
+	sum := sha256.Sum256(make([]byte, 300))
+	if jar := artifacts[3]; jar.Size != 300 || jar.SHA256 != hex.EncodeToString(sum[:]) || jar.Version != "1.0.0" {
+		t.Errorf("unexpected jar entry: %+v", jar)
+	}
+}
+
+func TestDeployedArtifactsClassifierExtension(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+	if err := os.WriteFile("pom.xml", []byte(`<project><artifactId>my-app</artifactId><packaging>pom</packaging></project>`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	writeBuildOutput(t, dir, map[string]int{"my-app-1.0.0-dist.tar.gz": 10})
+
+	cfg := &Config{GroupID: "com.example", ArtifactID: "my-app", PomPath: "pom.xml"}
+	artifacts := cfg.deployedArtifacts("1.0.0")
+	if len(artifacts) != 2 {
+		t.Fatalf("expected the POM and the distribution, got %+v", artifacts)
+	}
+	if dist := artifacts[1]; dist.Classifier != "dist" || dist.Extension != "tar.gz" || dist.File != "my-app-1.0.0-dist.tar.gz" {
+		t.Errorf("unexpected distribution entry: %+v", dist)
+	}
+}
+
+func TestExecuteArtifactsOutput(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+	if err := os.WriteFile("pom.xml", []byte(`<project><artifactId>my-app</artifactId></project>`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	writeBuildOutput(t, dir, map[string]int{"my-app-1.0.0.jar": 10})
+
+	p := &MavenPlugin{executor: &MockCommandExecutor{}}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":    "com.example",
+			"artifact_id": "my-app",
+			"repository":  "http://localhost:8081/repository/maven-releases",
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+	artifacts, _ := resp.Outputs["artifacts"].([]map[string]any)
+	if len(artifacts) != 2 || artifacts[1]["file"] != "my-app-1.0.0.jar" || artifacts[1]["size"] != int64(10) {
+		t.Errorf("unexpected artifacts output: %v", artifacts)
+	}
+	if files, _ := resp.Outputs["built_files"].([]string); !reflect.DeepEqual(files, []string{"target/my-app-1.0.0.jar"}) {
+		t.Errorf("unexpected built_files output: %v", resp.Outputs["built_files"])
+	}
+}
+
+// versionedReactor is a reactor versioned through ${revision} whose example
+// module skips the deploy.
+var versionedReactor = map[string]string{
+	"pom.xml": `<project><modelVersion>4.0.0</modelVersion>
+  <groupId>com.example</groupId><artifactId>my-app-parent</artifactId><version>${revision}</version>
+  <packaging>pom</packaging>
+  <properties><revision>1.0.0-SNAPSHOT</revision></properties>
+  <modules><module>core</module><module>examples</module><module>tools</module></modules>
+</project>`,
+	"core/pom.xml": `<project><modelVersion>4.0.0</modelVersion>
+  <parent><groupId>com.example</groupId><artifactId>my-app-parent</artifactId><version>${revision}</version></parent>
+  <artifactId>my-app</artifactId>
+</project>`,
+	"examples/pom.xml": `<project><modelVersion>4.0.0</modelVersion>
+  <parent><groupId>com.example</groupId><artifactId>my-app-parent</artifactId><version>${revision}</version></parent>
+  <artifactId>my-app-examples</artifactId>
+  <properties><maven.deploy.skip>true</maven.deploy.skip></properties>
+</project>`,
+	"tools/pom.xml": `<project><modelVersion>4.0.0</modelVersion>
+  <groupId>org.example.tools</groupId><artifactId>my-app-tools</artifactId><version>2.0.0</version>
+  <packaging>maven-plugin</packaging>
+</project>`,
+}
+
+func TestModuleStatuses(t *testing.T) {
+	root := writeReactor(t, versionedReactor)
+	cfg := &Config{PomPath: filepath.Join(root, "pom.xml"), ExtraArgs: []string{"-Drevision=1.2.0"}}
+
+	statuses := cfg.moduleStatuses("v1.2.0")
+	want := []moduleStatus{
+		{GroupID: "com.example", ArtifactID: "my-app-parent", Version: "1.2.0", Packaging: "pom", Deployed: true},
+		{GroupID: "com.example", ArtifactID: "my-app", Version: "1.2.0", Packaging: "jar", Deployed: true},
+		{GroupID: "com.example", ArtifactID: "my-app-examples", Version: "1.2.0", Packaging: "jar", SkipReason: "maven.deploy.skip is true"},
+		{GroupID: "org.example.tools", ArtifactID: "my-app-tools", Version: "2.0.0", Packaging: "maven-plugin", Deployed: true},
+	}
+	if !reflect.DeepEqual(statuses, want) {
+		t.Errorf("moduleStatuses() =\n%+v\nwant\n%+v", statuses, want)
+	}
+
+	cfg = &Config{PomPath: filepath.Join(root, "pom.xml"), Module: "core/"}
+	statuses = cfg.moduleStatuses("1.2.0")
+	if statuses[0].Version != "1.0.0-SNAPSHOT" {
+		t.Errorf("expected the version from the POM property, got %s", statuses[0].Version)
+	}
+	for _, s := range statuses {
+		if s.Deployed != (s.ArtifactID == "my-app") {
+			t.Errorf("unexpected deployed status for %s: %+v", s.ArtifactID, s)
+		}
+	}
+}
+
+func TestModuleStatusesSingleProject(t *testing.T) {
+	chdir(t, t.TempDir())
+	if err := os.WriteFile("pom.xml", []byte(`<project><artifactId>my-app</artifactId></project>`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if statuses := (&Config{PomPath: "pom.xml"}).moduleStatuses("1.0.0"); statuses != nil {
+		t.Errorf("expected no module statuses, got %+v", statuses)
+	}
+}
+
+func TestExecuteModulesOutput(t *testing.T) {
+	chdir(t, writeReactor(t, versionedReactor))
+
+	p := &MavenPlugin{executor: &MockCommandExecutor{}}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":          "com.example",
+			"artifact_id":       "my-app-parent",
+			"repository":        "http://localhost:8081/repository/maven-releases",
+			"deploy_skip_check": "off",
+			"flatten_check":     "off",
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+	modules, _ := resp.Outputs["modules"].([]moduleStatus)
+	if len(modules) != 4 || modules[2].Deployed {
+		t.Errorf("unexpected modules output: %+v", resp.Outputs["modules"])
+	}
+}
+
+func TestBuiltFiles(t *testing.T) {
+	root := writeReactor(t, versionedReactor)
+	chdir(t, root)
+	writeBuildOutput(t, filepath.Join(root, "core"), map[string]int{
+		"my-app-1.2.0.jar":         10,
+		"my-app-1.2.0-sources.jar": 5,
+		"other.txt":                1,
+	})
+
+	cfg := &Config{PomPath: "pom.xml", GroupID: "com.example", ArtifactID: "my-app-parent"}
+	files := cfg.builtFiles("v1.2.0")
+	want := []string{"core/target/my-app-1.2.0-sources.jar", "core/target/my-app-1.2.0.jar"}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("builtFiles() = %v, want %v", files, want)
+	}
+}
@@ -0,0 +1,137 @@
+// Package main implements detecting projects whose deploy is skipped.
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Names of the maven-deploy-plugin and its skip property.
+const (
+	deployPluginArtifactID = "maven-deploy-plugin"
+	deploySkipProperty     = "maven.deploy.skip"
+)
+
+// maxParentDepth bounds the parent chain read for a POM.
+const maxParentDepth = 16
+
+// readPOMChain reads the POM at pomPath followed by the parents found on disk
+// through their relativePath. Parents only available from a repository end
+// the chain.
+func readPOMChain(pomPath string) ([]*pomModel, error) {
+	pom, err := readPOM(pomPath)
+	if err != nil {
+		return nil, err
+	}
+	chain := []*pomModel{pom}
+	for len(chain) < maxParentDepth {
+		parent := chain[len(chain)-1].Parent
+		if parent.ArtifactID == "" {
+			break
+		}
+		rel := "../pom.xml"
+		if parent.RelativePath != nil {
+			rel = strings.TrimSpace(*parent.RelativePath)
+		}
+		if rel == "" {
+			break
+		}
+		path := filepath.Join(filepath.Dir(pomPath), filepath.FromSlash(rel))
+		if !strings.HasSuffix(path, ".xml") {
+			path = filepath.Join(path, "pom.xml")
+		}
+		model, err := readPOM(path)
+		if err != nil || model.ArtifactID != parent.ArtifactID {
+			break
+		}
+		chain = append(chain, model)
+		pomPath = path
+	}
+	return chain, nil
+}
+
+// commandLineProperties returns the user properties set on the Maven command
+// line through properties and extra_args.
+func (cfg *Config) commandLineProperties() map[string]string {
+	props := map[string]string{}
+	for name, value := range cfg.Properties {
+		props[name] = value
+	}
+	for _, arg := range cfg.ExtraArgs {
+		if define, ok := strings.CutPrefix(arg, "-D"); ok {
+			name, value, found := strings.Cut(define, "=")
+			if !found {
+				value = "true"
+			}
+			props[name] = value
+		}
+	}
+	return props
+}
+
+// deploySkipReason reports why the project described by chain is not deployed,
+// or "" when it is. An explicit skip in the maven-deploy-plugin configuration
+// takes precedence over the maven.deploy.skip property, and properties set on
+// the command line override the POM.
+func deploySkipReason(chain []*pomModel, commandLine map[string]string) string {
+	props := map[string]string{}
+	for i := len(chain) - 1; i >= 0; i-- {
+		for name, value := range chain[i].Properties {
+			props[name] = value
+		}
+	}
+	for name, value := range commandLine {
+		props[name] = value
+	}
+	resolve := func(value string) string {
+		value = strings.TrimSpace(value)
+		if name, ok := strings.CutPrefix(value, "${"); ok && strings.HasSuffix(name, "}") {
+			return props[strings.TrimSuffix(name, "}")]
+		}
+		return value
+	}
+
+	for _, pom := range chain {
+		for _, plugins := range [][]pomPlugin{pom.BuildPlugins, pom.ManagedPlugins} {
+			for _, plugin := range plugins {
+				if plugin.ArtifactID != deployPluginArtifactID {
+					continue
+				}
+				if skip, ok := plugin.Configuration["skip"]; ok {
+					if resolve(skip) == "true" {
+						return fmt.Sprintf("%s is configured with <skip>%s</skip>", deployPluginArtifactID, skip)
+					}
+					return ""
+				}
+			}
+		}
+	}
+	if resolve(props[deploySkipProperty]) == "true" {
+		return deploySkipProperty + " is true"
+	}
+	return ""
+}
+
+// checkDeploySkip finds the deployed projects that skip the deploy. It fails
+// when the deploy would upload nothing: the published project, or every
+// project of the reactor, is skipped.
+func (cfg *Config) checkDeploySkip() ([]string, error) {
+	commandLine := cfg.commandLineProperties()
+	modules := cfg.deployedModules()
+	var skipped, reasons []string
+	for _, c := range modules {
+		chain, err := readPOMChain(c.projectPOM())
+		if err != nil {
+			continue
+		}
+		if reason := deploySkipReason(chain, commandLine); reason != "" {
+			skipped = append(skipped, c.GroupID+":"+c.ArtifactID)
+			reasons = append(reasons, fmt.Sprintf("%s:%s (%s)", c.GroupID, c.ArtifactID, reason))
+		}
+	}
+	if len(skipped) > 0 && len(skipped) == len(modules) {
+		return skipped, fmt.Errorf("nothing would be deployed: %s; Maven would report BUILD SUCCESS without uploading anything", strings.Join(reasons, ", "))
+	}
+	return skipped, nil
+}
@@ -0,0 +1,182 @@
+// Package main provides tests for detecting skipped deploys.
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestDeploySkipReason(t *testing.T) {
+	parent := `<project><groupId>com.example</groupId><artifactId>parent</artifactId><packaging>pom</packaging>
+  <properties><maven.deploy.skip>true</maven.deploy.skip></properties>
+</project>`
+
+	tests := []struct {
+		name        string
+		poms        map[string]string
+		commandLine map[string]string
+		wantReason  string
+	}{
+		{
+			name: "not skipped",
+			poms: map[string]string{"app/pom.xml": `<project><artifactId>app</artifactId></project>`},
+		},
+		{
+			name:       "skip property",
+			poms:       map[string]string{"app/pom.xml": `<project><artifactId>app</artifactId><properties><maven.deploy.skip>true</maven.deploy.skip></properties></project>`},
+			wantReason: "maven.deploy.skip is true",
+		},
+		{
+			name: "inherited skip property",
+			poms: map[string]string{
+				"pom.xml":     parent,
+				"app/pom.xml": `<project><parent><groupId>com.example</groupId><artifactId>parent</artifactId></parent><artifactId>app</artifactId></project>`,
+			},
+			wantReason: "maven.deploy.skip is true",
+		},
+		{
+			name: "child overrides inherited property",
+			poms: map[string]string{
+				"pom.xml":     parent,
+				"app/pom.xml": `<project><parent><groupId>com.example</groupId><artifactId>parent</artifactId></parent><artifactId>app</artifactId><properties><maven.deploy.skip>false</maven.deploy.skip></properties></project>`,
+			},
+		},
+		{
+			name: "parent resolved from the repository",
+			poms: map[string]string{
+				"pom.xml":     parent,
+				"app/pom.xml": `<project><parent><groupId>com.example</groupId><artifactId>parent</artifactId><relativePath/></parent><artifactId>app</artifactId></project>`,
+			},
+		},
+		{
+			name: "deploy plugin skip",
+			poms: map[string]string{"app/pom.xml": `<project><artifactId>app</artifactId><build><plugins>
+  <plugin><artifactId>maven-deploy-plugin</artifactId><configuration><skip>true</skip></configuration></plugin>
+</plugins></build></project>`},
+			wantReason: "<skip>true</skip>",
+		},
+		{
+			name: "managed deploy plugin skip from a property",
+			poms: map[string]string{"app/pom.xml": `<project><artifactId>app</artifactId><properties><skip.publish>true</skip.publish></properties><build><pluginManagement><plugins>
+  <plugin><artifactId>maven-deploy-plugin</artifactId><configuration><skip>${skip.publish}</skip></configuration></plugin>
+</plugins></pluginManagement></build></project>`},
+			wantReason: "<skip>${skip.publish}</skip>",
+		},
+		{
+			name: "plugin configuration overrides the property",
+			poms: map[string]string{"app/pom.xml": `<project><artifactId>app</artifactId><properties><maven.deploy.skip>true</maven.deploy.skip></properties><build><plugins>
+  <plugin><artifactId>maven-deploy-plugin</artifactId><configuration><skip>false</skip></configuration></plugin>
+</plugins></build></project>`},
+		},
+		{
+			name:        "command line property",
+			poms:        map[string]string{"app/pom.xml": `<project><artifactId>app</artifactId></project>`},
+			commandLine: map[string]string{"maven.deploy.skip": "true"},
+			wantReason:  "maven.deploy.skip is true",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := writeReactor(t, tt.poms)
+			chain, err := readPOMChain(filepath.Join(root, "app", "pom.xml"))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			reason := deploySkipReason(chain, tt.commandLine)
+			if tt.wantReason == "" {
+				if reason != "" {
+					t.Errorf("expected no skip, got %q", reason)
+				}
+				return
+			}
+			if !strings.Contains(reason, tt.wantReason) {
+				t.Errorf("expected reason containing %q, got %q", tt.wantReason, reason)
+			}
+		})
+	}
+}
+
+func TestCommandLineProperties(t *testing.T) {
+	cfg := &Config{
+		Properties: map[string]string{"env": "prod"},
+		ExtraArgs:  []string{"-Dmaven.deploy.skip", "-Dgpg.skip=false", "-U"},
+	}
+	props := cfg.commandLineProperties()
+	if props["env"] != "prod" || props["maven.deploy.skip"] != "true" || props["gpg.skip"] != "false" || len(props) != 3 {
+		t.Errorf("unexpected properties: %v", props)
+	}
+}
+
+func TestCheckDeploySkipReactor(t *testing.T) {
+	poms := map[string]string{}
+	for path, content := range sampleReactor {
+		poms[path] = content
+	}
+	poms["examples/basic/pom.xml"] = `<project><modelVersion>4.0.0</modelVersion>
+  <parent><groupId>com.example</groupId><artifactId>my-app-parent</artifactId><version>1.0.0</version><relativePath>../../parent</relativePath></parent>
+  <artifactId>my-app-example-basic</artifactId>
+  <properties><maven.deploy.skip>true</maven.deploy.skip></properties>
+</project>`
+	chdir(t, writeReactor(t, poms))
+
+	cfg := &Config{GroupID: "com.example", ArtifactID: "my-app-aggregator", PomPath: "pom.xml"}
+	skipped, err := cfg.checkDeploySkip()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(skipped, []string{"com.example:my-app-example-basic"}) {
+		t.Errorf("unexpected skipped modules: %v", skipped)
+	}
+
+	cfg.Module = "examples/basic"
+	if _, err := cfg.checkDeploySkip(); err == nil || !strings.Contains(err.Error(), "nothing would be deployed") {
+		t.Errorf("expected the skipped module to fail, got %v", err)
+	}
+}
+
+func TestExecuteDeploySkipped(t *testing.T) {
+	chdir(t, writeReactor(t, map[string]string{
+		"pom.xml": `<project><artifactId>my-app</artifactId><properties><maven.deploy.skip>true</maven.deploy.skip></properties></project>`,
+	}))
+
+	config := map[string]any{
+		"group_id":    "com.example",
+		"artifact_id": "my-app",
+		"repository":  "http://localhost:8081/repository/maven-releases",
+	}
+	mockExec := &MockCommandExecutor{}
+	p := &MavenPlugin{executor: mockExec}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:    plugin.HookPostPublish,
+		Config:  config,
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Success || !strings.Contains(resp.Error, "maven.deploy.skip is true") {
+		t.Errorf("expected the skipped deploy to fail, got %+v", resp)
+	}
+	if len(mockExec.Calls) != 0 {
+		t.Errorf("expected no Maven commands, got %d", len(mockExec.Calls))
+	}
+
+	config["deploy_skip_check"] = "warn"
+	resp, err = p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:    plugin.HookPostPublish,
+		Config:  config,
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if warnings, _ := resp.Outputs["warnings"].([]string); !resp.Success || len(warnings) != 1 {
+		t.Errorf("expected a warning, got %+v", resp)
+	}
+}
@@ -0,0 +1,86 @@
+// Package main implements the free disk space check before the release build.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// errDiskFreeUnsupported is returned where free disk space cannot be measured.
+var errDiskFreeUnsupported = errors.New("free disk space cannot be measured on this platform")
+
+// diskLocation is a directory the release build writes to.
+type diskLocation struct {
+	Name string
+	Path string
+}
+
+// localRepositoryDir returns the local repository Maven uses: the
+// maven.repo.local property, the isolated configuration's repository, or
+// ~/.m2/repository.
+func (cfg *Config) localRepositoryDir() string {
+	if dir := cfg.Properties["maven.repo.local"]; dir != "" {
+		return dir
+	}
+	for _, arg := range cfg.ExtraArgs {
+		if dir, ok := strings.CutPrefix(arg, "-Dmaven.repo.local="); ok {
+			return dir
+		}
+	}
+	if dir := cfg.mavenConfigDir(); dir != "" {
+		return filepath.Join(dir, "repository")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".m2", "repository")
+}
+
+// diskLocations returns the workspace and the local repository.
+func (cfg *Config) diskLocations() []diskLocation {
+	locations := []diskLocation{{Name: "workspace", Path: filepath.Dir(cfg.PomPath)}}
+	if dir := cfg.localRepositoryDir(); dir != "" {
+		locations = append(locations, diskLocation{Name: "local repository", Path: dir})
+	}
+	return locations
+}
+
+// existingDir returns the nearest existing directory of path, which is on the
+// file system path will be created on.
+func existingDir(path string) string {
+	path = filepath.Clean(path)
+	for {
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			return path
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return path
+		}
+		path = parent
+	}
+}
+
+// checkDiskSpace checks that every location the build writes to has at least
+// min_free_disk free, so the build does not run out of space halfway through
+// the deploy.
+func (cfg *Config) checkDiskSpace() error {
+	for _, location := range cfg.diskLocations() {
+		free, err := diskFree(existingDir(location.Path))
+		if errors.Is(err, errDiskFreeUnsupported) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to measure free space of the %s %s: %w", location.Name, location.Path, err)
+		}
+		if free < uint64(cfg.MinFreeDisk) {
+			return fmt.Errorf("only %s free for the %s %s, below min_free_disk of %s: free up space or move it to a larger volume",
+				formatSize(int64(free)), location.Name, location.Path, formatSize(cfg.MinFreeDisk))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+//go:build !(unix && !aix && !solaris) && !windows
+
+// Package main implements the free disk space fallback of other platforms.
+package main
+
+// diskFree reports that free disk space cannot be measured.
+func diskFree(path string) (uint64, error) {
+	return 0, errDiskFreeUnsupported
+}
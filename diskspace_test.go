@@ -0,0 +1,80 @@
+// Package main provides tests for the free disk space check before the release build.
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestLocalRepositoryDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	tests := []struct {
+		name string
+		cfg  *Config
+		want string
+	}{
+		{name: "default", cfg: &Config{}, want: filepath.Join(home, ".m2", "repository")},
+		{name: "isolated", cfg: &Config{MavenConfig: "/ci/m2"}, want: filepath.Join("/ci/m2", "repository")},
+		{name: "property", cfg: &Config{MavenConfig: "/ci/m2", Properties: map[string]string{"maven.repo.local": "/cache/repo"}}, want: "/cache/repo"},
+		{name: "extra args", cfg: &Config{ExtraArgs: []string{"-Dmaven.repo.local=/cache/repo"}}, want: "/cache/repo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.localRepositoryDir(); got != tt.want {
+				t.Errorf("localRepositoryDir() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExistingDir(t *testing.T) {
+	dir := t.TempDir()
+	if got := existingDir(filepath.Join(dir, "a", "b")); got != dir {
+		t.Errorf("existingDir() = %q, want %q", got, dir)
+	}
+}
+
+func TestCheckDiskSpace(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &Config{PomPath: filepath.Join(dir, "pom.xml"), MavenConfig: filepath.Join(dir, "m2"), MinFreeDisk: 1}
+	if err := cfg.checkDiskSpace(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	cfg.MinFreeDisk = 1 << 62
+	err := cfg.checkDiskSpace()
+	if err == nil || !strings.Contains(err.Error(), "free for the workspace "+dir+", below min_free_disk") {
+		t.Errorf("expected the workspace to lack space, got %v", err)
+	}
+}
+
+func TestExecuteMinFreeDisk(t *testing.T) {
+	mockExec := &MockCommandExecutor{}
+	p := &MavenPlugin{executor: mockExec}
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":      "com.example",
+			"artifact_id":   "my-app",
+			"repository":    "http://localhost:8081/repository/maven-releases",
+			"min_free_disk": "1000000000GB",
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Success || !strings.HasPrefix(resp.Error, "disk space: only ") {
+		t.Fatalf("expected the disk space check to fail, got %+v", resp)
+	}
+	if len(mockExec.Calls) != 0 {
+		t.Errorf("expected Maven not to run, got %v", mockExec.Calls)
+	}
+}
@@ -0,0 +1,16 @@
+//go:build unix && !aix && !solaris
+
+// Package main implements measuring free disk space on Unix.
+package main
+
+import "syscall"
+
+// diskFree returns the bytes available to the plugin's user on the file
+// system of path.
+func diskFree(path string) (uint64, error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return 0, err
+	}
+	return uint64(st.Bavail) * uint64(st.Bsize), nil
+}
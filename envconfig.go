@@ -0,0 +1,91 @@
+// Package main implements environment variable references in configuration values.
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// envReferencePattern matches ${ENV:NAME} references.
+var envReferencePattern = regexp.MustCompile(`\$\{ENV:([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// interpolateEnv replaces the ${ENV:NAME} references in the strings of a
+// configuration value and records the names of unset variables in missing.
+// An unset variable is left as is.
+func interpolateEnv(value any, missing map[string]bool) any {
+	switch v := value.(type) {
+	case string:
+		return envReferencePattern.ReplaceAllStringFunc(v, func(ref string) string {
+			name := envReferencePattern.FindStringSubmatch(ref)[1]
+			resolved, ok := os.LookupEnv(name)
+			if !ok {
+				missing[name] = true
+				return ref
+			}
+			return resolved
+		})
+	case []any:
+		interpolated := make([]any, len(v))
+		for i, item := range v {
+			interpolated[i] = interpolateEnv(item, missing)
+		}
+		return interpolated
+	case []string:
+		interpolated := make([]string, len(v))
+		for i, item := range v {
+			interpolated[i], _ = interpolateEnv(item, missing).(string)
+		}
+		return interpolated
+	case map[string]any:
+		interpolated := make(map[string]any, len(v))
+		for k, item := range v {
+			interpolated[k] = interpolateEnv(item, missing)
+		}
+		return interpolated
+	default:
+		return value
+	}
+}
+
+// interpolateConfig returns a copy of the raw configuration with the
+// environment variable references resolved, and the unset variables of each
+// option. The original configuration is not modified.
+func interpolateConfig(raw map[string]any) (map[string]any, map[string][]string) {
+	interpolated := make(map[string]any, len(raw))
+	unset := map[string][]string{}
+	for option, value := range raw {
+		missing := map[string]bool{}
+		interpolated[option] = interpolateEnv(value, missing)
+		if len(missing) == 0 {
+			continue
+		}
+		names := make([]string, 0, len(missing))
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		unset[option] = names
+	}
+	return interpolated, unset
+}
+
+// unsetOptions returns the options referencing unset variables, sorted.
+func unsetOptions(unset map[string][]string) []string {
+	options := make([]string, 0, len(unset))
+	for option := range unset {
+		options = append(options, option)
+	}
+	sort.Strings(options)
+	return options
+}
+
+// unsetEnvError describes the unset variables referenced by one option.
+func unsetEnvError(names []string) error {
+	if len(names) == 1 {
+		return fmt.Errorf("environment variable %s is not set", names[0])
+	}
+	return fmt.Errorf("environment variables %s are not set", strings.Join(names, ", "))
+}
@@ -0,0 +1,114 @@
+// Package main provides tests for environment variable references in configuration values.
+package main
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestInterpolateConfig(t *testing.T) {
+	t.Setenv("NEXUS_URL", "https://nexus.example.com")
+	t.Setenv("ORG", "example")
+
+	raw := map[string]any{
+		"repository": "${ENV:NEXUS_URL}/repository/maven-releases",
+		"group_id":   "com.${ENV:ORG}",
+		"profiles":   []any{"release", "${ENV:ORG}"},
+		"properties": map[string]any{"build.host": "${ENV:BUILD_HOST}", "team": "${ENV:TEAM}"},
+		"extra_args": []string{"-Dorg=${ENV:ORG}"},
+		"skip_tests": true,
+		"settings":   "${env.HOME}/settings.xml",
+	}
+	got, unset := interpolateConfig(raw)
+
+	want := map[string]any{
+		"repository": "https://nexus.example.com/repository/maven-releases",
+		"group_id":   "com.example",
+		"profiles":   []any{"release", "example"},
+		"properties": map[string]any{"build.host": "${ENV:BUILD_HOST}", "team": "${ENV:TEAM}"},
+		"extra_args": []string{"-Dorg=example"},
+		"skip_tests": true,
+		"settings":   "${env.HOME}/settings.xml",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("interpolateConfig() = %v, want %v", got, want)
+	}
+	if wantUnset := map[string][]string{"properties": {"BUILD_HOST", "TEAM"}}; !reflect.DeepEqual(unset, wantUnset) {
+		t.Errorf("unset = %v, want %v", unset, wantUnset)
+	}
+	if raw["group_id"] != "com.${ENV:ORG}" {
+		t.Error("interpolateConfig modified the configuration")
+	}
+}
+
+func TestExecuteEnvInterpolation(t *testing.T) {
+	t.Setenv("NEXUS_URL", "http://localhost:8081")
+	config := map[string]any{
+		"group_id":      "com.example",
+		"artifact_id":   "my-app",
+		"repository":    "${ENV:NEXUS_URL}/repository/maven-releases",
+		"repository_id": "releases",
+	}
+
+	mockExec := &MockCommandExecutor{}
+	resp, err := (&MavenPlugin{executor: mockExec}).Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:    plugin.HookPostPublish,
+		Config:  config,
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+	if args := strings.Join(mockExec.Calls[0].Args, " "); !strings.Contains(args, "releases::http://localhost:8081/repository/maven-releases") {
+		t.Errorf("expected the interpolated repository, got %s", args)
+	}
+
+	config["repository"] = "${ENV:MISSING_NEXUS_URL}/repository/maven-releases"
+	resp, err = (&MavenPlugin{executor: &MockCommandExecutor{}}).Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:    plugin.HookPostPublish,
+		Config:  config,
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Success || resp.Error != "repository: environment variable MISSING_NEXUS_URL is not set" {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+}
+
+func TestValidateEnvInterpolation(t *testing.T) {
+	t.Setenv("NEXUS_URL", "http://localhost:8081")
+	resp, err := (&MavenPlugin{}).Validate(context.Background(), map[string]any{
+		"group_id":       "com.example",
+		"artifact_id":    "my-app",
+		"repository":     "${ENV:NEXUS_URL}/repository/maven-releases",
+		"repository_id":  "${ENV:MISSING_REPOSITORY_ID}",
+		"snapshot_token": "${ENV:MISSING_B} ${ENV:MISSING_A}",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	messages := map[string][]string{}
+	for _, e := range resp.Errors {
+		messages[e.Field] = append(messages[e.Field], e.Message)
+	}
+	if msgs, ok := messages["repository"]; ok {
+		t.Errorf("unexpected repository errors: %v", msgs)
+	}
+	if !slices.Contains(messages["repository_id"], "environment variable MISSING_REPOSITORY_ID is not set") {
+		t.Errorf("unexpected repository_id errors: %v", messages["repository_id"])
+	}
+	if !slices.Contains(messages["snapshot_token"], "environment variables MISSING_A, MISSING_B are not set") {
+		t.Errorf("unexpected snapshot_token errors: %v", messages["snapshot_token"])
+	}
+}
@@ -0,0 +1,95 @@
+// Package main implements passing allowlisted environment variables to Maven.
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// envVarPattern matches environment variable names.
+var envVarPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// parsePassEnvProperties parses pass_env_properties into a map from
+// environment variable to Maven property. A list passes each variable under
+// its own name; a mapping names the property explicitly.
+func parsePassEnvProperties(raw map[string]any) map[string]string {
+	switch v := raw["pass_env_properties"].(type) {
+	case []any:
+		props := make(map[string]string, len(v))
+		for _, item := range v {
+			if name, ok := item.(string); ok {
+				props[name] = name
+			}
+		}
+		return props
+	case map[string]any:
+		props := make(map[string]string, len(v))
+		for env, item := range v {
+			if property, ok := item.(string); ok {
+				props[env] = property
+			}
+		}
+		return props
+	}
+	return nil
+}
+
+// envPropertyArgs returns -Dproperty=value arguments for the allowlisted
+// environment variables that are set, sorted by property name.
+func envPropertyArgs(cfg *Config) []string {
+	values := map[string]string{}
+	for env, property := range cfg.PassEnvProperties {
+		if value, ok := os.LookupEnv(env); ok {
+			values[property] = value
+		}
+	}
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	args := make([]string, 0, len(names))
+	for _, name := range names {
+		args = append(args, fmt.Sprintf("-D%s=%s", name, values[name]))
+	}
+	return args
+}
+
+// maskEnvProperties replaces the values of environment-supplied properties in
+// Maven arguments, which often carry tokens.
+func (cfg *Config) maskEnvProperties(args []string) []string {
+	if len(cfg.PassEnvProperties) == 0 {
+		return args
+	}
+	masked := make([]string, len(args))
+	for i, arg := range args {
+		masked[i] = arg
+		for _, property := range cfg.PassEnvProperties {
+			if strings.HasPrefix(arg, "-D"+property+"=") {
+				masked[i] = "-D" + property + "=" + maskedValue
+			}
+		}
+	}
+	return masked
+}
+
+// validatePassEnvProperties validates the environment variable names and
+// property names of pass_env_properties.
+func validatePassEnvProperties(props map[string]string, properties map[string]string) error {
+	for env, property := range props {
+		if !envVarPattern.MatchString(env) {
+			return fmt.Errorf("invalid environment variable name %q", env)
+		}
+		if err := validatePropertyName(property); err != nil {
+			return err
+		}
+		if _, ok := properties[property]; ok {
+			return fmt.Errorf("property %q is also set in properties", property)
+		}
+	}
+	return nil
+}
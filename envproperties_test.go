@@ -0,0 +1,122 @@
+// Package main provides tests for environment property passthrough.
+package main
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestParsePassEnvProperties(t *testing.T) {
+	list := parsePassEnvProperties(map[string]any{"pass_env_properties": []any{"BUILD_NUMBER"}})
+	if len(list) != 1 || list["BUILD_NUMBER"] != "BUILD_NUMBER" {
+		t.Errorf("unexpected list properties: %v", list)
+	}
+
+	mapping := parsePassEnvProperties(map[string]any{"pass_env_properties": map[string]any{"SONAR_TOKEN": "sonar.token"}})
+	if len(mapping) != 1 || mapping["SONAR_TOKEN"] != "sonar.token" {
+		t.Errorf("unexpected mapped properties: %v", mapping)
+	}
+}
+
+func TestEnvPropertyArgs(t *testing.T) {
+	t.Setenv("SONAR_TOKEN", "squ_secret")
+	t.Setenv("BUILD_NUMBER", "42")
+
+	cfg := &Config{PassEnvProperties: map[string]string{
+		"SONAR_TOKEN":  "sonar.token",
+		"BUILD_NUMBER": "build.number",
+		"UNSET_VAR":    "unset",
+	}}
+	args := envPropertyArgs(cfg)
+	want := []string{"-Dbuild.number=42", "-Dsonar.token=squ_secret"}
+	if !slices.Equal(args, want) {
+		t.Errorf("expected %v, got %v", want, args)
+	}
+
+	masked := cfg.maskEnvProperties(append([]string{"deploy"}, args...))
+	if !slices.Equal(masked, []string{"deploy", "-Dbuild.number=********", "-Dsonar.token=********"}) {
+		t.Errorf("unexpected masked args: %v", masked)
+	}
+}
+
+func TestExecutePassEnvProperties(t *testing.T) {
+	t.Setenv("SONAR_TOKEN", "squ_secret")
+
+	config := map[string]any{
+		"group_id":            "com.example",
+		"artifact_id":         "my-app",
+		"pass_env_properties": map[string]any{"SONAR_TOKEN": "sonar.token"},
+	}
+	mockExec := &MockCommandExecutor{}
+	p := &MavenPlugin{executor: mockExec}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:    plugin.HookPostPublish,
+		Config:  config,
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+	if !slices.Contains(mockExec.Calls[0].Args, "-Dsonar.token=squ_secret") {
+		t.Errorf("expected the token property, got %v", mockExec.Calls[0].Args)
+	}
+
+	resp, err = p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:    plugin.HookPostPublish,
+		Config:  config,
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+		DryRun:  true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	command, _ := resp.Outputs["command"].(string)
+	if strings.Contains(command, "squ_secret") || !strings.Contains(command, "-Dsonar.token=********") {
+		t.Errorf("expected the token to be masked, got %s", command)
+	}
+}
+
+func TestValidatePassEnvProperties(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		wantErr bool
+	}{
+		{name: "list", value: []any{"BUILD_NUMBER"}},
+		{name: "mapping", value: map[string]any{"SONAR_TOKEN": "sonar.token"}},
+		{name: "invalid variable", value: []any{"BUILD-NUMBER"}, wantErr: true},
+		{name: "invalid property", value: map[string]any{"SONAR_TOKEN": "sonar token"}, wantErr: true},
+		{name: "conflicts with properties", value: map[string]any{"REVISION": "revision"}, wantErr: true},
+		{name: "wrong type", value: "SONAR_TOKEN", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := (&MavenPlugin{}).Validate(context.Background(), map[string]any{
+				"group_id":            "com.example",
+				"artifact_id":         "my-app",
+				"properties":          map[string]any{"revision": "1.0.0"},
+				"pass_env_properties": tt.value,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			found := false
+			for _, e := range resp.Errors {
+				if e.Field == "pass_env_properties" {
+					found = true
+				}
+			}
+			if found != tt.wantErr {
+				t.Errorf("expected error=%v, got %v", tt.wantErr, resp.Errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+// Package main implements exporting the deployment to an archive for manual import.
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+)
+
+// exportArchiveFormats are the archive file extensions export_archive accepts.
+var exportArchiveFormats = []string{".zip", ".tar", ".tar.gz", ".tgz"}
+
+// exportArchiveConflicts are the options that need the release to reach a
+// repository during the run, which an exported deployment does not.
+var exportArchiveConflicts = append([]string{"user_token"}, fileRepositoryConflicts...)
+
+// exportArchiveFormat returns the extension of an export archive path, or ""
+// when it names no supported format.
+func exportArchiveFormat(path string) string {
+	for _, ext := range exportArchiveFormats {
+		if strings.HasSuffix(path, ext) {
+			return ext
+		}
+	}
+	return ""
+}
+
+// withExportRepository returns the configuration deploying releases and
+// snapshots to the local directory dir.
+func (cfg *Config) withExportRepository(dir string) *Config {
+	path := filepath.ToSlash(dir)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	exported := *cfg
+	exported.Repository = (&url.URL{Scheme: "file", Path: path}).String()
+	exported.SnapshotRepository = exported.Repository
+	return &exported
+}
+
+// exportDirectory creates the temporary directory the deployment is written
+// to and returns it with a function removing it. Dry runs only name it.
+func exportDirectory(dryRun bool) (string, func(), error) {
+	if dryRun {
+		return filepath.Join(os.TempDir(), "maven-export"), func() {}, nil
+	}
+	dir, err := os.MkdirTemp("", "maven-export-")
+	if err != nil {
+		return "", nil, err
+	}
+	return dir, func() { _ = os.RemoveAll(dir) }, nil
+}
+
+// exportFiles lists the files deployed to the export directory in the
+// repository layout.
+func exportFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	return files, err
+}
+
+// writeExportArchive writes the files deployed to dir to the archive at path
+// and returns their paths in the repository layout.
+func writeExportArchive(path, dir string) ([]string, error) {
+	files, err := exportFiles(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the deployed files: %w", err)
+	}
+	if len(files) == 0 {
+		return nil, errors.New("the deploy wrote no files")
+	}
+
+	if parent := filepath.Dir(path); parent != "." {
+		if err := os.MkdirAll(parent, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create archive directory: %w", err)
+		}
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create archive: %w", err)
+	}
+	if err := writeArchive(f, exportArchiveFormat(path), dir, files); err != nil {
+		f.Close()
+		os.Remove(path)
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(path)
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+	return files, nil
+}
+
+// writeArchive writes the files below dir to w in the archive format named by
+// its extension.
+func writeArchive(w io.Writer, format, dir string, files []string) error {
+	if format == ".zip" {
+		zw := zip.NewWriter(w)
+		for _, name := range files {
+			info, err := os.Stat(filepath.Join(dir, filepath.FromSlash(name)))
+			if err != nil {
+				return err
+			}
+			header, err := zip.FileInfoHeader(info)
+			if err != nil {
+				return err
+			}
+			header.Name = name
+			header.Method = zip.Deflate
+			entry, err := zw.CreateHeader(header)
+			if err != nil {
+				return err
+			}
+			if err := copyFile(entry, filepath.Join(dir, filepath.FromSlash(name))); err != nil {
+				return err
+			}
+		}
+		return zw.Close()
+	}
+
+	var gz *gzip.Writer
+	if format != ".tar" {
+		gz = gzip.NewWriter(w)
+		w = gz
+	}
+	tw := tar.NewWriter(w)
+	for _, name := range files {
+		info, err := os.Stat(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil {
+			return err
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = name
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if err := copyFile(tw, filepath.Join(dir, filepath.FromSlash(name))); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if gz != nil {
+		return gz.Close()
+	}
+	return nil
+}
+
+// copyFile copies the file at path to w.
+func copyFile(w io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return err
+}
+
+// validateExportArchive validates export_archive and the options it cannot
+// be combined with.
+func validateExportArchive(vb *helpers.ValidationBuilder, config map[string]any) {
+	parser := helpers.NewConfigParser(config)
+	path := parser.GetString("export_archive", "", "")
+	if path == "" {
+		return
+	}
+	if err := validatePath(path); err != nil {
+		vb.AddError("export_archive", err.Error())
+	} else if exportArchiveFormat(path) == "" {
+		vb.AddError("export_archive", fmt.Sprintf("export_archive must name a %s file", strings.Join(exportArchiveFormats, ", ")))
+	}
+
+	for _, field := range exportArchiveConflicts {
+		if parser.GetBool(field, false) {
+			vb.AddError(field, fmt.Sprintf("%s cannot be used with export_archive", field))
+		}
+	}
+	for _, field := range []string{"invoker_tests", "staging_profile_id", "promote_from", "p2_deploy_url"} {
+		if parser.GetString(field, "", "") != "" {
+			vb.AddError(field, fmt.Sprintf("%s cannot be used with export_archive", field))
+		}
+	}
+	if parser.GetString("downgrade_policy", "", policyOff) != policyOff {
+		vb.AddError("downgrade_policy", "downgrade_policy cannot be used with export_archive")
+	}
+	if parser.Has("repositories") {
+		vb.AddError("export_archive", "export_archive cannot be combined with repositories")
+	}
+}
@@ -0,0 +1,249 @@
+// Package main provides tests for exporting the deployment to an archive.
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// exportedFiles are the files of a deploy to the export repository.
+var exportedFiles = []string{
+	"com/example/my-app/1.0.0/my-app-1.0.0.jar",
+	"com/example/my-app/1.0.0/my-app-1.0.0.jar.asc",
+	"com/example/my-app/1.0.0/my-app-1.0.0.jar.sha1",
+	"com/example/my-app/1.0.0/my-app-1.0.0.pom",
+	"com/example/my-app/maven-metadata.xml",
+}
+
+// writeExportedFiles writes exportedFiles below dir.
+func writeExportedFiles(t *testing.T, dir string) {
+	t.Helper()
+	for _, name := range exportedFiles {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(name), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// archiveEntries returns the names and contents of the entries of an archive.
+func archiveEntries(t *testing.T, path string) map[string]string {
+	t.Helper()
+	entries := map[string]string{}
+	if strings.HasSuffix(path, ".zip") {
+		zr, err := zip.OpenReader(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer zr.Close()
+		for _, f := range zr.File {
+			r, err := f.Open()
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err := io.ReadAll(r)
+			r.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+			entries[f.Name] = string(data)
+		}
+		return entries
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var r io.Reader = f
+	if !strings.HasSuffix(path, ".tar") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r = gz
+	}
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return entries
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries[header.Name] = string(data)
+	}
+}
+
+func TestWriteExportArchive(t *testing.T) {
+	dir := t.TempDir()
+	writeExportedFiles(t, dir)
+
+	for _, ext := range exportArchiveFormats {
+		t.Run(ext, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "out", "release"+ext)
+			files, err := writeExportArchive(path, dir)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(files, exportedFiles) {
+				t.Errorf("expected files %v, got %v", exportedFiles, files)
+			}
+			entries := archiveEntries(t, path)
+			if len(entries) != len(exportedFiles) {
+				t.Errorf("expected %d entries, got %v", len(exportedFiles), entries)
+			}
+			for _, name := range exportedFiles {
+				if entries[name] != name {
+					t.Errorf("expected entry %s with its content, got %q", name, entries[name])
+				}
+			}
+		})
+	}
+
+	if _, err := writeExportArchive(filepath.Join(t.TempDir(), "release.zip"), t.TempDir()); err == nil || !strings.Contains(err.Error(), "the deploy wrote no files") {
+		t.Errorf("expected an error for an empty deploy, got %v", err)
+	}
+}
+
+func TestExecuteExportArchive(t *testing.T) {
+	chdir(t, t.TempDir())
+
+	var deployArgs []string
+	mockExec := &MockCommandExecutor{
+		RunFunc: func(ctx context.Context, name string, args ...string) ([]byte, error) {
+			deployArgs = args
+			for _, arg := range args {
+				if repo, ok := strings.CutPrefix(arg, "-DaltReleaseDeploymentRepository=releases::"); ok {
+					u, err := url.Parse(repo)
+					if err != nil {
+						t.Fatal(err)
+					}
+					writeExportedFiles(t, filepath.FromSlash(u.Path))
+				}
+			}
+			return []byte("[INFO] BUILD SUCCESS"), nil
+		},
+	}
+	p := &MavenPlugin{executor: mockExec}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":       "com.example",
+			"artifact_id":    "my-app",
+			"repository":     "http://localhost:8081/repository/maven-releases",
+			"export_archive": "dist/release.tar.gz",
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+	if strings.Contains(strings.Join(deployArgs, " "), "localhost:8081") {
+		t.Errorf("expected no deploy to the repository, got %v", deployArgs)
+	}
+	if resp.Message != "Wrote deployment archive for com.example:my-app:1.0.0 to dist/release.tar.gz" {
+		t.Errorf("unexpected message %q", resp.Message)
+	}
+	if got := resp.Outputs["export_files"]; !reflect.DeepEqual(got, exportedFiles) {
+		t.Errorf("expected export_files %v, got %v", exportedFiles, got)
+	}
+	if entries := archiveEntries(t, filepath.Join("dist", "release.tar.gz")); len(entries) != len(exportedFiles) {
+		t.Errorf("expected %d archive entries, got %v", len(exportedFiles), entries)
+	}
+	if _, ok := p.findDeploy("com.example", "my-app", "1.0.0"); ok {
+		t.Error("expected an exported deploy not to be recorded for rollback")
+	}
+}
+
+func TestExecuteExportArchiveDryRun(t *testing.T) {
+	resp, err := (&MavenPlugin{executor: &MockCommandExecutor{}}).Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":       "com.example",
+			"artifact_id":    "my-app",
+			"repository":     "http://localhost:8081/repository/maven-releases",
+			"export_archive": "release.zip",
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+		DryRun:  true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Outputs["export_archive"] != "release.zip" {
+		t.Errorf("expected export_archive output, got %v", resp.Outputs["export_archive"])
+	}
+	command, _ := resp.Outputs["command"].(string)
+	if !strings.Contains(command, "-DaltReleaseDeploymentRepository=releases::file://") || strings.Contains(command, "localhost:8081") {
+		t.Errorf("expected a deploy to the export directory, got %q", command)
+	}
+}
+
+func TestValidateExportArchive(t *testing.T) {
+	tests := []struct {
+		name   string
+		config map[string]any
+		field  string
+		errMsg string
+	}{
+		{name: "valid", config: map[string]any{"export_archive": "dist/release.tgz"}},
+		{name: "unsupported format", config: map[string]any{"export_archive": "release.rar"}, field: "export_archive", errMsg: "must name a .zip, .tar, .tar.gz, .tgz file"},
+		{name: "path traversal", config: map[string]any{"export_archive": "../release.zip"}, field: "export_archive"},
+		{name: "verify checksums", config: map[string]any{"export_archive": "release.zip", "verify_checksums": true}, field: "verify_checksums", errMsg: "cannot be used with export_archive"},
+		{name: "staging", config: map[string]any{"export_archive": "release.zip", "staging_profile_id": "abc123"}, field: "staging_profile_id", errMsg: "cannot be used with export_archive"},
+		{name: "http deployer", config: map[string]any{"export_archive": "release.zip", "deployer": "http"}, field: "export_archive", errMsg: "requires the maven deployer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]any{
+				"group_id":    "com.example",
+				"artifact_id": "my-app",
+				"repository":  "http://localhost:8081/repository/maven-releases",
+			}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+			resp, err := (&MavenPlugin{}).Validate(context.Background(), config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.field == "" {
+				if !resp.Valid {
+					t.Errorf("expected valid config, got %v", resp.Errors)
+				}
+				return
+			}
+			for _, e := range resp.Errors {
+				if e.Field == tt.field && strings.Contains(e.Message, tt.errMsg) {
+					return
+				}
+			}
+			t.Errorf("expected %s error containing %q, got %v", tt.field, tt.errMsg, resp.Errors)
+		})
+	}
+}
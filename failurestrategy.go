@@ -0,0 +1,90 @@
+// Package main implements the reactor failure strategy of the release build.
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Failure strategies of the reactor, named after Maven's options.
+const (
+	failureStrategyFailFast  = "fail-fast"
+	failureStrategyFailAtEnd = "fail-at-end"
+	failureStrategyFailNever = "fail-never"
+)
+
+// failureStrategies are the valid failure_strategy values.
+var failureStrategies = []string{failureStrategyFailFast, failureStrategyFailAtEnd, failureStrategyFailNever}
+
+// failureStrategyFlags maps Maven's failure options to their strategy.
+var failureStrategyFlags = map[string]string{
+	"-ff":           failureStrategyFailFast,
+	"--fail-fast":   failureStrategyFailFast,
+	"-fae":          failureStrategyFailAtEnd,
+	"--fail-at-end": failureStrategyFailAtEnd,
+	"-fn":           failureStrategyFailNever,
+	"--fail-never":  failureStrategyFailNever,
+}
+
+// failureStrategyArgs returns the Maven option selecting the configured
+// failure strategy. Maven fails fast when none is configured.
+func failureStrategyArgs(cfg *Config) []string {
+	if cfg.FailureStrategy == "" {
+		return nil
+	}
+	return []string{"--" + cfg.FailureStrategy}
+}
+
+// failureFlagArg returns the extra argument selecting a failure strategy, or "".
+func failureFlagArg(extraArgs []string) string {
+	for _, arg := range extraArgs {
+		if _, ok := failureStrategyFlags[arg]; ok {
+			return arg
+		}
+	}
+	return ""
+}
+
+// moduleFailures returns the failed modules of the reactor summary and the
+// number of modules skipped because a module they depend on failed.
+func moduleFailures(summary []reactorModuleResult) (failed []string, skipped int) {
+	for _, result := range summary {
+		switch result.Status {
+		case "FAILURE":
+			failed = append(failed, result.Module)
+		case "SKIPPED":
+			skipped++
+		}
+	}
+	return failed, skipped
+}
+
+// moduleFailureSummary lists the failed modules of a build that kept going
+// after a failure, e.g. "2 modules failed: core, web (3 skipped)". It returns
+// "" when no module failed.
+func moduleFailureSummary(summary []reactorModuleResult) string {
+	failed, skipped := moduleFailures(summary)
+	if len(failed) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	if len(failed) == 1 {
+		b.WriteString("1 module failed: ")
+	} else {
+		fmt.Fprintf(&b, "%d modules failed: ", len(failed))
+	}
+	b.WriteString(strings.Join(failed, ", "))
+	if skipped > 0 {
+		fmt.Fprintf(&b, " (%d skipped)", skipped)
+	}
+	return b.String()
+}
+
+// continuesAfterFailure reports whether the build keeps going after a module
+// fails, so its reactor summary can list several failed modules.
+func (cfg *Config) continuesAfterFailure() bool {
+	if cfg.FailureStrategy != "" {
+		return cfg.FailureStrategy != failureStrategyFailFast
+	}
+	return failureModeArg(cfg.ExtraArgs) != ""
+}
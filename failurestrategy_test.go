@@ -0,0 +1,164 @@
+// Package main provides tests for the reactor failure strategy.
+package main
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// failAtEndLog is the reactor summary of a fail-at-end build with two failed modules.
+const failAtEndLog = `[INFO] Reactor Summary for parent 1.0.0:
+[INFO]
+[INFO] parent ............................................. SUCCESS [  0.412 s]
+[INFO] core ............................................... FAILURE [  3.021 s]
+[INFO] web ................................................ FAILURE [  1.100 s]
+[INFO] app ................................................ SKIPPED
+[INFO] ------------------------------------------------------------------------
+[INFO] BUILD FAILURE
+`
+
+func TestModuleFailureSummary(t *testing.T) {
+	tests := []struct {
+		name    string
+		summary []reactorModuleResult
+		want    string
+	}{
+		{name: "none", summary: []reactorModuleResult{{Module: "core", Status: "SUCCESS"}}, want: ""},
+		{name: "one", summary: []reactorModuleResult{{Module: "core", Status: "FAILURE"}}, want: "1 module failed: core"},
+		{
+			name: "skipped",
+			summary: []reactorModuleResult{
+				{Module: "core", Status: "FAILURE"},
+				{Module: "web", Status: "FAILURE"},
+				{Module: "app", Status: "SKIPPED"},
+			},
+			want: "2 modules failed: core, web (1 skipped)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := moduleFailureSummary(tt.summary); got != tt.want {
+				t.Errorf("moduleFailureSummary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateFailureStrategy(t *testing.T) {
+	tests := []struct {
+		name   string
+		config map[string]any
+		errMsg string
+	}{
+		{name: "fail at end", config: map[string]any{"failure_strategy": "fail-at-end"}},
+		{name: "unknown", config: map[string]any{"failure_strategy": "fail-later"}, errMsg: "must be one of"},
+		{
+			name:   "extra args",
+			config: map[string]any{"failure_strategy": "fail-fast", "extra_args": []any{"-fae"}},
+			errMsg: "cannot be combined with -fae",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]any{
+				"group_id":    "com.example",
+				"artifact_id": "my-app",
+				"repository":  "http://localhost:8081/repository/maven-releases",
+			}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+			resp, err := (&MavenPlugin{}).Validate(context.Background(), config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var messages []string
+			for _, e := range resp.Errors {
+				if e.Field == "failure_strategy" {
+					messages = append(messages, e.Message)
+				}
+			}
+			if tt.errMsg == "" {
+				if len(messages) > 0 {
+					t.Errorf("unexpected errors: %v", messages)
+				}
+				return
+			}
+			if !strings.Contains(strings.Join(messages, "\n"), tt.errMsg) {
+				t.Errorf("expected error containing %q, got %v", tt.errMsg, messages)
+			}
+		})
+	}
+}
+
+func TestExecuteFailAtEnd(t *testing.T) {
+	mockExec := &MockCommandExecutor{
+		RunFunc: func(ctx context.Context, name string, args ...string) ([]byte, error) {
+			return []byte(failAtEndLog), errors.New("exit status 1")
+		},
+	}
+	p := &MavenPlugin{executor: mockExec}
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":         "com.example",
+			"artifact_id":      "parent",
+			"repository":       "http://localhost:8081/repository/maven-releases",
+			"failure_strategy": "fail-at-end",
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Success {
+		t.Fatal("expected failure")
+	}
+	if !strings.Contains(strings.Join(mockExec.Calls[0].Args, " "), "--fail-at-end") {
+		t.Errorf("expected --fail-at-end, got %v", mockExec.Calls[0].Args)
+	}
+	if !strings.HasPrefix(resp.Error, "Maven deploy failed: 2 modules failed: core, web (1 skipped)\n") {
+		t.Errorf("expected the failed modules first, got %q", resp.Error)
+	}
+	if got := resp.Outputs["failed_modules"]; !reflect.DeepEqual(got, []string{"core", "web"}) {
+		t.Errorf("unexpected failed_modules %v", got)
+	}
+}
+
+func TestExecuteFailNever(t *testing.T) {
+	mockExec := &MockCommandExecutor{
+		RunFunc: func(ctx context.Context, name string, args ...string) ([]byte, error) {
+			return []byte(strings.ReplaceAll(failAtEndLog, "BUILD FAILURE", "BUILD SUCCESS")), nil
+		},
+	}
+	p := &MavenPlugin{executor: mockExec}
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":         "com.example",
+			"artifact_id":      "parent",
+			"repository":       "http://localhost:8081/repository/maven-releases",
+			"failure_strategy": "fail-never",
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+	warnings, _ := resp.Outputs["warnings"].([]string)
+	if !strings.Contains(strings.Join(warnings, "\n"), "2 modules failed: core, web") {
+		t.Errorf("expected a failed modules warning, got %v", warnings)
+	}
+}
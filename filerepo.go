@@ -0,0 +1,132 @@
+// Package main implements deploying to a file:// repository for air-gapped environments.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+)
+
+// fileRepositoryConflicts are the options that read the deploy repository
+// over HTTP, which a file:// repository does not serve.
+var fileRepositoryConflicts = []string{"verify_checksums", "verify_metadata", "canary_build", "verify_resolution", "rollback_on_error"}
+
+// isFileRepository reports whether a repository URL is a file:// URL.
+func isFileRepository(rawURL string) bool {
+	return len(rawURL) >= 5 && strings.EqualFold(rawURL[:5], "file:")
+}
+
+// fileRepositoryPath returns the local directory of a file:// repository URL.
+func fileRepositoryPath(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid URL: %w", err)
+	}
+	if u.Host != "" && u.Host != "localhost" {
+		return "", fmt.Errorf("file:// URLs must not name a host (got %s)", u.Host)
+	}
+	path := u.Path
+	// file:///C:/repo has the path /C:/repo.
+	if runtime.GOOS == "windows" && len(path) >= 3 && path[0] == '/' && path[2] == ':' {
+		path = path[1:]
+	}
+	path = filepath.FromSlash(path)
+	if !filepath.IsAbs(path) {
+		return "", fmt.Errorf("file:// URLs must hold an absolute path")
+	}
+	return filepath.Clean(path), nil
+}
+
+// resolveExisting resolves the symbolic links of the longest existing prefix
+// of an absolute path, so a link inside the base directory cannot point out
+// of it.
+func resolveExisting(path string) string {
+	var rest []string
+	for dir := path; ; dir = filepath.Dir(dir) {
+		if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+			return filepath.Join(append([]string{resolved}, rest...)...)
+		}
+		if filepath.Dir(dir) == dir {
+			return path
+		}
+		rest = append([]string{filepath.Base(dir)}, rest...)
+	}
+}
+
+// validateFileRepository checks that a file:// repository lies inside the
+// base directory, which must exist.
+func validateFileRepository(rawURL, base string) error {
+	if base == "" {
+		return errors.New("file:// repositories require file_repository_base")
+	}
+	dir, err := fileRepositoryPath(rawURL)
+	if err != nil {
+		return err
+	}
+	baseDir, err := filepath.Abs(base)
+	if err != nil {
+		return fmt.Errorf("invalid file_repository_base: %w", err)
+	}
+	if info, err := os.Stat(baseDir); err != nil || !info.IsDir() {
+		return fmt.Errorf("file_repository_base %s is not a directory", base)
+	}
+	rel, err := filepath.Rel(resolveExisting(baseDir), resolveExisting(dir))
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("%s is outside file_repository_base %s", dir, base)
+	}
+	return nil
+}
+
+// validateDeployRepositoryURL validates the URL of repository or
+// snapshot_repository, which may be a file:// repository inside base.
+func validateDeployRepositoryURL(rawURL string, policy networkPolicy, base string) error {
+	if isFileRepository(rawURL) {
+		return validateFileRepository(rawURL, base)
+	}
+	return validateRepositoryURL(rawURL, policy)
+}
+
+// validateFileRepositories validates repository and snapshot_repository when
+// they are file:// URLs, and the options they cannot be combined with.
+func validateFileRepositories(vb *helpers.ValidationBuilder, config map[string]any) {
+	parser := helpers.NewConfigParser(config)
+	base := parser.GetString("file_repository_base", "", "")
+	fileRepository := false
+	for _, field := range []string{"repository", "snapshot_repository"} {
+		rawURL := parser.GetString(field, "", "")
+		if !isFileRepository(rawURL) {
+			continue
+		}
+		fileRepository = true
+		if err := validateFileRepository(rawURL, base); err != nil {
+			vb.AddError(field, err.Error())
+		}
+	}
+	if !fileRepository {
+		if base != "" {
+			vb.AddError("file_repository_base", "file_repository_base requires a file:// repository or snapshot_repository")
+		}
+		return
+	}
+
+	if parser.GetString("deployer", "", deployerMaven) == deployerHTTP {
+		vb.AddError("deployer", "file:// repositories require the maven deployer")
+	}
+	for _, field := range fileRepositoryConflicts {
+		if parser.GetBool(field, false) {
+			vb.AddError(field, fmt.Sprintf("%s cannot be used with a file:// repository", field))
+		}
+	}
+	if parser.GetString("invoker_tests", "", "") != "" {
+		vb.AddError("invoker_tests", "invoker_tests cannot be used with a file:// repository")
+	}
+	if parser.GetString("downgrade_policy", "", policyOff) != policyOff {
+		vb.AddError("downgrade_policy", "downgrade_policy cannot be used with a file:// repository")
+	}
+}
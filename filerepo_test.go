@@ -0,0 +1,156 @@
+// Package main provides tests for deploying to a file:// repository for air-gapped environments.
+package main
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// fileURL returns the file:// URL of a local path.
+func fileURL(path string) string {
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
+
+func TestValidateFileRepository(t *testing.T) {
+	base := t.TempDir()
+	outside := t.TempDir()
+	if err := os.Symlink(outside, filepath.Join(base, "escape")); err != nil && runtime.GOOS != "windows" {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		url     string
+		base    string
+		wantErr string
+	}{
+		{name: "inside", url: fileURL(filepath.Join(base, "releases")), base: base},
+		{name: "base itself", url: fileURL(base), base: base},
+		{name: "no base", url: fileURL(filepath.Join(base, "releases")), wantErr: "require file_repository_base"},
+		{name: "outside", url: fileURL(filepath.Join(outside, "releases")), base: base, wantErr: "is outside file_repository_base"},
+		{name: "traversal", url: fileURL(base) + "/../releases", base: base, wantErr: "is outside file_repository_base"},
+		{name: "host", url: "file://server/share/releases", base: base, wantErr: "must not name a host"},
+		{name: "relative", url: "file:releases", base: base, wantErr: "absolute path"},
+		{name: "missing base", url: fileURL(filepath.Join(base, "releases")), base: filepath.Join(base, "missing"), wantErr: "is not a directory"},
+	}
+	if runtime.GOOS != "windows" {
+		tests = append(tests, struct {
+			name    string
+			url     string
+			base    string
+			wantErr string
+		}{name: "symlink out of base", url: fileURL(filepath.Join(base, "escape", "releases")), base: base, wantErr: "is outside file_repository_base"})
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateFileRepository(tt.url, tt.base)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateFileRepositories(t *testing.T) {
+	base := t.TempDir()
+	repository := fileURL(filepath.Join(base, "releases"))
+
+	tests := []struct {
+		name   string
+		config map[string]any
+		field  string
+		errMsg string
+	}{
+		{name: "valid", config: map[string]any{"repository": repository, "file_repository_base": base}},
+		{name: "snapshot repository", config: map[string]any{"snapshot_repository": fileURL(filepath.Join(base, "snapshots")), "file_repository_base": base}},
+		{name: "no base", config: map[string]any{"repository": repository}, field: "repository", errMsg: "file:// repositories require file_repository_base"},
+		{name: "base without file repository", config: map[string]any{"repository": "https://repo.example.com/releases", "file_repository_base": base}, field: "file_repository_base", errMsg: "requires a file:// repository"},
+		{name: "http deployer", config: map[string]any{"repository": repository, "file_repository_base": base, "deployer": "http"}, field: "deployer", errMsg: "file:// repositories require the maven deployer"},
+		{name: "verify metadata", config: map[string]any{"repository": repository, "file_repository_base": base, "verify_metadata": true}, field: "verify_metadata", errMsg: "cannot be used with a file:// repository"},
+		{name: "downgrade policy", config: map[string]any{"repository": repository, "file_repository_base": base, "downgrade_policy": "fail"}, field: "downgrade_policy", errMsg: "cannot be used with a file:// repository"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]any{
+				"group_id":    "com.example",
+				"artifact_id": "my-app",
+			}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+			resp, err := (&MavenPlugin{}).Validate(context.Background(), config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.errMsg == "" {
+				if !resp.Valid {
+					t.Errorf("expected valid config, got %v", resp.Errors)
+				}
+				return
+			}
+			for _, e := range resp.Errors {
+				if e.Field == tt.field && strings.Contains(e.Message, tt.errMsg) {
+					return
+				}
+			}
+			t.Errorf("expected %s error containing %q, got %v", tt.field, tt.errMsg, resp.Errors)
+		})
+	}
+}
+
+func TestExecuteFileRepository(t *testing.T) {
+	base := t.TempDir()
+	repository := fileURL(filepath.Join(base, "releases"))
+
+	mockExec := &MockCommandExecutor{}
+	resp, err := (&MavenPlugin{executor: mockExec}).Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":             "com.example",
+			"artifact_id":          "my-app",
+			"repository":           repository,
+			"file_repository_base": base,
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+	if len(mockExec.Calls) == 0 || !strings.Contains(strings.Join(mockExec.Calls[len(mockExec.Calls)-1].Args, " "), "::"+repository) {
+		t.Errorf("expected a deploy to %s, got %v", repository, mockExec.Calls)
+	}
+
+	resp, err = (&MavenPlugin{executor: &MockCommandExecutor{}}).Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":    "com.example",
+			"artifact_id": "my-app",
+			"repository":  repository,
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Success || !strings.Contains(resp.Error, "invalid repository URL: file:// repositories require file_repository_base") {
+		t.Errorf("expected a file_repository_base error, got %q", resp.Error)
+	}
+}
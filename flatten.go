@@ -0,0 +1,79 @@
+// Package main implements the flatten-maven-plugin check for CI-friendly versions.
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Coordinates of the flatten-maven-plugin.
+const (
+	flattenPluginGroupID    = "org.codehaus.mojo"
+	flattenPluginArtifactID = "flatten-maven-plugin"
+	flattenGoal             = "flatten"
+)
+
+// flattenModes lists the flattenMode values of the flatten-maven-plugin.
+var flattenModes = []string{"defaults", "clean", "oss", "ossrh", "bom", "fatjar", "resolveCiFriendliesOnly", "minimum"}
+
+// ciFriendlyPattern matches the CI-friendly version placeholders Maven resolves
+// at build time but leaves in installed and deployed POMs.
+var ciFriendlyPattern = regexp.MustCompile(`\$\{(revision|sha1|changelist)\}`)
+
+// flattenGuidance explains how to configure the flatten-maven-plugin.
+const flattenGuidance = "add org.codehaus.mojo:flatten-maven-plugin to <build><plugins> with " +
+	"<flattenMode>resolveCiFriendliesOnly</flattenMode> and <updatePomFile>true</updatePomFile>, " +
+	"running the flatten goal in the process-resources phase"
+
+// ciFriendlyVersion returns the CI-friendly version expression of a POM, if any.
+func ciFriendlyVersion(pom *pomModel) string {
+	for _, v := range []string{pom.Version, pom.Parent.Version} {
+		if ciFriendlyPattern.MatchString(v) {
+			return v
+		}
+	}
+	return ""
+}
+
+// checkFlatten verifies that a POM using CI-friendly versions flattens the
+// POMs it publishes, which would otherwise contain unresolved placeholders.
+func checkFlatten(pom *pomModel) error {
+	version := ciFriendlyVersion(pom)
+	if version == "" {
+		return nil
+	}
+
+	var plugin *pomPlugin
+	for i := range pom.BuildPlugins {
+		p := &pom.BuildPlugins[i]
+		if p.ArtifactID == flattenPluginArtifactID && (p.GroupID == "" || p.GroupID == flattenPluginGroupID) {
+			plugin = p
+			break
+		}
+	}
+	if plugin == nil {
+		return fmt.Errorf("version %s is CI-friendly but flatten-maven-plugin is not configured, so published POMs would contain unresolved placeholders; %s", version, flattenGuidance)
+	}
+
+	bound := false
+	for _, execution := range plugin.Executions {
+		for _, goal := range execution.Goals {
+			if goal == flattenGoal {
+				bound = true
+			}
+		}
+	}
+	if !bound {
+		return fmt.Errorf("flatten-maven-plugin has no execution of the %s goal; %s", flattenGoal, flattenGuidance)
+	}
+
+	if mode := plugin.Configuration["flattenMode"]; mode != "" && !slices.Contains(flattenModes, mode) {
+		return fmt.Errorf("flatten-maven-plugin has unknown flattenMode %q (expected one of: %s)", mode, strings.Join(flattenModes, ", "))
+	}
+	if pom.Packaging == pomPackaging && plugin.Configuration["updatePomFile"] != "true" {
+		return fmt.Errorf("flatten-maven-plugin does not flatten pom-packaged projects unless <updatePomFile>true</updatePomFile> is set; %s", flattenGuidance)
+	}
+	return nil
+}
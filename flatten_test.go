@@ -0,0 +1,123 @@
+// Package main provides tests for the flatten-maven-plugin check.
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+const flattenPluginXML = `<build><plugins><plugin>
+    <groupId>org.codehaus.mojo</groupId><artifactId>flatten-maven-plugin</artifactId>
+    <configuration><flattenMode>%s</flattenMode><updatePomFile>true</updatePomFile></configuration>
+    <executions><execution><id>flatten</id><phase>process-resources</phase><goals><goal>flatten</goal></goals></execution></executions>
+  </plugin></plugins></build>`
+
+func TestCheckFlatten(t *testing.T) {
+	tests := []struct {
+		name   string
+		pom    string
+		errMsg string
+	}{
+		{
+			name: "fixed version",
+			pom:  `<project><version>1.0.0</version><packaging>jar</packaging></project>`,
+		},
+		{
+			name: "flattened revision",
+			pom: `<project><version>${revision}</version><packaging>pom</packaging>` +
+				strings.Replace(flattenPluginXML, "%s", "resolveCiFriendliesOnly", 1) + `</project>`,
+		},
+		{
+			name:   "missing plugin",
+			pom:    `<project><version>${revision}${changelist}</version></project>`,
+			errMsg: "flatten-maven-plugin is not configured",
+		},
+		{
+			name: "inherited revision",
+			pom: `<project><parent><groupId>com.example</groupId><artifactId>parent</artifactId><version>${revision}</version></parent>
+  <build><plugins><plugin><groupId>org.codehaus.mojo</groupId><artifactId>flatten-maven-plugin</artifactId></plugin></plugins></build></project>`,
+			errMsg: "no execution of the flatten goal",
+		},
+		{
+			name: "unknown mode",
+			pom: `<project><version>${revision}</version>` +
+				strings.Replace(flattenPluginXML, "%s", "everything", 1) + `</project>`,
+			errMsg: `unknown flattenMode "everything"`,
+		},
+		{
+			name: "pom packaging without updatePomFile",
+			pom: `<project><version>${revision}</version><packaging>pom</packaging>
+  <build><plugins><plugin><artifactId>flatten-maven-plugin</artifactId>
+  <executions><execution><goals><goal>flatten</goal></goals></execution></executions></plugin></plugins></build></project>`,
+			errMsg: "updatePomFile",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pom, err := parsePOM(strings.NewReader(tt.pom))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			err = checkFlatten(pom)
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("expected error containing %q, got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestExecuteFlattenCheck(t *testing.T) {
+	pomPath := writePOM(t, `<project><modelVersion>4.0.0</modelVersion><groupId>com.example</groupId>
+  <artifactId>my-app</artifactId><version>${revision}</version></project>`)
+	chdir(t, filepath.Dir(pomPath))
+
+	config := map[string]any{
+		"group_id":    "com.example",
+		"artifact_id": "my-app",
+		"pom_path":    "pom.xml",
+		"repository":  "http://localhost:8081/repository/maven-releases",
+	}
+
+	mockExec := &MockCommandExecutor{}
+	p := &MavenPlugin{executor: mockExec}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:    plugin.HookPostPublish,
+		Config:  config,
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Success || !strings.Contains(resp.Error, "flatten check") {
+		t.Errorf("expected the flatten check to fail the deploy, got %+v", resp)
+	}
+	if len(mockExec.Calls) != 0 {
+		t.Errorf("expected no deploy, got %d calls", len(mockExec.Calls))
+	}
+
+	config["flatten_check"] = "warn"
+	resp, err = p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:    plugin.HookPostPublish,
+		Config:  config,
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+		DryRun:  true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	warnings, _ := resp.Outputs["warnings"].([]string)
+	if !resp.Success || len(warnings) == 0 || !strings.HasPrefix(warnings[0], "flatten check") {
+		t.Errorf("expected a flatten warning, got %+v", resp)
+	}
+}
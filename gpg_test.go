@@ -0,0 +1,115 @@
+// Package main provides tests for non-interactive GPG signing.
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestParseGPGVersion(t *testing.T) {
+	tests := []struct {
+		output  string
+		want    string
+		wantErr bool
+	}{
+		{output: "gpg (GnuPG) 2.4.3\nlibgcrypt 1.10.2\n", want: "2.4.3"},
+		{output: "gpg (GnuPG/MacGPG2) 2.2.41\n", want: "2.2.41"},
+		{output: "gpg (GnuPG) 1.4.23\n", want: "1.4.23"},
+		{output: "command not found", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseGPGVersion(tt.output)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseGPGVersion(%q) = %q, %v; want %q", tt.output, got, err, tt.want)
+		}
+	}
+}
+
+func TestGPGArgs(t *testing.T) {
+	cfg := &Config{GPGLoopback: gpgLoopbackAlways, GPGPassphraseEnv: "SIGNING_PASSPHRASE", GPGExecutable: "gpg2"}
+	want := "-Dgpg.executable=gpg2 -Dgpg.gpgArguments=--pinentry-mode,loopback -Dgpg.passphraseEnvName=SIGNING_PASSPHRASE"
+	if got := strings.Join(gpgArgs(cfg), " "); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	cfg = &Config{GPGLoopback: gpgLoopbackNever, GPGPassphraseEnv: defaultGPGPassphraseEnv, GPGExecutable: defaultGPGExecutable}
+	if args := gpgArgs(cfg); len(args) != 0 {
+		t.Errorf("expected no arguments, got %v", args)
+	}
+}
+
+func TestResolveGPGLoopback(t *testing.T) {
+	tests := []struct {
+		name       string
+		passphrase string
+		output     string
+		runErr     error
+		want       string
+		wantCalls  int
+		wantErr    bool
+	}{
+		{name: "no passphrase", want: gpgLoopbackNever},
+		{name: "gpg 2", passphrase: "secret", output: "gpg (GnuPG) 2.4.3\n", want: gpgLoopbackAlways, wantCalls: 1},
+		{name: "gpg 1", passphrase: "secret", output: "gpg (GnuPG) 1.4.23\n", want: gpgLoopbackNever, wantCalls: 1},
+		{name: "gpg missing", passphrase: "secret", runErr: errors.New("exit status 127"), wantCalls: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(defaultGPGPassphraseEnv, tt.passphrase)
+			mockExec := &MockCommandExecutor{
+				RunFunc: func(_ context.Context, _ string, _ ...string) ([]byte, error) {
+					return []byte(tt.output), tt.runErr
+				},
+			}
+			p := &MavenPlugin{executor: mockExec}
+			cfg := p.parseConfig(map[string]any{})
+
+			resolved, err := p.resolveGPGLoopback(context.Background(), cfg)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resolved.GPGLoopback != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, resolved.GPGLoopback)
+			}
+			if len(mockExec.Calls) != tt.wantCalls {
+				t.Errorf("expected %d gpg calls, got %d", tt.wantCalls, len(mockExec.Calls))
+			}
+			if cfg.GPGLoopback != gpgLoopbackAuto {
+				t.Error("expected the original configuration to be unchanged")
+			}
+		})
+	}
+}
+
+func TestExecuteGPGLoopback(t *testing.T) {
+	t.Setenv(defaultGPGPassphraseEnv, "")
+	p := &MavenPlugin{executor: &MockCommandExecutor{}}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":     "com.example",
+			"artifact_id":  "my-app",
+			"repository":   "http://localhost:8081/repository/maven-releases",
+			"gpg_loopback": "always",
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+		DryRun:  true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if command, _ := resp.Outputs["command"].(string); !strings.Contains(command, "-Dgpg.gpgArguments=--pinentry-mode,loopback") {
+		t.Errorf("expected loopback signing in the command, got %s", command)
+	}
+}
@@ -0,0 +1,69 @@
+// Package main implements sizing the Maven heap and detecting out-of-memory failures.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// failureOutOfMemory classifies a build that ran out of memory.
+const failureOutOfMemory = "out_of_memory"
+
+// killedExitCode is the exit code of a process killed by SIGKILL, which is
+// how the kernel and container runtimes end a process out of memory.
+const killedExitCode = 137
+
+var (
+	// maxHeapPattern matches a JVM memory size such as 2g or 1536m.
+	maxHeapPattern = regexp.MustCompile(`^[1-9][0-9]*[kKmMgG]?$`)
+	// outOfMemoryPattern matches the error the JVM logs when a memory area is exhausted.
+	outOfMemoryPattern = regexp.MustCompile(`java\.lang\.OutOfMemoryError(?::[^\r\n]*)?`)
+)
+
+// validateMaxHeap validates the heap size of the Maven JVM.
+func validateMaxHeap(size string) error {
+	if size != "" && !maxHeapPattern.MatchString(size) {
+		return fmt.Errorf("max_heap must be a JVM memory size such as 2g or 1536m")
+	}
+	return nil
+}
+
+// mavenOptsEnv returns the MAVEN_OPTS setting max_heap, keeping the options
+// of the plugin's environment. A later -Xmx overrides an earlier one.
+func mavenOptsEnv(cfg *Config) []string {
+	if cfg.MaxHeap == "" {
+		return nil
+	}
+	opts := strings.TrimSpace(os.Getenv("MAVEN_OPTS") + " -Xmx" + cfg.MaxHeap)
+	return []string{"MAVEN_OPTS=" + opts}
+}
+
+// killed reports whether a command was killed by SIGKILL.
+func killed(err error) bool {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return false
+	}
+	return exitErr.ExitCode() == killedExitCode || strings.Contains(exitErr.String(), "signal: killed")
+}
+
+// outOfMemoryHint describes a build that ran out of memory and how to fix
+// it, or returns "" for other failures. Builds killed by the cancellation of
+// the release are not out of memory.
+func outOfMemoryHint(cfg *Config, output string, err error, cancelled bool) string {
+	if m := outOfMemoryPattern.FindString(output); m != "" {
+		hint := fmt.Sprintf("Maven ran out of memory (%s): ", strings.TrimSpace(m))
+		if cfg.MaxHeap == "" {
+			return hint + "set max_heap to give the Maven JVM a larger heap, e.g. 4g"
+		}
+		return hint + fmt.Sprintf("raise max_heap above %s", cfg.MaxHeap)
+	}
+	if !cancelled && killed(err) {
+		return fmt.Sprintf("Maven was killed (exit code %d), usually because the runner ran out of memory: give the runner more memory or lower max_heap", killedExitCode)
+	}
+	return ""
+}
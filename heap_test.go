@@ -0,0 +1,114 @@
+// Package main provides tests for sizing the Maven heap and detecting out-of-memory failures.
+package main
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestMavenOptsEnv(t *testing.T) {
+	t.Setenv("MAVEN_OPTS", "-Xmx1g -Dfile.encoding=UTF-8")
+	got := mavenOptsEnv(&Config{MaxHeap: "4g"})
+	if len(got) != 1 || got[0] != "MAVEN_OPTS=-Xmx1g -Dfile.encoding=UTF-8 -Xmx4g" {
+		t.Errorf("mavenOptsEnv() = %v", got)
+	}
+	if got := mavenOptsEnv(&Config{}); got != nil {
+		t.Errorf("expected no MAVEN_OPTS without max_heap, got %v", got)
+	}
+}
+
+func TestValidateMaxHeap(t *testing.T) {
+	for _, size := range []string{"", "4g", "1536m", "2G"} {
+		if err := validateMaxHeap(size); err != nil {
+			t.Errorf("validateMaxHeap(%q) = %v", size, err)
+		}
+	}
+	for _, size := range []string{"0g", "4gb", "-Xmx4g", "4g -Dx=y"} {
+		if err := validateMaxHeap(size); err == nil {
+			t.Errorf("expected %q to be rejected", size)
+		}
+	}
+}
+
+func TestOutOfMemoryHint(t *testing.T) {
+	exitErr := exec.Command("sh", "-c", "exit 137").Run()
+
+	tests := []struct {
+		name      string
+		cfg       *Config
+		output    string
+		err       error
+		cancelled bool
+		want      string
+	}{
+		{
+			name:   "heap",
+			cfg:    &Config{},
+			output: "[ERROR] java.lang.OutOfMemoryError: Java heap space\n",
+			err:    errors.New("exit status 1"),
+			want:   "Maven ran out of memory (java.lang.OutOfMemoryError: Java heap space): set max_heap",
+		},
+		{
+			name:   "configured heap",
+			cfg:    &Config{MaxHeap: "2g"},
+			output: "Exception in thread \"main\" java.lang.OutOfMemoryError: Metaspace",
+			err:    errors.New("exit status 1"),
+			want:   "raise max_heap above 2g",
+		},
+		{name: "killed", cfg: &Config{}, err: exitErr, want: "Maven was killed (exit code 137)"},
+		{name: "cancelled", cfg: &Config{}, err: exitErr, cancelled: true},
+		{name: "other failure", cfg: &Config{}, output: "[ERROR] BUILD FAILURE", err: errors.New("exit status 1")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := outOfMemoryHint(tt.cfg, tt.output, tt.err, tt.cancelled)
+			if tt.want == "" {
+				if got != "" {
+					t.Errorf("expected no hint, got %q", got)
+				}
+				return
+			}
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("expected hint containing %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestExecuteOutOfMemory(t *testing.T) {
+	mockExec := &MockCommandExecutor{
+		RunFunc: func(ctx context.Context, name string, args ...string) ([]byte, error) {
+			return []byte("[ERROR] java.lang.OutOfMemoryError: Java heap space\n"), errors.New("exit status 1")
+		},
+	}
+	p := &MavenPlugin{executor: mockExec}
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":    "com.example",
+			"artifact_id": "my-app",
+			"repository":  "http://localhost:8081/repository/maven-releases",
+			"max_heap":    "2g",
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Success {
+		t.Fatal("expected failure")
+	}
+	if !strings.HasPrefix(resp.Error, "Maven deploy failed: Maven ran out of memory") {
+		t.Errorf("expected the out-of-memory hint first, got %q", resp.Error)
+	}
+	if resp.Outputs["failure"] != failureOutOfMemory {
+		t.Errorf("expected the failure to be classified, got %v", resp.Outputs)
+	}
+}
@@ -0,0 +1,103 @@
+// Package main implements the heartbeat logged while a Maven build runs.
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultHeartbeatInterval is the heartbeat_interval in seconds when it is not set.
+const defaultHeartbeatInterval = 60
+
+// heartbeatLineLimit bounds the last log line quoted in a heartbeat.
+const heartbeatLineLimit = 200
+
+// heartbeat follows a Maven run and logs its state every interval, so hosts
+// with an inactivity timeout do not kill a long but healthy build.
+type heartbeat struct {
+	log      io.Writer
+	progress *reactorProgress
+	started  time.Time
+
+	// mu guards progress, lastLine and writes to log.
+	mu       sync.Mutex
+	lastLine string
+}
+
+// newHeartbeat returns a heartbeat for a run starting now.
+func newHeartbeat(log io.Writer, progress *reactorProgress) *heartbeat {
+	return &heartbeat{log: log, progress: progress, started: time.Now()}
+}
+
+// observe reads one line of the build log, logging each change of module or goal.
+func (h *heartbeat) observe(line string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if trimmed := strings.TrimSpace(ansiPattern.ReplaceAllString(line, "")); trimmed != "" {
+		h.lastLine = trimmed
+	}
+	if h.progress.observe(line) && h.progress.Module != "" {
+		fmt.Fprintf(h.log, "maven: %s\n", h.progress)
+	}
+}
+
+// beat logs the elapsed time, the module being built and the last log line,
+// e.g. "maven: still running after 5m0s: module 2 of 5: core 1.0.0; last output: [INFO] ...".
+func (h *heartbeat) beat(now time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	msg := "maven: still running after " + now.Sub(h.started).Round(time.Second).String()
+	if h.progress.Module != "" {
+		msg += ": " + h.progress.String()
+	}
+	if h.lastLine != "" {
+		last := h.lastLine
+		if len(last) > heartbeatLineLimit {
+			last = last[:heartbeatLineLimit] + "..."
+		}
+		msg += "; last output: " + last
+	}
+	fmt.Fprintln(h.log, msg)
+}
+
+// start logs a heartbeat every interval until the returned function is
+// called. A zero interval disables the heartbeat.
+func (h *heartbeat) start(interval time.Duration) func() {
+	if interval <= 0 {
+		return func() {}
+	}
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case now := <-ticker.C:
+				h.beat(now)
+			case <-done:
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			ticker.Stop()
+			close(done)
+			wg.Wait()
+		})
+	}
+}
+
+// validateHeartbeatInterval validates the heartbeat interval in seconds.
+func validateHeartbeatInterval(seconds int) error {
+	if seconds < 0 {
+		return fmt.Errorf("heartbeat_interval cannot be negative")
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+// Package main provides tests for the heartbeat logged while a Maven build runs.
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHeartbeatBeat(t *testing.T) {
+	var log strings.Builder
+	hb := newHeartbeat(&log, &reactorProgress{})
+	hb.beat(hb.started.Add(90 * time.Second))
+	hb.observe("[INFO] Building core 1.0.0                                                [2/3]")
+	hb.observe("[INFO] Compiling 42 source files")
+	hb.beat(hb.started.Add(5 * time.Minute))
+
+	want := "maven: still running after 1m30s\n" +
+		"maven: module 2 of 3: core 1.0.0\n" +
+		"maven: still running after 5m0s: module 2 of 3: core 1.0.0; last output: [INFO] Compiling 42 source files\n"
+	if log.String() != want {
+		t.Errorf("unexpected heartbeat log:\n%s", log.String())
+	}
+}
+
+func TestHeartbeatStart(t *testing.T) {
+	var log strings.Builder
+	hb := newHeartbeat(&log, &reactorProgress{})
+	stop := hb.start(5 * time.Millisecond)
+	time.Sleep(30 * time.Millisecond)
+	stop()
+	stop()
+
+	hb.mu.Lock()
+	beats := strings.Count(log.String(), "still running")
+	hb.mu.Unlock()
+	if beats == 0 {
+		t.Error("expected heartbeats while running")
+	}
+	time.Sleep(20 * time.Millisecond)
+	if after := strings.Count(log.String(), "still running"); after != beats {
+		t.Errorf("expected no heartbeats after stop, got %d more", after-beats)
+	}
+
+	// A zero interval disables the heartbeat.
+	hb.start(0)()
+}
@@ -0,0 +1,41 @@
+// Package main implements the Maven goals run before and after the deploy.
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// goalInvocation returns the Maven arguments running goals with the options
+// of the deploy invocation args, whose first argument is the deploy goal.
+func goalInvocation(goals, args []string) []string {
+	if len(goals) == 0 {
+		return nil
+	}
+	return append(slices.Clone(goals), args[1:]...)
+}
+
+// runGoals runs an invocation of pre_goals or post_goals, streaming its output
+// like the deploy.
+func (p *MavenPlugin) runGoals(ctx context.Context, cfg *Config, args []string) error {
+	output, err := p.runMaven(ctx, cfg, &reactorProgress{}, args)
+	if err != nil {
+		return fmt.Errorf("%s\nOutput: %s", describeExecError(cfg.mavenCommand(), err), cfg.errorOutput(output))
+	}
+	return nil
+}
+
+// withHookGoals returns the deploy invocations between the pre_goals and
+// post_goals invocations, as a dry run reports them.
+func withHookGoals(cfg *Config, invocations [][]string, args []string) [][]string {
+	var all [][]string
+	if pre := goalInvocation(cfg.PreGoals, args); pre != nil {
+		all = append(all, pre)
+	}
+	all = append(all, invocations...)
+	if post := goalInvocation(cfg.PostGoals, args); post != nil {
+		all = append(all, post)
+	}
+	return all
+}
@@ -0,0 +1,150 @@
+// Package main provides tests for the Maven goals run before and after the deploy.
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestExecuteHookGoals(t *testing.T) {
+	tests := []struct {
+		name      string
+		failGoal  string
+		wantErr   string
+		wantGoals []string
+	}{
+		{name: "success", wantGoals: []string{"com.example:custom-plugin:prepare", "deploy", "site:deploy"}},
+		{name: "pre goals fail", failGoal: "com.example:custom-plugin:prepare", wantErr: "pre_goals failed: ", wantGoals: []string{"com.example:custom-plugin:prepare"}},
+		{name: "post goals fail", failGoal: "site:deploy", wantErr: "post_goals failed after the deploy: ", wantGoals: []string{"com.example:custom-plugin:prepare", "deploy", "site:deploy"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockExec := &MockCommandExecutor{
+				RunFunc: func(ctx context.Context, name string, args ...string) ([]byte, error) {
+					if args[0] == tt.failGoal {
+						return []byte("[ERROR] BUILD FAILURE"), errors.New("exit status 1")
+					}
+					return []byte("[INFO] BUILD SUCCESS"), nil
+				},
+			}
+			resp, err := (&MavenPlugin{executor: mockExec}).Execute(context.Background(), plugin.ExecuteRequest{
+				Hook: plugin.HookPostPublish,
+				Config: map[string]any{
+					"group_id":    "com.example",
+					"artifact_id": "my-app",
+					"repository":  "http://localhost:8081/repository/maven-releases",
+					"profiles":    []any{"release"},
+					"pre_goals":   []any{"com.example:custom-plugin:prepare"},
+					"post_goals":  []any{"site:deploy", "-Dsite.skip.index=false"},
+				},
+				Context: plugin.ReleaseContext{Version: "1.0.0"},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr != "" {
+				if resp.Success || !strings.HasPrefix(resp.Error, tt.wantErr) {
+					t.Errorf("expected error starting with %q, got %q", tt.wantErr, resp.Error)
+				}
+			} else if !resp.Success {
+				t.Fatalf("expected success, got error: %s", resp.Error)
+			}
+
+			var goals []string
+			for _, call := range mockExec.Calls {
+				goals = append(goals, call.Args[0])
+				if !strings.Contains(strings.Join(call.Args, " "), "-P release") {
+					t.Errorf("expected the deploy profiles in %v", call.Args)
+				}
+				if call.Args[0] == "site:deploy" && call.Args[1] != "-Dsite.skip.index=false" {
+					t.Errorf("expected the post_goals flag after the goal, got %v", call.Args)
+				}
+			}
+			if strings.Join(goals, " ") != strings.Join(tt.wantGoals, " ") {
+				t.Errorf("expected runs %v, got %v", tt.wantGoals, goals)
+			}
+		})
+	}
+}
+
+func TestExecuteHookGoalsDryRun(t *testing.T) {
+	resp, err := (&MavenPlugin{executor: &MockCommandExecutor{}}).Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":    "com.example",
+			"artifact_id": "my-app",
+			"repository":  "http://localhost:8081/repository/maven-releases",
+			"pre_goals":   []any{"generate-resources"},
+			"post_goals":  []any{"site:deploy"},
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+		DryRun:  true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	command, _ := resp.Outputs["command"].(string)
+	commands := strings.Split(command, " && ")
+	if len(commands) != 3 || !strings.HasPrefix(commands[0], "mvn generate-resources -f pom.xml") || !strings.HasPrefix(commands[1], "mvn deploy -f pom.xml") || !strings.HasPrefix(commands[2], "mvn site:deploy -f pom.xml") {
+		t.Errorf("expected the pre_goals, deploy and post_goals commands, got %q", command)
+	}
+}
+
+func TestValidateHookGoals(t *testing.T) {
+	tests := []struct {
+		name   string
+		config map[string]any
+		field  string
+		errMsg string
+	}{
+		{name: "valid", config: map[string]any{"pre_goals": []any{"com.example:custom-plugin:1.0:prepare", "-Dprepare.skip=false"}, "post_goals": []any{"site:deploy"}}},
+		{name: "not a list", config: map[string]any{"pre_goals": "clean"}, field: "pre_goals", errMsg: "pre_goals must be a list of strings"},
+		{name: "invalid goal", config: map[string]any{"post_goals": []any{"site:deploy; rm -rf /"}}, field: "post_goals", errMsg: "invalid goal"},
+		{name: "option", config: map[string]any{"pre_goals": []any{"clean", "-X"}}, field: "pre_goals", errMsg: "only -Dname[=value] flags are allowed"},
+		{name: "flags only", config: map[string]any{"post_goals": []any{"-Dskip=true"}}, field: "post_goals", errMsg: "at least one goal or phase is required"},
+		{name: "http deployer", config: map[string]any{"post_goals": []any{"site:deploy"}, "deployer": "http"}, field: "post_goals", errMsg: "requires the maven deployer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]any{
+				"group_id":    "com.example",
+				"artifact_id": "my-app",
+				"repository":  "http://localhost:8081/repository/maven-releases",
+			}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+			resp, err := (&MavenPlugin{}).Validate(context.Background(), config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var messages []string
+			for _, e := range resp.Errors {
+				if e.Field == "pre_goals" || e.Field == "post_goals" {
+					messages = append(messages, e.Field+": "+e.Message)
+				}
+			}
+			if tt.errMsg == "" {
+				if len(messages) > 0 {
+					t.Errorf("unexpected errors: %v", messages)
+				}
+				return
+			}
+			found := false
+			for _, m := range messages {
+				if strings.HasPrefix(m, tt.field+": ") && strings.Contains(m, tt.errMsg) {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("expected %s error containing %q, got %v", tt.field, tt.errMsg, messages)
+			}
+		})
+	}
+}
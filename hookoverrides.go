@@ -0,0 +1,107 @@
+// Package main implements the per-hook overrides of the configuration.
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// hookOverrideForbidden are the options a hooks entry cannot override: the
+// defaults file is read before the overrides are applied.
+var hookOverrideForbidden = []string{"hooks", "defaults_file"}
+
+// withoutHooks returns the configuration without its hooks section.
+func withoutHooks(raw map[string]any) map[string]any {
+	if _, ok := raw["hooks"]; !ok {
+		return raw
+	}
+	base := make(map[string]any, len(raw))
+	for k, v := range raw {
+		if k != "hooks" {
+			base[k] = v
+		}
+	}
+	return base
+}
+
+// withHookOverrides returns the configuration with the hooks entry of hook
+// merged over it like the configuration over the defaults: objects are merged
+// key by key, while lists and scalars replace the base value.
+func withHookOverrides(raw map[string]any, hook plugin.Hook) map[string]any {
+	hooks, _ := raw["hooks"].(map[string]any)
+	base := withoutHooks(raw)
+	overrides, ok := hooks[string(hook)].(map[string]any)
+	if !ok {
+		return base
+	}
+	return mergeDefaults(base, overrides)
+}
+
+// validateHooks validates the configuration of every hook with overrides.
+// Errors of an override are reported under hooks.<hook>.<option> unless the
+// base configuration has them too.
+func (p *MavenPlugin) validateHooks(ctx context.Context, config map[string]any) (*plugin.ValidateResponse, error) {
+	resp, err := p.validateConfig(ctx, withoutHooks(config))
+	if err != nil {
+		return nil, err
+	}
+	raw, ok := config["hooks"]
+	if !ok {
+		return resp, nil
+	}
+	errs := resp.Errors
+	hooks, ok := raw.(map[string]any)
+	if !ok {
+		errs = append(errs, plugin.ValidationError{Field: "hooks", Message: "hooks must be an object of option overrides keyed by hook name"})
+		return &plugin.ValidateResponse{Valid: false, Errors: errs}, nil
+	}
+
+	reported := make(map[plugin.ValidationError]bool, len(resp.Errors))
+	for _, e := range resp.Errors {
+		reported[e] = true
+	}
+	names := make([]string, 0, len(hooks))
+	for name := range hooks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	supported := p.GetInfo().Hooks
+	for _, name := range names {
+		field := "hooks." + name
+		if !slices.Contains(supported, plugin.Hook(name)) {
+			errs = append(errs, plugin.ValidationError{Field: field, Message: fmt.Sprintf("unknown hook %q", name)})
+			continue
+		}
+		overrides, ok := hooks[name].(map[string]any)
+		if !ok {
+			errs = append(errs, plugin.ValidationError{Field: field, Message: fmt.Sprintf("%s must be an object of option overrides", field)})
+			continue
+		}
+		forbidden := false
+		for _, option := range hookOverrideForbidden {
+			if _, ok := overrides[option]; ok {
+				errs = append(errs, plugin.ValidationError{Field: field + "." + option, Message: fmt.Sprintf("%s cannot be overridden per hook", option)})
+				forbidden = true
+			}
+		}
+		if forbidden {
+			continue
+		}
+
+		hookResp, err := p.validateConfig(ctx, withHookOverrides(config, plugin.Hook(name)))
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range hookResp.Errors {
+			if !reported[e] {
+				e.Field = field + "." + e.Field
+				errs = append(errs, e)
+			}
+		}
+	}
+	return &plugin.ValidateResponse{Valid: len(errs) == 0, Errors: errs}, nil
+}
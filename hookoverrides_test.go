@@ -0,0 +1,139 @@
+// Package main provides tests for the per-hook overrides of the configuration.
+package main
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestWithHookOverrides(t *testing.T) {
+	raw := map[string]any{
+		"group_id":   "com.example",
+		"profiles":   []any{"verify"},
+		"properties": map[string]any{"a": "1", "b": "2"},
+		"hooks": map[string]any{
+			"post-publish": map[string]any{
+				"profiles":   []any{"release"},
+				"properties": map[string]any{"b": "3"},
+				"skip_tests": true,
+			},
+		},
+	}
+
+	got := withHookOverrides(raw, plugin.HookPostPublish)
+	want := map[string]any{
+		"group_id":   "com.example",
+		"profiles":   []any{"release"},
+		"properties": map[string]any{"a": "1", "b": "3"},
+		"skip_tests": true,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	got = withHookOverrides(raw, plugin.HookPrePublish)
+	want = map[string]any{
+		"group_id":   "com.example",
+		"profiles":   []any{"verify"},
+		"properties": map[string]any{"a": "1", "b": "2"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected the base configuration without hooks %v, got %v", want, got)
+	}
+	if _, ok := raw["hooks"]; !ok {
+		t.Error("expected the raw configuration to be left unchanged")
+	}
+}
+
+func TestExecuteHookOverrides(t *testing.T) {
+	resp, err := (&MavenPlugin{executor: &MockCommandExecutor{}}).Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":    "com.example",
+			"artifact_id": "my-app",
+			"repository":  "http://localhost:8081/repository/maven-releases",
+			"profiles":    []any{"verify"},
+			"hooks": map[string]any{
+				"pre-publish":  map[string]any{"profiles": []any{"ci"}},
+				"post-publish": map[string]any{"profiles": []any{"release"}, "skip_tests": true},
+			},
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+		DryRun:  true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+	command, _ := resp.Outputs["command"].(string)
+	if !strings.Contains(command, "-P release") || strings.Contains(command, "verify") || !strings.Contains(command, "-DskipTests") {
+		t.Errorf("expected the post-publish overrides in %q", command)
+	}
+}
+
+func TestValidateHookOverrides(t *testing.T) {
+	tests := []struct {
+		name   string
+		config map[string]any
+		field  string
+		errMsg string
+	}{
+		{name: "valid", config: map[string]any{"hooks": map[string]any{"post-publish": map[string]any{"profiles": []any{"release"}}}}},
+		{name: "not an object", config: map[string]any{"hooks": []any{"post-publish"}}, field: "hooks", errMsg: "hooks must be an object"},
+		{name: "unknown hook", config: map[string]any{"hooks": map[string]any{"post-deploy": map[string]any{}}}, field: "hooks.post-deploy", errMsg: `unknown hook "post-deploy"`},
+		{name: "entry not an object", config: map[string]any{"hooks": map[string]any{"post-publish": "release"}}, field: "hooks.post-publish", errMsg: "must be an object of option overrides"},
+		{name: "nested hooks", config: map[string]any{"hooks": map[string]any{"pre-publish": map[string]any{"hooks": map[string]any{}}}}, field: "hooks.pre-publish.hooks", errMsg: "cannot be overridden per hook"},
+		{name: "invalid override", config: map[string]any{"hooks": map[string]any{"post-publish": map[string]any{"profiles": []any{"release;rm"}}}}, field: "hooks.post-publish.profiles", errMsg: "invalid profile"},
+		{name: "conflicting override", config: map[string]any{"hooks": map[string]any{"post-publish": map[string]any{"deployer": "http", "pre_goals": []any{"verify"}}}}, field: "hooks.post-publish.pre_goals", errMsg: "requires the maven deployer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]any{
+				"group_id":    "com.example",
+				"artifact_id": "my-app",
+				"repository":  "http://localhost:8081/repository/maven-releases",
+			}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+			resp, err := (&MavenPlugin{}).Validate(context.Background(), config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.errMsg == "" {
+				if !resp.Valid {
+					t.Errorf("expected valid config, got %v", resp.Errors)
+				}
+				return
+			}
+			for _, e := range resp.Errors {
+				if e.Field == tt.field && strings.Contains(e.Message, tt.errMsg) {
+					return
+				}
+			}
+			t.Errorf("expected %s error containing %q, got %v", tt.field, tt.errMsg, resp.Errors)
+		})
+	}
+
+	// Errors of the base configuration are not repeated for every hook.
+	resp, err := (&MavenPlugin{}).Validate(context.Background(), map[string]any{
+		"group_id":    "com.example",
+		"artifact_id": "my-app",
+		"repository":  "http://localhost:8081/repository/maven-releases",
+		"profiles":    []any{"bad;profile"},
+		"hooks":       map[string]any{"post-publish": map[string]any{"skip_tests": true}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Errors) != 1 || resp.Errors[0].Field != "profiles" {
+		t.Errorf("expected only the base profiles error, got %v", resp.Errors)
+	}
+}
@@ -0,0 +1,431 @@
+// Package main implements deploying pre-built artifacts over HTTP without Maven.
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// Deployers publishing the release.
+const (
+	deployerMaven = "maven"
+	deployerHTTP  = "http"
+)
+
+// deployers lists the accepted values of the deployer option.
+var deployers = []string{deployerMaven, deployerHTTP}
+
+// Central Portal publishing types.
+const (
+	publishingTypeUserManaged = "USER_MANAGED"
+	publishingTypeAutomatic   = "AUTOMATIC"
+)
+
+// centralPortalUploadPath is the Central Portal Publisher API bundle upload endpoint.
+const centralPortalUploadPath = "/api/v1/publisher/upload"
+
+// defaultUploadRetries is the number of attempts for each upload.
+const defaultUploadRetries = 3
+
+// uploadBackoff is the delay before the first retry; it doubles on each attempt.
+var uploadBackoff = time.Second
+
+// deployChecksums lists the checksums uploaded next to every file. Signatures
+// get no checksums.
+var deployChecksums = []string{".md5", ".sha1", ".sha256", ".sha512"}
+
+// deployFile is a file uploaded by the HTTP deployer. Either Local names a
+// file on disk or Data holds the content.
+type deployFile struct {
+	Local string
+	Data  []byte
+	// Remote is the unescaped path below the repository URL.
+	Remote string
+}
+
+// open returns the content of the file.
+func (f deployFile) open() (io.ReadCloser, error) {
+	if f.Local == "" {
+		return io.NopCloser(bytes.NewReader(f.Data)), nil
+	}
+	return os.Open(f.Local)
+}
+
+// httpDeployResult describes a completed HTTP deploy.
+type httpDeployResult struct {
+	Files []string
+	// Uploaded lists the files uploaded so far; it equals Files once every
+	// file was uploaded.
+	Uploaded     []string
+	DeploymentID string
+	// Deployment is the last known status of the Central Portal deployment.
+	Deployment *portalDeployment
+	// Dropped reports whether the failed Central Portal deployment was dropped.
+	Dropped bool
+	// BundlePath is the path the Central bundle was written to instead of
+	// being uploaded.
+	BundlePath string
+}
+
+// outputs returns the outputs describing the upload. It is safe to call on a
+// nil result.
+func (r *httpDeployResult) outputs() map[string]any {
+	if r == nil {
+		return nil
+	}
+	if r.BundlePath != "" {
+		return map[string]any{"bundle_path": r.BundlePath, "bundle_files": r.Files}
+	}
+	outputs := map[string]any{"uploaded_files": r.Uploaded}
+	if r.DeploymentID != "" {
+		outputs["central_deployment_id"] = r.DeploymentID
+	}
+	if r.Dropped {
+		outputs["central_deployment_dropped"] = true
+	}
+	if r.Deployment != nil {
+		outputs["central_deployment_state"] = r.Deployment.State
+		if len(r.Deployment.PURLs) > 0 {
+			outputs["central_purls"] = r.Deployment.PURLs
+		}
+	}
+	return outputs
+}
+
+// layoutPath returns the unescaped repository layout directory of a GAV.
+func layoutPath(groupID, artifactID, version string) string {
+	return strings.ReplaceAll(groupID, ".", "/") + "/" + artifactID + "/" + version
+}
+
+// deployedPOM returns the POM published for the project: the POM written by
+// flatten-maven-plugin when present, otherwise the project POM.
+func (cfg *Config) deployedPOM() string {
+	pomPath := cfg.projectPOM()
+	if flattened := filepath.Join(filepath.Dir(pomPath), ".flattened-pom.xml"); fileExists(flattened) {
+		return flattened
+	}
+	return pomPath
+}
+
+// fileExists reports whether a regular file exists at path.
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+// httpDeployFiles returns the POM and the built files of the release in the
+// repository layout. Checksums found in the build directory are ignored
+// because fresh ones are computed for every file.
+func (cfg *Config) httpDeployFiles(version string) ([]deployFile, error) {
+	out, err := cfg.readBuildOutput(version)
+	if err != nil {
+		return nil, err
+	}
+
+	version = mavenVersion(version)
+	dir := layoutPath(cfg.GroupID, cfg.ArtifactID, version)
+	base := cfg.ArtifactID + "-" + version
+	files := []deployFile{{Local: cfg.deployedPOM(), Remote: dir + "/" + base + ".pom"}}
+	for _, path := range out.Files {
+		name := filepath.Base(path)
+		if isChecksumFile(name) || strings.HasSuffix(name, ".pom") {
+			continue
+		}
+		files = append(files, deployFile{Local: path, Remote: dir + "/" + base + strings.TrimPrefix(name, out.FinalName)})
+	}
+	if !fileExists(files[0].Local) {
+		return nil, fmt.Errorf("POM %s not found", files[0].Local)
+	}
+
+	// Upload the POM with the configured metadata. A signed POM cannot be
+	// changed after the build.
+	patched, elements, err := cfg.patchedPOM(files[0].Local)
+	if err != nil {
+		return nil, fmt.Errorf("pom_metadata: %w", err)
+	}
+	if patched != nil {
+		for _, f := range files {
+			if f.Remote == files[0].Remote+".asc" {
+				return nil, fmt.Errorf("pom_metadata cannot change the signed POM %s; add %s to the POM", files[0].Local, strings.Join(elements, ", "))
+			}
+		}
+		files[0] = deployFile{Data: patched, Remote: files[0].Remote}
+	}
+	return files, nil
+}
+
+// withChecksums returns the files followed by their checksum files with the
+// given extensions.
+func withChecksums(files []deployFile, exts []string) ([]deployFile, error) {
+	all := make([]deployFile, 0, len(files)*(len(exts)+1))
+	for _, f := range files {
+		all = append(all, f)
+		if strings.HasSuffix(f.Remote, ".asc") {
+			continue
+		}
+
+		hashes := make([]hash.Hash, len(exts))
+		writers := make([]io.Writer, len(exts))
+		for i, ext := range exts {
+			hashes[i] = checksumAlgorithms[ext]()
+			writers[i] = hashes[i]
+		}
+		r, err := f.open()
+		if err != nil {
+			return nil, err
+		}
+		_, err = io.Copy(io.MultiWriter(writers...), r)
+		r.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash %s: %w", f.Remote, err)
+		}
+		for i, ext := range exts {
+			all = append(all, deployFile{
+				Data:   []byte(hex.EncodeToString(hashes[i].Sum(nil))),
+				Remote: f.Remote + ext,
+			})
+		}
+	}
+	return all, nil
+}
+
+// retryable reports whether a failed request may succeed when repeated:
+// network errors, server errors, and rate limiting.
+func retryable(err error) bool {
+	var status *statusError
+	if errors.As(err, &status) {
+		return status.status >= http.StatusInternalServerError || status.status == http.StatusTooManyRequests
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr) && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
+
+// withRetry runs fn until it succeeds, fails permanently, or runs out of attempts.
+func withRetry(ctx context.Context, attempts int, fn func() error) error {
+	backoff := uploadBackoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = fn(); err == nil || !retryable(err) || attempt >= attempts {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// upload uploads a single file with retries.
+func (c *repositoryClient) upload(ctx context.Context, f deployFile, attempts int) error {
+	return withRetry(ctx, attempts, func() error {
+		if f.Local != "" {
+			return c.uploadFile(ctx, f.Remote, f.Local)
+		}
+		return c.uploadBytes(ctx, f.Remote, f.Data)
+	})
+}
+
+// updatedMetadata returns the artifact-level maven-metadata.xml listing the
+// new version, based on the metadata already in the repository.
+func updatedMetadata(existing *mavenMetadata, groupID, artifactID, version string, now time.Time) ([]byte, error) {
+	metadata := &mavenMetadata{GroupID: groupID, ArtifactID: artifactID}
+	if existing != nil {
+		metadata.Versioning = existing.Versioning
+	}
+	v := &metadata.Versioning
+	if !slices.Contains(v.Versions, version) {
+		v.Versions = append(v.Versions, version)
+	}
+	v.Latest = latestMavenVersion(v.Versions)
+	v.Release = v.Latest
+	v.LastUpdated = now.UTC().Format("20060102150405")
+
+	data, err := xml.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to render maven-metadata.xml: %w", err)
+	}
+	return append([]byte(xml.Header), append(data, '\n')...), nil
+}
+
+// httpDeploy uploads the POM, built files, signatures, and checksums of a
+// release directly to the repository, then updates maven-metadata.xml.
+// Releases for the Central Portal are uploaded as a single bundle instead,
+// and with bundle_path the bundle is only written to disk.
+func (p *MavenPlugin) httpDeploy(ctx context.Context, cfg *Config, releaseCtx plugin.ReleaseContext) (*httpDeployResult, error) {
+	version := mavenVersion(releaseCtx.Version)
+	if strings.HasSuffix(version, "-SNAPSHOT") {
+		return nil, fmt.Errorf("the http deployer does not support SNAPSHOT versions")
+	}
+	files, err := cfg.httpDeployFiles(version)
+	if err != nil {
+		return nil, err
+	}
+	files, err = withChecksums(files, cfg.checksumExtensions(deployChecksums))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &httpDeployResult{}
+	for _, f := range files {
+		result.Files = append(result.Files, f.Remote)
+	}
+
+	if cfg.BundlePath != "" {
+		if err := writeCentralBundle(cfg.BundlePath, files, cfg.ArtifactID, version, cfg.packaging()); err != nil {
+			return nil, err
+		}
+		result.BundlePath = cfg.BundlePath
+		return result, nil
+	}
+
+	if cfg.CentralPortalURL != "" {
+		result.DeploymentID, err = p.uploadCentralBundle(ctx, cfg, files, stagingDescription(cfg, releaseCtx))
+		if err != nil {
+			return nil, err
+		}
+		result.Uploaded = result.Files
+		if cfg.CentralWaitTimeout == 0 {
+			return result, nil
+		}
+		result.Deployment, err = p.waitForCentralDeployment(ctx, cfg, result.DeploymentID)
+		if err != nil && cfg.CentralDropFailed && result.Deployment != nil && droppable(result.Deployment.State) {
+			if dropErr := p.dropCentralDeployment(ctx, cfg, result.DeploymentID); dropErr != nil {
+				return result, fmt.Errorf("%w\ndropping the deployment failed: %v", err, dropErr)
+			}
+			result.Dropped = true
+		}
+		return result, err
+	}
+
+	attempts := cfg.UploadRetries
+	if attempts < 1 {
+		attempts = 1
+	}
+	client := newRepositoryClient(p.getHTTPClient(), cfg.targetRepository(version), cfg.Username, cfg.Password)
+	// A failed upload returns the files uploaded before it, which need
+	// cleaning up.
+	for _, f := range files {
+		if err := client.upload(ctx, f, attempts); err != nil {
+			return result, err
+		}
+		result.Uploaded = append(result.Uploaded, f.Remote)
+	}
+
+	existing, err := client.fetchMetadata(ctx, cfg.GroupID, cfg.ArtifactID)
+	if err != nil && !errors.Is(err, errComponentNotFound) {
+		return result, err
+	}
+	data, err := updatedMetadata(existing, cfg.GroupID, cfg.ArtifactID, version, time.Now())
+	if err != nil {
+		return result, err
+	}
+	gaDir := strings.ReplaceAll(cfg.GroupID, ".", "/") + "/" + cfg.ArtifactID
+	metadataFiles, err := withChecksums([]deployFile{{Data: data, Remote: gaDir + "/maven-metadata.xml"}}, cfg.checksumExtensions(deployChecksums))
+	if err != nil {
+		return result, err
+	}
+	for _, f := range metadataFiles {
+		if err := client.upload(ctx, f, attempts); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// centralBundle zips the files in the repository layout for the Central Portal.
+func centralBundle(files []deployFile) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range files {
+		w, err := zw.Create(f.Remote)
+		if err != nil {
+			return nil, err
+		}
+		r, err := f.open()
+		if err != nil {
+			return nil, err
+		}
+		_, err = io.Copy(w, r)
+		r.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to add %s to the bundle: %w", f.Remote, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// uploadCentralBundle uploads the release bundle to the Central Portal
+// Publisher API as the deployment named name and returns the deployment ID.
+func (p *MavenPlugin) uploadCentralBundle(ctx context.Context, cfg *Config, files []deployFile, name string) (string, error) {
+	bundle, err := centralBundle(files)
+	if err != nil {
+		return "", fmt.Errorf("failed to create bundle: %w", err)
+	}
+
+	publishingType := publishingTypeUserManaged
+	if cfg.AutoRelease != nil && *cfg.AutoRelease {
+		publishingType = publishingTypeAutomatic
+	}
+	uploadURL := strings.TrimSuffix(cfg.CentralPortalURL, "/") + centralPortalUploadPath + "?" + url.Values{"publishingType": {publishingType}, "name": {name}}.Encode()
+
+	attempts := cfg.UploadRetries
+	if attempts < 1 {
+		attempts = 1
+	}
+	var deploymentID string
+	err = withRetry(ctx, attempts, func() error {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		part, err := mw.CreateFormFile("bundle", cfg.ArtifactID+"-bundle.zip")
+		if err != nil {
+			return err
+		}
+		if _, err := part.Write(bundle); err != nil {
+			return err
+		}
+		if err := mw.Close(); err != nil {
+			return err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, uploadURL, &body)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+		req.Header.Set("Authorization", cfg.portalAuthorization())
+		resp, err := p.getHTTPClient().Do(req)
+		if err != nil {
+			return fmt.Errorf("bundle upload to %s failed: %w", redactURL(cfg.CentralPortalURL), err)
+		}
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<16))
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return &statusError{op: "bundle upload", status: resp.StatusCode, text: strings.TrimSpace(resp.Status + " " + string(data))}
+		}
+		deploymentID = strings.TrimSpace(string(data))
+		return nil
+	})
+	return deploymentID, err
+}
@@ -0,0 +1,302 @@
+// Package main provides tests for the HTTP deployer.
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// writeHTTPDeployProject writes a built project for com.example:my-app:1.0.0.
+func writeHTTPDeployProject(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	chdir(t, dir)
+	if err := os.WriteFile(filepath.Join(dir, "pom.xml"), []byte(`<project><artifactId>my-app</artifactId><version>1.0.0</version></project>`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	target := filepath.Join(dir, "target")
+	if err := os.MkdirAll(target, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{
+		"my-app-1.0.0.jar":         "main",
+		"my-app-1.0.0.jar.asc":     "signature",
+		"my-app-1.0.0-sources.jar": "sources",
+		"my-app-1.0.0.jar.sha1":    "stale",
+	} {
+		if err := os.WriteFile(filepath.Join(target, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// fakeRepository is an in-memory repository accepting PUT uploads.
+type fakeRepository struct {
+	mu    sync.Mutex
+	files map[string]string
+	// failures is the number of PUT requests answered with 503 before uploads succeed.
+	failures int
+}
+
+func (f *fakeRepository) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		if f.failures > 0 {
+			f.failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		f.files[r.URL.Path] = string(data)
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodGet:
+		content, ok := f.files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(content))
+	}
+}
+
+func TestExecuteHTTPDeploy(t *testing.T) {
+	writeHTTPDeployProject(t)
+	uploadBackoff = 0
+	t.Cleanup(func() { uploadBackoff = time.Second })
+
+	repo := &fakeRepository{
+		files: map[string]string{
+			"/repo/com/example/my-app/maven-metadata.xml": `<metadata><groupId>com.example</groupId><artifactId>my-app</artifactId><versioning><release>0.9.0</release><versions><version>0.9.0</version></versions></versioning></metadata>`,
+		},
+		failures: 1,
+	}
+	server := httptest.NewServer(repo)
+	defer server.Close()
+
+	mockExec := &MockCommandExecutor{}
+	p := &MavenPlugin{executor: mockExec, httpClient: server.Client()}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":    "com.example",
+			"artifact_id": "my-app",
+			"repository":  server.URL + "/repo",
+			"deployer":    "http",
+		},
+		Context: plugin.ReleaseContext{Version: "v1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+	if len(mockExec.Calls) != 0 {
+		t.Errorf("expected no Maven commands, got %v", mockExec.Calls)
+	}
+
+	gav := "/repo/com/example/my-app/1.0.0/"
+	for _, name := range []string{
+		"my-app-1.0.0.pom", "my-app-1.0.0.pom.md5", "my-app-1.0.0.pom.sha512",
+		"my-app-1.0.0.jar", "my-app-1.0.0.jar.sha1", "my-app-1.0.0.jar.sha256", "my-app-1.0.0.jar.asc",
+		"my-app-1.0.0-sources.jar", "my-app-1.0.0-sources.jar.md5",
+	} {
+		if _, ok := repo.files[gav+name]; !ok {
+			t.Errorf("expected %s to be uploaded", name)
+		}
+	}
+	if _, ok := repo.files[gav+"my-app-1.0.0.jar.asc.md5"]; ok {
+		t.Error("expected no checksums for signatures")
+	}
+	sum := sha1.Sum([]byte("main"))
+	if got := repo.files[gav+"my-app-1.0.0.jar.sha1"]; got != hex.EncodeToString(sum[:]) {
+		t.Errorf("expected a fresh checksum, got %q", got)
+	}
+
+	metadata := repo.files["/repo/com/example/my-app/maven-metadata.xml"]
+	for _, want := range []string{"<version>0.9.0</version>", "<version>1.0.0</version>", "<release>1.0.0</release>", "<latest>1.0.0</latest>", "<lastUpdated>"} {
+		if !strings.Contains(metadata, want) {
+			t.Errorf("expected metadata to contain %s, got %s", want, metadata)
+		}
+	}
+	if _, ok := repo.files["/repo/com/example/my-app/maven-metadata.xml.sha1"]; !ok {
+		t.Error("expected metadata checksums to be uploaded")
+	}
+	if files, _ := resp.Outputs["uploaded_files"].([]string); len(files) != 16 {
+		t.Errorf("expected 16 uploaded files, got %v", files)
+	}
+}
+
+func TestHTTPDeployFailure(t *testing.T) {
+	writeHTTPDeployProject(t)
+	uploadBackoff = 0
+	t.Cleanup(func() { uploadBackoff = time.Second })
+
+	var puts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		puts++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	p := &MavenPlugin{httpClient: server.Client()}
+	cfg := p.parseConfig(map[string]any{
+		"group_id":    "com.example",
+		"artifact_id": "my-app",
+		"repository":  server.URL + "/repo",
+		"deployer":    "http",
+	})
+	_, err := p.httpDeploy(context.Background(), cfg, plugin.ReleaseContext{Version: "1.0.0"})
+	if err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("expected an authorization error, got %v", err)
+	}
+	if puts != 1 {
+		t.Errorf("expected client errors not to be retried, got %d requests", puts)
+	}
+
+	if _, err := p.httpDeploy(context.Background(), cfg, plugin.ReleaseContext{Version: "1.1.0-SNAPSHOT"}); err == nil || !strings.Contains(err.Error(), "SNAPSHOT") {
+		t.Errorf("expected snapshots to be rejected, got %v", err)
+	}
+}
+
+func TestHTTPDeployCentralPortal(t *testing.T) {
+	writeHTTPDeployProject(t)
+
+	var query, auth string
+	var entries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/publisher/status" {
+			_, _ = w.Write([]byte(`{"deploymentId":"28570f16-da32-4c14-bd2e-c1acc0782365","deploymentState":"PUBLISHED","purls":["pkg:maven/com.example/my-app@1.0.0"]}`))
+			return
+		}
+		query, auth = r.URL.RawQuery, r.Header.Get("Authorization")
+		if r.URL.Path != "/api/v1/publisher/upload" {
+			http.NotFound(w, r)
+			return
+		}
+		file, _, err := r.FormFile("bundle")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		data, _ := io.ReadAll(file)
+		zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for _, f := range zr.File {
+			entries = append(entries, f.Name)
+		}
+		_, _ = w.Write([]byte("28570f16-da32-4c14-bd2e-c1acc0782365"))
+	}))
+	defer server.Close()
+
+	p := &MavenPlugin{httpClient: server.Client()}
+	cfg := p.parseConfig(map[string]any{
+		"group_id":           "com.example",
+		"artifact_id":        "my-app",
+		"username":           "token-user",
+		"password":           "token-pass",
+		"deployer":           "http",
+		"central_portal_url": server.URL,
+		"auto_release":       true,
+	})
+	result, err := p.httpDeploy(context.Background(), cfg, plugin.ReleaseContext{Version: "1.0.0", ReleaseNotes: "## Fixes\n- Fix the upload"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.DeploymentID != "28570f16-da32-4c14-bd2e-c1acc0782365" {
+		t.Errorf("unexpected deployment ID: %s", result.DeploymentID)
+	}
+	if result.Deployment == nil || result.Deployment.State != "PUBLISHED" {
+		t.Errorf("expected the published deployment status, got %+v", result.Deployment)
+	}
+	if query != "name=com.example%3Amy-app+1.0.0+-+Fixes%3B+Fix+the+upload&publishingType=AUTOMATIC" || auth != "Bearer dG9rZW4tdXNlcjp0b2tlbi1wYXNz" {
+		t.Errorf("unexpected request: query %q, authorization %q", query, auth)
+	}
+	if len(entries) != 16 || entries[0] != "com/example/my-app/1.0.0/my-app-1.0.0.pom" {
+		t.Errorf("unexpected bundle entries: %v", entries)
+	}
+}
+
+func TestHTTPDeployFlattenedPOM(t *testing.T) {
+	writeHTTPDeployProject(t)
+	if err := os.WriteFile(".flattened-pom.xml", []byte("<project/>"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &Config{GroupID: "com.example", ArtifactID: "my-app", PomPath: "pom.xml"}
+	files, err := cfg.httpDeployFiles("1.0.0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if files[0].Local != ".flattened-pom.xml" {
+		t.Errorf("expected the flattened POM to be published, got %s", files[0].Local)
+	}
+}
+
+func TestValidateHTTPDeployer(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    map[string]any
+		wantField string
+	}{
+		{name: "repository", config: map[string]any{"deployer": "http", "repository": "http://localhost:8081/repository/maven-releases"}},
+		{name: "central portal", config: map[string]any{"deployer": "http", "central_portal_url": "http://localhost:8080"}},
+		{name: "unknown deployer", config: map[string]any{"deployer": "gradle"}, wantField: "deployer"},
+		{name: "no target", config: map[string]any{"deployer": "http"}, wantField: "deployer"},
+		{name: "portal without http deployer", config: map[string]any{"central_portal_url": "http://localhost:8080"}, wantField: "central_portal_url"},
+		{name: "bundle path", config: map[string]any{"deployer": "http", "bundle_path": "dist/bundle.zip"}},
+		{name: "bundle path not a zip", config: map[string]any{"deployer": "http", "bundle_path": "dist/bundle"}, wantField: "bundle_path"},
+		{name: "bundle path with portal", config: map[string]any{"deployer": "http", "bundle_path": "dist/bundle.zip", "central_portal_url": "http://localhost:8080"}, wantField: "bundle_path"},
+		{name: "bundle path without http deployer", config: map[string]any{"bundle_path": "dist/bundle.zip"}, wantField: "bundle_path"},
+		{name: "maven-only option", config: map[string]any{"deployer": "http", "repository": "http://localhost:8081/repository/maven-releases", "tycho": true}, wantField: "tycho"},
+		{name: "invalid retries", config: map[string]any{"upload_retries": 0}, wantField: "upload_retries"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]any{"group_id": "com.example", "artifact_id": "my-app"}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+
+			resp, err := (&MavenPlugin{}).Validate(context.Background(), config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantField == "" {
+				if !resp.Valid {
+					t.Errorf("expected valid config, got errors: %v", resp.Errors)
+				}
+				return
+			}
+			found := false
+			for _, e := range resp.Errors {
+				if e.Field == tt.wantField {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("expected error on field %q, got %v", tt.wantField, resp.Errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+// Package main implements detecting repositories the release build reaches over plain HTTP.
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// settingsRepositories is the part of a settings.xml declaring repositories.
+type settingsRepositories struct {
+	Mirrors []struct {
+		ID      string `xml:"id"`
+		URL     string `xml:"url"`
+		Blocked bool   `xml:"blocked"`
+	} `xml:"mirrors>mirror"`
+	Profiles []settingsProfile `xml:"profiles>profile"`
+}
+
+// insecureRepositoryURL reports whether Maven reaches a repository URL over
+// plain HTTP. Like Maven's default HTTP blocker, it ignores localhost.
+func insecureRepositoryURL(rawURL string) bool {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || !strings.EqualFold(u.Scheme, "http") {
+		return false
+	}
+	host := u.Hostname()
+	if strings.EqualFold(host, "localhost") {
+		return false
+	}
+	ip := net.ParseIP(host)
+	return ip == nil || !ip.IsLoopback()
+}
+
+// insecureRepository describes a repository declared with a plain HTTP URL.
+func insecureRepository(kind, id, rawURL string) string {
+	return fmt.Sprintf("%s %s uses %s", kind, id, redactURL(strings.TrimSpace(rawURL)))
+}
+
+// insecurePOMRepositories lists the repositories and plugin repositories the
+// first POM of a chain declares, including those of its profiles, using plain
+// HTTP. Properties in their URLs resolve against the chain.
+func insecurePOMRepositories(chain []*pomModel) []string {
+	var problems []string
+	check := func(kind string, repositories []pomRepository) {
+		for _, r := range repositories {
+			if rawURL := resolvePOMValue(r.URL, chain); insecureRepositoryURL(rawURL) {
+				problems = append(problems, insecureRepository(kind, r.ID, rawURL))
+			}
+		}
+	}
+	pom := chain[0]
+	check("repository", pom.Repositories)
+	check("pluginRepository", pom.PluginRepositories)
+	for _, profile := range pom.Profiles {
+		check("repository", profile.Repositories)
+		check("pluginRepository", profile.PluginRepositories)
+	}
+	return problems
+}
+
+// insecureSettingsRepositories lists the mirrors and the profile repositories
+// of settings.xml content using plain HTTP. Blocked mirrors are ignored.
+func insecureSettingsRepositories(r io.Reader) ([]string, error) {
+	var settings settingsRepositories
+	if err := xml.NewDecoder(io.LimitReader(r, 10<<20)).Decode(&settings); err != nil {
+		return nil, fmt.Errorf("failed to parse settings file: %w", err)
+	}
+	var problems []string
+	for _, m := range settings.Mirrors {
+		if !m.Blocked && insecureRepositoryURL(m.URL) {
+			problems = append(problems, insecureRepository("mirror", m.ID, m.URL))
+		}
+	}
+	for _, profile := range settings.Profiles {
+		problems = append(problems, insecureProfileRepositories(&profile)...)
+	}
+	return problems, nil
+}
+
+// insecureProfileRepositories lists the repositories of a settings profile
+// using plain HTTP.
+func insecureProfileRepositories(profile *settingsProfile) []string {
+	var problems []string
+	for _, r := range profile.Repositories {
+		if insecureRepositoryURL(r.URL) {
+			problems = append(problems, insecureRepository("repository", r.ID, r.URL))
+		}
+	}
+	for _, r := range profile.PluginRepositories {
+		if insecureRepositoryURL(r.URL) {
+			problems = append(problems, insecureRepository("pluginRepository", r.ID, r.URL))
+		}
+	}
+	return problems
+}
+
+// checkInsecureRepositories checks the repositories declared by the POM of
+// every deployed module and its local parents, by the user settings, and by
+// resolution_repositories for plain HTTP URLs. Maven 3.8 and later blocks
+// them while resolving, after the build already started. POMs and settings
+// that cannot be read are skipped; Maven reports those itself.
+func (cfg *Config) checkInsecureRepositories() error {
+	var problems []string
+	seen := map[string]bool{}
+	add := func(source string, found []string) {
+		for _, problem := range found {
+			if entry := source + ": " + problem; !seen[entry] {
+				seen[entry] = true
+				problems = append(problems, entry)
+			}
+		}
+	}
+
+	for _, module := range cfg.deployedModules() {
+		chain, err := readPOMChain(module.projectPOM())
+		if err != nil || len(chain) == 0 {
+			continue
+		}
+		add(module.GroupID+":"+module.ArtifactID, insecurePOMRepositories(chain))
+		for i := 1; i < len(chain); i++ {
+			add(pomKey(chain[i]), insecurePOMRepositories(chain[i:]))
+		}
+	}
+
+	if path, _ := cfg.settingsLookup(); path != "" {
+		if f, err := os.Open(path); err == nil {
+			found, err := insecureSettingsRepositories(f)
+			f.Close()
+			if err == nil {
+				add(path, found)
+			}
+		}
+	}
+	if profile := cfg.resolutionProfile(); profile != nil {
+		add("resolution_repositories", insecureProfileRepositories(profile))
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("repositories use plain HTTP, which Maven blocks: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// pomKey returns the groupId:artifactId of a POM, inheriting the groupId
+// from the parent.
+func pomKey(pom *pomModel) string {
+	groupID := pom.GroupID
+	if groupID == "" {
+		groupID = pom.Parent.GroupID
+	}
+	return groupID + ":" + pom.ArtifactID
+}
@@ -0,0 +1,169 @@
+// Package main implements post-deploy integration tests with the Maven Invoker Plugin.
+package main
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// invokerPlugin is the Maven Invoker Plugin running the integration tests.
+const invokerPlugin = "org.apache.maven.plugins:maven-invoker-plugin:3.8.0"
+
+// invokerRepositoryID is the server ID of the deploy repository in the invoker settings.
+const invokerRepositoryID = "relicta-invoker"
+
+// invokerBuildJob is the report the invoker writes for each sample project.
+type invokerBuildJob struct {
+	Project        string `xml:"project,attr"`
+	Result         string `xml:"result,attr"`
+	FailureMessage string `xml:"failureMessage,attr"`
+}
+
+// invokerResults summarizes the invoker reports.
+type invokerResults struct {
+	Passed   int      `json:"passed"`
+	Failed   int      `json:"failed"`
+	Skipped  int      `json:"skipped"`
+	Failures []string `json:"failures,omitempty"`
+}
+
+// invokerProfile is a settings profile adding the deploy repository.
+type invokerProfile struct {
+	ID                 string             `xml:"id"`
+	Repositories       []canaryRepository `xml:"repositories>repository"`
+	PluginRepositories []canaryRepository `xml:"pluginRepositories>pluginRepository"`
+}
+
+// invokerSettings is the settings file of the invoked builds. The invoker
+// merges it with the user settings.
+type invokerSettings struct {
+	XMLName        xml.Name         `xml:"settings"`
+	Xmlns          string           `xml:"xmlns,attr"`
+	Servers        []settingsServer `xml:"servers>server"`
+	Profiles       []invokerProfile `xml:"profiles>profile"`
+	ActiveProfiles []string         `xml:"activeProfiles>activeProfile"`
+}
+
+// renderInvokerSettings renders settings resolving artifacts and plugins
+// from the deploy repository with its credentials.
+func renderInvokerSettings(repoURL, username, password string) ([]byte, error) {
+	repository := canaryRepository{ID: invokerRepositoryID, URL: repoURL, Releases: true, Snapshots: true}
+	doc := invokerSettings{
+		Xmlns: "http://maven.apache.org/SETTINGS/1.0.0",
+		Profiles: []invokerProfile{{
+			ID:                 invokerRepositoryID,
+			Repositories:       []canaryRepository{repository},
+			PluginRepositories: []canaryRepository{repository},
+		}},
+		ActiveProfiles: []string{invokerRepositoryID},
+	}
+	if username != "" || password != "" {
+		doc.Servers = []settingsServer{{ID: invokerRepositoryID, Username: username, Password: password}}
+	}
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to render invoker settings: %w", err)
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+// readInvokerReports summarizes the BUILD-*.xml reports of a run.
+func readInvokerReports(dir string) (*invokerResults, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "BUILD-*.xml"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no invoker reports found; is the projects directory empty?")
+	}
+	sort.Strings(paths)
+
+	results := &invokerResults{}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read invoker report: %w", err)
+		}
+		var job invokerBuildJob
+		if err := xml.Unmarshal(data, &job); err != nil {
+			return nil, fmt.Errorf("failed to parse invoker report %s: %w", filepath.Base(path), err)
+		}
+		switch job.Result {
+		case "success":
+			results.Passed++
+		case "skipped":
+			results.Skipped++
+		default:
+			results.Failed++
+			failure := fmt.Sprintf("%s: %s", job.Project, job.Result)
+			if job.FailureMessage != "" {
+				failure += " (" + job.FailureMessage + ")"
+			}
+			results.Failures = append(results.Failures, failure)
+		}
+	}
+	return results, nil
+}
+
+// runInvokerTests runs the sample projects of the invoker_tests directory
+// against the released version in the deploy repository. Each run uses an
+// empty local repository.
+func (p *MavenPlugin) runInvokerTests(ctx context.Context, cfg *Config, version string) (*invokerResults, error) {
+	version = mavenVersion(version)
+	dir, err := os.MkdirTemp("", "relicta-maven-invoker-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create invoker directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	projects, err := filepath.Abs(cfg.InvokerTests)
+	if err != nil {
+		return nil, err
+	}
+	username, password := cfg.targetCredentials(version)
+	settings, err := renderInvokerSettings(cfg.targetRepository(version), username, password)
+	if err != nil {
+		return nil, err
+	}
+	settingsPath := filepath.Join(dir, "settings.xml")
+	if err := os.WriteFile(settingsPath, settings, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write invoker settings: %w", err)
+	}
+	reports := filepath.Join(dir, "reports")
+
+	args := []string{"-B", "-f", cfg.projectPOM()}
+	if path := cfg.userSettings(); path != "" {
+		args = append(args, "-s", path)
+	}
+	args = append(args, cfg.isolationArgs(true)...)
+	args = append(args,
+		invokerPlugin+":run",
+		"-Dinvoker.projectsDirectory="+projects,
+		"-Dinvoker.cloneProjectsTo="+filepath.Join(dir, "projects"),
+		"-Dinvoker.localRepositoryPath="+filepath.Join(dir, "repository"),
+		"-Dinvoker.settingsFile="+settingsPath,
+		"-Dinvoker.mergeUserSettings=true",
+		"-Dinvoker.reportsDirectory="+reports,
+		"-Dinvoker.streamLogs=true",
+		"-Dinvoker.ignoreFailures=true",
+	)
+
+	output, err := p.runMavenCommand(ctx, cfg, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s\nOutput: %s", describeExecError(cfg.mavenCommand(), err), cfg.errorOutput(output))
+	}
+	results, err := readInvokerReports(reports)
+	if err != nil {
+		return nil, err
+	}
+	if results.Failed > 0 {
+		return results, fmt.Errorf("%d of %d integration tests failed: %s",
+			results.Failed, results.Passed+results.Failed+results.Skipped, strings.Join(results.Failures, "; "))
+	}
+	return results, nil
+}
@@ -0,0 +1,125 @@
+// Package main provides tests for post-deploy invoker integration tests.
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// writeInvokerReports writes a BUILD-*.xml report per project with the given result.
+func writeInvokerReports(t *testing.T, dir string, results map[string]string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for project, result := range results {
+		report := `<?xml version="1.0" encoding="UTF-8"?>
+<build-job xmlns="http://maven.apache.org/BUILD-JOB/1.0.0" project="` + project + `/pom.xml" result="` + result + `" time="1.2"/>`
+		if err := os.WriteFile(filepath.Join(dir, "BUILD-"+project+".xml"), []byte(report), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestReadInvokerReports(t *testing.T) {
+	dir := t.TempDir()
+	writeInvokerReports(t, dir, map[string]string{"basic": "success", "goal": "failure-build", "skip": "skipped"})
+
+	results, err := readInvokerReports(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results.Passed != 1 || results.Failed != 1 || results.Skipped != 1 {
+		t.Errorf("unexpected results: %+v", results)
+	}
+	if len(results.Failures) != 1 || results.Failures[0] != "goal/pom.xml: failure-build" {
+		t.Errorf("unexpected failures: %v", results.Failures)
+	}
+
+	if _, err := readInvokerReports(t.TempDir()); err == nil {
+		t.Error("expected an error without reports")
+	}
+}
+
+func TestRenderInvokerSettings(t *testing.T) {
+	data, err := renderInvokerSettings("http://localhost:8081/repository/maven-releases", "deployer", "secret")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		"<activeProfile>relicta-invoker</activeProfile>",
+		"<pluginRepository>",
+		"<url>http://localhost:8081/repository/maven-releases</url>",
+		"<username>deployer</username>",
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected %q in settings:\n%s", want, data)
+		}
+	}
+}
+
+func TestExecuteInvokerTests(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+	if err := os.MkdirAll(filepath.Join("src", "it", "basic"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(t *testing.T, results map[string]string) *plugin.ExecuteResponse {
+		t.Helper()
+		mockExec := &MockCommandExecutor{
+			RunFunc: func(_ context.Context, _ string, args ...string) ([]byte, error) {
+				if !slices.Contains(args, invokerPlugin+":run") {
+					return []byte("[INFO] BUILD SUCCESS"), nil
+				}
+				for _, arg := range args {
+					if reports, ok := strings.CutPrefix(arg, "-Dinvoker.reportsDirectory="); ok {
+						writeInvokerReports(t, reports, results)
+					}
+				}
+				return []byte("[INFO] BUILD SUCCESS"), nil
+			},
+		}
+		p := &MavenPlugin{executor: mockExec}
+		resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+			Hook: plugin.HookPostPublish,
+			Config: map[string]any{
+				"group_id":      "com.example",
+				"artifact_id":   "my-plugin",
+				"repository":    "http://localhost:8081/repository/maven-releases",
+				"invoker_tests": "src/it",
+			},
+			Context: plugin.ReleaseContext{Version: "1.0.0"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return resp
+	}
+
+	t.Run("passed", func(t *testing.T) {
+		resp := run(t, map[string]string{"basic": "success"})
+		if !resp.Success {
+			t.Fatalf("expected success, got error: %s", resp.Error)
+		}
+		if results, _ := resp.Outputs["invoker_results"].(*invokerResults); results == nil || results.Passed != 1 {
+			t.Errorf("unexpected outputs: %v", resp.Outputs)
+		}
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		resp := run(t, map[string]string{"basic": "success", "broken": "failure-post-hook"})
+		if resp.Success || !strings.Contains(resp.Error, "1 of 2 integration tests failed") {
+			t.Errorf("expected an invoker failure, got %+v", resp)
+		}
+		if results, _ := resp.Outputs["invoker_results"].(*invokerResults); results == nil || results.Failed != 1 {
+			t.Errorf("expected the results in outputs, got %v", resp.Outputs)
+		}
+	})
+}
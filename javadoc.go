@@ -0,0 +1,64 @@
+// Package main implements the javadoc jar sanity check for Central releases.
+package main
+
+import (
+	"archive/zip"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// javadocGuidance explains how to fix an empty javadoc jar.
+const javadocGuidance = "generate the javadoc jar with maven-javadoc-plugin (goal jar) instead of attaching an empty stub jar"
+
+// targetsCentral reports whether the release is published to Maven Central,
+// through the Central Portal, as a manual upload bundle, or via a Central
+// repository URL.
+func (cfg *Config) targetsCentral(version string) bool {
+	return cfg.CentralPortalURL != "" || cfg.BundlePath != "" || isCentralRepository(cfg.targetRepository(version))
+}
+
+// checkJavadocJar verifies that a javadoc jar contains documentation: an
+// index.html or HTML pages outside META-INF.
+func checkJavadocJar(path string) error {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", filepath.Base(path), err)
+	}
+	defer zr.Close()
+
+	for _, f := range zr.File {
+		name := strings.ToLower(f.Name)
+		if strings.HasPrefix(name, "meta-inf/") || f.FileInfo().IsDir() {
+			continue
+		}
+		if strings.HasSuffix(name, ".html") {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s contains no documentation (no index.html or class pages); %s", filepath.Base(path), javadocGuidance)
+}
+
+// checkJavadocJars checks the javadoc jars of every deployed module. Modules
+// without a javadoc jar or build output are skipped.
+func (cfg *Config) checkJavadocJars(version string) error {
+	var problems []string
+	for _, module := range cfg.deployedModules() {
+		out, err := module.readBuildOutput(version)
+		if err != nil {
+			continue
+		}
+		for _, path := range out.Files {
+			if !strings.HasSuffix(path, "-javadoc.jar") {
+				continue
+			}
+			if err := checkJavadocJar(path); err != nil {
+				problems = append(problems, err.Error())
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+// Package main provides tests for the javadoc jar sanity check.
+package main
+
+import (
+	"archive/zip"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// writeJar writes a jar with the given entries.
+func writeJar(t *testing.T, path string, entries ...string) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	zw := zip.NewWriter(f)
+	for _, name := range entries {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckJavadocJar(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []string
+		wantErr bool
+	}{
+		{name: "javadoc", entries: []string{"META-INF/MANIFEST.MF", "index.html", "com/example/App.html"}},
+		{name: "class pages only", entries: []string{"com/example/App.html"}},
+		{name: "manifest only", entries: []string{"META-INF/MANIFEST.MF"}, wantErr: true},
+		{name: "readme stub", entries: []string{"META-INF/MANIFEST.MF", "README.md"}, wantErr: true},
+		{name: "empty", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "my-app-1.0.0-javadoc.jar")
+			writeJar(t, path, tt.entries...)
+
+			err := checkJavadocJar(path)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "contains no documentation") || !strings.Contains(err.Error(), "maven-javadoc-plugin") {
+					t.Errorf("expected an empty javadoc error with guidance, got %v", err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestExecuteJavadocCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		repository  string
+		policy      string
+		wantSuccess bool
+		wantWarning bool
+	}{
+		{name: "central", repository: "https://repo1.maven.org/maven2"},
+		{name: "central warn", repository: "https://repo1.maven.org/maven2", policy: "warn", wantSuccess: true, wantWarning: true},
+		{name: "central off", repository: "https://repo1.maven.org/maven2", policy: "off", wantSuccess: true},
+		{name: "other repository", repository: "http://localhost:8081/repository/maven-releases", wantSuccess: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			chdir(t, dir)
+			if err := os.WriteFile("pom.xml", []byte(`<project><artifactId>my-app</artifactId><version>1.0.0</version></project>`), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			writeBuildOutput(t, dir, map[string]int{"my-app-1.0.0.jar": 10})
+			writeJar(t, filepath.Join(dir, "target", "my-app-1.0.0-javadoc.jar"), "META-INF/MANIFEST.MF")
+
+			config := map[string]any{
+				"group_id":          "com.example",
+				"artifact_id":       "my-app",
+				"repository":        tt.repository,
+				"dns_policy":        "skip_dns",
+				"central_pom_check": "off",
+			}
+			if tt.policy != "" {
+				config["javadoc_check"] = tt.policy
+			}
+			p := &MavenPlugin{executor: &MockCommandExecutor{}}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook:    plugin.HookPostPublish,
+				Config:  config,
+				Context: plugin.ReleaseContext{Version: "1.0.0"},
+				DryRun:  true,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Success != tt.wantSuccess {
+				t.Fatalf("expected success=%v, got %+v", tt.wantSuccess, resp)
+			}
+			if !tt.wantSuccess && !strings.Contains(resp.Error, "javadoc check: my-app-1.0.0-javadoc.jar contains no documentation") {
+				t.Errorf("unexpected error: %s", resp.Error)
+			}
+			warnings, _ := resp.Outputs["warnings"].([]string)
+			if hasWarning := strings.Contains(strings.Join(warnings, "\n"), "javadoc check"); hasWarning != tt.wantWarning {
+				t.Errorf("expected javadoc warning=%v, got %v", tt.wantWarning, warnings)
+			}
+		})
+	}
+}
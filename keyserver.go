@@ -0,0 +1,131 @@
+// Package main implements checking that the signing key is published on a keyserver.
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultKeyservers are the keyservers Maven Central looks signing keys up on.
+var defaultKeyservers = []string{"https://keys.openpgp.org", "https://keyserver.ubuntu.com"}
+
+// keyserverKey is the state of a key in a keyserver's index.
+type keyserverKey struct {
+	Found   bool
+	Revoked bool
+	Expired bool
+}
+
+// validateKeyserver validates a keyserver URL.
+func validateKeyserver(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return fmt.Errorf("invalid keyserver URL %q", rawURL)
+	}
+	return nil
+}
+
+// parseKeyserverIndex reads the key with the given fingerprint from a
+// machine-readable HKP index. Its pub lines are
+// pub:<fingerprint or key ID>:<algorithm>:<length>:<created>:<expires>:<flags>.
+func parseKeyserverIndex(r io.Reader, fingerprint string, now time.Time) keyserverKey {
+	var key keyserverKey
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Split(strings.TrimSpace(scanner.Text()), ":")
+		if len(fields) < 2 || fields[0] != "pub" {
+			continue
+		}
+		id := strings.ToUpper(fields[1])
+		if id == "" || !strings.HasSuffix(fingerprint, id) {
+			continue
+		}
+		key.Found = true
+		if len(fields) > 6 {
+			key.Revoked = strings.Contains(fields[6], "r")
+			key.Expired = strings.Contains(fields[6], "e")
+		}
+		if len(fields) > 5 && fields[5] != "" {
+			if expires, err := strconv.ParseInt(fields[5], 10, 64); err == nil && !now.Before(time.Unix(expires, 0)) {
+				key.Expired = true
+			}
+		}
+		return key
+	}
+	return key
+}
+
+// lookupKey looks the key up in a keyserver's HKP index.
+func (p *MavenPlugin) lookupKey(ctx context.Context, keyserver, fingerprint string) (keyserverKey, error) {
+	query := url.Values{"op": {"index"}, "options": {"mr"}, "search": {"0x" + fingerprint}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(keyserver, "/")+"/pks/lookup?"+query.Encode(), nil)
+	if err != nil {
+		return keyserverKey{}, err
+	}
+	resp, err := p.getHTTPClient().Do(req)
+	if err != nil {
+		return keyserverKey{}, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return keyserverKey{}, nil
+	case resp.StatusCode != http.StatusOK:
+		return keyserverKey{}, fmt.Errorf("returned %d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+	return parseKeyserverIndex(io.LimitReader(resp.Body, 1<<20), fingerprint, time.Now()), nil
+}
+
+// checkKeyserver verifies that the public part of the signing key is on at
+// least one keyserver, valid there, and revoked on none. Keyservers that
+// cannot be reached only fail the check when no other keyserver has the key.
+func (p *MavenPlugin) checkKeyserver(ctx context.Context, cfg *Config) error {
+	if len(cfg.Keyservers) == 0 {
+		return fmt.Errorf("no keyservers configured")
+	}
+	var published, expired, revoked []string
+	var failures []string
+	for _, keyserver := range cfg.Keyservers {
+		key, err := p.lookupKey(ctx, keyserver, cfg.GPGKey)
+		switch {
+		case err != nil:
+			failures = append(failures, fmt.Sprintf("%s: %v", keyserver, err))
+		case key.Revoked:
+			revoked = append(revoked, keyserver)
+		case key.Expired:
+			expired = append(expired, keyserver)
+		case key.Found:
+			published = append(published, keyserver)
+		}
+	}
+
+	switch {
+	case len(revoked) > 0:
+		return fmt.Errorf("signing key %s is revoked on %s; sign with another key", cfg.GPGKey, strings.Join(revoked, ", "))
+	case len(published) > 0:
+		return nil
+	case len(expired) > 0:
+		return fmt.Errorf("signing key %s is expired on %s; extend it with gpg --quick-set-expire %s and publish it again with gpg --keyserver %s --send-keys %s",
+			cfg.GPGKey, strings.Join(expired, ", "), cfg.GPGKey, keyserverHost(expired[0]), cfg.GPGKey)
+	case len(failures) == len(cfg.Keyservers):
+		return fmt.Errorf("no keyserver could be queried: %s", strings.Join(failures, "; "))
+	}
+	return fmt.Errorf("signing key %s is not published on %s; publish it with gpg --keyserver %s --send-keys %s (keys.openpgp.org also requires verifying the key's email address)",
+		cfg.GPGKey, strings.Join(cfg.Keyservers, ", "), keyserverHost(cfg.Keyservers[0]), cfg.GPGKey)
+}
+
+// keyserverHost returns the host of a keyserver URL for gpg --keyserver.
+func keyserverHost(keyserver string) string {
+	if u, err := url.Parse(keyserver); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return keyserver
+}
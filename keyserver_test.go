@@ -0,0 +1,162 @@
+// Package main provides tests for the keyserver check of the signing key.
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestParseKeyserverIndex(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	tests := []struct {
+		name  string
+		index string
+		want  keyserverKey
+	}{
+		{
+			name:  "full fingerprint",
+			index: "info:1:1\npub:" + oldKey + ":1:4096:1600000000::\nuid:Release Bot <release@example.com>:1600000000::\n",
+			want:  keyserverKey{Found: true},
+		},
+		{
+			name:  "long key ID",
+			index: "info:1:1\npub:89abcdef01234567:22:256:1600000000:1800000000:\n",
+			want:  keyserverKey{Found: true},
+		},
+		{
+			name:  "expired by date",
+			index: "pub:" + oldKey + ":1:4096:1600000000:1650000000:\n",
+			want:  keyserverKey{Found: true, Expired: true},
+		},
+		{
+			name:  "revoked",
+			index: "pub:" + oldKey + ":1:4096:1600000000::r\n",
+			want:  keyserverKey{Found: true, Revoked: true},
+		},
+		{
+			name:  "other key",
+			index: "pub:" + newKey + ":1:4096:1600000000::\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseKeyserverIndex(strings.NewReader(tt.index), oldKey, now); got != tt.want {
+				t.Errorf("parseKeyserverIndex() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// keyserver serves an HKP index for oldKey, or 404 when index is empty.
+func keyserver(t *testing.T, index string) string {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/pks/lookup" || q.Get("op") != "index" || q.Get("options") != "mr" || q.Get("search") != "0x"+oldKey {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		if index == "" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(index))
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func TestCheckKeyserver(t *testing.T) {
+	published := "pub:" + oldKey + ":1:4096:1600000000::\n"
+	tests := []struct {
+		name    string
+		indexes []string
+		wantErr string
+	}{
+		{name: "published on one", indexes: []string{"", published}},
+		{name: "not published", indexes: []string{"", ""}, wantErr: "is not published on"},
+		{name: "revoked", indexes: []string{published, "pub:" + oldKey + ":1:4096:1600000000::r\n"}, wantErr: "is revoked"},
+		{name: "expired", indexes: []string{"pub:" + oldKey + ":1:4096:1600000000:1650000000:\n"}, wantErr: "--quick-set-expire"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{GPGKey: oldKey}
+			for _, index := range tt.indexes {
+				cfg.Keyservers = append(cfg.Keyservers, keyserver(t, index))
+			}
+			err := (&MavenPlugin{}).checkKeyserver(context.Background(), cfg)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCheckKeyserverUnreachable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	err := (&MavenPlugin{}).checkKeyserver(context.Background(), &Config{GPGKey: oldKey, Keyservers: []string{srv.URL}})
+	if err == nil || !strings.Contains(err.Error(), "no keyserver could be queried") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestExecuteKeyserverCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      string
+		wantSuccess bool
+	}{
+		{name: "fail", policy: policyFail},
+		{name: "warn", policy: policyWarn, wantSuccess: true},
+		{name: "off", policy: policyOff, wantSuccess: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chdir(t, t.TempDir())
+			p := &MavenPlugin{executor: &MockCommandExecutor{}}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook: plugin.HookPostPublish,
+				Config: map[string]any{
+					"group_id":          "com.example",
+					"artifact_id":       "my-app",
+					"repository":        "https://s01.oss.sonatype.org/service/local/staging/deploy/maven2",
+					"dns_policy":        "skip_dns",
+					"central_pom_check": "off",
+					"javadoc_check":     "off",
+					"gpg_key":           oldKey,
+					"keyserver_check":   tt.policy,
+					"keyservers":        []any{keyserver(t, "")},
+				},
+				Context: plugin.ReleaseContext{Version: "1.0.0"},
+				DryRun:  true,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Success != tt.wantSuccess {
+				t.Fatalf("unexpected response %+v", resp)
+			}
+			if !resp.Success && !strings.Contains(resp.Error, "keyserver check: signing key "+oldKey+" is not published") {
+				t.Errorf("unexpected error: %s", resp.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// Package main implements the license header check of the sources of the release.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// licensePlugin is the License Maven Plugin checking the source headers.
+const licensePlugin = "com.mycila:license-maven-plugin:4.6"
+
+// missingHeadersLimit is the number of files without a header listed in an error.
+const missingHeadersLimit = 10
+
+// missingHeaderPattern matches a file the license check reports:
+// "[WARNING] Missing header in: /work/core/src/main/java/App.java".
+var missingHeaderPattern = regexp.MustCompile(`^\[(?:WARNING|ERROR)\] Missing header in: (.+)$`)
+
+// licenseCheckArgs returns the Maven arguments checking the license headers
+// of every project of the reactor. The reactor fails at the end so that the
+// files of every module are reported.
+func licenseCheckArgs(cfg *Config) []string {
+	args := []string{"-B", "-f", cfg.PomPath}
+	if path := cfg.userSettings(); path != "" {
+		args = append(args, "-s", path)
+	}
+	args = append(args, cfg.isolationArgs(true)...)
+	args = append(args, licensePlugin+":check", "--fail-at-end")
+	if cfg.LicenseHeader != "" {
+		header, err := filepath.Abs(cfg.LicenseHeader)
+		if err != nil {
+			header = cfg.LicenseHeader
+		}
+		args = append(args, "-Dlicense.header="+header)
+	}
+	return args
+}
+
+// missingHeaders returns the files the license check reported without a
+// header, relative to the reactor root when they are inside it.
+func missingHeaders(output, rootDir string) []string {
+	var files []string
+	for _, line := range strings.Split(ansiPattern.ReplaceAllString(output, ""), "\n") {
+		m := missingHeaderPattern.FindStringSubmatch(strings.TrimRight(line, "\r "))
+		if m == nil {
+			continue
+		}
+		file := m[1]
+		if rel, err := filepath.Rel(rootDir, file); err == nil && !strings.HasPrefix(rel, "..") {
+			file = filepath.ToSlash(rel)
+		}
+		files = append(files, file)
+	}
+	return files
+}
+
+// missingHeadersSummary lists the files without a license header, e.g.
+// "2 source files lack the license header: core/src/main/java/App.java, ...".
+func missingHeadersSummary(files []string) string {
+	var b strings.Builder
+	if len(files) == 1 {
+		b.WriteString("1 source file lacks the license header: ")
+	} else {
+		fmt.Fprintf(&b, "%d source files lack the license header: ", len(files))
+	}
+	if len(files) > missingHeadersLimit {
+		b.WriteString(strings.Join(files[:missingHeadersLimit], ", "))
+		fmt.Fprintf(&b, " and %d more", len(files)-missingHeadersLimit)
+	} else {
+		b.WriteString(strings.Join(files, ", "))
+	}
+	return b.String()
+}
+
+// checkLicenseHeaders runs the license check over the sources of the reactor.
+func (p *MavenPlugin) checkLicenseHeaders(ctx context.Context, cfg *Config) error {
+	output, err := p.runMavenCommand(ctx, cfg, licenseCheckArgs(cfg)...)
+	if err == nil {
+		return nil
+	}
+	rootDir, absErr := filepath.Abs(filepath.Dir(cfg.PomPath))
+	if absErr != nil {
+		rootDir = filepath.Dir(cfg.PomPath)
+	}
+	if files := missingHeaders(string(output), rootDir); len(files) > 0 {
+		return errors.New(missingHeadersSummary(files))
+	}
+	return fmt.Errorf("license check failed: %s\nOutput: %s", describeExecError(cfg.mavenCommand(), err), cfg.errorOutput(output))
+}
@@ -0,0 +1,192 @@
+// Package main provides tests for the license header check of the sources of the release.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestMissingHeaders(t *testing.T) {
+	root := filepath.Join(string(filepath.Separator), "work", "project")
+	output := fmt.Sprintf(`[INFO] --- license:4.6:check (default-cli) @ core ---
+[INFO] Checking licenses...
+[WARNING] Missing header in: %s
+[WARNING] Missing header in: %s
+[ERROR] Failed to execute goal com.mycila:license-maven-plugin:4.6:check (default-cli) on project core: Some files do not have the expected license header. Run license:format to update them.
+[WARNING] Missing header in: %s
+`,
+		filepath.Join(root, "core", "src", "main", "java", "App.java"),
+		filepath.Join(root, "core", "src", "test", "java", "AppTest.java"),
+		filepath.Join(string(filepath.Separator), "elsewhere", "Gen.java"))
+
+	got := missingHeaders(output, root)
+	want := []string{"core/src/main/java/App.java", "core/src/test/java/AppTest.java", filepath.Join(string(filepath.Separator), "elsewhere", "Gen.java")}
+	if strings.Join(got, "; ") != strings.Join(want, "; ") {
+		t.Errorf("missingHeaders() = %v, want %v", got, want)
+	}
+}
+
+func TestMissingHeadersSummary(t *testing.T) {
+	if got := missingHeadersSummary([]string{"App.java"}); got != "1 source file lacks the license header: App.java" {
+		t.Errorf("unexpected summary %q", got)
+	}
+
+	var files []string
+	for i := 0; i < missingHeadersLimit+2; i++ {
+		files = append(files, fmt.Sprintf("F%d.java", i))
+	}
+	got := missingHeadersSummary(files)
+	if !strings.HasPrefix(got, "12 source files lack the license header: F0.java, F1.java") || !strings.HasSuffix(got, "F9.java and 2 more") {
+		t.Errorf("unexpected summary %q", got)
+	}
+}
+
+func TestExecuteLicenseHeaderCheck(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+	if err := os.WriteFile("pom.xml", []byte(`<project><artifactId>my-app</artifactId><version>1.0.0</version></project>`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile("HEADER.txt", []byte("Copyright Example Inc."), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	missing := "[WARNING] Missing header in: " + filepath.Join(cwd, "src", "main", "java", "App.java") + "\n"
+
+	tests := []struct {
+		name        string
+		config      map[string]any
+		output      string
+		dryRun      bool
+		wantErr     string
+		wantWarning string
+		wantArgs    string
+	}{
+		{name: "off"},
+		{name: "compliant", config: map[string]any{"license_header_check": "fail"}, wantArgs: licensePlugin + ":check --fail-at-end"},
+		{
+			name:     "fail",
+			config:   map[string]any{"license_header_check": "fail", "license_header": "HEADER.txt"},
+			output:   missing,
+			wantErr:  "license header check: 1 source file lacks the license header: src/main/java/App.java",
+			wantArgs: licensePlugin + ":check --fail-at-end -Dlicense.header=" + filepath.Join(cwd, "HEADER.txt"),
+		},
+		{
+			name:        "warn",
+			config:      map[string]any{"license_header_check": "warn"},
+			output:      missing,
+			wantWarning: "license header check: 1 source file lacks the license header: src/main/java/App.java",
+			wantArgs:    licensePlugin + ":check --fail-at-end",
+		},
+		{name: "dry run", config: map[string]any{"license_header_check": "fail"}, output: missing, dryRun: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var checkArgs string
+			mockExec := &MockCommandExecutor{
+				RunFunc: func(ctx context.Context, name string, args ...string) ([]byte, error) {
+					if joined := strings.Join(args, " "); strings.Contains(joined, licensePlugin+":check") {
+						checkArgs = joined
+						if tt.output != "" {
+							return []byte(tt.output), errors.New("exit status 1")
+						}
+					}
+					return []byte("[INFO] BUILD SUCCESS"), nil
+				},
+			}
+			config := map[string]any{
+				"group_id":    "com.example",
+				"artifact_id": "my-app",
+				"repository":  "http://localhost:8081/repository/maven-releases",
+			}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+			resp, err := (&MavenPlugin{executor: mockExec}).Execute(context.Background(), plugin.ExecuteRequest{
+				Hook:    plugin.HookPostPublish,
+				Config:  config,
+				Context: plugin.ReleaseContext{Version: "1.0.0"},
+				DryRun:  tt.dryRun,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantArgs == "" && checkArgs != "" {
+				t.Errorf("expected no license check, got %s", checkArgs)
+			}
+			if tt.wantArgs != "" && !strings.HasSuffix(checkArgs, tt.wantArgs) {
+				t.Errorf("expected license check args ending in %q, got %q", tt.wantArgs, checkArgs)
+			}
+			if tt.wantErr != "" {
+				if resp.Success || resp.Error != tt.wantErr {
+					t.Errorf("expected error %q, got %q", tt.wantErr, resp.Error)
+				}
+				return
+			}
+			if !resp.Success {
+				t.Fatalf("expected success, got error: %s", resp.Error)
+			}
+			warnings, _ := resp.Outputs["warnings"].([]string)
+			if tt.wantWarning != "" && (len(warnings) != 1 || warnings[0] != tt.wantWarning) {
+				t.Errorf("expected warning %q, got %v", tt.wantWarning, warnings)
+			}
+		})
+	}
+}
+
+func TestValidateLicenseHeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		config map[string]any
+		field  string
+		errMsg string
+	}{
+		{name: "valid", config: map[string]any{"license_header_check": "fail", "license_header": "HEADER.txt"}},
+		{name: "without check", config: map[string]any{"license_header": "HEADER.txt"}, field: "license_header", errMsg: "license_header requires license_header_check"},
+		{name: "traversal", config: map[string]any{"license_header_check": "fail", "license_header": "../HEADER.txt"}, field: "license_header", errMsg: "invalid license_header"},
+		{name: "http deployer", config: map[string]any{"license_header_check": "warn", "deployer": "http"}, field: "license_header_check", errMsg: "license_header_check requires the maven deployer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]any{
+				"group_id":    "com.example",
+				"artifact_id": "my-app",
+				"repository":  "http://localhost:8081/repository/maven-releases",
+			}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+			resp, err := (&MavenPlugin{}).Validate(context.Background(), config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var messages []string
+			for _, e := range resp.Errors {
+				if strings.HasPrefix(e.Field, "license_header") {
+					messages = append(messages, e.Field+": "+e.Message)
+				}
+			}
+			if tt.errMsg == "" {
+				if len(messages) > 0 {
+					t.Errorf("unexpected errors: %v", messages)
+				}
+				return
+			}
+			if !strings.Contains(strings.Join(messages, "\n"), tt.field+": "+tt.errMsg) {
+				t.Errorf("expected %s error containing %q, got %v", tt.field, tt.errMsg, messages)
+			}
+		})
+	}
+}
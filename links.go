@@ -0,0 +1,97 @@
+// Package main implements the URL outputs describing a published artifact.
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Maven Central endpoints used for published artifact links.
+const (
+	centralSearchBaseURL = "https://search.maven.org/artifact"
+	centralRepoBaseURL   = "https://repo1.maven.org/maven2"
+	shieldsBadgeBaseURL  = "https://img.shields.io/badge"
+)
+
+// defaultArtifactClassifiers lists the attached artifacts published alongside the main artifact.
+var defaultArtifactClassifiers = []string{"sources", "javadoc"}
+
+// publishesToCentral reports whether Maven Central links should be output for
+// the configuration. Without an explicit central_links setting this is the case
+// when the target repository is Maven Central.
+func (cfg *Config) publishesToCentral(version string) bool {
+	if cfg.CentralLinks != nil {
+		return *cfg.CentralLinks
+	}
+	return isCentralRepository(cfg.targetRepository(version))
+}
+
+// shieldsEscape escapes a value for use in a shields.io static badge path.
+func shieldsEscape(s string) string {
+	s = strings.ReplaceAll(s, "-", "--")
+	s = strings.ReplaceAll(s, "_", "__")
+	return url.PathEscape(s)
+}
+
+// centralLinks returns the Maven Central search URL, the repo1 browse URL, and
+// a badge for the released version.
+func centralLinks(groupID, artifactID, version string) map[string]string {
+	searchURL := fmt.Sprintf("%s/%s/%s/%s/jar", centralSearchBaseURL,
+		url.PathEscape(groupID), url.PathEscape(artifactID), url.PathEscape(version))
+	badgeURL := fmt.Sprintf("%s/maven--central-%s-blue", shieldsBadgeBaseURL, shieldsEscape(version))
+
+	return map[string]string{
+		"central_search_url": searchURL,
+		"central_browse_url": centralRepoBaseURL + "/" + gavPath(groupID, artifactID, version) + "/",
+		"central_badge":      fmt.Sprintf("[![Maven Central](%s)](%s)", badgeURL, searchURL),
+	}
+}
+
+// packagingExtension returns the file extension of the main artifact for a POM packaging.
+// It returns an empty string for pom packaging, which has no main artifact.
+func packagingExtension(packaging string) string {
+	switch packaging {
+	case "pom":
+		return ""
+	case "", "jar", "bundle", "maven-plugin", "ejb", "test-jar":
+		return "jar"
+	default:
+		return packaging
+	}
+}
+
+// downloadBaseURL returns the repository URL deployed files are downloaded from.
+// Deploys to Maven Central are served from repo1 rather than the upload endpoint.
+func (cfg *Config) downloadBaseURL(version string) string {
+	repository := cfg.targetRepository(version)
+	if isCentralRepository(repository) {
+		return centralRepoBaseURL
+	}
+	return strings.TrimSuffix(redactURL(repository), "/")
+}
+
+// artifactURLs returns the repository-layout URLs of the files deployed for a
+// release version, keyed by the classifier or extension of the file ("pom",
+// "jar", "sources", ...). Signature keys carry an ".asc" suffix.
+func artifactURLs(baseURL, groupID, artifactID, version, packaging string, classifiers []string, signed bool) map[string]string {
+	dir := baseURL + "/" + gavPath(groupID, artifactID, version) + "/"
+	prefix := url.PathEscape(artifactID + "-" + version)
+
+	files := map[string]string{"pom": prefix + ".pom"}
+	if ext := packagingExtension(packaging); ext != "" {
+		files[ext] = prefix + "." + url.PathEscape(ext)
+		for _, classifier := range classifiers {
+			files[classifier] = prefix + "-" + url.PathEscape(classifier) + ".jar"
+		}
+	}
+
+	urls := make(map[string]string, len(files)*2)
+	for key, name := range files {
+		urls[key] = dir + name
+		if signed {
+			urls[key+".asc"] = dir + name + ".asc"
+		}
+	}
+	return urls
+}
@@ -0,0 +1,173 @@
+// Package main implements the lock preventing concurrent deploys of a GAV.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// defaultLockStaleAfter is the age in seconds after which a lock is assumed to
+// be left behind by a process that died, and is taken over, when
+// lock_stale_after is unset.
+const defaultLockStaleAfter = 3600
+
+// lockPollInterval is how often a held lock is checked while waiting.
+var lockPollInterval = 500 * time.Millisecond
+
+// lockNameUnsafe matches characters not allowed in lock file names.
+var lockNameUnsafe = regexp.MustCompile(`[^a-zA-Z0-9._-]`)
+
+// lockOwner identifies the process holding a deploy lock.
+type lockOwner struct {
+	PID      int    `json:"pid"`
+	Host     string `json:"host"`
+	Acquired string `json:"acquired"`
+}
+
+// lockDir returns the directory holding the deploy lock files.
+func (cfg *Config) lockDir() string {
+	if cfg.LockDir != "" {
+		return cfg.LockDir
+	}
+	return filepath.Join(os.TempDir(), "relicta-maven-locks")
+}
+
+// lockStaleAge returns the age after which a lock that was not refreshed is
+// taken over.
+func (cfg *Config) lockStaleAge() time.Duration {
+	if cfg.LockStaleAfter <= 0 {
+		return defaultLockStaleAfter * time.Second
+	}
+	return time.Duration(cfg.LockStaleAfter) * time.Second
+}
+
+// lockFileName returns the lock file name for a GAV.
+func lockFileName(groupID, artifactID, version string) string {
+	return lockNameUnsafe.ReplaceAllString(groupID+"_"+artifactID+"_"+version, "_") + ".lock"
+}
+
+// acquireDeployLock takes the deploy lock of a GAV by creating its lock file
+// exclusively, waiting up to LockWait for another deploy to finish. While the
+// lock is held its modification time is refreshed, so only locks left behind
+// by a process that died go stale. The returned function releases the lock.
+func acquireDeployLock(ctx context.Context, cfg *Config, version string) (func(), error) {
+	dir := cfg.lockDir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create lock directory: %w", err)
+	}
+	path := filepath.Join(dir, lockFileName(cfg.GroupID, cfg.ArtifactID, version))
+
+	host, _ := os.Hostname()
+	owner, err := json.Marshal(lockOwner{PID: os.Getpid(), Host: host, Acquired: time.Now().UTC().Format(time.RFC3339)})
+	if err != nil {
+		return nil, err
+	}
+
+	staleAge := cfg.lockStaleAge()
+	deadline := time.Now().Add(time.Duration(cfg.LockWait) * time.Second)
+	for {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err == nil {
+			_, werr := f.Write(owner)
+			if cerr := f.Close(); werr == nil {
+				werr = cerr
+			}
+			if werr != nil {
+				_ = os.Remove(path)
+				return nil, fmt.Errorf("failed to write lock file: %w", werr)
+			}
+			return holdLock(path, owner, staleAge), nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to create lock file: %w", err)
+		}
+
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > staleAge {
+			if err := takeOverStaleLock(path, info); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if !time.Now().Before(deadline) {
+			holder := "another process"
+			if data, err := os.ReadFile(path); err == nil {
+				var o lockOwner
+				if json.Unmarshal(data, &o) == nil && o.PID != 0 {
+					holder = fmt.Sprintf("process %d on %s since %s", o.PID, o.Host, o.Acquired)
+				}
+			}
+			return nil, fmt.Errorf("%s:%s:%s is being deployed by %s (lock file %s)", cfg.GroupID, cfg.ArtifactID, version, holder, path)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(lockPollInterval):
+		}
+	}
+}
+
+// holdLock refreshes the modification time of the lock file written with
+// owner until the returned function releases it. A lock that was taken over
+// by another deploy is neither refreshed nor removed.
+func holdLock(path string, owner []byte, staleAge time.Duration) func() {
+	ours := func() bool {
+		data, err := os.ReadFile(path)
+		return err == nil && bytes.Equal(data, owner)
+	}
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(staleAge / 4)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if ours() {
+					now := time.Now()
+					_ = os.Chtimes(path, now, now)
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			<-stopped
+			if ours() {
+				_ = os.Remove(path)
+			}
+		})
+	}
+}
+
+// takeOverStaleLock removes the stale lock file found as info. The file is
+// first renamed to a name of its own, so a lock another waiter created in
+// the meantime is never removed; such a lock is put back.
+func takeOverStaleLock(path string, info os.FileInfo) error {
+	taken := fmt.Sprintf("%s.stale-%d-%d", path, os.Getpid(), time.Now().UnixNano())
+	if err := os.Rename(path, taken); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to take over stale lock file: %w", err)
+	}
+	if current, err := os.Stat(taken); err == nil && (!os.SameFile(info, current) || !current.ModTime().Equal(info.ModTime())) {
+		_ = os.Link(taken, path)
+	}
+	_ = os.Remove(taken)
+	return nil
+}
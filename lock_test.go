@@ -0,0 +1,162 @@
+// Package main provides tests for the deploy lock.
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestLockFileName(t *testing.T) {
+	if got := lockFileName("com.example", "my-app", "1.0.0+build/1"); got != "com.example_my-app_1.0.0_build_1.lock" {
+		t.Errorf("unexpected lock file name: %s", got)
+	}
+}
+
+func TestAcquireDeployLock(t *testing.T) {
+	cfg := &Config{GroupID: "com.example", ArtifactID: "my-app", LockDir: t.TempDir()}
+
+	firstRelease, err := acquireDeployLock(context.Background(), cfg, "1.0.0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := acquireDeployLock(context.Background(), cfg, "1.0.0"); err == nil || !strings.Contains(err.Error(), "is being deployed by process") {
+		t.Errorf("expected the held lock to block, got %v", err)
+	}
+	otherRelease, err := acquireDeployLock(context.Background(), cfg, "1.0.1")
+	if err != nil {
+		t.Errorf("expected other versions to be independent, got %v", err)
+	} else {
+		otherRelease()
+	}
+
+	// A waiting deploy takes the lock once it is released.
+	lockPollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { lockPollInterval = 500 * time.Millisecond })
+	cfg.LockWait = 5
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		firstRelease()
+	}()
+	release, err := acquireDeployLock(context.Background(), cfg, "1.0.0")
+	if err != nil {
+		t.Fatalf("expected the lock after release, got %v", err)
+	}
+	release()
+}
+
+func TestAcquireDeployLockStale(t *testing.T) {
+	cfg := &Config{GroupID: "com.example", ArtifactID: "my-app", LockDir: t.TempDir()}
+	path := filepath.Join(cfg.LockDir, lockFileName("com.example", "my-app", "1.0.0"))
+	if err := os.WriteFile(path, []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-2 * cfg.lockStaleAge())
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	release, err := acquireDeployLock(context.Background(), cfg, "1.0.0")
+	if err != nil {
+		t.Fatalf("expected the stale lock to be taken over, got %v", err)
+	}
+	release()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected the lock file to be removed on release, got %v", err)
+	}
+}
+
+func TestAcquireDeployLockRefresh(t *testing.T) {
+	cfg := &Config{GroupID: "com.example", ArtifactID: "my-app", LockDir: t.TempDir(), LockStaleAfter: 1}
+	path := filepath.Join(cfg.LockDir, lockFileName("com.example", "my-app", "1.0.0"))
+
+	release, err := acquireDeployLock(context.Background(), cfg, "1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-time.Minute)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(600 * time.Millisecond)
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if time.Since(info.ModTime()) > cfg.lockStaleAge() {
+		t.Errorf("expected the held lock to be refreshed, modified %s", info.ModTime())
+	}
+
+	// A lock taken over by another deploy is left in place on release.
+	if err := os.WriteFile(path, []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	release()
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("expected the other deploy's lock to be kept, got %v", err)
+	}
+}
+
+func TestTakeOverStaleLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "my-app.lock")
+	if err := os.WriteFile(path, []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	stale, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Another waiter replaced the stale lock after it was inspected.
+	if err := os.WriteFile(path+".new", []byte(`{"pid":1}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(path+".new", path); err != nil {
+		t.Fatal(err)
+	}
+	if err := takeOverStaleLock(path, stale); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != `{"pid":1}` {
+		t.Errorf("expected the fresh lock to be kept, got %q, %v", data, err)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+		t.Errorf("expected only the lock file to remain, got %v", entries)
+	}
+}
+
+func TestExecuteDeployLocked(t *testing.T) {
+	lockDir := t.TempDir()
+	cfg := &Config{GroupID: "com.example", ArtifactID: "my-app", LockDir: lockDir}
+	release, err := acquireDeployLock(context.Background(), cfg, "1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	mockExec := &MockCommandExecutor{}
+	resp, err := (&MavenPlugin{executor: mockExec}).Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":    "com.example",
+			"artifact_id": "my-app",
+			"deploy_lock": true,
+			"lock_dir":    lockDir,
+		},
+		Context: plugin.ReleaseContext{Version: "v1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Success || !strings.Contains(resp.Error, "deploy lock") {
+		t.Errorf("expected the locked deploy to fail, got %+v", resp)
+	}
+	if len(mockExec.Calls) != 0 {
+		t.Errorf("expected no Maven commands while locked, got %d", len(mockExec.Calls))
+	}
+}
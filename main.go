@@ -2,9 +2,25 @@
 package main
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
 )
 
 func main() {
+	if len(os.Args) > 1 && (os.Args[1] == "--version" || os.Args[1] == "version") {
+		fmt.Println(currentBuildInfo())
+		return
+	}
+	for _, err := range []error{
+		checkHostCookie(os.Getenv(plugin.MagicCookieKey)),
+		checkHostProtocol(os.Getenv(protocolVersionsEnv)),
+	} {
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "maven plugin: %v\n", err)
+			os.Exit(1)
+		}
+	}
 	plugin.Serve(&MavenPlugin{})
 }
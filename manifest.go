@@ -0,0 +1,55 @@
+// Package main implements the jar manifest metadata passed to the build.
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// manifestProperties maps the manifest headers stamped into the jars to the
+// Maven properties carrying their values. The POM references them in the
+// archiver configuration, e.g.
+//
+//	<manifestEntries>
+//	  <Implementation-Build>${manifest.implementationBuild}</Implementation-Build>
+//	  <Scm-Revision>${manifest.scmRevision}</Scm-Revision>
+//	  <Build-Timestamp>${manifest.buildTimestamp}</Build-Timestamp>
+//	</manifestEntries>
+var manifestProperties = []struct {
+	Header   string
+	Property string
+}{
+	{"Implementation-Build", "manifest.implementationBuild"},
+	{"Scm-Revision", "manifest.scmRevision"},
+	{"Build-Timestamp", "manifest.buildTimestamp"},
+}
+
+// manifestEntries returns the manifest header values of the release built at
+// built: the tag as build identifier (or the version when untagged), the
+// commit SHA and the UTC build time. The commit is required, as it is what
+// traces a shipped jar back to its sources.
+func manifestEntries(releaseCtx plugin.ReleaseContext, built time.Time) (map[string]string, error) {
+	if releaseCtx.CommitSHA == "" {
+		return nil, fmt.Errorf("manifest_metadata requires the commit SHA of the release")
+	}
+	build := releaseCtx.TagName
+	if build == "" {
+		build = mavenVersion(releaseCtx.Version)
+	}
+	return map[string]string{
+		"Implementation-Build": build,
+		"Scm-Revision":         releaseCtx.CommitSHA,
+		"Build-Timestamp":      built.UTC().Format(time.RFC3339),
+	}, nil
+}
+
+// manifestPropertyArgs returns the -Dmanifest.* arguments of the manifest entries.
+func manifestPropertyArgs(entries map[string]string) []string {
+	args := make([]string, 0, len(manifestProperties))
+	for _, p := range manifestProperties {
+		args = append(args, fmt.Sprintf("-D%s=%s", p.Property, entries[p.Header]))
+	}
+	return args
+}
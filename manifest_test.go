@@ -0,0 +1,125 @@
+// Package main provides tests for the jar manifest metadata.
+package main
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestManifestEntries(t *testing.T) {
+	built := time.Date(2024, 3, 1, 12, 30, 0, 0, time.FixedZone("CET", 3600))
+	tests := []struct {
+		name       string
+		releaseCtx plugin.ReleaseContext
+		want       map[string]string
+		wantErr    bool
+	}{
+		{
+			name:       "tagged release",
+			releaseCtx: plugin.ReleaseContext{Version: "1.2.0", TagName: "v1.2.0", CommitSHA: "abc123"},
+			want: map[string]string{
+				"Implementation-Build": "v1.2.0",
+				"Scm-Revision":         "abc123",
+				"Build-Timestamp":      "2024-03-01T11:30:00Z",
+			},
+		},
+		{
+			name:       "untagged release",
+			releaseCtx: plugin.ReleaseContext{Version: "v1.2.0", CommitSHA: "abc123"},
+			want: map[string]string{
+				"Implementation-Build": "1.2.0",
+				"Scm-Revision":         "abc123",
+				"Build-Timestamp":      "2024-03-01T11:30:00Z",
+			},
+		},
+		{
+			name:       "no commit",
+			releaseCtx: plugin.ReleaseContext{Version: "1.2.0", TagName: "v1.2.0"},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := manifestEntries(tt.releaseCtx, built)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("manifestEntries() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("manifestEntries() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManifestPropertyArgs(t *testing.T) {
+	args := manifestPropertyArgs(map[string]string{
+		"Implementation-Build": "v1.2.0",
+		"Scm-Revision":         "abc123",
+		"Build-Timestamp":      "2024-03-01T11:30:00Z",
+	})
+	want := []string{
+		"-Dmanifest.implementationBuild=v1.2.0",
+		"-Dmanifest.scmRevision=abc123",
+		"-Dmanifest.buildTimestamp=2024-03-01T11:30:00Z",
+	}
+	if !slices.Equal(args, want) {
+		t.Errorf("manifestPropertyArgs() = %v, want %v", args, want)
+	}
+}
+
+func TestExecuteManifestMetadata(t *testing.T) {
+	tests := []struct {
+		name      string
+		commitSHA string
+		wantErr   string
+	}{
+		{name: "with commit", commitSHA: "0123abcd"},
+		{name: "without commit", wantErr: "manifest_metadata requires the commit SHA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chdir(t, t.TempDir())
+			mockExec := &MockCommandExecutor{}
+			p := &MavenPlugin{executor: mockExec}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook: plugin.HookPostPublish,
+				Config: map[string]any{
+					"group_id":          "com.example",
+					"artifact_id":       "my-app",
+					"repository":        "http://localhost:8081/repository/maven-releases",
+					"manifest_metadata": true,
+				},
+				Context: plugin.ReleaseContext{Version: "1.0.0", TagName: "v1.0.0", CommitSHA: tt.commitSHA},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr != "" {
+				if resp.Success || !strings.Contains(resp.Error, tt.wantErr) {
+					t.Fatalf("expected error %q, got %+v", tt.wantErr, resp)
+				}
+				if len(mockExec.Calls) != 0 {
+					t.Errorf("expected Maven not to run, got %d calls", len(mockExec.Calls))
+				}
+				return
+			}
+			if !resp.Success {
+				t.Fatalf("expected success, got error: %s", resp.Error)
+			}
+			args := strings.Join(mockExec.Calls[0].Args, " ")
+			for _, arg := range []string{"-Dmanifest.implementationBuild=v1.0.0", "-Dmanifest.scmRevision=0123abcd", "-Dmanifest.buildTimestamp="} {
+				if !strings.Contains(args, arg) {
+					t.Errorf("expected %s in %s", arg, args)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+// Package main implements Maven installation detection for the Maven plugin.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Supported Maven executables.
+const (
+	mavenExecutableMvn     = "mvn"
+	mavenExecutableWrapper = "mvnw"
+)
+
+// Patterns for parsing `mvn -version` output.
+var (
+	mavenVersionPattern = regexp.MustCompile(`Apache Maven (\d+(?:\.\d+)*(?:-\S+)?)`)
+	javaVersionPattern  = regexp.MustCompile(`Java version: ([^,\s]+)(?:, vendor: ([^,]+))?`)
+
+	// Required Java pattern: a major version, optionally followed by '+' for "or newer".
+	requiredJavaPattern = regexp.MustCompile(`^(1\.)?\d+\+?$`)
+
+	// Minimum version pattern: dotted numeric version with an optional qualifier.
+	minVersionPattern = regexp.MustCompile(`^\d+(\.\d+)*(-[a-zA-Z0-9.-]+)?$`)
+)
+
+// mavenInfo describes the Maven installation reported by `mvn -version`.
+type mavenInfo struct {
+	Version     string
+	JavaVersion string
+	JavaVendor  string
+}
+
+// mavenCommand returns the command used to invoke Maven for the configuration.
+func (cfg *Config) mavenCommand() string {
+	if cfg.MavenExecutable == mavenExecutableWrapper {
+		if runtime.GOOS == "windows" {
+			return `.\mvnw.cmd`
+		}
+		return "./mvnw"
+	}
+	return mavenExecutableMvn
+}
+
+// parseMavenVersionOutput extracts installation details from `mvn -version` output.
+func parseMavenVersionOutput(output string) (*mavenInfo, error) {
+	match := mavenVersionPattern.FindStringSubmatch(output)
+	if match == nil {
+		return nil, fmt.Errorf("unrecognized mvn -version output")
+	}
+	info := &mavenInfo{Version: match[1]}
+	if java := javaVersionPattern.FindStringSubmatch(output); java != nil {
+		info.JavaVersion = java[1]
+		info.JavaVendor = strings.TrimSpace(java[2])
+	}
+	return info, nil
+}
+
+// validateMinVersion validates a minimum version option.
+func validateMinVersion(version, fieldName string) error {
+	if version == "" {
+		return nil // Optional field.
+	}
+	if !minVersionPattern.MatchString(version) {
+		return fmt.Errorf("invalid %s: must be a version such as 3.9.0", fieldName)
+	}
+	return nil
+}
+
+// describeExecError turns a failure to start Maven into a clear message.
+func describeExecError(command string, err error) string {
+	if errors.Is(err, exec.ErrNotFound) {
+		return fmt.Sprintf("%s not found: install Maven or add it to PATH", command)
+	}
+	return err.Error()
+}
+
+// detectMaven runs `mvn -version` and returns the detected installation.
+func (p *MavenPlugin) detectMaven(ctx context.Context, cfg *Config) (*mavenInfo, error) {
+	command := cfg.mavenCommand()
+	output, err := p.getExecutor().Run(ctx, command, "-version")
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return nil, errors.New(describeExecError(command, err))
+		}
+		return nil, fmt.Errorf("%s -version failed: %w: %s", command, err, strings.TrimSpace(string(output)))
+	}
+	return parseMavenVersionOutput(string(output))
+}
+
+// checkMaven verifies that Maven is available and satisfies min_maven_version.
+func (p *MavenPlugin) checkMaven(ctx context.Context, cfg *Config) (*mavenInfo, error) {
+	info, err := p.detectMaven(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.MinMavenVersion != "" && compareMavenVersions(info.Version, cfg.MinMavenVersion) < 0 {
+		return info, fmt.Errorf("requires Maven %s+, found %s", cfg.MinMavenVersion, info.Version)
+	}
+	return info, nil
+}
+
+// javaMajorVersion returns the feature release number of a Java version string,
+// handling the legacy "1.x" scheme (e.g. "1.8.0_392" is 8).
+func javaMajorVersion(version string) (int, error) {
+	version = strings.TrimSpace(version)
+	version = strings.TrimPrefix(version, "1.")
+	end := strings.IndexFunc(version, func(r rune) bool { return r < '0' || r > '9' })
+	if end >= 0 {
+		version = version[:end]
+	}
+	major, err := strconv.Atoi(version)
+	if err != nil || major <= 0 {
+		return 0, fmt.Errorf("cannot determine Java major version")
+	}
+	return major, nil
+}
+
+// validateRequiredJava validates the required_java option.
+func validateRequiredJava(required string) error {
+	if required == "" {
+		return nil // Optional field.
+	}
+	if !requiredJavaPattern.MatchString(required) {
+		return fmt.Errorf("invalid required_java: must be a major version such as 17 or 17+")
+	}
+	return nil
+}
+
+// javaRequirement resolves the required Java major version and whether newer
+// versions are accepted. It falls back to the POM's maven.compiler.release,
+// which any JDK at least that new can satisfy.
+func (cfg *Config) javaRequirement() (int, bool, error) {
+	if cfg.RequiredJava != "" {
+		required := strings.TrimSuffix(cfg.RequiredJava, "+")
+		major, err := javaMajorVersion(required)
+		if err != nil {
+			return 0, false, fmt.Errorf("invalid required_java: %w", err)
+		}
+		return major, strings.HasSuffix(cfg.RequiredJava, "+"), nil
+	}
+
+	pom, err := readPOM(cfg.PomPath)
+	if err != nil {
+		return 0, false, err
+	}
+	release := pom.Properties["maven.compiler.release"]
+	if release == "" {
+		return 0, false, fmt.Errorf("no required_java configured and the POM does not set maven.compiler.release")
+	}
+	major, err := javaMajorVersion(release)
+	if err != nil {
+		return 0, false, fmt.Errorf("cannot interpret maven.compiler.release %q", release)
+	}
+	return major, true, nil
+}
+
+// checkJava verifies that the JDK used by Maven satisfies the Java requirement.
+func checkJava(cfg *Config, info *mavenInfo) error {
+	required, orNewer, err := cfg.javaRequirement()
+	if err != nil {
+		return err
+	}
+
+	if info.JavaVersion == "" {
+		return fmt.Errorf("cannot determine the Java version used by Maven")
+	}
+	actual, err := javaMajorVersion(info.JavaVersion)
+	if err != nil {
+		return fmt.Errorf("cannot interpret Java version %q", info.JavaVersion)
+	}
+
+	if actual < required || (!orNewer && actual != required) {
+		want := strconv.Itoa(required)
+		if orNewer {
+			want += "+"
+		}
+		return fmt.Errorf("requires Java %s, but Maven uses Java %s", want, info.JavaVersion)
+	}
+	return nil
+}
+
+// needsMavenInfo reports whether any configured check requires `mvn -version`.
+func (cfg *Config) needsMavenInfo() bool {
+	return cfg.MinMavenVersion != "" || cfg.CheckJava
+}
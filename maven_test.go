@@ -0,0 +1,325 @@
+// Package main provides tests for Maven installation detection.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+const mavenVersionOutput = `Apache Maven 3.9.6 (bc0240f3c744dd6b6ec2920b3cd08dcc295161ae)
+Maven home: /usr/share/maven
+Java version: 21.0.2, vendor: Eclipse Adoptium, runtime: /opt/java/openjdk
+Default locale: en, platform encoding: UTF-8
+OS name: "linux", version: "6.5.0", arch: "amd64", family: "unix"`
+
+// mavenVersionExecutor returns a mock executor answering `mvn -version` with output.
+func mavenVersionExecutor(output string) *MockCommandExecutor {
+	return &MockCommandExecutor{
+		RunFunc: func(_ context.Context, _ string, args ...string) ([]byte, error) {
+			if len(args) == 1 && args[0] == "-version" {
+				return []byte(output), nil
+			}
+			return []byte("[INFO] BUILD SUCCESS"), nil
+		},
+	}
+}
+
+func TestParseMavenVersionOutput(t *testing.T) {
+	info, err := parseMavenVersionOutput(mavenVersionOutput)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.Version != "3.9.6" {
+		t.Errorf("expected version 3.9.6, got %s", info.Version)
+	}
+
+	info, err = parseMavenVersionOutput("Apache Maven 4.0.0-rc-2 (abc)")
+	if err != nil || info.Version != "4.0.0-rc-2" {
+		t.Errorf("expected version 4.0.0-rc-2, got %v (err=%v)", info, err)
+	}
+
+	if _, err := parseMavenVersionOutput("bash: mvn: command not found"); err == nil {
+		t.Error("expected error for unrecognized output")
+	}
+}
+
+func TestParseMavenVersionOutputJava(t *testing.T) {
+	info, err := parseMavenVersionOutput(mavenVersionOutput)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.JavaVersion != "21.0.2" {
+		t.Errorf("expected Java version 21.0.2, got %s", info.JavaVersion)
+	}
+	if info.JavaVendor != "Eclipse Adoptium" {
+		t.Errorf("expected Java vendor 'Eclipse Adoptium', got '%s'", info.JavaVendor)
+	}
+}
+
+func TestJavaMajorVersion(t *testing.T) {
+	tests := map[string]int{
+		"21.0.2":    21,
+		"17":        17,
+		"11.0.22":   11,
+		"1.8.0_392": 8,
+		"1.8":       8,
+		"22-ea":     22,
+	}
+	for in, expected := range tests {
+		got, err := javaMajorVersion(in)
+		if err != nil || got != expected {
+			t.Errorf("javaMajorVersion(%s): expected %d, got %d (err=%v)", in, expected, got, err)
+		}
+	}
+
+	if _, err := javaMajorVersion("${java.version}"); err == nil {
+		t.Error("expected error for unresolved property")
+	}
+}
+
+func TestCheckJava(t *testing.T) {
+	pomWithRelease := writePOM(t, samplePOM)
+	pomWithoutRelease := writePOM(t, "<project><modelVersion>4.0.0</modelVersion></project>")
+
+	tests := []struct {
+		name   string
+		config *Config
+		java   string
+		errMsg string
+	}{
+		{name: "exact match", config: &Config{RequiredJava: "17"}, java: "17.0.10"},
+		{name: "exact mismatch", config: &Config{RequiredJava: "17"}, java: "21.0.2", errMsg: "requires Java 17, but Maven uses Java 21.0.2"},
+		{name: "minimum satisfied", config: &Config{RequiredJava: "17+"}, java: "21.0.2"},
+		{name: "minimum not satisfied", config: &Config{RequiredJava: "17+"}, java: "11.0.22", errMsg: "requires Java 17+"},
+		{name: "legacy version scheme", config: &Config{RequiredJava: "1.8"}, java: "1.8.0_392"},
+		{name: "pom release satisfied", config: &Config{PomPath: pomWithRelease}, java: "21.0.2"},
+		{name: "pom release not satisfied", config: &Config{PomPath: pomWithRelease}, java: "11.0.22", errMsg: "requires Java 17+"},
+		{name: "no requirement available", config: &Config{PomPath: pomWithoutRelease}, java: "21.0.2", errMsg: "does not set maven.compiler.release"},
+		{name: "unknown java version", config: &Config{RequiredJava: "17"}, java: "", errMsg: "cannot determine the Java version"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkJava(tt.config, &mavenInfo{Version: "3.9.6", JavaVersion: tt.java})
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("expected error containing '%s', got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestValidateRequiredJava(t *testing.T) {
+	for _, valid := range []string{"", "8", "17", "17+", "1.8", "1.8+"} {
+		if err := validateRequiredJava(valid); err != nil {
+			t.Errorf("expected %q to be valid, got %v", valid, err)
+		}
+	}
+	for _, invalid := range []string{"java17", "17.0.1", "+17", "latest"} {
+		if err := validateRequiredJava(invalid); err == nil {
+			t.Errorf("expected %q to be invalid", invalid)
+		}
+	}
+}
+
+func TestExecuteChecksJava(t *testing.T) {
+	mockExec := mavenVersionExecutor(mavenVersionOutput)
+	p := &MavenPlugin{executor: mockExec}
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":      "com.example",
+			"artifact_id":   "my-app",
+			"required_java": "17",
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Success || !strings.Contains(resp.Error, "Java check failed: requires Java 17") {
+		t.Errorf("expected Java check failure, got %+v", resp)
+	}
+
+	validation, _ := p.Validate(context.Background(), map[string]any{
+		"group_id":      "com.example",
+		"artifact_id":   "my-app",
+		"required_java": "21+",
+	})
+	if !validation.Valid {
+		t.Errorf("expected Java 21 to satisfy 21+, got %+v", validation.Errors)
+	}
+}
+
+func TestMavenCommand(t *testing.T) {
+	if got := (&Config{}).mavenCommand(); got != "mvn" {
+		t.Errorf("expected default command 'mvn', got '%s'", got)
+	}
+
+	expected := "./mvnw"
+	if runtime.GOOS == "windows" {
+		expected = `.\mvnw.cmd`
+	}
+	if got := (&Config{MavenExecutable: "mvnw"}).mavenCommand(); got != expected {
+		t.Errorf("expected wrapper command '%s', got '%s'", expected, got)
+	}
+}
+
+func TestValidateMinVersion(t *testing.T) {
+	for _, valid := range []string{"", "3", "3.9", "3.9.6", "4.0.0-rc-2"} {
+		if err := validateMinVersion(valid, "min_maven_version"); err != nil {
+			t.Errorf("expected %q to be valid, got %v", valid, err)
+		}
+	}
+	for _, invalid := range []string{"latest", "3.9 ", "v3.9", "3.9;rm"} {
+		if err := validateMinVersion(invalid, "min_maven_version"); err == nil {
+			t.Errorf("expected %q to be invalid", invalid)
+		}
+	}
+}
+
+func TestCheckMaven(t *testing.T) {
+	tests := []struct {
+		name     string
+		executor *MockCommandExecutor
+		min      string
+		errMsg   string
+		wantInfo bool
+	}{
+		{
+			name:     "version satisfied",
+			executor: mavenVersionExecutor(mavenVersionOutput),
+			min:      "3.9",
+			wantInfo: true,
+		},
+		{
+			name:     "version too old",
+			executor: mavenVersionExecutor("Apache Maven 3.6.3"),
+			min:      "3.9",
+			errMsg:   "requires Maven 3.9+, found 3.6.3",
+			wantInfo: true,
+		},
+		{
+			name: "maven not installed",
+			executor: &MockCommandExecutor{
+				RunFunc: func(_ context.Context, name string, _ ...string) ([]byte, error) {
+					return nil, &exec.Error{Name: name, Err: exec.ErrNotFound}
+				},
+			},
+			min:    "3.9",
+			errMsg: "mvn not found",
+		},
+		{
+			name: "maven fails to start",
+			executor: &MockCommandExecutor{
+				RunFunc: func(_ context.Context, _ string, _ ...string) ([]byte, error) {
+					return []byte("JAVA_HOME is not defined correctly"), errors.New("exit status 1")
+				},
+			},
+			min:    "3.9",
+			errMsg: "JAVA_HOME is not defined correctly",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &MavenPlugin{executor: tt.executor}
+			info, err := p.checkMaven(context.Background(), &Config{MinMavenVersion: tt.min})
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("expected error containing '%s', got %v", tt.errMsg, err)
+			}
+			if (info != nil) != tt.wantInfo {
+				t.Errorf("expected info present=%v, got %v", tt.wantInfo, info)
+			}
+		})
+	}
+}
+
+func TestValidateMavenInstallation(t *testing.T) {
+	p := &MavenPlugin{executor: mavenVersionExecutor("Apache Maven 3.8.8")}
+
+	resp, err := p.Validate(context.Background(), map[string]any{
+		"group_id":          "com.example",
+		"artifact_id":       "my-app",
+		"min_maven_version": "3.9.0",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Valid || resp.Errors[0].Field != "min_maven_version" {
+		t.Errorf("expected min_maven_version error, got %+v", resp)
+	}
+
+	p = &MavenPlugin{executor: &MockCommandExecutor{
+		RunFunc: func(_ context.Context, name string, _ ...string) ([]byte, error) {
+			return nil, fmt.Errorf("start: %w", &exec.Error{Name: name, Err: exec.ErrNotFound})
+		},
+	}}
+	resp, _ = p.Validate(context.Background(), map[string]any{
+		"group_id":          "com.example",
+		"artifact_id":       "my-app",
+		"min_maven_version": "3.9.0",
+		"maven_executable":  "mvnw",
+	})
+	if resp.Valid || resp.Errors[0].Field != "maven_executable" {
+		t.Errorf("expected maven_executable error, got %+v", resp)
+	}
+}
+
+func TestExecuteChecksMavenVersion(t *testing.T) {
+	mockExec := mavenVersionExecutor("Apache Maven 3.6.3")
+	p := &MavenPlugin{executor: mockExec}
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":          "com.example",
+			"artifact_id":       "my-app",
+			"min_maven_version": "3.9",
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Success || !strings.Contains(resp.Error, "requires Maven 3.9+") {
+		t.Errorf("expected Maven version failure, got %+v", resp)
+	}
+	if len(mockExec.Calls) != 1 {
+		t.Errorf("expected only the version check to run, got %d calls", len(mockExec.Calls))
+	}
+}
+
+func TestExecuteMavenNotFound(t *testing.T) {
+	p := &MavenPlugin{executor: &MockCommandExecutor{
+		RunFunc: func(_ context.Context, name string, _ ...string) ([]byte, error) {
+			return nil, &exec.Error{Name: name, Err: exec.ErrNotFound}
+		},
+	}}
+
+	resp, _ := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:    plugin.HookPostPublish,
+		Config:  map[string]any{"group_id": "com.example", "artifact_id": "my-app"},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if resp.Success || !strings.Contains(resp.Error, "mvn not found: install Maven or add it to PATH") {
+		t.Errorf("expected clear not-found error, got %+v", resp)
+	}
+}
@@ -0,0 +1,63 @@
+// Package main implements isolating Maven from the runner user's configuration.
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// mavenConfigDir returns the directory used in place of ~/.m2, or "" when
+// Maven uses the runner user's configuration. maven_config names the
+// directory itself; maven_user_home names a home containing .m2.
+func (cfg *Config) mavenConfigDir() string {
+	if cfg.MavenConfig != "" {
+		return cfg.MavenConfig
+	}
+	if cfg.MavenUserHome != "" {
+		return filepath.Join(cfg.MavenUserHome, ".m2")
+	}
+	return ""
+}
+
+// userSettings returns the user settings file passed to Maven with -s, or ""
+// when Maven finds it itself: see settingsLookup for the order. The runner
+// user's ~/.m2/settings.xml is Maven's default and is not passed.
+func (cfg *Config) userSettings() string {
+	path, source := cfg.settingsLookup()
+	if source == settingsSourceUserHome {
+		return ""
+	}
+	return path
+}
+
+// isolationArgs returns the arguments pointing Maven at the isolated
+// configuration directory for the settings security file, the toolchains,
+// and, when localRepository is set, the local repository. The toolchains
+// rendered from the configuration are passed as the global toolchains.
+func (cfg *Config) isolationArgs(localRepository bool) []string {
+	var args []string
+	if dir := cfg.mavenConfigDir(); dir != "" {
+		args = append(args, "-Dsettings.security="+filepath.Join(dir, "settings-security.xml"))
+		if localRepository {
+			args = append(args, "-Dmaven.repo.local="+filepath.Join(dir, "repository"))
+		}
+		if toolchains := filepath.Join(dir, "toolchains.xml"); fileExists(toolchains) {
+			args = append(args, "-t", toolchains)
+		}
+	}
+	if cfg.toolchainsFile != "" {
+		args = append(args, "--global-toolchains", cfg.toolchainsFile)
+	}
+	return args
+}
+
+// validateMavenConfigDir checks that an isolated configuration directory is
+// not a file. A missing directory is created by Maven.
+func validateMavenConfigDir(path string) error {
+	info, err := os.Stat(path)
+	if err == nil && !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", path)
+	}
+	return nil
+}
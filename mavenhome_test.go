@@ -0,0 +1,147 @@
+// Package main provides tests for isolating the Maven configuration.
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestMavenConfigDir(t *testing.T) {
+	cfg := &Config{MavenUserHome: "/home/ci"}
+	if dir := cfg.mavenConfigDir(); dir != filepath.Join("/home/ci", ".m2") {
+		t.Errorf("expected .m2 below the user home, got %s", dir)
+	}
+	cfg.MavenConfig = "/opt/m2"
+	if dir := cfg.mavenConfigDir(); dir != "/opt/m2" {
+		t.Errorf("expected maven_config to be used as is, got %s", dir)
+	}
+	if args := (&Config{}).isolationArgs(true); args != nil {
+		t.Errorf("expected no arguments without isolation, got %v", args)
+	}
+}
+
+func TestBuildMavenCommandIsolation(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"settings.xml", "toolchains.xml"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("<settings/>"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	p := &MavenPlugin{}
+	cfg := p.parseConfig(map[string]any{"group_id": "com.example", "artifact_id": "my-app", "maven_config": dir})
+	args, err := p.buildMavenCommand(cfg, plugin.ReleaseContext{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	command := strings.Join(args, " ")
+	for _, want := range []string{
+		"-s " + filepath.Join(dir, "settings.xml"),
+		"-Dsettings.security=" + filepath.Join(dir, "settings-security.xml"),
+		"-Dmaven.repo.local=" + filepath.Join(dir, "repository"),
+		"-t " + filepath.Join(dir, "toolchains.xml"),
+	} {
+		if !strings.Contains(command, want) {
+			t.Errorf("expected %q in %s", want, command)
+		}
+	}
+
+	cfg.Settings = "ci-settings.xml"
+	args, _ = p.buildMavenCommand(cfg, plugin.ReleaseContext{Version: "1.0.0"})
+	if command := strings.Join(args, " "); !strings.Contains(command, "-s ci-settings.xml") || strings.Contains(command, "-s "+dir) {
+		t.Errorf("expected the configured settings file to take precedence, got %s", command)
+	}
+}
+
+func TestExecuteIsolatedWithoutSettings(t *testing.T) {
+	home := t.TempDir()
+	var settings string
+	mockExec := &MockCommandExecutor{
+		RunFunc: func(_ context.Context, _ string, args ...string) ([]byte, error) {
+			for i, arg := range args {
+				if arg == "-s" && i+1 < len(args) {
+					data, err := os.ReadFile(args[i+1])
+					if err != nil {
+						return nil, err
+					}
+					settings = string(data)
+				}
+			}
+			return []byte("[INFO] BUILD SUCCESS"), nil
+		},
+	}
+	p := &MavenPlugin{executor: mockExec}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":        "com.example",
+			"artifact_id":     "my-app",
+			"repository":      "http://localhost:8081/repository/maven-releases",
+			"maven_user_home": home,
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+	if !strings.Contains(settings, "<settings") {
+		t.Errorf("expected a generated settings file shadowing ~/.m2/settings.xml, got %q", settings)
+	}
+	if args := strings.Join(mockExec.Calls[0].Args, " "); !strings.Contains(args, "-Dmaven.repo.local="+filepath.Join(home, ".m2", "repository")) {
+		t.Errorf("expected the isolated local repository, got %s", args)
+	}
+}
+
+func TestValidateMavenConfig(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "m2")
+	if err := os.WriteFile(file, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		config    map[string]any
+		wantField string
+	}{
+		{name: "user home", config: map[string]any{"maven_user_home": t.TempDir()}},
+		{name: "missing directory", config: map[string]any{"maven_config": filepath.Join(t.TempDir(), "new")}},
+		{name: "not a directory", config: map[string]any{"maven_config": file}, wantField: "maven_config"},
+		{name: "both", config: map[string]any{"maven_config": "/opt/m2", "maven_user_home": "/home/ci"}, wantField: "maven_config"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]any{"group_id": "com.example", "artifact_id": "my-app"}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+			resp, err := (&MavenPlugin{}).Validate(context.Background(), config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantField == "" {
+				if !resp.Valid {
+					t.Errorf("expected valid config, got errors: %v", resp.Errors)
+				}
+				return
+			}
+			found := false
+			for _, e := range resp.Errors {
+				if e.Field == tt.wantField {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("expected error on field %q, got %v", tt.wantField, resp.Errors)
+			}
+		})
+	}
+}
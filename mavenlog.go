@@ -0,0 +1,147 @@
+// Package main implements the log file of the Maven output of a deploy.
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// defaultMavenLog is the log file written next to the POM when maven_log is unset.
+const defaultMavenLog = "target/relicta-maven.log"
+
+// defaultMaxErrorOutputBytes is the Maven output embedded in errors when
+// max_error_output_bytes is unset.
+const defaultMaxErrorOutputBytes = 8 << 10
+
+// urlCredentialsPattern matches the user information of URLs in Maven output.
+var urlCredentialsPattern = regexp.MustCompile(`(://)[^/\s:@]+:[^/\s@]+@`)
+
+// mavenLog collects the output of the Maven invocations of a deploy.
+type mavenLog struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+// mavenLogKey is the context key of the log of a deploy.
+type mavenLogKey struct{}
+
+// withMavenLog returns a context whose Maven invocations are recorded in log.
+func withMavenLog(ctx context.Context, log *mavenLog) context.Context {
+	return context.WithValue(ctx, mavenLogKey{}, log)
+}
+
+// recordMavenOutput appends an invocation and its output to the log of ctx, if any.
+func recordMavenOutput(ctx context.Context, cfg *Config, args []string, output []byte) {
+	log, ok := ctx.Value(mavenLogKey{}).(*mavenLog)
+	if !ok {
+		return
+	}
+	log.mu.Lock()
+	defer log.mu.Unlock()
+	fmt.Fprintf(&log.buf, "$ %s %s\n", cfg.mavenCommand(), shellJoin(cfg.maskEnvProperties(args)))
+	log.buf.Write(output)
+	if len(output) > 0 && output[len(output)-1] != '\n' {
+		log.buf.WriteByte('\n')
+	}
+}
+
+// runMavenCommand runs Maven with args, recording the output in the log of ctx.
+func (p *MavenPlugin) runMavenCommand(ctx context.Context, cfg *Config, args ...string) ([]byte, error) {
+	output, err := p.getExecutor().Run(ctx, cfg.mavenCommand(), args...)
+	recordMavenOutput(ctx, cfg, args, output)
+	return output, err
+}
+
+// mavenLogPath returns the log file, defaulting to target/relicta-maven.log
+// next to the POM. Each repository of a multi-repository deploy defaults to
+// its own log, such as target/relicta-maven-internal.log.
+func (cfg *Config) mavenLogPath() string {
+	if cfg.MavenLog != "" {
+		return cfg.MavenLog
+	}
+	name := defaultMavenLog
+	if cfg.stage != nil {
+		name = strings.TrimSuffix(name, ".log") + "-" + cfg.RepositoryID + ".log"
+	}
+	return filepath.Join(filepath.Dir(cfg.PomPath), filepath.FromSlash(name))
+}
+
+// secrets returns the credentials of the configuration that Maven may echo.
+func (cfg *Config) secrets() []string {
+	secrets := []string{cfg.Password, cfg.SnapshotPassword}
+	if cfg.GPGPassphraseEnv != "" {
+		secrets = append(secrets, os.Getenv(cfg.GPGPassphraseEnv))
+	}
+	for env := range cfg.PassEnvProperties {
+		secrets = append(secrets, os.Getenv(env))
+	}
+	return secrets
+}
+
+// redactLog masks the credentials, the secrets of settings.xml and the user
+// information of URLs in Maven output.
+func (cfg *Config) redactLog(data []byte) []byte {
+	data = maskSettings(data)
+	data = urlCredentialsPattern.ReplaceAll(data, []byte("${1}"+maskedValue+"@"))
+	for _, secret := range cfg.secrets() {
+		if secret != "" {
+			data = bytes.ReplaceAll(data, []byte(secret), []byte(maskedValue))
+		}
+	}
+	return data
+}
+
+// writeMavenLog writes the redacted log of a deploy and references it in the
+// outputs, whether the deploy succeeded or not. A log that cannot be written
+// only warns.
+func (cfg *Config) writeMavenLog(log *mavenLog, resp *plugin.ExecuteResponse) *plugin.ExecuteResponse {
+	if resp == nil {
+		return nil
+	}
+	if resp.Outputs == nil {
+		resp.Outputs = map[string]any{}
+	}
+	path := cfg.mavenLogPath()
+	log.mu.Lock()
+	data := cfg.redactLog(log.buf.Bytes())
+	log.mu.Unlock()
+
+	err := os.MkdirAll(filepath.Dir(path), 0o755)
+	if err == nil {
+		err = os.WriteFile(path, data, 0o600)
+	}
+	if err != nil {
+		warnings, _ := resp.Outputs["warnings"].([]string)
+		resp.Outputs["warnings"] = append(warnings, fmt.Sprintf("maven_log: failed to write the Maven log: %v", err))
+		return resp
+	}
+	resp.Outputs["maven_log"] = path
+	return resp
+}
+
+// errorOutput returns the end of Maven output for the error of a failed run:
+// at most MaxErrorOutputBytes bytes, starting at a line when the cut leaves
+// one.
+func (cfg *Config) errorOutput(output []byte) string {
+	limit := cfg.MaxErrorOutputBytes
+	if limit <= 0 || len(output) <= limit {
+		return string(output)
+	}
+	tail := output[len(output)-limit:]
+	if i := bytes.IndexByte(tail, '\n'); i >= 0 && i+1 < len(tail) {
+		tail = tail[i+1:]
+	}
+	for len(tail) > 0 && !utf8.RuneStart(tail[0]) {
+		tail = tail[1:]
+	}
+	return fmt.Sprintf("[... %d earlier bytes omitted]\n%s", len(output)-len(tail), tail)
+}
@@ -0,0 +1,140 @@
+// Package main implements verifying maven-metadata.xml after deploy.
+package main
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// mavenPluginPackaging is the packaging of Maven plugins, the only artifacts
+// listed in the group-level maven-metadata.xml.
+const mavenPluginPackaging = "maven-plugin"
+
+// groupMetadata is the group-level maven-metadata.xml listing the plugins of a group.
+type groupMetadata struct {
+	Plugins []struct {
+		Prefix     string `xml:"prefix"`
+		ArtifactID string `xml:"artifactId"`
+	} `xml:"plugins>plugin"`
+}
+
+// metadataReport describes the verified maven-metadata.xml of a release.
+type metadataReport struct {
+	Latest  string
+	Release string
+	// PluginPrefix is the goal prefix found in the group-level metadata of Maven plugins.
+	PluginPrefix string
+}
+
+// outputs returns the report as plugin outputs.
+func (r *metadataReport) outputs() map[string]any {
+	out := map[string]any{
+		"listed":  true,
+		"latest":  r.Latest,
+		"release": r.Release,
+	}
+	if r.PluginPrefix != "" {
+		out["plugin_prefix"] = r.PluginPrefix
+	}
+	return out
+}
+
+// checkArtifactMetadata checks that the artifact-level metadata lists version
+// and, when version is the newest, names it as latest and release. Older
+// versions, such as maintenance releases, leave latest and release untouched.
+func checkArtifactMetadata(metadata *mavenMetadata, version string) error {
+	v := metadata.Versioning
+	if !slices.Contains(v.Versions, version) {
+		return fmt.Errorf("version %s is not listed in maven-metadata.xml (versions: %s)", version, strings.Join(v.Versions, ", "))
+	}
+
+	newest, newestRelease := true, true
+	for _, other := range v.Versions {
+		if compareMavenVersions(other, version) > 0 {
+			newest = false
+			if !strings.HasSuffix(other, "-SNAPSHOT") {
+				newestRelease = false
+			}
+		}
+	}
+	if newest && v.Latest != "" && v.Latest != version {
+		return fmt.Errorf("maven-metadata.xml names %s as latest instead of %s", v.Latest, version)
+	}
+	if newestRelease && !strings.HasSuffix(version, "-SNAPSHOT") && v.Release != version {
+		return fmt.Errorf("maven-metadata.xml names %q as release instead of %s", v.Release, version)
+	}
+	return nil
+}
+
+// fetchGroupMetadata downloads the group-level maven-metadata.xml.
+func (c *repositoryClient) fetchGroupMetadata(ctx context.Context, groupID string) (*groupMetadata, error) {
+	segments := strings.Split(groupID, ".")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	body, err := c.fetchFile(ctx, strings.Join(segments, "/")+"/maven-metadata.xml")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var metadata groupMetadata
+	if err := xml.NewDecoder(io.LimitReader(body, 10<<20)).Decode(&metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse group maven-metadata.xml: %w", err)
+	}
+	return &metadata, nil
+}
+
+// packaging returns the packaging of the deployed project's POM, or "" when
+// the POM cannot be read.
+func (cfg *Config) packaging() string {
+	pom, err := readPOM(cfg.projectPOM())
+	if err != nil {
+		return ""
+	}
+	return pom.Packaging
+}
+
+// verifyMetadata fetches the maven-metadata.xml of the release and checks
+// that it lists the new version. For Maven plugins the group-level metadata
+// must also list the plugin.
+func (p *MavenPlugin) verifyMetadata(ctx context.Context, cfg *Config, version string) (*metadataReport, error) {
+	version = mavenVersion(version)
+	client := newRepositoryClient(p.getHTTPClient(), cfg.targetRepository(version), cfg.Username, cfg.Password)
+
+	metadata, err := client.fetchMetadata(ctx, cfg.GroupID, cfg.ArtifactID)
+	if errors.Is(err, errComponentNotFound) {
+		return nil, fmt.Errorf("maven-metadata.xml for %s:%s was not found in the repository", cfg.GroupID, cfg.ArtifactID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := checkArtifactMetadata(metadata, version); err != nil {
+		return nil, err
+	}
+	report := &metadataReport{Latest: metadata.Versioning.Latest, Release: metadata.Versioning.Release}
+
+	if cfg.packaging() != mavenPluginPackaging {
+		return report, nil
+	}
+	group, err := client.fetchGroupMetadata(ctx, cfg.GroupID)
+	if errors.Is(err, errComponentNotFound) {
+		return nil, fmt.Errorf("group maven-metadata.xml for %s was not found in the repository", cfg.GroupID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range group.Plugins {
+		if entry.ArtifactID == cfg.ArtifactID {
+			report.PluginPrefix = entry.Prefix
+			return report, nil
+		}
+	}
+	return nil, fmt.Errorf("plugin %s is not listed in the group maven-metadata.xml of %s", cfg.ArtifactID, cfg.GroupID)
+}
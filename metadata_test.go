@@ -0,0 +1,112 @@
+// Package main provides tests for maven-metadata.xml verification.
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestCheckArtifactMetadata(t *testing.T) {
+	metadata := func(latest, release string, versions ...string) *mavenMetadata {
+		m := &mavenMetadata{}
+		m.Versioning.Latest = latest
+		m.Versioning.Release = release
+		m.Versioning.Versions = versions
+		return m
+	}
+
+	tests := []struct {
+		name     string
+		metadata *mavenMetadata
+		version  string
+		errMsg   string
+	}{
+		{name: "newest release", metadata: metadata("1.1.0", "1.1.0", "1.0.0", "1.1.0"), version: "1.1.0"},
+		{name: "maintenance release", metadata: metadata("2.0.0", "2.0.0", "1.0.0", "2.0.0", "1.0.1"), version: "1.0.1"},
+		{name: "release below a snapshot", metadata: metadata("1.2.0-SNAPSHOT", "1.1.0", "1.1.0", "1.2.0-SNAPSHOT"), version: "1.1.0"},
+		{name: "snapshot", metadata: metadata("1.2.0-SNAPSHOT", "1.1.0", "1.1.0", "1.2.0-SNAPSHOT"), version: "1.2.0-SNAPSHOT"},
+		{name: "no latest field", metadata: metadata("", "1.1.0", "1.1.0"), version: "1.1.0"},
+		{name: "not listed", metadata: metadata("1.0.0", "1.0.0", "1.0.0"), version: "1.1.0", errMsg: "not listed"},
+		{name: "stale latest", metadata: metadata("1.0.0", "1.1.0", "1.0.0", "1.1.0"), version: "1.1.0", errMsg: "as latest"},
+		{name: "stale release", metadata: metadata("1.1.0", "1.0.0", "1.0.0", "1.1.0"), version: "1.1.0", errMsg: "as release"},
+		{name: "missing release", metadata: metadata("", "", "1.1.0"), version: "1.1.0", errMsg: "as release"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkArtifactMetadata(tt.metadata, tt.version)
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("expected error containing %q, got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestExecuteVerifyMetadata(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+	if err := os.WriteFile(filepath.Join(dir, "pom.xml"), []byte(`<project><packaging>maven-plugin</packaging></project>`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	remote := map[string]string{
+		"/repo/com/example/my-app/maven-metadata.xml": `<metadata><versioning><latest>1.0.0</latest><release>1.0.0</release><versions><version>0.9.0</version><version>1.0.0</version></versions></versioning></metadata>`,
+		"/repo/com/example/maven-metadata.xml":        `<metadata><plugins><plugin><name>My App</name><prefix>my</prefix><artifactId>my-app</artifactId></plugin></plugins></metadata>`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := remote[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	execute := func() *plugin.ExecuteResponse {
+		t.Helper()
+		p := &MavenPlugin{executor: &MockCommandExecutor{}, httpClient: server.Client()}
+		resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+			Hook: plugin.HookPostPublish,
+			Config: map[string]any{
+				"group_id":        "com.example",
+				"artifact_id":     "my-app",
+				"repository":      server.URL + "/repo",
+				"verify_metadata": true,
+			},
+			Context: plugin.ReleaseContext{Version: "1.0.0"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return resp
+	}
+
+	resp := execute()
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+	metadata, _ := resp.Outputs["metadata"].(map[string]any)
+	if metadata["release"] != "1.0.0" || metadata["plugin_prefix"] != "my" {
+		t.Errorf("unexpected metadata output: %v", metadata)
+	}
+
+	delete(remote, "/repo/com/example/maven-metadata.xml")
+	resp = execute()
+	if resp.Success || !strings.Contains(resp.Error, "group maven-metadata.xml") {
+		t.Errorf("expected missing group metadata to fail, got %+v", resp)
+	}
+}
@@ -0,0 +1,204 @@
+// Package main implements publishing platform-specific native artifacts.
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+)
+
+// maxNativeArtifactSize bounds the size of downloaded native artifacts.
+const maxNativeArtifactSize = 1 << 30
+
+// NativeArtifact is a platform-specific file attached to the release GAV.
+type NativeArtifact struct {
+	// Classifier identifies the platform, e.g. linux-x86_64.
+	Classifier string
+	// Path is a local file; URL is downloaded instead when Path is empty.
+	Path string
+	URL  string
+	// Type is the file extension (default jar).
+	Type string
+}
+
+// parseNativeArtifacts parses the native_artifacts list from the raw configuration.
+func parseNativeArtifacts(raw map[string]any) []NativeArtifact {
+	items, ok := raw["native_artifacts"].([]any)
+	if !ok {
+		return nil
+	}
+	natives := make([]NativeArtifact, 0, len(items))
+	for _, item := range items {
+		entry, _ := item.(map[string]any)
+		parser := helpers.NewConfigParser(entry)
+		natives = append(natives, NativeArtifact{
+			Classifier: parser.GetString("classifier", "", ""),
+			Path:       parser.GetString("path", "", ""),
+			URL:        parser.GetString("url", "", ""),
+			Type:       parser.GetString("type", "", "jar"),
+		})
+	}
+	return natives
+}
+
+// validateNativeArtifact validates a native artifact entry and returns the
+// offending field on error.
+func validateNativeArtifact(native NativeArtifact, policy networkPolicy) (string, error) {
+	if err := validateMavenCoordinate(native.Classifier, "classifier"); err != nil {
+		return "classifier", err
+	}
+	if err := validateMavenCoordinate(native.Type, "type"); err != nil {
+		return "type", err
+	}
+	switch {
+	case native.Path != "" && native.URL != "":
+		return "path", fmt.Errorf("set either path or url, not both")
+	case native.Path != "":
+		if err := validatePath(native.Path); err != nil {
+			return "path", err
+		}
+		// deploy-file takes comma-separated file lists.
+		if strings.Contains(native.Path, ",") {
+			return "path", fmt.Errorf("path cannot contain ','")
+		}
+	case native.URL != "":
+		if err := validateRepositoryURL(native.URL, policy); err != nil {
+			return "url", err
+		}
+	default:
+		return "path", fmt.Errorf("path or url is required")
+	}
+	return "", nil
+}
+
+// nativeDeployArgs builds the deploy:deploy-file arguments that attach every
+// native file to the GAV in a single deploy. No POM is generated, so the POM
+// from the main deploy is left untouched.
+func nativeDeployArgs(cfg *Config, version string, natives []NativeArtifact, files []string, settingsPath string) []string {
+	repositoryID := cfg.RepositoryID
+	if cfg.targetRepository(version) == cfg.SnapshotRepository && cfg.SnapshotRepository != "" {
+		repositoryID = cfg.SnapshotRepositoryID
+	}
+
+	args := []string{
+		"deploy:deploy-file",
+		"-DgroupId=" + cfg.GroupID,
+		"-DartifactId=" + cfg.ArtifactID,
+		"-Dversion=" + version,
+		"-Dpackaging=" + natives[0].Type,
+		"-Dfile=" + files[0],
+		"-Dclassifier=" + natives[0].Classifier,
+		"-DgeneratePom=false",
+		"-Durl=" + cfg.targetRepository(version),
+		"-DrepositoryId=" + repositoryID,
+	}
+
+	if len(natives) > 1 {
+		var classifiers, types []string
+		for _, native := range natives[1:] {
+			classifiers = append(classifiers, native.Classifier)
+			types = append(types, native.Type)
+		}
+		args = append(args,
+			"-Dfiles="+strings.Join(files[1:], ","),
+			"-Dclassifiers="+strings.Join(classifiers, ","),
+			"-Dtypes="+strings.Join(types, ","),
+		)
+	}
+
+	if cfg.DeployRetries > 0 {
+		args = append(args, fmt.Sprintf("-DretryFailedDeploymentCount=%d", cfg.DeployRetries))
+	}
+	if settingsPath != "" {
+		args = append(args, "-s", settingsPath)
+	}
+	return append(args, cfg.isolationArgs(true)...)
+}
+
+// nativeSources returns the configured location of every native artifact.
+func nativeSources(natives []NativeArtifact) []string {
+	sources := make([]string, 0, len(natives))
+	for _, native := range natives {
+		if native.Path != "" {
+			sources = append(sources, native.Path)
+		} else {
+			sources = append(sources, redactURL(native.URL))
+		}
+	}
+	return sources
+}
+
+// fetchNatives resolves native artifacts to local files, downloading those
+// given by URL into a temporary directory removed by the returned cleanup.
+func (p *MavenPlugin) fetchNatives(ctx context.Context, natives []NativeArtifact) ([]string, func(), error) {
+	files := make([]string, len(natives))
+	dir := ""
+	cleanup := func() {
+		if dir != "" {
+			_ = os.RemoveAll(dir)
+		}
+	}
+
+	for i, native := range natives {
+		if native.Path != "" {
+			if _, err := os.Stat(native.Path); err != nil {
+				cleanup()
+				return nil, nil, fmt.Errorf("native artifact %s: %w", native.Classifier, err)
+			}
+			files[i] = native.Path
+			continue
+		}
+
+		if dir == "" {
+			var err error
+			if dir, err = os.MkdirTemp("", "relicta-maven-natives-*"); err != nil {
+				return nil, nil, fmt.Errorf("failed to create download directory: %w", err)
+			}
+		}
+		path := filepath.Join(dir, native.Classifier+"."+native.Type)
+		if err := p.download(ctx, native.URL, path); err != nil {
+			cleanup()
+			return nil, nil, fmt.Errorf("native artifact %s: %w", native.Classifier, err)
+		}
+		files[i] = path
+	}
+	return files, cleanup, nil
+}
+
+// download fetches a URL into a local file.
+func (p *MavenPlugin) download(ctx context.Context, rawURL, path string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := p.getHTTPClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("download from %s failed: %w", redactURL(rawURL), err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("download from %s returned %s", redactURL(rawURL), resp.Status)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	n, err := io.Copy(f, io.LimitReader(resp.Body, maxNativeArtifactSize+1))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if n > maxNativeArtifactSize {
+		return fmt.Errorf("download from %s exceeds %d bytes", redactURL(rawURL), maxNativeArtifactSize)
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+// Package main provides tests for native artifact publishing.
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestNativeDeployArgs(t *testing.T) {
+	cfg := &Config{
+		GroupID:      "com.example",
+		ArtifactID:   "my-app",
+		RepositoryID: "releases",
+		Repository:   "http://localhost:8081/repository/maven-releases",
+	}
+	natives := []NativeArtifact{
+		{Classifier: "linux-x86_64", Type: "jar"},
+		{Classifier: "osx-aarch64", Type: "jar"},
+		{Classifier: "windows-x86_64", Type: "zip"},
+	}
+
+	args := strings.Join(nativeDeployArgs(cfg, "1.0.0", natives, []string{"a.jar", "b.jar", "c.zip"}, "/tmp/settings.xml"), " ")
+	for _, want := range []string{
+		"deploy:deploy-file",
+		"-DgroupId=com.example -DartifactId=my-app -Dversion=1.0.0",
+		"-Dfile=a.jar -Dclassifier=linux-x86_64",
+		"-DgeneratePom=false",
+		"-Durl=http://localhost:8081/repository/maven-releases -DrepositoryId=releases",
+		"-Dfiles=b.jar,c.zip -Dclassifiers=osx-aarch64,windows-x86_64 -Dtypes=jar,zip",
+		"-s /tmp/settings.xml",
+	} {
+		if !strings.Contains(args, want) {
+			t.Errorf("expected %q in %s", want, args)
+		}
+	}
+
+	single := strings.Join(nativeDeployArgs(cfg, "1.0.0", natives[:1], []string{"a.jar"}, ""), " ")
+	if strings.Contains(single, "-Dfiles=") || strings.Contains(single, "-s ") {
+		t.Errorf("unexpected args for a single native artifact: %s", single)
+	}
+}
+
+func TestExecuteNativeArtifacts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/osx-aarch64.jar" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("native"))
+	}))
+	defer server.Close()
+
+	local := filepath.Join(t.TempDir(), "linux.jar")
+	if err := os.WriteFile(local, []byte("native"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	config := map[string]any{
+		"group_id":    "com.example",
+		"artifact_id": "my-app",
+		"repository":  "http://localhost:8081/repository/maven-releases",
+		"native_artifacts": []any{
+			map[string]any{"classifier": "linux-x86_64", "path": local},
+			map[string]any{"classifier": "osx-aarch64", "url": server.URL + "/osx-aarch64.jar"},
+		},
+	}
+
+	t.Run("deploy", func(t *testing.T) {
+		var downloaded string
+		mockExec := &MockCommandExecutor{
+			RunFunc: func(_ context.Context, _ string, args ...string) ([]byte, error) {
+				for _, arg := range args {
+					if path, ok := strings.CutPrefix(arg, "-Dfiles="); ok {
+						data, err := os.ReadFile(path)
+						if err != nil {
+							return nil, err
+						}
+						downloaded = string(data)
+					}
+				}
+				return []byte("[INFO] BUILD SUCCESS"), nil
+			},
+		}
+		p := &MavenPlugin{executor: mockExec, httpClient: server.Client()}
+
+		resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+			Hook:    plugin.HookPostPublish,
+			Config:  config,
+			Context: plugin.ReleaseContext{Version: "1.0.0"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !resp.Success {
+			t.Fatalf("expected success, got error: %s", resp.Error)
+		}
+		if len(mockExec.Calls) != 2 {
+			t.Fatalf("expected the deploy and a single native deploy, got %d calls", len(mockExec.Calls))
+		}
+		if args := strings.Join(mockExec.Calls[1].Args, " "); !strings.Contains(args, "-Dfile="+local) {
+			t.Errorf("unexpected native deploy args: %s", args)
+		}
+		if downloaded != "native" {
+			t.Errorf("expected the downloaded native artifact, got %q", downloaded)
+		}
+		if classifiers, _ := resp.Outputs["native_artifacts"].([]string); len(classifiers) != 2 {
+			t.Errorf("unexpected native_artifacts output: %v", resp.Outputs["native_artifacts"])
+		}
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		mockExec := &MockCommandExecutor{}
+		p := &MavenPlugin{executor: mockExec}
+
+		resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+			Hook:    plugin.HookPostPublish,
+			Config:  config,
+			Context: plugin.ReleaseContext{Version: "1.0.0"},
+			DryRun:  true,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(mockExec.Calls) != 0 {
+			t.Errorf("expected no commands in dry run, got %d", len(mockExec.Calls))
+		}
+		command, _ := resp.Outputs["native_command"].(string)
+		if !strings.Contains(command, "-Dclassifiers=osx-aarch64") || !strings.Contains(command, server.URL) {
+			t.Errorf("unexpected native command: %s", command)
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		missing := map[string]any{}
+		for k, v := range config {
+			missing[k] = v
+		}
+		missing["native_artifacts"] = []any{map[string]any{"classifier": "linux-x86_64", "path": filepath.Join(t.TempDir(), "missing.jar")}}
+
+		p := &MavenPlugin{executor: &MockCommandExecutor{}}
+		resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+			Hook:    plugin.HookPostPublish,
+			Config:  missing,
+			Context: plugin.ReleaseContext{Version: "1.0.0"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Success || !strings.Contains(resp.Error, "native artifact linux-x86_64") {
+			t.Errorf("expected a missing file error, got %+v", resp)
+		}
+	})
+}
+
+func TestValidateNativeArtifacts(t *testing.T) {
+	tests := []struct {
+		name      string
+		natives   []any
+		wantField string
+	}{
+		{
+			name:    "valid",
+			natives: []any{map[string]any{"classifier": "linux-x86_64", "path": "target/native.jar"}},
+		},
+		{
+			name:      "missing source",
+			natives:   []any{map[string]any{"classifier": "linux-x86_64"}},
+			wantField: "native_artifacts[0].path",
+		},
+		{
+			name:      "invalid classifier",
+			natives:   []any{map[string]any{"classifier": "linux x86", "path": "a.jar"}},
+			wantField: "native_artifacts[0].classifier",
+		},
+		{
+			name:      "comma in path",
+			natives:   []any{map[string]any{"classifier": "linux-x86_64", "path": "a,b.jar"}},
+			wantField: "native_artifacts[0].path",
+		},
+		{
+			name:      "insecure url",
+			natives:   []any{map[string]any{"classifier": "linux-x86_64", "url": "http://example.com/a.jar"}},
+			wantField: "native_artifacts[0].url",
+		},
+		{
+			name: "duplicate classifier",
+			natives: []any{
+				map[string]any{"classifier": "linux-x86_64", "path": "a.jar"},
+				map[string]any{"classifier": "linux-x86_64", "path": "b.jar"},
+			},
+			wantField: "native_artifacts[1].classifier",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &MavenPlugin{}
+			resp, err := p.Validate(context.Background(), map[string]any{
+				"group_id":         "com.example",
+				"artifact_id":      "my-app",
+				"repository":       "http://localhost:8081/repository/maven-releases",
+				"native_artifacts": tt.natives,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantField == "" {
+				if !resp.Valid {
+					t.Errorf("expected valid config, got errors: %v", resp.Errors)
+				}
+				return
+			}
+			found := false
+			for _, e := range resp.Errors {
+				if e.Field == tt.wantField {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("expected error on field %q, got %v", tt.wantField, resp.Errors)
+			}
+		})
+	}
+}
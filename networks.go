@@ -0,0 +1,115 @@
+// Package main implements the network policy for repository URL validation.
+package main
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// nat64Prefix is the well-known NAT64 prefix (RFC 6052), which embeds an IPv4
+// address in its last four bytes.
+var nat64Prefix = mustParseCIDR("64:ff9b::/96")
+
+// cloudMetadataRanges are always blocked, even when allowed_networks covers them.
+var cloudMetadataRanges = []*net.IPNet{
+	mustParseCIDR("169.254.169.254/32"), // AWS/GCP/Azure metadata.
+	mustParseCIDR("fd00:ec2::254/128"),  // AWS IMDSv2 IPv6.
+}
+
+// networkPolicy controls which hosts repository URLs may point to.
+type networkPolicy struct {
+	// DNS is the DNS policy: strict, skip_dns or offline.
+	DNS string
+	// Allowed lists networks exempt from the private network check.
+	Allowed []*net.IPNet
+	// Blocked lists networks rejected in addition to the private ranges.
+	Blocked []*net.IPNet
+}
+
+// mustParseCIDR parses a CIDR constant.
+func mustParseCIDR(cidr string) *net.IPNet {
+	_, block, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(err)
+	}
+	return block
+}
+
+// parseNetworks parses a list of CIDRs. A bare IP address is treated as a
+// single-host network.
+func parseNetworks(values []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if !strings.Contains(value, "/") {
+			ip := net.ParseIP(value)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid network %q: expected a CIDR or IP address", value)
+			}
+			bits := 128
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, block, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid network %q: %w", value, err)
+		}
+		networks = append(networks, block)
+	}
+	return networks, nil
+}
+
+// networkPolicy returns the network policy of the configuration. Invalid
+// networks are reported by Validate and ignored here.
+func (cfg *Config) networkPolicy() networkPolicy {
+	allowed, _ := parseNetworks(cfg.AllowedNetworks)
+	blocked, _ := parseNetworks(cfg.BlockedNetworks)
+	return networkPolicy{DNS: cfg.DNSPolicy, Allowed: allowed, Blocked: blocked}
+}
+
+// embeddedIPv4 returns the IPv4 address carried by an IPv4-mapped or NAT64
+// IPv6 address, and the address itself otherwise.
+func embeddedIPv4(ip net.IP) net.IP {
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4
+	}
+	if nat64Prefix.Contains(ip) {
+		return net.IP(ip[12:16]).To4()
+	}
+	return ip
+}
+
+// containsIP reports whether any of the networks contains the address.
+func containsIP(networks []*net.IPNet, ip net.IP) bool {
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// blocks reports whether the policy rejects an address. Cloud metadata
+// endpoints are always rejected; otherwise blocked networks win over allowed
+// networks, which win over the built-in private ranges.
+func (np networkPolicy) blocks(ip net.IP) bool {
+	candidates := []net.IP{ip}
+	if embedded := embeddedIPv4(ip); !embedded.Equal(ip) {
+		candidates = append(candidates, embedded)
+	}
+	for _, candidate := range candidates {
+		if containsIP(cloudMetadataRanges, candidate) || containsIP(np.Blocked, candidate) {
+			return true
+		}
+	}
+	for _, candidate := range candidates {
+		if containsIP(np.Allowed, candidate) {
+			return false
+		}
+	}
+	return isPrivateIP(ip)
+}
@@ -0,0 +1,69 @@
+// Package main provides tests for the repository network policy.
+package main
+
+import (
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestParseNetworks(t *testing.T) {
+	networks, err := parseNetworks([]string{"fd00:1234::/32", "10.1.2.3", " 192.168.0.0/16 "})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"fd00:1234::/32", "10.1.2.3/32", "192.168.0.0/16"}
+	for i, network := range networks {
+		if network.String() != want[i] {
+			t.Errorf("network %d: expected %s, got %s", i, want[i], network)
+		}
+	}
+
+	if _, err := parseNetworks([]string{"internal"}); err == nil || !strings.Contains(err.Error(), "invalid network") {
+		t.Errorf("expected invalid network error, got %v", err)
+	}
+}
+
+func TestNetworkPolicyBlocks(t *testing.T) {
+	allowed, _ := parseNetworks([]string{"fd00:1234::/32", "10.20.0.0/16", "fd00::/8"})
+	blocked, _ := parseNetworks([]string{"203.0.113.0/24", "10.20.30.0/24"})
+	policy := networkPolicy{Allowed: allowed, Blocked: blocked}
+
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"fd00:1234::10", false},
+		{"fd99::1", false},
+		{"fc00::1", true},
+		{"10.20.1.1", false},
+		{"::ffff:10.20.1.1", false},
+		{"64:ff9b::a14:101", false},
+		{"10.20.30.1", true},
+		{"203.0.113.7", true},
+		{"64:ff9b::cb00:7107", true},
+		{"8.8.8.8", false},
+		{"fd00:ec2::254", true},
+		{"169.254.169.254", true},
+	}
+	for _, tt := range tests {
+		if got := policy.blocks(net.ParseIP(tt.ip)); got != tt.want {
+			t.Errorf("blocks(%s): expected %v, got %v", tt.ip, tt.want, got)
+		}
+	}
+}
+
+func TestValidateRepositoryURLNetworks(t *testing.T) {
+	allowed, _ := parseNetworks([]string{"fd00:1234::/32"})
+	policy := networkPolicy{DNS: dnsPolicyStrict, Allowed: allowed}
+
+	if err := validateRepositoryURL("https://[fd00:1234::10]/repository", policy); err != nil {
+		t.Errorf("expected allowed ULA to pass, got %v", err)
+	}
+	if err := validateRepositoryURL("https://[fd00:5678::10]/repository", policy); err == nil {
+		t.Error("expected other ULA to be rejected")
+	}
+	if err := validateRepositoryURL("https://[64:ff9b::a9fe:a9fe]/latest", policy); err == nil {
+		t.Error("expected NAT64 metadata address to be rejected")
+	}
+}
@@ -0,0 +1,98 @@
+// Package main implements release notes additions for the Maven plugin.
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// installationSection renders an "Installation" release notes block with the
+// Maven dependency declaration of every artifact in the release.
+func installationSection(configs []*Config, version string) string {
+	var b strings.Builder
+	b.WriteString("## Installation\n\n")
+	b.WriteString("Add to your `pom.xml`:\n\n")
+	b.WriteString("```xml\n")
+	b.WriteString(mavenDependencies(configs, version))
+	b.WriteString("```\n")
+	return b.String()
+}
+
+// mavenDependencies renders the Maven dependency declaration of every artifact.
+func mavenDependencies(configs []*Config, version string) string {
+	var b strings.Builder
+	for _, c := range configs {
+		b.WriteString("<dependency>\n")
+		fmt.Fprintf(&b, "  <groupId>%s</groupId>\n", c.GroupID)
+		fmt.Fprintf(&b, "  <artifactId>%s</artifactId>\n", c.ArtifactID)
+		fmt.Fprintf(&b, "  <version>%s</version>\n", version)
+		b.WriteString("</dependency>\n")
+	}
+	return b.String()
+}
+
+// dependencySnippets holds ready-to-paste dependency declarations of the
+// released artifacts for the common JVM build tools.
+type dependencySnippets struct {
+	Maven        string `json:"maven"`
+	GradleKotlin string `json:"gradle_kotlin"`
+	GradleGroovy string `json:"gradle_groovy"`
+	SBT          string `json:"sbt"`
+}
+
+// renderDependencySnippets renders the declarations of every artifact at the
+// Maven form of version. Scala artifacts keep their binary version suffix, so
+// sbt uses % rather than %%.
+func renderDependencySnippets(configs []*Config, version string) dependencySnippets {
+	version = mavenVersion(version)
+	var kotlin, groovy, sbt strings.Builder
+	for _, c := range configs {
+		fmt.Fprintf(&kotlin, "implementation(\"%s:%s:%s\")\n", c.GroupID, c.ArtifactID, version)
+		fmt.Fprintf(&groovy, "implementation '%s:%s:%s'\n", c.GroupID, c.ArtifactID, version)
+		fmt.Fprintf(&sbt, "libraryDependencies += \"%s\" %% \"%s\" %% \"%s\"\n", c.GroupID, c.ArtifactID, version)
+	}
+	return dependencySnippets{
+		Maven:        mavenDependencies(configs, version),
+		GradleKotlin: kotlin.String(),
+		GradleGroovy: groovy.String(),
+		SBT:          sbt.String(),
+	}
+}
+
+// installationNotes appends the installation block to the release notes.
+func (p *MavenPlugin) installationNotes(cfg *Config, releaseCtx plugin.ReleaseContext) (*plugin.ExecuteResponse, error) {
+	configs := cfg.expandScala(cfg.artifactConfigs())
+	for _, c := range configs {
+		if err := validateMavenCoordinate(c.GroupID, "group_id"); err != nil {
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   err.Error(),
+			}, nil
+		}
+		if err := validateMavenCoordinate(c.ArtifactID, "artifact_id"); err != nil {
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   err.Error(),
+			}, nil
+		}
+	}
+
+	section := installationSection(configs, releaseCtx.Version)
+	notes := strings.TrimRight(releaseCtx.ReleaseNotes, "\n")
+	if notes != "" {
+		notes += "\n\n"
+	}
+	notes += section
+
+	return &plugin.ExecuteResponse{
+		Success: true,
+		Message: "Added Maven installation instructions to release notes",
+		Outputs: map[string]any{
+			"installation_notes":  section,
+			"release_notes":       notes,
+			"dependency_snippets": renderDependencySnippets(configs, releaseCtx.Version),
+		},
+	}, nil
+}
@@ -0,0 +1,127 @@
+// Package main provides tests for release notes additions.
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestInstallationSection(t *testing.T) {
+	section := installationSection([]*Config{
+		{GroupID: "com.example", ArtifactID: "core"},
+		{GroupID: "com.example", ArtifactID: "client"},
+	}, "1.2.0")
+
+	want := "## Installation\n\n" +
+		"Add to your `pom.xml`:\n\n" +
+		"```xml\n" +
+		"<dependency>\n  <groupId>com.example</groupId>\n  <artifactId>core</artifactId>\n  <version>1.2.0</version>\n</dependency>\n" +
+		"<dependency>\n  <groupId>com.example</groupId>\n  <artifactId>client</artifactId>\n  <version>1.2.0</version>\n</dependency>\n" +
+		"```\n"
+	if section != want {
+		t.Errorf("unexpected section:\n%s", section)
+	}
+}
+
+func TestExecutePostNotesInstallation(t *testing.T) {
+	tests := []struct {
+		name      string
+		notes     string
+		wantStart string
+	}{
+		{name: "appends to existing notes", notes: "## Changes\n\n- Fix bug\n\n", wantStart: "## Changes\n\n- Fix bug\n\n## Installation"},
+		{name: "empty notes", wantStart: "## Installation"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &MavenPlugin{}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook: plugin.HookPostNotes,
+				Config: map[string]any{
+					"group_id":           "com.example",
+					"artifact_id":        "my-app",
+					"installation_notes": true,
+				},
+				Context: plugin.ReleaseContext{Version: "1.2.0", ReleaseNotes: tt.notes},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !resp.Success {
+				t.Fatalf("expected success, got error: %s", resp.Error)
+			}
+
+			notes, _ := resp.Outputs["release_notes"].(string)
+			if !strings.HasPrefix(notes, tt.wantStart) {
+				t.Errorf("unexpected release notes:\n%s", notes)
+			}
+			if !strings.Contains(notes, "<artifactId>my-app</artifactId>\n  <version>1.2.0</version>") {
+				t.Errorf("expected dependency snippet, got:\n%s", notes)
+			}
+		})
+	}
+}
+
+func TestExecutePostNotesInvalidCoordinates(t *testing.T) {
+	p := &MavenPlugin{}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostNotes,
+		Config: map[string]any{
+			"group_id":           "com.example",
+			"artifact_id":        "<script>",
+			"installation_notes": true,
+		},
+		Context: plugin.ReleaseContext{Version: "1.2.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Success {
+		t.Error("expected failure for invalid coordinates")
+	}
+}
+
+func TestRenderDependencySnippets(t *testing.T) {
+	snippets := renderDependencySnippets([]*Config{
+		{GroupID: "com.example", ArtifactID: "core"},
+		{GroupID: "com.example", ArtifactID: "client_2.13"},
+	}, "v1.2.0")
+
+	want := dependencySnippets{
+		Maven: "<dependency>\n  <groupId>com.example</groupId>\n  <artifactId>core</artifactId>\n  <version>1.2.0</version>\n</dependency>\n" +
+			"<dependency>\n  <groupId>com.example</groupId>\n  <artifactId>client_2.13</artifactId>\n  <version>1.2.0</version>\n</dependency>\n",
+		GradleKotlin: "implementation(\"com.example:core:1.2.0\")\nimplementation(\"com.example:client_2.13:1.2.0\")\n",
+		GradleGroovy: "implementation 'com.example:core:1.2.0'\nimplementation 'com.example:client_2.13:1.2.0'\n",
+		SBT:          "libraryDependencies += \"com.example\" % \"core\" % \"1.2.0\"\nlibraryDependencies += \"com.example\" % \"client_2.13\" % \"1.2.0\"\n",
+	}
+	if snippets != want {
+		t.Errorf("unexpected snippets:\n%+v", snippets)
+	}
+}
+
+func TestExecuteDependencySnippetsOutput(t *testing.T) {
+	p := &MavenPlugin{executor: &MockCommandExecutor{}}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":    "com.example",
+			"artifact_id": "my-app",
+			"repository":  "http://localhost:8081/repository/maven-releases",
+		},
+		Context: plugin.ReleaseContext{Version: "1.2.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+	snippets, ok := resp.Outputs["dependency_snippets"].(dependencySnippets)
+	if !ok || snippets.GradleKotlin != "implementation(\"com.example:my-app:1.2.0\")\n" {
+		t.Errorf("unexpected dependency_snippets output: %v", resp.Outputs["dependency_snippets"])
+	}
+}
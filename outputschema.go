@@ -0,0 +1,146 @@
+// Package main implements the declared schema of the outputs the plugin produces per hook.
+package main
+
+// outputsSchema maps every hook of the plugin to a JSON schema of the outputs
+// it produces. The SDK's plugin.Info has no field for it, so GetInfo
+// publishes it in the x-outputs annotation of the configuration schema.
+const outputsSchema = `{
+				"pre-notes": {
+					"type": "object",
+					"properties": {
+						"previous_version": {"type": "string", "description": "Release the dependencies are compared with"},
+						"dependency_changes": {"type": "object", "description": "Dependency changes since the previous release", "properties": {
+							"added": {"type": "array", "items": {"type": "object", "properties": {"key": {"type": "string", "description": "groupId:artifactId"}, "from": {"type": "string"}, "to": {"type": "string"}}}},
+							"removed": {"type": "array", "items": {"type": "object", "properties": {"key": {"type": "string", "description": "groupId:artifactId"}, "from": {"type": "string"}, "to": {"type": "string"}}}},
+							"upgraded": {"type": "array", "items": {"type": "object", "properties": {"key": {"type": "string", "description": "groupId:artifactId"}, "from": {"type": "string"}, "to": {"type": "string"}}}},
+							"downgraded": {"type": "array", "items": {"type": "object", "properties": {"key": {"type": "string", "description": "groupId:artifactId"}, "from": {"type": "string"}, "to": {"type": "string"}}}}
+						}},
+						"dependency_notes": {"type": "string", "description": "Markdown section listing the dependency changes"}
+					}
+				},
+				"post-notes": {
+					"type": "object",
+					"properties": {
+						"installation_notes": {"type": "string", "description": "Markdown installation section"},
+						"release_notes": {"type": "string", "description": "Release notes with the installation section added"},
+						"dependency_snippets": {"type": "object", "description": "Declarations of the release for each build tool", "properties": {"maven": {"type": "string"}, "gradle_kotlin": {"type": "string"}, "gradle_groovy": {"type": "string"}, "sbt": {"type": "string"}}}
+					}
+				},
+				"pre-publish": {
+					"type": "object",
+					"properties": {
+						"property_updates": {"type": "array", "description": "POM properties set before the deploy", "items": {"type": "object", "properties": {"property": {"type": "string"}, "value": {"type": "string"}}}},
+						"command": {"type": "string", "description": "Maven commands run, or that would run in a dry run"}
+					}
+				},
+				"post-publish": {
+					"type": "object",
+					"properties": {
+						"group_id": {"type": "string"},
+						"artifact_id": {"type": "string"},
+						"version": {"type": "string"},
+						"pom_path": {"type": "string", "description": "Dry run: POM the deploy builds"},
+						"command": {"type": "string", "description": "Dry run: Maven commands that would run"},
+						"native_command": {"type": "string", "description": "Dry run: Maven command deploying the native artifacts"},
+						"skip_tests": {"type": "boolean", "description": "Dry run: whether tests would be skipped"},
+						"skip_its": {"type": "boolean", "description": "Dry run: whether integration tests would be skipped"},
+						"profiles": {"type": "array", "items": {"type": "string"}, "description": "Dry run: Maven profiles that would be activated"},
+						"output_timestamp": {"type": "string", "description": "Build timestamp pinned for reproducible builds"},
+						"signing_key": {"type": "string", "description": "Fingerprint of the signing key"},
+						"deployer": {"type": "string", "description": "Dry run: http when the files would be uploaded without Maven"},
+						"upload_files": {"type": "array", "items": {"type": "string"}, "description": "Dry run: repository paths the http deployer would upload"},
+						"uploaded_files": {"type": "array", "items": {"type": "string"}, "description": "Repository paths uploaded, also reported for an interrupted deploy"},
+						"bundle_path": {"type": "string", "description": "Path the Central bundle was written to instead of being uploaded"},
+						"bundle_files": {"type": "array", "items": {"type": "string"}, "description": "Repository paths in the Central bundle"},
+						"export_archive": {"type": "string", "description": "Path the deployment archive was written to instead of being uploaded"},
+						"export_files": {"type": "array", "items": {"type": "string"}, "description": "Repository paths in the deployment archive"},
+						"central_deployment_id": {"type": "string", "description": "Central Portal deployment ID"},
+						"central_deployment_state": {"type": "string", "description": "Central Portal deployment state"},
+						"central_deployment_dropped": {"type": "boolean", "description": "Whether the failed Central Portal deployment was dropped"},
+						"central_purls": {"type": "array", "items": {"type": "string"}, "description": "Package URLs of the Central Portal deployment"},
+						"central_search_url": {"type": "string", "format": "uri", "description": "Maven Central search page of the release"},
+						"central_browse_url": {"type": "string", "format": "uri", "description": "Maven Central directory of the release"},
+						"central_badge": {"type": "string", "description": "Markdown Maven Central badge"},
+						"artifact_urls": {"type": "object", "additionalProperties": {"type": "string", "format": "uri"}, "description": "Download URLs of the release files keyed by classifier or extension (pom, jar, sources, ...); signatures carry an .asc suffix"},
+						"artifacts": {"type": "array", "description": "Files deployed for every module with their SHA-256 digests; with the artifacts option, the outputs of each artifact's deploy with success and error", "items": {"type": "object", "properties": {
+							"group_id": {"type": "string"},
+							"artifact_id": {"type": "string"},
+							"version": {"type": "string"},
+							"packaging": {"type": "string"},
+							"classifier": {"type": "string"},
+							"extension": {"type": "string"},
+							"file": {"type": "string", "description": "File name in the repository"},
+							"size": {"type": "integer", "description": "Size in bytes"},
+							"sha256": {"type": "string", "description": "Hex SHA-256 digest of the file"},
+							"skipped": {"type": "boolean"},
+							"success": {"type": "boolean"},
+							"error": {"type": "string"}
+						}}},
+						"repositories": {"type": "array", "description": "With the repositories option, the outputs of each repository's deploy with its id, url, success and error", "items": {"type": "object", "properties": {"id": {"type": "string"}, "url": {"type": "string"}, "success": {"type": "boolean"}, "error": {"type": "string"}}}},
+						"built_files": {"type": "array", "items": {"type": "string"}, "description": "Local files the build produced"},
+						"native_artifacts": {"type": "array", "items": {"type": "string"}, "description": "Classifiers of the native artifacts attached to the release"},
+						"modules": {"type": "array", "description": "Projects of a multi-module reactor and whether the deploy publishes them", "items": {"type": "object", "properties": {"group_id": {"type": "string"}, "artifact_id": {"type": "string"}, "version": {"type": "string"}, "packaging": {"type": "string"}, "deployed": {"type": "boolean"}, "skip_reason": {"type": "string"}}}},
+						"skipped_modules": {"type": "array", "items": {"type": "string"}, "description": "Modules whose deploy is skipped"},
+						"reactor_summary": {"type": "array", "description": "Status and duration of every module Maven built", "items": {"type": "object", "properties": {"module": {"type": "string"}, "status": {"type": "string"}, "duration": {"type": "string"}}}},
+						"failed_modules": {"type": "array", "items": {"type": "string"}, "description": "Modules that failed to build"},
+						"failure": {"type": "string", "enum": ["out_of_memory"], "description": "Classified cause of a failed deploy"},
+						"interrupted": {"type": "string", "description": "Reason the deploy was cancelled"},
+						"completed_modules": {"type": "array", "items": {"type": "string"}, "description": "Modules built before the deploy was cancelled"},
+						"cleanup_required": {"type": "boolean", "description": "Whether a cancelled deploy left files in the repository"},
+						"test_results": {"type": "object", "description": "Test metrics of the release build", "properties": {"tests": {"type": "integer"}, "failures": {"type": "integer"}, "errors": {"type": "integer"}, "skipped": {"type": "integer"}, "seconds": {"type": "number"}, "slowest": {"type": "array"}, "failed": {"type": "array"}, "flaky": {"type": "array"}}},
+						"flaky_tests": {"type": "array", "items": {"type": "string"}, "description": "Tests that passed only on a rerun"},
+						"coverage": {"type": "object", "description": "Coverage metrics in percent", "properties": {"line": {"type": "number"}, "branch": {"type": "number"}}},
+						"static_analysis": {"type": "array", "description": "Violations found by each static analysis tool", "items": {"type": "object", "properties": {"tool": {"type": "string"}, "violations": {"type": "integer"}, "max_violations": {"type": "integer"}}}},
+						"available_updates": {"type": "object", "description": "Newer versions of the dependencies and build plugins", "properties": {"dependencies": {"type": "array", "items": {"type": "object", "properties": {"key": {"type": "string", "description": "groupId:artifactId"}, "from": {"type": "string"}, "to": {"type": "string"}}}}, "plugins": {"type": "array", "items": {"type": "object", "properties": {"key": {"type": "string", "description": "groupId:artifactId"}, "from": {"type": "string"}, "to": {"type": "string"}}}}}},
+						"verified_files": {"type": "array", "items": {"type": "string"}, "description": "Files whose remote checksums matched the build output"},
+						"metadata": {"type": "object", "description": "maven-metadata.xml of the repository after the deploy", "properties": {"listed": {"type": "boolean"}, "latest": {"type": "string"}, "release": {"type": "string"}}},
+						"resolution_verified": {"type": "boolean", "description": "Whether the release resolved from the repository"},
+						"canary_build": {"type": "boolean", "description": "Whether the canary consumer built against the release"},
+						"invoker_results": {"type": "object", "description": "Integration test metrics", "properties": {"passed": {"type": "integer"}, "failed": {"type": "integer"}, "skipped": {"type": "integer"}, "failures": {"type": "array", "items": {"type": "string"}}}},
+						"p2_repository_path": {"type": "string", "description": "Local p2 repository of a Tycho build"},
+						"p2_files": {"type": "integer", "description": "Number of files in the p2 repository"},
+						"p2_site_url": {"type": "string", "description": "URL the p2 repository is published to"},
+						"promoted_from": {"type": "string", "description": "Staging repository the release was promoted from"},
+						"promoted": {"type": "boolean"},
+						"repository": {"type": "string", "description": "Repository the release was promoted to"},
+						"rehearsal_files": {"type": "array", "items": {"type": "string"}, "description": "Files a rehearsal deploy staged"},
+						"rehearsal_signed": {"type": "boolean", "description": "Whether the rehearsal files were signed"},
+						"repository_urls": {"type": "object", "description": "Dry run: repositories the deploy would use", "properties": {"release": {"type": "string"}, "snapshot": {"type": "string"}, "target": {"type": "string"}}},
+						"environment": {"type": "object", "additionalProperties": {"type": "string"}, "description": "Dry run: options whose values come from the environment, with the variable names"},
+						"settings_file": {"type": "string", "description": "Dry run: settings file the deploy would use"},
+						"settings": {"type": "string", "description": "Dry run: settings.xml with the secrets masked"},
+						"settings_source": {"type": "string", "enum": ["config", "project", "maven_home", "user_home", "generated", "none"], "description": "Where the Maven user settings came from"},
+						"user_token_url": {"type": "string", "description": "Dry run: user token endpoint"},
+						"pom_metadata_injected": {"type": "array", "items": {"type": "string"}, "description": "POM elements added from pom_metadata"},
+						"scala_version": {"type": "string"},
+						"scala_artifact_ids": {"type": "array", "items": {"type": "string"}, "description": "Artifact IDs of the cross-built Scala versions"},
+						"dependency_snippets": {"type": "object", "description": "Declarations of the release for each build tool", "properties": {"maven": {"type": "string"}, "gradle_kotlin": {"type": "string"}, "gradle_groovy": {"type": "string"}, "sbt": {"type": "string"}}},
+						"report_file": {"type": "string", "description": "Path of the JSON deploy report"},
+						"maven_log": {"type": "string", "description": "Path of the complete, redacted Maven output of the deploy, also set when it fails"},
+						"audit": {"type": "object", "description": "Audit record of the deploy"},
+						"warnings": {"type": "array", "items": {"type": "string"}}
+					}
+				},
+				"on-success": {
+					"type": "object",
+					"properties": {
+						"bom_updates": {"type": "array", "description": "BOM properties updated to the release", "items": {"type": "object", "properties": {"path": {"type": "string"}, "property": {"type": "string"}, "previous": {"type": "string"}, "version": {"type": "string"}, "changed": {"type": "boolean"}}}},
+						"updated_files": {"type": "array", "items": {"type": "string"}, "description": "BOM files changed"}
+					}
+				},
+				"on-error": {
+					"type": "object",
+					"properties": {
+						"group_id": {"type": "string"},
+						"artifact_id": {"type": "string"},
+						"version": {"type": "string"},
+						"repository": {"type": "string", "description": "Repository the release was deleted from"},
+						"rolled_back": {"type": "boolean", "description": "Whether the published component was deleted"},
+						"central_deployment_id": {"type": "string"},
+						"central_deployment_state": {"type": "string"},
+						"central_deployment_dropped": {"type": "boolean"},
+						"artifacts": {"type": "array", "description": "With the artifacts option, the rollback outputs of each artifact", "items": {"type": "object"}},
+						"repositories": {"type": "array", "description": "With the repositories option, the rollback outputs of each repository", "items": {"type": "object"}}
+					}
+				}
+			}`
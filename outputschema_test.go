@@ -0,0 +1,72 @@
+// Package main provides tests for the declared schema of the plugin outputs.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// declaredOutputs returns the output properties GetInfo declares per hook.
+func declaredOutputs(t *testing.T) map[string]map[string]any {
+	t.Helper()
+	var schema struct {
+		Outputs map[string]struct {
+			Properties map[string]any `json:"properties"`
+		} `json:"x-outputs"`
+	}
+	if err := json.Unmarshal([]byte((&MavenPlugin{}).GetInfo().ConfigSchema), &schema); err != nil {
+		t.Fatalf("config schema is not valid JSON: %v", err)
+	}
+	outputs := make(map[string]map[string]any, len(schema.Outputs))
+	for hook, s := range schema.Outputs {
+		outputs[hook] = s.Properties
+	}
+	return outputs
+}
+
+func TestGetInfoOutputsSchema(t *testing.T) {
+	outputs := declaredOutputs(t)
+	hooks := (&MavenPlugin{}).GetInfo().Hooks
+	if len(outputs) != len(hooks) {
+		t.Errorf("expected outputs for the %d hooks, got %d", len(hooks), len(outputs))
+	}
+	for _, hook := range hooks {
+		if len(outputs[string(hook)]) == 0 {
+			t.Errorf("expected declared outputs for %s", hook)
+		}
+	}
+}
+
+func TestOutputsSchemaCoversDeployOutputs(t *testing.T) {
+	properties := declaredOutputs(t)[string(plugin.HookPostPublish)]
+
+	for _, dryRun := range []bool{false, true} {
+		resp, err := (&MavenPlugin{executor: &MockCommandExecutor{}}).Execute(context.Background(), plugin.ExecuteRequest{
+			Hook: plugin.HookPostPublish,
+			Config: map[string]any{
+				"group_id":    "com.example",
+				"artifact_id": "my-app",
+				"repository":  "http://localhost:8081/repository/maven-releases",
+				"username":    "deployer",
+				"password":    "secret",
+				"profiles":    []any{"release"},
+			},
+			Context: plugin.ReleaseContext{Version: "1.0.0"},
+			DryRun:  dryRun,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !resp.Success {
+			t.Fatalf("expected success, got error: %s", resp.Error)
+		}
+		for key := range resp.Outputs {
+			if _, ok := properties[key]; !ok {
+				t.Errorf("dry run %v: output %q is not declared in the outputs schema", dryRun, key)
+			}
+		}
+	}
+}
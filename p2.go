@@ -0,0 +1,121 @@
+// Package main implements Tycho builds and p2 repository publishing.
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// eclipseRepositoryPackaging is the Tycho packaging that builds a p2 repository.
+const eclipseRepositoryPackaging = "eclipse-repository"
+
+// p2MetadataFiles lists the alternative file names of the p2 metadata and
+// artifact indexes; a p2 repository contains one of each.
+var p2MetadataFiles = [][]string{
+	{"content.jar", "content.xml", "content.xml.xz"},
+	{"artifacts.jar", "artifacts.xml", "artifacts.xml.xz"},
+}
+
+// tychoArgs returns the extra Maven arguments of Tycho builds. Locally built
+// artifacts are ignored so stale bundles never leak into the release.
+func tychoArgs(cfg *Config) []string {
+	if !cfg.Tycho {
+		return nil
+	}
+	return []string{"-Dtycho.localArtifacts=ignore"}
+}
+
+// p2RepositoryDir returns the directory of the p2 repository built by Tycho:
+// the configured path, or target/repository of the reactor's eclipse-repository module.
+func (cfg *Config) p2RepositoryDir() (string, error) {
+	if cfg.P2RepositoryPath != "" {
+		return cfg.P2RepositoryPath, nil
+	}
+
+	pomPath := cfg.PomPath
+	if pomPath == "" {
+		pomPath = "pom.xml"
+	}
+	modules, err := readReactor(pomPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read reactor: %w", err)
+	}
+	for _, m := range modules {
+		if m.POM.Packaging == eclipseRepositoryPackaging {
+			return filepath.Join(filepath.Dir(pomPath), filepath.FromSlash(m.Dir), "target", "repository"), nil
+		}
+	}
+	return "", fmt.Errorf("no %s module found; set p2_repository_path", eclipseRepositoryPackaging)
+}
+
+// readP2Repository checks that dir holds a p2 repository and returns the
+// slash-separated paths of its files.
+func readP2Repository(dir string) ([]string, error) {
+	for _, names := range p2MetadataFiles {
+		found := false
+		for _, name := range names {
+			if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%s is not a p2 repository: missing %s", dir, strings.Join(names, " or "))
+		}
+	}
+
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read p2 repository: %w", err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// p2SiteURL returns the URL the p2 site of a version is published to.
+func (cfg *Config) p2SiteURL(version string) string {
+	return strings.TrimSuffix(cfg.P2DeployURL, "/") + "/" + version
+}
+
+// publishP2 verifies the p2 repository built by Tycho and, when a deploy URL
+// is configured, uploads the site below a directory named after the version.
+func (p *MavenPlugin) publishP2(ctx context.Context, cfg *Config, version string, outputs map[string]any) error {
+	dir, err := cfg.p2RepositoryDir()
+	if err != nil {
+		return err
+	}
+	files, err := readP2Repository(dir)
+	if err != nil {
+		return err
+	}
+	outputs["p2_repository_path"] = dir
+	outputs["p2_files"] = len(files)
+
+	if cfg.P2DeployURL == "" {
+		return nil
+	}
+	client := newRepositoryClient(p.getHTTPClient(), cfg.p2SiteURL(version), cfg.Username, cfg.Password)
+	for _, file := range files {
+		if err := client.uploadFile(ctx, file, filepath.Join(dir, filepath.FromSlash(file))); err != nil {
+			return err
+		}
+	}
+	outputs["p2_site_url"] = redactURL(cfg.p2SiteURL(version))
+	return nil
+}
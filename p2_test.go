@@ -0,0 +1,139 @@
+// Package main provides tests for Tycho builds and p2 publishing.
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// tychoReactor is a Tycho reactor with a bundle and an eclipse-repository module.
+var tychoReactor = map[string]string{
+	"pom.xml": `<project><modelVersion>4.0.0</modelVersion>
+  <groupId>com.example</groupId><artifactId>my-plugin-parent</artifactId><version>1.0.0</version>
+  <packaging>pom</packaging>
+  <modules><module>bundle</module><module>site</module></modules>
+</project>`,
+	"bundle/pom.xml": `<project><modelVersion>4.0.0</modelVersion>
+  <parent><groupId>com.example</groupId><artifactId>my-plugin-parent</artifactId><version>1.0.0</version></parent>
+  <artifactId>com.example.bundle</artifactId><packaging>eclipse-plugin</packaging>
+</project>`,
+	"site/pom.xml": `<project><modelVersion>4.0.0</modelVersion>
+  <parent><groupId>com.example</groupId><artifactId>my-plugin-parent</artifactId><version>1.0.0</version></parent>
+  <artifactId>com.example.site</artifactId><packaging>eclipse-repository</packaging>
+</project>`,
+	"site/target/repository/content.jar":                          "content",
+	"site/target/repository/artifacts.xml.xz":                     "artifacts",
+	"site/target/repository/plugins/com.example.bundle_1.0.0.jar": "bundle",
+}
+
+func TestReadP2Repository(t *testing.T) {
+	root := writeReactor(t, tychoReactor)
+
+	cfg := &Config{PomPath: filepath.Join(root, "pom.xml")}
+	dir, err := cfg.p2RepositoryDir()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dir != filepath.Join(root, "site", "target", "repository") {
+		t.Errorf("unexpected p2 repository directory: %s", dir)
+	}
+
+	files, err := readP2Repository(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"artifacts.xml.xz", "content.jar", "plugins/com.example.bundle_1.0.0.jar"}
+	if !slices.Equal(files, want) {
+		t.Errorf("expected files %v, got %v", want, files)
+	}
+
+	if err := os.Remove(filepath.Join(dir, "content.jar")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readP2Repository(dir); err == nil || !strings.Contains(err.Error(), "missing content.jar") {
+		t.Errorf("expected a missing metadata error, got %v", err)
+	}
+}
+
+func TestExecuteTycho(t *testing.T) {
+	root := writeReactor(t, tychoReactor)
+	chdir(t, root)
+
+	var mu sync.Mutex
+	uploads := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, _ := r.BasicAuth(); r.Method != http.MethodPut || user != "deployer" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		uploads[r.URL.Path] = string(data)
+		mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	mockExec := &MockCommandExecutor{}
+	p := &MavenPlugin{executor: mockExec, httpClient: server.Client()}
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":      "com.example",
+			"artifact_id":   "com.example.bundle",
+			"repository":    "http://localhost:8081/repository/maven-releases",
+			"username":      "deployer",
+			"password":      "secret",
+			"tycho":         true,
+			"p2_deploy_url": server.URL + "/repository/p2/",
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+
+	if !slices.Contains(mockExec.Calls[0].Args, "-Dtycho.localArtifacts=ignore") {
+		t.Errorf("expected Tycho arguments, got %v", mockExec.Calls[0].Args)
+	}
+	if len(uploads) != 3 || uploads["/repository/p2/1.0.0/plugins/com.example.bundle_1.0.0.jar"] != "bundle" {
+		t.Errorf("unexpected uploads: %v", uploads)
+	}
+	if resp.Outputs["p2_site_url"] != server.URL+"/repository/p2/1.0.0" {
+		t.Errorf("unexpected p2_site_url: %v", resp.Outputs["p2_site_url"])
+	}
+}
+
+func TestValidateTycho(t *testing.T) {
+	p := &MavenPlugin{}
+	resp, err := p.Validate(context.Background(), map[string]any{
+		"group_id":      "com.example",
+		"artifact_id":   "my-app",
+		"p2_deploy_url": "http://example.com/p2",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	fields := map[string]bool{}
+	for _, e := range resp.Errors {
+		fields[e.Field] = true
+	}
+	if !fields["p2_deploy_url"] || !fields["tycho"] {
+		t.Errorf("expected p2_deploy_url and tycho errors, got %v", resp.Errors)
+	}
+}
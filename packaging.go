@@ -0,0 +1,165 @@
+// Package main implements per-packaging goal mapping for heterogeneous reactors.
+package main
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+)
+
+// defaultPackaging is the packaging of POMs that do not declare one.
+const defaultPackaging = "jar"
+
+var (
+	// goalPattern matches Maven lifecycle phases and plugin goals
+	// (phase, prefix:goal, or groupId:artifactId[:version]:goal).
+	goalPattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*(:[a-zA-Z0-9._-]+){0,3}$`)
+
+	// propertyFlagPattern matches -Dname[=value] system property flags.
+	propertyFlagPattern = regexp.MustCompile(`^-D[a-zA-Z0-9][a-zA-Z0-9._-]*(=\S*)?$`)
+)
+
+// parsePackagingGoals parses the packaging_goals mapping from the raw configuration.
+func parsePackagingGoals(raw map[string]any) map[string][]string {
+	entries, ok := raw["packaging_goals"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	parser := helpers.NewConfigParser(entries)
+	goals := make(map[string][]string, len(entries))
+	for packaging := range entries {
+		goals[packaging] = parser.GetStringSlice(packaging, nil)
+	}
+	return goals
+}
+
+// validateGoals validates a list of Maven goals and phases with -D flags.
+func validateGoals(goals []string) error {
+	hasGoal := false
+	for _, goal := range goals {
+		switch {
+		case strings.HasPrefix(goal, "-"):
+			if !propertyFlagPattern.MatchString(goal) {
+				return fmt.Errorf("invalid flag %q: only -Dname[=value] flags are allowed", goal)
+			}
+		case goalPattern.MatchString(goal):
+			hasGoal = true
+		default:
+			return fmt.Errorf("invalid goal %q", goal)
+		}
+	}
+	if !hasGoal {
+		return fmt.Errorf("at least one goal or phase is required")
+	}
+	return nil
+}
+
+// withProjects sets the -pl value of Maven arguments, inserting it after the
+// POM file option when absent.
+func withProjects(args []string, projects string) []string {
+	for i := 0; i+1 < len(args); i++ {
+		if args[i] == "-pl" {
+			args[i+1] = projects
+			return args
+		}
+	}
+	for i := 0; i+1 < len(args); i++ {
+		if args[i] == "-f" {
+			return append(args[:i+2:i+2], append([]string{"-pl", projects}, args[i+2:]...)...)
+		}
+	}
+	return append(args, "-pl", projects)
+}
+
+// projectsArg returns the -pl value of Maven arguments.
+func projectsArg(args []string) string {
+	for i := 0; i+1 < len(args); i++ {
+		if args[i] == "-pl" {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+// mavenInvocations splits the deploy arguments into one Maven invocation per
+// mapped packaging. Modules whose packaging has no mapping are deployed first
+// with the default goal, which also installs them locally so the mapped
+// invocations can resolve them.
+func (cfg *Config) mavenInvocations(args []string) ([][]string, error) {
+	if len(cfg.PackagingGoals) == 0 {
+		return [][]string{args}, nil
+	}
+
+	pomPath := cfg.PomPath
+	if pomPath == "" {
+		pomPath = "pom.xml"
+	}
+	modules, err := readReactor(pomPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reactor: %w", err)
+	}
+
+	skipped := map[string]bool{}
+	if cfg.SkipAggregators {
+		for _, key := range aggregatorModules(modules) {
+			skipped[key] = true
+		}
+	}
+
+	groups := map[string][]string{}
+	unmapped := 0
+	for _, m := range modules {
+		if cfg.Module != "" && m.Dir != path.Clean(filepath.ToSlash(cfg.Module)) {
+			continue
+		}
+		if skipped[m.key()] {
+			continue
+		}
+		packaging := m.POM.Packaging
+		if packaging == "" {
+			packaging = defaultPackaging
+		}
+		if _, ok := cfg.PackagingGoals[packaging]; ok {
+			groups[packaging] = append(groups[packaging], m.key())
+		} else {
+			unmapped++
+		}
+	}
+	if len(groups) == 0 {
+		return [][]string{args}, nil
+	}
+
+	packagings := make([]string, 0, len(groups))
+	for packaging := range groups {
+		packagings = append(packagings, packaging)
+	}
+	sort.Strings(packagings)
+
+	var invocations [][]string
+	if unmapped > 0 {
+		selection := []string{}
+		if projects := projectsArg(args); projects != "" {
+			selection = append(selection, projects)
+		}
+		for _, packaging := range packagings {
+			for _, key := range groups[packaging] {
+				selection = append(selection, "!"+key)
+			}
+		}
+		invocations = append(invocations, withProjects(slices.Clone(args), strings.Join(selection, ",")))
+	}
+	for _, packaging := range packagings {
+		invocation := append(slices.Clone(cfg.PackagingGoals[packaging]), args[1:]...)
+		if cfg.Module == "" {
+			invocation = withProjects(invocation, strings.Join(groups[packaging], ","))
+		}
+		invocations = append(invocations, invocation)
+	}
+	return invocations, nil
+}
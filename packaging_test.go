@@ -0,0 +1,150 @@
+// Package main provides tests for per-packaging goal mapping.
+package main
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// heterogeneousReactor is a reactor with jar, war, and maven-plugin modules.
+var heterogeneousReactor = map[string]string{
+	"pom.xml": `<project><modelVersion>4.0.0</modelVersion>
+  <groupId>com.example</groupId><artifactId>my-app-parent</artifactId><version>1.0.0</version>
+  <packaging>pom</packaging>
+  <modules><module>core</module><module>web</module><module>plugin</module></modules>
+</project>`,
+	"core/pom.xml": `<project><modelVersion>4.0.0</modelVersion>
+  <parent><groupId>com.example</groupId><artifactId>my-app-parent</artifactId><version>1.0.0</version></parent>
+  <artifactId>my-app</artifactId>
+</project>`,
+	"web/pom.xml": `<project><modelVersion>4.0.0</modelVersion>
+  <parent><groupId>com.example</groupId><artifactId>my-app-parent</artifactId><version>1.0.0</version></parent>
+  <artifactId>my-app-web</artifactId><packaging>war</packaging>
+</project>`,
+	"plugin/pom.xml": `<project><modelVersion>4.0.0</modelVersion>
+  <parent><groupId>com.example</groupId><artifactId>my-app-parent</artifactId><version>1.0.0</version></parent>
+  <artifactId>my-app-maven-plugin</artifactId><packaging>maven-plugin</packaging>
+</project>`,
+}
+
+func TestMavenInvocations(t *testing.T) {
+	root := writeReactor(t, heterogeneousReactor)
+	chdir(t, root)
+
+	cfg := &Config{
+		PomPath: "pom.xml",
+		PackagingGoals: map[string][]string{
+			"maven-plugin": {"plugin:descriptor", "deploy"},
+			"war":          {"deploy", "-Dmaven.war.skip=false"},
+		},
+	}
+	args := []string{"deploy", "-f", "pom.xml", "-DskipTests"}
+
+	invocations, err := cfg.mavenInvocations(args)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := [][]string{
+		{"deploy", "-f", "pom.xml", "-pl", "!com.example:my-app-maven-plugin,!com.example:my-app-web", "-DskipTests"},
+		{"plugin:descriptor", "deploy", "-f", "pom.xml", "-pl", "com.example:my-app-maven-plugin", "-DskipTests"},
+		{"deploy", "-Dmaven.war.skip=false", "-f", "pom.xml", "-pl", "com.example:my-app-web", "-DskipTests"},
+	}
+	if len(invocations) != len(want) {
+		t.Fatalf("expected %d invocations, got %v", len(want), invocations)
+	}
+	for i := range want {
+		if !slices.Equal(invocations[i], want[i]) {
+			t.Errorf("invocation %d: expected %v, got %v", i, want[i], invocations[i])
+		}
+	}
+	if !slices.Equal(args, []string{"deploy", "-f", "pom.xml", "-DskipTests"}) {
+		t.Errorf("expected the original arguments to be unchanged, got %v", args)
+	}
+
+	cfg.Module = "plugin"
+	invocations, err = cfg.mavenInvocations([]string{"deploy", "-f", "pom.xml", "-pl", "plugin"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(invocations) != 1 || strings.Join(invocations[0], " ") != "plugin:descriptor deploy -f pom.xml -pl plugin" {
+		t.Errorf("expected a single mapped invocation for the module, got %v", invocations)
+	}
+}
+
+func TestValidatePackagingGoals(t *testing.T) {
+	tests := []struct {
+		goals   []string
+		wantErr bool
+	}{
+		{goals: []string{"plugin:descriptor", "deploy"}},
+		{goals: []string{"org.apache.maven.plugins:maven-deploy-plugin:3.1.1:deploy"}},
+		{goals: []string{"deploy", "-Dmaven.war.skip=false"}},
+		{goals: []string{"-Dmaven.war.skip"}, wantErr: true},
+		{goals: []string{"deploy", "-s", "evil.xml"}, wantErr: true},
+		{goals: []string{"deploy; rm -rf /"}, wantErr: true},
+		{goals: nil, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		err := validateGoals(tt.goals)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("validateGoals(%v): expected error %v, got %v", tt.goals, tt.wantErr, err)
+		}
+	}
+}
+
+func TestExecutePackagingGoals(t *testing.T) {
+	root := writeReactor(t, heterogeneousReactor)
+	chdir(t, root)
+
+	mockExec := &MockCommandExecutor{}
+	p := &MavenPlugin{executor: mockExec}
+
+	config := map[string]any{
+		"group_id":    "com.example",
+		"artifact_id": "my-app",
+		"repository":  "http://localhost:8081/repository/maven-releases",
+		"username":    "deployer",
+		"password":    "secret",
+		"packaging_goals": map[string]any{
+			"maven-plugin": []any{"plugin:descriptor", "deploy"},
+		},
+	}
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:    plugin.HookPostPublish,
+		Config:  config,
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+	if len(mockExec.Calls) != 2 {
+		t.Fatalf("expected 2 Maven invocations, got %d", len(mockExec.Calls))
+	}
+	for _, call := range mockExec.Calls {
+		if !slices.Contains(call.Args, "-s") {
+			t.Errorf("expected every invocation to use the generated settings, got %v", call.Args)
+		}
+	}
+
+	vresp, err := p.Validate(context.Background(), map[string]any{
+		"group_id":        "com.example",
+		"artifact_id":     "my-app",
+		"packaging_goals": map[string]any{"war": []any{"-X"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if vresp.Valid || vresp.Errors[0].Field != "packaging_goals.war" {
+		t.Errorf("expected an error for packaging_goals.war, got %v", vresp.Errors)
+	}
+}
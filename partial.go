@@ -0,0 +1,59 @@
+// Package main implements reporting what an interrupted deploy completed.
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// uploadedPattern matches a file maven-deploy-plugin uploaded:
+// "Uploaded to nexus: https://host/repo/com/example/core/1.0.0/core-1.0.0.jar (2.1 kB at 10 kB/s)".
+var uploadedPattern = regexp.MustCompile(`^\[INFO\] Uploaded(?: to [^:\s]+)?: (\S+) \(`)
+
+// uploadedFromLog returns the URLs of the files uploaded according to the
+// build log, with credentials redacted.
+func uploadedFromLog(output string) []string {
+	var uploaded []string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(ansiPattern.ReplaceAllString(line, ""))
+		if m := uploadedPattern.FindStringSubmatch(line); m != nil {
+			uploaded = append(uploaded, redactURL(m[1]))
+		}
+	}
+	return uploaded
+}
+
+// interruptedOutputs describes what a Maven deploy completed before the
+// release was cancelled or timed out: the modules built, the files uploaded,
+// and whether the repository needs cleaning up.
+func interruptedOutputs(ctx context.Context, progress *reactorProgress, output string) map[string]any {
+	uploaded := uploadedFromLog(output)
+	return map[string]any{
+		"interrupted":       ctx.Err().Error(),
+		"completed_modules": progress.Built,
+		"uploaded_files":    uploaded,
+		"cleanup_required":  len(uploaded) > 0,
+	}
+}
+
+// interruptedSummary summarizes the outputs of an interrupted deploy, e.g.
+// "2 modules built, 6 files uploaded".
+func interruptedSummary(outputs map[string]any) string {
+	modules, _ := outputs["completed_modules"].([]string)
+	uploaded, _ := outputs["uploaded_files"].([]string)
+	summary := fmt.Sprintf("%s built, %s uploaded", plural(len(modules), "module"), plural(len(uploaded), "file"))
+	if len(uploaded) > 0 {
+		summary += "; the repository needs cleaning up"
+	}
+	return summary
+}
+
+// plural formats a count of things, e.g. "1 module" or "3 modules".
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -4,12 +4,16 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
 	"net"
 	"net/url"
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
 	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
@@ -21,7 +25,10 @@ var (
 	mavenCoordinatePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
 
 	// Profile name pattern: alphanumerics, dashes, underscores.
-	profilePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]*$`)
+	// A leading ! deactivates the profile.
+	profilePattern = regexp.MustCompile(`^!?[a-zA-Z][a-zA-Z0-9_-]*$`)
+	// shellSafePattern matches arguments that need no quoting in a shell command line.
+	shellSafePattern = regexp.MustCompile(`^[a-zA-Z0-9_@%+=:,./-]+$`)
 )
 
 // CommandExecutor abstracts command execution for testability.
@@ -29,18 +36,32 @@ type CommandExecutor interface {
 	Run(ctx context.Context, name string, args ...string) ([]byte, error)
 }
 
-// RealCommandExecutor executes actual system commands.
+// RealCommandExecutor executes actual system commands. Each command runs in
+// its own process group, killed as a whole when the context is cancelled.
 type RealCommandExecutor struct{}
 
+// processWaitDelay bounds how long a cancelled command's output is read
+// after its process group was killed.
+const processWaitDelay = 10 * time.Second
+
 // Run executes a command and returns combined output.
 func (e *RealCommandExecutor) Run(ctx context.Context, name string, args ...string) ([]byte, error) {
 	cmd := exec.CommandContext(ctx, name, args...)
+	applyCommandEnv(ctx, cmd)
+	setProcessGroup(cmd)
 	return cmd.CombinedOutput()
 }
 
 // MavenPlugin implements the Publish artifacts to Maven Central (Java) plugin.
 type MavenPlugin struct {
-	executor CommandExecutor
+	executor   CommandExecutor
+	httpClient HTTPClient
+	// progressLog receives the reactor progress lines, defaulting to stderr.
+	progressLog io.Writer
+
+	// mu guards deployed.
+	mu       sync.Mutex
+	deployed []deployRecord
 }
 
 // getExecutor returns the command executor, defaulting to RealCommandExecutor.
@@ -62,6 +83,348 @@ type Config struct {
 	SkipTests  bool
 	Settings   string
 	Profiles   []string
+
+	// SkipITs skips the Failsafe integration tests while still running the
+	// unit tests. It has no effect when SkipTests is set.
+	SkipITs bool
+	// StrictChecksums fails the build when a resolved dependency does not
+	// match its checksum, instead of only warning.
+	StrictChecksums bool
+	// FailureStrategy is fail-fast, fail-at-end or fail-never; empty keeps
+	// Maven's default of failing fast.
+	FailureStrategy string
+	// RerunFailingTestsCount reruns failed tests up to this many times, so a
+	// flaky test that passes on rerun does not fail the release build.
+	RerunFailingTestsCount int
+	// ManifestMetadata passes the build identifier, commit SHA and build
+	// timestamp as -Dmanifest.* properties for the jar manifests.
+	ManifestMetadata bool
+	// OutputTimestamp pins project.build.outputTimestamp and SOURCE_DATE_EPOCH
+	// for byte-identical rebuilds: "commit" for the release commit's date, an
+	// RFC 3339 date or seconds since the epoch.
+	OutputTimestamp string
+
+	// RepositoryID is the server ID used for the release repository.
+	RepositoryID string
+	// SnapshotRepository is the URL snapshot versions are deployed to.
+	SnapshotRepository string
+	// SnapshotRepositoryID is the server ID used for the snapshot repository.
+	SnapshotRepositoryID string
+	// FileRepositoryBase is the directory file:// repositories must lie in.
+	FileRepositoryBase string
+	// ExportArchive makes the maven deployer write the deployment to this
+	// .zip, .tar or .tar.gz archive in the repository layout instead of
+	// uploading it.
+	ExportArchive string
+	// SnapshotUsername and SnapshotPassword authenticate against the snapshot
+	// repository. They default to Username and Password when unset.
+	SnapshotUsername string
+	SnapshotPassword string
+	// UserToken exchanges Username and Password for a Sonatype user token
+	// before the deploy, so the raw credentials never reach Maven.
+	UserToken bool
+	// UserTokenURL overrides the user token endpoint derived from Repository.
+	UserTokenURL string
+	// MergeSettingsCredentials adds the repository credentials to the
+	// configured settings file instead of leaving its servers as they are.
+	MergeSettingsCredentials bool
+
+	// StagingProfileID selects the Nexus staging profile.
+	StagingProfileID string
+	// StagingProgressTimeout is the staging close/release timeout in minutes (0 uses the plugin default).
+	StagingProgressTimeout int
+	// AutoRelease releases the staging repository after close; nil leaves the POM setting untouched.
+	AutoRelease *bool
+
+	// ConnectTimeout and ReadTimeout are the repository transport timeouts in
+	// seconds (0 keeps Maven's defaults).
+	ConnectTimeout int
+	ReadTimeout    int
+	// DeployRetries is the number of times maven-deploy-plugin retries a failed
+	// upload (0 keeps the plugin default).
+	DeployRetries int
+	// BuildRetries reruns a failed Maven deploy up to this many times,
+	// resuming a multi-module build from the module that failed.
+	BuildRetries int
+	// HeartbeatInterval is the number of seconds between the heartbeat lines
+	// logged while Maven runs (0 disables them).
+	HeartbeatInterval int
+	// MaxHeap is the maximum heap of the Maven JVM, e.g. 4g, passed as -Xmx
+	// in MAVEN_OPTS.
+	MaxHeap string
+	// DeployAtEnd and InstallAtEnd defer deploying and installing until every
+	// module of the reactor has been built, so a failing module publishes nothing.
+	DeployAtEnd  bool
+	InstallAtEnd bool
+
+	// RepositoryType identifies the repository manager (generic, nexus, artifactory).
+	RepositoryType string
+	// RollbackOnError deletes the deployed component when the release fails.
+	RollbackOnError bool
+	// PromoteFrom is a staging repository on the same repository manager; the
+	// release is promoted from it into the repository instead of being built.
+	PromoteFrom string
+
+	// DowngradePolicy controls the published-version downgrade check (off, warn, fail).
+	DowngradePolicy string
+	// CoverageMinLine and CoverageMinBranch are the minimum JaCoCo line and
+	// branch coverage percentages required to publish (0 disables the gate).
+	CoverageMinLine   float64
+	CoverageMinBranch float64
+	// CoverageReport is the JaCoCo XML report; empty uses the report goal's default location.
+	CoverageReport string
+	// CoverageRun runs mvn verify with JaCoCo to produce the report before the gate.
+	CoverageRun bool
+
+	// VerifyChecksums compares the uploaded files with the local build output after deploy.
+	VerifyChecksums bool
+	// Checksums selects the checksum algorithms generated and uploaded for
+	// every file (md5, sha1, sha256, sha512); empty keeps the defaults.
+	Checksums []string
+	// VerifyMetadata checks after deploy that maven-metadata.xml lists the new version.
+	VerifyMetadata bool
+	// CanaryBuild compiles a throwaway project depending on the released GAV
+	// against the deploy repository after the deploy.
+	CanaryBuild bool
+	// VerifyResolution runs dependency:get for the release against the deploy
+	// repository from an empty local repository after the deploy.
+	VerifyResolution bool
+	// InvokerTests is a directory of sample projects run with the Maven Invoker
+	// Plugin against the released version after the deploy.
+	InvokerTests string
+	// UpdatesReport lists the dependency and plugin upgrades available for the
+	// release after the deploy.
+	UpdatesReport bool
+
+	// MaxArtifactSize and MaxUploadSize limit the size in bytes of the built main
+	// artifact and of all files uploaded for the GAV (0 disables the limit).
+	MaxArtifactSize int64
+	MaxUploadSize   int64
+	// SizeLimitPolicy controls whether exceeding a size limit fails the deploy or warns (warn, fail).
+	SizeLimitPolicy string
+	// MinFreeDisk is the free space in bytes the workspace and the local
+	// repository need before Maven runs (0 disables the check).
+	MinFreeDisk int64
+
+	// DeploySkipCheck controls the check that maven.deploy.skip or the deploy
+	// plugin's skip setting does not leave nothing to upload (off, warn, fail).
+	DeploySkipCheck string
+
+	// JavadocCheck controls the check that javadoc jars published to Maven
+	// Central contain documentation (off, warn, fail).
+	JavadocCheck string
+	// CentralPOMCheck controls the check that the POMs published to Maven
+	// Central contain the required metadata (off, warn, fail).
+	CentralPOMCheck string
+	// POMMetadata is injected into the published POM where the POM and its
+	// local parents lack it.
+	POMMetadata *POMMetadata
+	// PluginVersionCheck controls the check that every build plugin has a
+	// pinned version (off, warn, fail). With PluginVersionEnforcer the check
+	// runs the Maven Enforcer requirePluginVersions rule instead of reading
+	// the POMs.
+	PluginVersionCheck    string
+	PluginVersionEnforcer bool
+	// InsecureRepositoryCheck controls the check that no repository of the
+	// POMs, the settings, or resolution_repositories uses plain HTTP (off,
+	// warn, fail).
+	InsecureRepositoryCheck string
+	// DependencyConvergenceCheck controls the Maven Enforcer
+	// dependencyConvergence check for dependencies resolving to different
+	// versions along different paths (off, warn, fail).
+	DependencyConvergenceCheck string
+	// LicenseHeaderCheck controls the license-maven-plugin check that every
+	// source file carries the license header (off, warn, fail).
+	LicenseHeaderCheck string
+	// LicenseHeader is the header template of the check; empty uses the
+	// plugin configuration of the POM.
+	LicenseHeader string
+	// StaticAnalysis lists the tools run before the deploy, failing the
+	// release when one finds more violations than it tolerates.
+	StaticAnalysis []StaticAnalysis
+
+	// DeployLock takes a lock file per GAV so concurrent pipelines cannot deploy
+	// the same version at once.
+	DeployLock bool
+	// LockDir holds the lock files; share it between runners to lock across machines.
+	LockDir string
+	// LockWait is how long to wait in seconds for another deploy of the GAV to finish.
+	LockWait int
+	// LockStaleAfter is the age in seconds after which a lock that is no
+	// longer refreshed is taken over.
+	LockStaleAfter int
+
+	// AuditLog is a file receiving an append-only, hash-chained JSON record of every deploy.
+	AuditLog string
+	// AuditOutput adds the audit record to the outputs.
+	AuditOutput bool
+	// ReportFile is a file receiving a JSON report of the deploy.
+	ReportFile string
+	// MavenLog is the file receiving the complete, redacted Maven output of a
+	// deploy (default: target/relicta-maven.log next to the POM).
+	MavenLog string
+	// MaxErrorOutputBytes is the number of trailing bytes of Maven output
+	// embedded in the error of a failed run (0 embeds all of it).
+	MaxErrorOutputBytes int
+
+	// FlattenCheck controls the flatten-maven-plugin check for CI-friendly versions (off, warn, fail).
+	FlattenCheck string
+
+	// MavenExecutable selects the Maven launcher (mvn or the project's mvnw wrapper).
+	MavenExecutable string
+	// MinMavenVersion is the minimum Maven version required for the release.
+	MinMavenVersion string
+	// MavenUserHome and MavenConfig point Maven at an isolated configuration
+	// directory (MavenUserHome/.m2, or MavenConfig itself) for the settings,
+	// settings security, toolchains, and local repository.
+	MavenUserHome string
+	MavenConfig   string
+	// Toolchains are the JDKs rendered into a temporary toolchains.xml passed
+	// with --global-toolchains, for runners without one.
+	Toolchains []Toolchain
+	// GPGLoopback controls non-interactive signing with --pinentry-mode
+	// loopback (auto, always, never).
+	GPGLoopback string
+	// GPGPassphraseEnv names the variable holding the signing passphrase.
+	GPGPassphraseEnv string
+	// GPGExecutable is the gpg command used for signing.
+	GPGExecutable string
+	// GPGKey is the fingerprint of the signing key; empty uses gpg's default key.
+	GPGKey string
+	// GPGKeys select the signing key by repository and artifact, falling
+	// back to GPGKey when none matches.
+	GPGKeys []SigningKey
+	// KeyserverCheck controls the check that the signing key is published on
+	// a keyserver before a Maven Central release (off, warn, fail).
+	KeyserverCheck string
+	// Keyservers are the keyservers searched for the signing key.
+	Keyservers []string
+	// CheckJava verifies the JDK used by Maven before deploying.
+	CheckJava bool
+	// RequiredJava is the required Java major version ("17" exact, "17+" minimum).
+	// When empty, the POM's maven.compiler.release is used as a minimum.
+	RequiredJava string
+
+	// CheckPOM verifies during validation that pom_path exists and is well-formed.
+	CheckPOM bool
+	// CheckSettings verifies during validation that the settings file has
+	// server entries for the configured repository IDs.
+	CheckSettings bool
+	// DNSPolicy controls host resolution when validating repository URLs.
+	DNSPolicy string
+	// AllowedNetworks are CIDRs exempt from the private network check.
+	AllowedNetworks []string
+	// BlockedNetworks are CIDRs rejected in addition to the private ranges.
+	BlockedNetworks []string
+
+	// Artifacts lists the artifacts of a multi-artifact release. When empty,
+	// the top-level coordinates describe the only artifact.
+	Artifacts []ArtifactConfig
+	// Parallel deploys the artifacts and repositories concurrently instead of
+	// one after another.
+	Parallel bool
+	// MaxParallel bounds the number of concurrent deploys.
+	MaxParallel int
+	// Module restricts the deploy to one module of the reactor POM (set per artifact).
+	Module string
+	// Properties are passed to Maven as -Dname=value user properties.
+	Properties map[string]string
+	// ExtraArgs are appended to the Maven command line.
+	ExtraArgs []string
+	// PassEnvProperties maps allowlisted environment variables to the Maven
+	// properties they are passed as.
+	PassEnvProperties map[string]string
+
+	// SkipAggregators excludes aggregator-only pom modules from the deploy.
+	SkipAggregators bool
+	// ScalaVersions lists the Scala binary versions artifacts are published for
+	// with an _<version> suffix on the artifact ID.
+	ScalaVersions []string
+	// ScalaVersionProperty is the property selecting the Scala version; when set,
+	// the deploy runs once per Scala version.
+	ScalaVersionProperty string
+	// ScalaVersion is the Scala version of a single cross build (set per deploy).
+	ScalaVersion string
+
+	// Tycho builds an Eclipse/Tycho project and verifies the p2 repository it produces.
+	Tycho bool
+	// P2RepositoryPath is the p2 repository directory; empty locates the eclipse-repository module.
+	P2RepositoryPath string
+	// P2DeployURL is where the p2 site is uploaded, in a directory named after the version.
+	P2DeployURL string
+	// PackagingGoals maps packaging types to the goals and -D flags that replace
+	// the deploy goal for modules with that packaging.
+	PackagingGoals map[string][]string
+	// PreGoals and PostGoals are goals and -D flags run with the options of
+	// the deploy before it and after it succeeded.
+	PreGoals  []string
+	PostGoals []string
+
+	// EnvOverrides maps options whose values come from the environment to the
+	// environment variable that supplied them.
+	EnvOverrides map[string]string
+
+	// Rehearsal makes dry runs deploy into a temporary file repository and
+	// verify the artifacts, checksums, and signatures.
+	Rehearsal bool
+
+	// DependencyDiff computes dependency changes since the previous release in PreNotes.
+	DependencyDiff bool
+	// TagPrefix is prepended to versions to form release tag names; empty derives it from the current tag.
+	TagPrefix string
+
+	// InstallationNotes appends the Maven dependency snippet to the release notes in PostNotes.
+	InstallationNotes bool
+
+	// CentralLinks outputs Maven Central search, browse, and badge links after
+	// deploying; nil enables them when the target repository is Maven Central.
+	CentralLinks *bool
+	// ArtifactClassifiers lists the attached artifacts included in artifact_urls.
+	ArtifactClassifiers []string
+
+	// BOMUpdates lists downstream BOMs and version catalogs bumped to the new version in OnSuccess.
+	BOMUpdates []BOMUpdate
+	// PropertyUpdates lists version properties of the project POMs set in PrePublish.
+	PropertyUpdates []PropertyUpdate
+
+	// NativeArtifacts lists platform-specific files attached to the GAV after the main deploy.
+	NativeArtifacts []NativeArtifact
+
+	// Deployer selects how the release is published (maven, http). The http
+	// deployer uploads the pre-built files without running Maven.
+	Deployer string
+	// UploadRetries is the number of attempts for each HTTP upload.
+	UploadRetries int
+	// CentralPortalURL makes the http deployer upload a bundle to the Central Portal Publisher API.
+	CentralPortalURL string
+	// CentralWaitTimeout is the time in minutes to wait for the Central Portal
+	// deployment to be validated or published (0 does not wait).
+	CentralWaitTimeout int
+	// CentralDropFailed drops the Central Portal deployment when its
+	// validation fails or the release errors.
+	CentralDropFailed bool
+	// BundlePath makes the http deployer write the Central bundle zip to this
+	// path for manual upload instead of publishing.
+	BundlePath string
+
+	// Repositories lists the targets of a multi-repository deploy. Each target
+	// receives the same artifacts and replaces the repository settings above.
+	Repositories []RepositoryTarget
+	// ResolutionRepositories are added to the generated settings for the
+	// release build to resolve internal parent POMs and plugins from.
+	ResolutionRepositories []ResolutionRepository
+
+	// toolchainsFile is the toolchains.xml rendered from Toolchains for the
+	// deploy; empty when none was written.
+	toolchainsFile string
+	// redirectDeploy is set when repository_id or snapshot_repository is
+	// configured or an artifact names its own repository.
+	redirectDeploy bool
+	// snapshotRepositoryIDSet is set when snapshot_repository_id is configured.
+	snapshotRepositoryIDSet bool
+	// stage is the local repository a multi-repository deploy builds into
+	// once; nil for other deploys.
+	stage *deployStage
 }
 
 // validateMavenCoordinate validates a Maven group ID or artifact ID.
@@ -104,6 +467,41 @@ func validatePath(path string) error {
 	return nil
 }
 
+// validatePropertyName validates the name of a user property.
+func validatePropertyName(name string) error {
+	if !versionPropertyPattern.MatchString(name) {
+		return fmt.Errorf("invalid property name %q", name)
+	}
+	return nil
+}
+
+// maxDeployRetries is the largest retryFailedDeploymentCount maven-deploy-plugin accepts.
+const maxDeployRetries = 10
+
+// validateDeployRetries validates the maven-deploy-plugin retry count (0 leaves it unset).
+func validateDeployRetries(retries int) error {
+	if retries < 0 || retries > maxDeployRetries {
+		return fmt.Errorf("deploy_retries must be between 1 and %d", maxDeployRetries)
+	}
+	return nil
+}
+
+// managedArgs lists the Maven options the plugin sets itself.
+var managedArgs = []string{"-f", "--file", "-s", "--settings", "-gs", "--global-settings", "-pl", "--projects"}
+
+// validateExtraArg validates an extra Maven command-line argument.
+func validateExtraArg(arg string) error {
+	if !strings.HasPrefix(arg, "-") {
+		return fmt.Errorf("invalid extra argument %q: only options are allowed", arg)
+	}
+	for _, managed := range managedArgs {
+		if arg == managed || strings.HasPrefix(arg, managed+"=") {
+			return fmt.Errorf("extra argument %q is managed by the plugin", arg)
+		}
+	}
+	return nil
+}
+
 // validateProfile validates a Maven profile name.
 func validateProfile(profile string) error {
 	if profile == "" {
@@ -113,13 +511,43 @@ func validateProfile(profile string) error {
 		return fmt.Errorf("profile name too long (max 128 characters)")
 	}
 	if !profilePattern.MatchString(profile) {
-		return fmt.Errorf("invalid profile name: must be alphanumeric with dashes or underscores")
+		return fmt.Errorf("invalid profile name: must be alphanumeric with dashes or underscores, optionally prefixed with ! to deactivate it")
+	}
+	return nil
+}
+
+// validateRepositoryID validates a server ID used in settings.xml and deploy arguments.
+func validateRepositoryID(id, fieldName string) error {
+	if id == "" {
+		return fmt.Errorf("%s cannot be empty", fieldName)
+	}
+	if len(id) > 128 {
+		return fmt.Errorf("%s too long (max 128 characters)", fieldName)
+	}
+	if !mavenCoordinatePattern.MatchString(id) {
+		return fmt.Errorf("invalid %s: contains disallowed characters", fieldName)
 	}
 	return nil
 }
 
+// DNS policies for repository URL validation.
+const (
+	// dnsPolicyStrict resolves the host and rejects unresolvable hosts.
+	dnsPolicyStrict = "strict"
+	// dnsPolicySkipDNS never resolves the host; only IP literals are checked.
+	dnsPolicySkipDNS = "skip_dns"
+	// dnsPolicyOffline resolves the host but accepts hosts that do not resolve.
+	dnsPolicyOffline = "offline"
+)
+
+// dnsPolicies lists the supported DNS policies.
+var dnsPolicies = []string{dnsPolicyStrict, dnsPolicySkipDNS, dnsPolicyOffline}
+
 // validateRepositoryURL validates a Maven repository URL with SSRF protection.
-func validateRepositoryURL(rawURL string) error {
+// The DNS policy controls whether the host is resolved for the private network
+// check and how unresolvable hosts are treated; the allowed and blocked
+// networks adjust which addresses count as private.
+func validateRepositoryURL(rawURL string, policy networkPolicy) error {
 	if rawURL == "" {
 		return nil // Optional field.
 	}
@@ -144,14 +572,28 @@ func validateRepositoryURL(rawURL string) error {
 		return nil
 	}
 
+	// IP literals are checked without a lookup under every policy.
+	if ip := net.ParseIP(host); ip != nil {
+		if policy.blocks(ip) {
+			return fmt.Errorf("URLs pointing to private networks are not allowed")
+		}
+		return nil
+	}
+	if policy.DNS == dnsPolicySkipDNS {
+		return nil
+	}
+
 	// Resolve hostname to check for private IPs.
 	ips, err := net.LookupIP(host)
 	if err != nil {
+		if policy.DNS == dnsPolicyOffline {
+			return nil
+		}
 		return fmt.Errorf("failed to resolve hostname: %w", err)
 	}
 
 	for _, ip := range ips {
-		if isPrivateIP(ip) {
+		if policy.blocks(ip) {
 			return fmt.Errorf("URLs pointing to private networks are not allowed")
 		}
 	}
@@ -160,7 +602,13 @@ func validateRepositoryURL(rawURL string) error {
 }
 
 // isPrivateIP checks if an IP address is in a private/reserved range.
+// IPv4-mapped and NAT64 addresses are checked by their embedded IPv4 address.
 func isPrivateIP(ip net.IP) bool {
+	if containsIP(cloudMetadataRanges, ip) {
+		return true
+	}
+	ip = embeddedIPv4(ip)
+
 	// Private IPv4 ranges.
 	privateRanges := []string{
 		"10.0.0.0/8",
@@ -171,15 +619,13 @@ func isPrivateIP(ip net.IP) bool {
 		"0.0.0.0/8",
 	}
 
-	// Cloud metadata endpoints.
-	cloudMetadata := []string{
-		"169.254.169.254/32", // AWS/GCP/Azure metadata.
-		"fd00:ec2::254/128",  // AWS IMDSv2 IPv6.
-	}
-
-	allRanges := append(privateRanges, cloudMetadata...)
+	// Private IPv6 ranges.
+	privateRanges = append(privateRanges,
+		"fc00::/7",       // Unique local addresses.
+		"64:ff9b:1::/48", // Local-use NAT64 (RFC 8215).
+	)
 
-	for _, cidr := range allRanges {
+	for _, cidr := range privateRanges {
 		_, block, err := net.ParseCIDR(cidr)
 		if err != nil {
 			continue
@@ -190,7 +636,7 @@ func isPrivateIP(ip net.IP) bool {
 	}
 
 	// Check for IPv6 private ranges.
-	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsPrivate() {
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsPrivate() || ip.IsUnspecified() {
 		return true
 	}
 
@@ -199,13 +645,19 @@ func isPrivateIP(ip net.IP) bool {
 
 // GetInfo returns plugin metadata.
 func (p *MavenPlugin) GetInfo() plugin.Info {
+	build := currentBuildInfo()
 	return plugin.Info{
 		Name:        "maven",
-		Version:     "2.0.0",
+		Version:     build.Version,
 		Description: "Publish artifacts to Maven Central (Java)",
 		Author:      "Relicta Team",
 		Hooks: []plugin.Hook{
+			plugin.HookPreNotes,
+			plugin.HookPostNotes,
+			plugin.HookPrePublish,
 			plugin.HookPostPublish,
+			plugin.HookOnSuccess,
+			plugin.HookOnError,
 		},
 		ConfigSchema: `{
 			"type": "object",
@@ -213,35 +665,319 @@ func (p *MavenPlugin) GetInfo() plugin.Info {
 				"group_id": {"type": "string", "description": "Maven group ID (e.g., com.example)"},
 				"artifact_id": {"type": "string", "description": "Maven artifact ID"},
 				"pom_path": {"type": "string", "description": "Path to pom.xml", "default": "pom.xml"},
+				"defaults_file": {"type": "string", "description": "JSON file of shared default options, overridden by this configuration (objects are merged key by key)", "default": ".relicta/maven.json"},
+				"hooks": {"type": "object", "description": "Options overriding the configuration for a single hook, keyed by hook name (e.g. pre-publish, post-publish); objects are merged key by key, while lists and scalars replace the base value (optional)", "additionalProperties": {"type": "object"}},
 				"username": {"type": "string", "description": "Maven repository username (or use MAVEN_USERNAME env)"},
 				"password": {"type": "string", "description": "Maven repository password (or use MAVEN_PASSWORD env)"},
-				"repository": {"type": "string", "description": "Maven repository URL"},
+				"repository": {"type": "string", "description": "Maven repository URL; a file:// URL inside file_repository_base deploys to a local directory"},
 				"skip_tests": {"type": "boolean", "description": "Skip tests during deploy", "default": false},
-				"settings": {"type": "string", "description": "Path to settings.xml (optional)"},
-				"profiles": {"type": "array", "items": {"type": "string"}, "description": "Maven profiles to activate (optional)"}
+				"skip_its": {"type": "boolean", "description": "Skip the Failsafe integration tests during deploy while still running the unit tests (-DskipITs)", "default": false},
+				"failure_strategy": {"type": "string", "enum": ["fail-fast", "fail-at-end", "fail-never"], "description": "How the reactor handles a failed module: stop at once (--fail-fast), build the independent modules and fail at the end listing every failed module (--fail-at-end), or never fail (--fail-never) (optional)"},
+				"strict_checksums": {"type": "boolean", "description": "Fail the build when a resolved dependency does not match its checksum instead of warning (--strict-checksums)", "default": false},
+				"surefire_rerun_failing_tests_count": {"type": "integer", "description": "Rerun failed tests up to this many times (-Dsurefire.rerunFailingTestsCount); tests passing only on rerun are listed in the flaky_tests output", "minimum": 0, "maximum": 10, "default": 0},
+				"output_timestamp": {"type": "string", "description": "Pin project.build.outputTimestamp and SOURCE_DATE_EPOCH for byte-identical rebuilds: commit (the release commit's date), an RFC 3339 date or seconds since the epoch (optional)"},
+				"manifest_metadata": {"type": "boolean", "description": "Pass the tag (build identifier), commit SHA and UTC build time as manifest.implementationBuild, manifest.scmRevision and manifest.buildTimestamp properties, for the POM to stamp into the jar manifests as Implementation-Build, Scm-Revision and Build-Timestamp. Requires the release commit SHA", "default": false},
+				"settings": {"type": "string", "description": "Path to settings.xml; defaults to .mvn/local-settings.xml next to the POM, then ~/.m2/settings.xml, then settings generated for the credentials (reported in settings_source)"},
+				"merge_settings_credentials": {"type": "boolean", "description": "Add username/password and the snapshot credentials as server entries to the configured settings file; without it the servers of that file are used as they are", "default": false},
+				"gpg_loopback": {"type": "string", "enum": ["auto", "always", "never"], "description": "Sign non-interactively by passing --pinentry-mode loopback to gpg; auto does so when the passphrase variable is set and gpg is 2.1 or newer", "default": "auto"},
+				"gpg_passphrase_env": {"type": "string", "description": "Environment variable holding the signing passphrase for maven-gpg-plugin", "default": "MAVEN_GPG_PASSPHRASE"},
+				"gpg_executable": {"type": "string", "description": "gpg command used for signing", "default": "gpg"},
+				"gpg_key": {"type": "string", "description": "Fingerprint of the signing key (gpg.keyname); the fallback when no gpg_keys entry matches (optional)"},
+				"keyserver_check": {"type": "string", "enum": ["off", "warn", "fail"], "description": "When publishing to Maven Central with gpg_key or gpg_keys, check that the signing key is published on a keyserver and neither expired nor revoked", "default": "fail"},
+				"keyservers": {"type": "array", "items": {"type": "string"}, "description": "Keyservers searched by keyserver_check (HKP over HTTPS)", "default": ["https://keys.openpgp.org", "https://keyserver.ubuntu.com"]},
+				"gpg_keys": {"type": "array", "description": "Signing keys selected per deploy: the first entry matching the target repository and the artifact signs, so old and new keys can be used side by side during a rotation", "items": {"type": "object", "properties": {"fingerprint": {"type": "string", "description": "Key fingerprint (40 or 64 hex digits)"}, "repositories": {"type": "array", "items": {"type": "string"}, "description": "Repository IDs or URLs the key signs for; empty matches all"}, "artifacts": {"type": "array", "items": {"type": "string"}, "description": "groupId:artifactId patterns the key signs for, e.g. com.example:*; empty matches all"}, "passphrase_env": {"type": "string", "description": "Environment variable holding the key's passphrase (defaults to gpg_passphrase_env)"}}, "required": ["fingerprint"]}},
+				"maven_user_home": {"type": "string", "description": "Home directory whose .m2 holds the settings, settings security, toolchains, and local repository used instead of the runner user's (optional)"},
+				"maven_config": {"type": "string", "description": "Maven configuration directory used in place of ~/.m2 (optional; alternative to maven_user_home)"},
+				"toolchains": {"type": "array", "description": "JDK toolchains rendered into a temporary toolchains.xml passed to every Maven run with --global-toolchains, for runners without one (optional)", "items": {"type": "object", "properties": {"version": {"type": "string", "description": "JDK version the toolchain provides, e.g. 17"}, "vendor": {"type": "string", "description": "JDK vendor, e.g. temurin (optional)"}, "jdk_home": {"type": "string", "description": "Absolute path of the JDK installation"}}, "required": ["version", "jdk_home"]}},
+				"profiles": {"type": "array", "items": {"type": "string"}, "description": "Maven profiles to activate; prefix a profile with ! to deactivate it (optional)"},
+				"repository_id": {"type": "string", "description": "Server ID for the release repository; setting it (or snapshot_repository) deploys to repository instead of the distributionManagement of the POM", "default": "releases"},
+				"snapshot_repository": {"type": "string", "description": "Maven repository URL for snapshot versions (optional)"},
+				"snapshot_repository_id": {"type": "string", "description": "Server ID for the snapshot repository", "default": "snapshots"},
+				"file_repository_base": {"type": "string", "description": "Directory that file:// repository and snapshot_repository URLs must lie in, for deploys in air-gapped networks whose artifacts are transferred out of band; the checks that read the repository over HTTP are not available (optional)"},
+				"export_archive": {"type": "string", "description": "Write the deployment (files, signatures, checksums and metadata in the repository layout) to this .zip, .tar or .tar.gz archive for import through a separate transfer process instead of uploading it (optional)"},
+				"snapshot_username": {"type": "string", "description": "Snapshot repository username (or use MAVEN_SNAPSHOT_USERNAME env, defaults to username)"},
+				"snapshot_password": {"type": "string", "description": "Snapshot repository password (or use MAVEN_SNAPSHOT_PASSWORD env, defaults to password)"},
+				"staging_profile_id": {"type": "string", "description": "Nexus staging profile ID (optional)"},
+				"staging_progress_timeout": {"type": "integer", "description": "Nexus staging close/release timeout in minutes (optional)", "minimum": 0},
+				"auto_release": {"type": "boolean", "description": "Release the staging repository automatically after close (optional)"},
+				"connect_timeout": {"type": "integer", "description": "Repository connection timeout in seconds, rendered into the generated settings and resolver properties (optional)", "minimum": 0, "maximum": 3600},
+				"read_timeout": {"type": "integer", "description": "Repository read/request timeout in seconds for slow proxies, rendered into the generated settings and transport properties (optional)", "minimum": 0, "maximum": 3600},
+				"deploy_at_end": {"type": "boolean", "description": "Pass -DdeployAtEnd=true so nothing is deployed unless every module builds; with parallel builds (-T) maven-deploy-plugin 3.0.0 or newer is required, and --fail-at-end/--fail-never are rejected", "default": false},
+				"install_at_end": {"type": "boolean", "description": "Pass -DinstallAtEnd=true so nothing is installed into the local repository unless every module builds", "default": false},
+				"max_heap": {"type": "string", "description": "Maximum heap of the Maven JVM, e.g. 4g, added to MAVEN_OPTS as -Xmx; builds that run out of memory are reported with a hint (optional)"},
+				"heartbeat_interval": {"type": "integer", "description": "Seconds between the heartbeat lines (elapsed time, current module, last log line) logged while Maven runs, so orchestrators with an inactivity timeout do not kill long builds; 0 disables them", "minimum": 0, "default": 60},
+				"build_retries": {"type": "integer", "description": "Rerun a failed Maven deploy up to this many times; a multi-module build resumes from the failed module (-rf) instead of rebuilding the reactor. Test failures are not retried", "minimum": 0, "maximum": 5, "default": 0},
+				"deploy_retries": {"type": "integer", "description": "Passed to maven-deploy-plugin as retryFailedDeploymentCount so it retries failed uploads itself; independent of upload_retries (optional)", "minimum": 1, "maximum": 10},
+				"repository_type": {"type": "string", "enum": ["generic", "nexus", "artifactory"], "description": "Repository manager type", "default": "generic"},
+				"promote_from": {"type": "string", "description": "Staging repository on the same Nexus/Artifactory server; the release is promoted from it into repository through the repository manager API instead of being rebuilt (optional)"},
+				"rollback_on_error": {"type": "boolean", "description": "Delete the deployed component from Nexus/Artifactory when the release fails (never for Maven Central)", "default": false},
+				"downgrade_policy": {"type": "string", "enum": ["off", "warn", "fail"], "description": "Check that the release version is not lower than the latest published version", "default": "off"},
+				"verify_resolution": {"type": "boolean", "description": "After deploy, run mvn dependency:get for the release against the deploy repository from an empty local repository, checking that it resolves with its parent POMs and imports", "default": false},
+				"invoker_tests": {"type": "string", "description": "Directory of sample projects run with the Maven Invoker Plugin against the released version after deploy, with results summarized in outputs (optional)"},
+				"updates_report": {"type": "boolean", "description": "After deploy, list the newer versions available for the dependencies and build plugins with versions:display-dependency-updates and display-plugin-updates in the available_updates output; failures only warn", "default": false},
+				"canary_build": {"type": "boolean", "description": "After deploy, compile a throwaway project depending on the released GAV against the deploy repository with an empty local repository, failing the release if resolution breaks", "default": false},
+				"verify_metadata": {"type": "boolean", "description": "After deploy, check that maven-metadata.xml lists the new version with latest/release updated, and that the group metadata lists Maven plugins", "default": false},
+				"checksums": {"type": "array", "items": {"type": "string", "enum": ["md5", "sha1", "sha256", "sha512"]}, "description": "Checksum algorithms generated and uploaded for every file, e.g. [sha256, sha512] to publish no MD5 or SHA-1 checksums. Maven deploys need Maven 3.9 or newer (aether.checksums.algorithms); verify_checksums then requires each of them in the repository. Maven Central requires md5 and sha1 (optional)"},
+				"verify_checksums": {"type": "boolean", "description": "After deploy, compare the repository's .sha512/.sha256/.sha1 checksums (or the downloaded files) with the local build output", "default": false},
+				"min_free_disk": {"type": ["string", "integer"], "description": "Free space the workspace and the local repository need before Maven runs, in bytes or with a unit such as 2GB; the deploy fails fast below it (optional)"},
+				"max_artifact_size": {"type": ["string", "integer"], "description": "Maximum size of the built main artifact, in bytes or with a unit such as 50MB or 1GiB (optional)"},
+				"max_upload_size": {"type": ["string", "integer"], "description": "Maximum total size of the files uploaded for the artifact (optional)"},
+				"size_limit_policy": {"type": "string", "enum": ["warn", "fail"], "description": "Whether exceeding a size limit fails the deploy or only warns", "default": "fail"},
+				"coverage_min_line": {"type": "number", "description": "Minimum JaCoCo line coverage percentage required to publish (optional)"},
+				"coverage_min_branch": {"type": "number", "description": "Minimum JaCoCo branch coverage percentage required to publish (optional)"},
+				"coverage_report": {"type": "string", "description": "JaCoCo XML report to check (default: target/site/jacoco/jacoco.xml next to the POM)"},
+				"coverage_run": {"type": "boolean", "description": "Run mvn verify with JaCoCo to produce the report before checking coverage", "default": false},
+				"flatten_check": {"type": "string", "enum": ["off", "warn", "fail"], "description": "Check that POMs using ${revision}, ${sha1} or ${changelist} versions are flattened by flatten-maven-plugin before deploy", "default": "fail"},
+				"deploy_lock": {"type": "boolean", "description": "Take a lock file per GAV so two pipeline runs cannot deploy the same version at once", "default": false},
+				"lock_dir": {"type": "string", "description": "Directory of the deploy lock files; use a shared directory to lock across runners (defaults to the system temp directory)"},
+				"lock_wait": {"type": "integer", "description": "Seconds to wait for another deploy of the same GAV to finish before failing", "default": 0, "minimum": 0},
+				"lock_stale_after": {"type": "integer", "description": "Seconds after which a lock file its deploy no longer refreshes is assumed to be left behind and is taken over", "default": 3600, "minimum": 1},
+				"audit_log": {"type": "string", "description": "File receiving an append-only JSON Lines audit record of every deploy (timestamp, GAV, repository, actor, dry-run flag, result, digests), hash-chained to detect tampering (optional)"},
+				"audit_output": {"type": "boolean", "description": "Add the audit record of the deploy to the outputs", "default": false},
+				"report_file": {"type": "string", "description": "File receiving a schema-versioned JSON report of the deploy (GAVs, files and digests, repository, timings, Maven and Java versions, status), referenced by the report_file output (optional)"},
+				"maven_log": {"type": "string", "description": "File receiving the complete Maven output of a deploy with credentials masked, written whether the deploy succeeds or fails and referenced by the maven_log output; the error of a failed deploy only embeds the end of the output (see max_error_output_bytes)", "default": "target/relicta-maven.log"},
+				"max_error_output_bytes": {"type": "integer", "description": "Trailing bytes of Maven output embedded in the error of a failed Maven run, cut at a line boundary, for messaging systems with size limits; the maven_log file has the complete output. 0 embeds all of it", "minimum": 0, "default": 8192},
+				"pom_metadata": {"type": "object", "description": "Project metadata injected into the published POM where it is missing", "properties": {"description": {"type": "string"}, "url": {"type": "string"}, "licenses": {"type": "array", "items": {"type": "object", "properties": {"name": {"type": "string"}, "url": {"type": "string"}}}}, "developers": {"type": "array", "items": {"type": "object", "properties": {"id": {"type": "string"}, "name": {"type": "string"}, "email": {"type": "string"}}}}, "scm": {"type": "object", "properties": {"connection": {"type": "string"}, "developer_connection": {"type": "string"}, "url": {"type": "string"}}}}},
+				"plugin_version_check": {"type": "string", "enum": ["off", "warn", "fail"], "description": "Check that every build plugin of the POMs (with their local parents) has a pinned version, not missing, a range, LATEST, RELEASE or a snapshot", "default": "off"},
+				"plugin_version_enforcer": {"type": "boolean", "description": "Run the plugin version check with the Maven Enforcer requirePluginVersions rule, which also catches plugins bound by the default lifecycle", "default": false},
+				"insecure_repository_check": {"type": "string", "enum": ["off", "warn", "fail"], "description": "Check that no repository, plugin repository or mirror declared by the POMs (with their local parents and profiles), the settings file, or resolution_repositories uses plain http://, which Maven 3.8+ blocks during the build; localhost is allowed", "default": "warn"},
+				"dependency_convergence_check": {"type": "string", "enum": ["off", "warn", "fail"], "description": "Run the Maven Enforcer dependencyConvergence rule over the reactor before the release and report every dependency resolving to diverging versions; not run in dry runs", "default": "off"},
+				"license_header_check": {"type": "string", "enum": ["off", "warn", "fail"], "description": "Run the license-maven-plugin check goal over the reactor before the release and report the source files missing the license header; not run in dry runs", "default": "off"},
+				"license_header": {"type": "string", "description": "License header template of the license header check, relative to the working directory (default: the plugin configuration of the POM)"},
+				"static_analysis": {
+					"type": "array",
+					"description": "Static analysis tools run over the reactor before the release with the configuration of the POM, failing it when a tool finds more violations than tolerated; counts are reported in the static_analysis output. Not run in dry runs (optional)",
+					"items": {
+						"type": "object",
+						"properties": {
+							"tool": {"type": "string", "enum": ["checkstyle", "pmd", "spotbugs"], "description": "Tool to run"},
+							"max_violations": {"type": "integer", "description": "Violations tolerated across the reactor", "default": 0}
+						},
+						"required": ["tool"]
+					}
+				},
+				"central_pom_check": {"type": "string", "enum": ["off", "warn", "fail"], "description": "When publishing to Maven Central, check that the POMs (with their local parents) contain name, description, url, licenses, developers, and scm", "default": "fail"},
+				"javadoc_check": {"type": "string", "enum": ["off", "warn", "fail"], "description": "When publishing to Maven Central, check that built javadoc jars contain documentation rather than an empty stub", "default": "fail"},
+				"deploy_skip_check": {"type": "string", "enum": ["off", "warn", "fail"], "description": "Check that maven.deploy.skip or maven-deploy-plugin <skip> does not leave the published project (or the whole reactor) undeployed", "default": "fail"},
+				"maven_executable": {"type": "string", "enum": ["mvn", "mvnw"], "description": "Maven launcher to use (mvn from PATH or the project's ./mvnw wrapper)", "default": "mvn"},
+				"min_maven_version": {"type": "string", "description": "Minimum required Maven version, checked during validation and before deploy (optional)"},
+				"check_java": {"type": "boolean", "description": "Verify the JDK used by Maven against required_java or the POM's maven.compiler.release", "default": false},
+				"required_java": {"type": "string", "description": "Required Java major version, e.g. 17 (exact) or 17+ (minimum); implies check_java"},
+				"user_token": {"type": "boolean", "description": "Exchange username and password for a Sonatype user token through the Nexus API before deploying, so the raw credentials never reach Maven", "default": false},
+				"user_token_url": {"type": "string", "description": "User token endpoint (default: /service/local/usertoken/current on the repository host)"},
+				"deep_validate": {"type": "boolean", "description": "During validation, send an authenticated HEAD (or OPTIONS) request to each repository to check reachability and credentials before the release starts", "default": false},
+				"dns_policy": {"type": "string", "description": "How repository hosts are resolved for the private network check: strict (resolve, reject unresolvable hosts), skip_dns (no lookup, only IP literals are checked) or offline (resolve, accept unresolvable hosts)", "enum": ["strict", "skip_dns", "offline"], "default": "strict"},
+				"allowed_networks": {"type": "array", "items": {"type": "string"}, "description": "CIDRs exempt from the private network check of repository URLs, such as internal IPv6 ULA ranges; cloud metadata endpoints stay blocked"},
+				"blocked_networks": {"type": "array", "items": {"type": "string"}, "description": "CIDRs rejected for repository URLs in addition to the private ranges; takes precedence over allowed_networks"},
+				"check_pom": {"type": "boolean", "description": "Verify during validation that pom_path exists and is a well-formed 4.0.0 POM (requires workspace access)", "default": false},
+				"check_settings": {"type": "boolean", "description": "Verify during validation that the settings file has server entries for repository_id and snapshot_repository_id", "default": true},
+				"artifacts": {
+					"type": "array",
+					"description": "Artifacts to deploy in one release; unset fields inherit the top-level values (optional)",
+					"items": {
+						"type": "object",
+						"properties": {
+							"group_id": {"type": "string", "description": "Maven group ID"},
+							"artifact_id": {"type": "string", "description": "Maven artifact ID"},
+							"pom_path": {"type": "string", "description": "Path to the artifact's pom.xml"},
+							"profiles": {"type": "array", "items": {"type": "string"}, "description": "Maven profiles to activate for this artifact, replacing the top-level profiles (use [] for none)"},
+							"repository": {"type": "string", "description": "Maven repository URL for this artifact"},
+							"module": {"type": "string", "description": "Reactor module to deploy on its own with -pl (multi-module builds)"}
+						}
+					}
+				},
+				"parallel": {"type": "boolean", "description": "Deploy artifacts and repositories concurrently instead of sequentially", "default": false},
+				"max_parallel": {"type": "integer", "description": "Maximum number of concurrent deploys when parallel is set, shared between repositories and their artifacts", "minimum": 1, "maximum": 16, "default": 4},
+				"scala_versions": {"type": "array", "items": {"type": "string"}, "description": "Scala binary versions (e.g. 2.13, 3) published with an _<version> artifact ID suffix (optional)"},
+				"scala_version_property": {"type": "string", "description": "Property set to each Scala version to deploy once per cross version; profiles can activate on it (optional)"},
+				"tycho": {"type": "boolean", "description": "Build an Eclipse/Tycho project and verify the p2 repository it produces", "default": false},
+				"p2_repository_path": {"type": "string", "description": "p2 repository directory (defaults to target/repository of the eclipse-repository module)"},
+				"p2_deploy_url": {"type": "string", "description": "URL the p2 site is uploaded to with HTTP PUT, in a directory named after the version (optional)"},
+				"packaging_goals": {
+					"type": "object",
+					"description": "Goals and -D flags replacing the deploy goal for modules of a packaging type, e.g. {\"maven-plugin\": [\"plugin:descriptor\", \"deploy\"]} (optional)",
+					"additionalProperties": {"type": "array", "items": {"type": "string"}}
+				},
+				"pre_goals": {"type": "array", "items": {"type": "string"}, "description": "Goals and -D flags run with the profiles, properties and settings of the deploy before it, e.g. [\"com.example:custom-plugin:prepare\"] (optional)"},
+				"post_goals": {"type": "array", "items": {"type": "string"}, "description": "Goals and -D flags run with the profiles, properties and settings of the deploy after it succeeded, e.g. [\"site:deploy\"] (optional)"},
+				"properties": {
+					"type": "object",
+					"description": "User properties passed as -Dname=value; values may use {{version}}, {{tag}}, {{commit}}, {{short_commit}}, {{branch}}, {{previous_version}}, and {{release_type}} (optional)",
+					"additionalProperties": {"type": "string"}
+				},
+				"pass_env_properties": {"type": ["array", "object"], "items": {"type": "string"}, "additionalProperties": {"type": "string"}, "description": "Allowlisted environment variables passed to Maven as -D properties: a list uses the variable name as the property, a mapping names the property (e.g. {\"SONAR_TOKEN\": \"sonar.token\"}); values are masked in dry-run output (optional)"},
+				"extra_args": {"type": "array", "items": {"type": "string"}, "description": "Extra Maven options appended to the command; supports the same templates as properties (optional)"},
+				"skip_aggregators": {"type": "boolean", "description": "Exclude aggregator-only pom modules (not a parent of any module) from the deploy", "default": false},
+				"dependency_diff": {"type": "boolean", "description": "Compute dependency changes since the previous release tag in pre-notes for the release notes", "default": false},
+				"tag_prefix": {"type": "string", "description": "Prefix of release tag names (defaults to the prefix of the current tag, or v)"},
+				"installation_notes": {"type": "boolean", "description": "Append an Installation section with the <dependency> snippet to the release notes in post-notes", "default": false},
+				"central_links": {"type": "boolean", "description": "Output Maven Central search, browse, and badge links after deploying (defaults to true when the repository is Maven Central)"},
+				"artifact_classifiers": {"type": "array", "items": {"type": "string"}, "description": "Attached artifact classifiers included in the artifact_urls output", "default": ["sources", "javadoc"]},
+				"bom_updates": {
+					"type": "array",
+					"description": "Downstream BOMs or version catalogs to bump to the released version on success (optional)",
+					"items": {
+						"type": "object",
+						"properties": {
+							"path": {"type": "string", "description": "Path to a BOM pom.xml or a Gradle libs.versions.toml"},
+							"property": {"type": "string", "description": "POM property or [versions] key holding this artifact's version"}
+						},
+						"required": ["path", "property"]
+					}
+				},
+				"property_updates": {
+					"type": "array",
+					"description": "Version properties of the project POMs set with versions:set-property before the deploy, for dependencies versioned through properties (optional)",
+					"items": {
+						"type": "object",
+						"properties": {
+							"property": {"type": "string", "description": "POM property to set (e.g., platform.version)"},
+							"value": {"type": "string", "description": "New value; defaults to the release version"}
+						},
+						"required": ["property"]
+					}
+				},
+				"native_artifacts": {
+					"type": "array",
+					"description": "Platform-specific files attached to the release GAV with their classifiers in one deploy-file run; requires a repository URL (optional)",
+					"items": {
+						"type": "object",
+						"properties": {
+							"classifier": {"type": "string", "description": "Platform classifier, e.g. linux-x86_64"},
+							"path": {"type": "string", "description": "Path to the native artifact"},
+							"url": {"type": "string", "description": "HTTPS URL to download the native artifact from instead of path"},
+							"type": {"type": "string", "description": "Artifact type/extension", "default": "jar"}
+						},
+						"required": ["classifier"]
+					}
+				},
+				"deployer": {"type": "string", "description": "How the release is published: maven runs mvn deploy, http uploads the pre-built POM, files, signatures, and checksums over HTTP without Maven", "enum": ["maven", "http"], "default": "maven"},
+				"upload_retries": {"type": "integer", "description": "Attempts per file for the http deployer; network errors, 5xx, and 429 responses are retried", "default": 3},
+				"central_portal_url": {"type": "string", "description": "Central Portal base URL (e.g. https://central.sonatype.com); the http deployer then uploads a single bundle to the Publisher API (optional)"},
+				"central_wait_timeout": {"type": "integer", "description": "Minutes to wait for the Central Portal deployment to be validated (or published with auto_release); validation errors fail the deploy. 0 does not wait", "default": 30},
+				"bundle_path": {"type": "string", "description": "With the http deployer, write the Central bundle zip (files, signatures, and checksums in the repository layout) to this path for manual upload instead of publishing (optional)"},
+				"central_drop_failed": {"type": "boolean", "description": "Drop the Central Portal deployment when its validation fails or the release errors (on-error hook); published deployments are never dropped", "default": true},
+				"rehearsal": {"type": "boolean", "description": "In dry runs, run the full deploy into a temporary file:// repository and verify artifacts, checksums, and signatures", "default": false},
+				"repositories": {
+					"type": "array",
+					"description": "Repositories to deploy the same artifacts to, each reported separately; replaces repository and snapshot_repository (optional)",
+					"items": {
+						"type": "object",
+						"properties": {
+							"id": {"type": "string", "description": "Server ID for the repository"},
+							"url": {"type": "string", "description": "Maven repository URL"},
+							"type": {"type": "string", "enum": ["generic", "nexus", "artifactory"], "description": "Repository manager type (defaults to repository_type)"},
+							"username": {"type": "string", "description": "Repository username"},
+							"password": {"type": "string", "description": "Repository password"},
+							"username_env": {"type": "string", "description": "Environment variable holding the repository username"},
+							"password_env": {"type": "string", "description": "Environment variable holding the repository password"}
+						},
+						"required": ["id", "url"]
+					}
+				},
+				"resolution_repositories": {
+					"type": "array",
+					"description": "Repositories added to the generated settings (as an active profile) for the release build to resolve internal parent POMs, dependencies and plugins from (optional)",
+					"items": {
+						"type": "object",
+						"properties": {
+							"id": {"type": "string", "description": "Repository ID, also the server ID of its credentials"},
+							"url": {"type": "string", "description": "Maven repository URL"},
+							"username": {"type": "string", "description": "Repository username"},
+							"password": {"type": "string", "description": "Repository password"},
+							"username_env": {"type": "string", "description": "Environment variable holding the repository username"},
+							"password_env": {"type": "string", "description": "Environment variable holding the repository password"},
+							"snapshots": {"type": "boolean", "description": "Resolve snapshot versions from the repository", "default": false},
+							"plugins": {"type": "boolean", "description": "Also resolve Maven plugins from the repository", "default": true}
+						},
+						"required": ["id", "url"]
+					}
+				}
 			},
-			"required": ["group_id", "artifact_id"]
+			"anyOf": [
+				{"required": ["group_id", "artifact_id"]},
+				{"required": ["artifacts"]}
+			],
+			"x-outputs": ` + outputsSchema + `,
+			"x-build": ` + build.schema() + `
 		}`,
 	}
 }
 
 // Execute runs the plugin for a given hook.
 func (p *MavenPlugin) Execute(ctx context.Context, req plugin.ExecuteRequest) (*plugin.ExecuteResponse, error) {
-	cfg := p.parseConfig(req.Config)
+	raw, err := withDefaults(req.Config)
+	if err != nil {
+		return &plugin.ExecuteResponse{
+			Success: false,
+			Error:   fmt.Sprintf("defaults_file: %v", err),
+		}, nil
+	}
+	raw = withHookOverrides(raw, req.Hook)
+	raw, unset := interpolateConfig(raw)
+	if options := unsetOptions(unset); len(options) > 0 {
+		return &plugin.ExecuteResponse{
+			Success: false,
+			Error:   fmt.Sprintf("%s: %v", options[0], unsetEnvError(unset[options[0]])),
+		}, nil
+	}
+	raw, err = expandConfig(raw, templateVariables(req.Context))
+	if err != nil {
+		return &plugin.ExecuteResponse{
+			Success: false,
+			Error:   fmt.Sprintf("invalid template: %v", err),
+		}, nil
+	}
+	cfg := p.parseConfig(raw)
 
 	switch req.Hook {
+	case plugin.HookPreNotes:
+		if cfg.DependencyDiff {
+			return p.dependencyUpdates(ctx, cfg, req.Context)
+		}
+	case plugin.HookPostNotes:
+		if cfg.InstallationNotes {
+			return p.installationNotes(cfg, req.Context)
+		}
+	case plugin.HookPrePublish:
+		if len(cfg.PropertyUpdates) > 0 {
+			return p.updateProperties(ctx, cfg, req.Context, req.DryRun)
+		}
 	case plugin.HookPostPublish:
+		if len(cfg.Repositories) > 0 {
+			return p.deployTargets(ctx, cfg, req.Context, req.DryRun)
+		}
+		if cfg.multiArtifact() {
+			return p.deployArtifacts(ctx, cfg, req.Context, req.DryRun)
+		}
 		return p.deploy(ctx, cfg, req.Context, req.DryRun)
-	default:
-		return &plugin.ExecuteResponse{
-			Success: true,
-			Message: fmt.Sprintf("Hook %s not handled", req.Hook),
-		}, nil
+	case plugin.HookOnSuccess:
+		if len(cfg.BOMUpdates) > 0 {
+			return p.updateBOMs(cfg, req.Context, req.DryRun)
+		}
+	case plugin.HookOnError:
+		if cfg.Deployer == deployerHTTP && cfg.CentralPortalURL != "" && cfg.CentralDropFailed {
+			return p.dropFailedDeployment(ctx, cfg, req.Context, req.DryRun)
+		}
+		if cfg.RollbackOnError {
+			if len(cfg.Repositories) > 0 {
+				return p.rollbackTargets(ctx, cfg, req.Context, req.DryRun)
+			}
+			if cfg.multiArtifact() {
+				return p.rollbackArtifacts(ctx, cfg, req.Context, req.DryRun)
+			}
+			return p.rollback(ctx, cfg, req.Context, req.DryRun)
+		}
 	}
+
+	return &plugin.ExecuteResponse{
+		Success: true,
+		Message: fmt.Sprintf("Hook %s not handled", req.Hook),
+	}, nil
 }
 
 // buildMavenCommand constructs the Maven deploy command arguments.
-func (p *MavenPlugin) buildMavenCommand(cfg *Config) ([]string, error) {
+func (p *MavenPlugin) buildMavenCommand(cfg *Config, releaseCtx plugin.ReleaseContext) ([]string, error) {
 	args := []string{"deploy"}
 
 	// Add pom file path.
@@ -254,9 +990,50 @@ func (p *MavenPlugin) buildMavenCommand(cfg *Config) ([]string, error) {
 	}
 	args = append(args, "-f", pomPath)
 
+	// Restrict the reactor to a single module and skip aggregator-only modules.
+	projects, err := cfg.projectSelection(pomPath)
+	if err != nil {
+		return nil, err
+	}
+	if projects != "" {
+		args = append(args, "-pl", projects)
+	}
+
+	args = append(args, tychoArgs(cfg)...)
+	args = append(args, scalaArgs(cfg)...)
+
+	// Add transport timeouts.
+	if err := validateTransportTimeout(cfg.ConnectTimeout, "connect_timeout"); err != nil {
+		return nil, err
+	}
+	if err := validateTransportTimeout(cfg.ReadTimeout, "read_timeout"); err != nil {
+		return nil, err
+	}
+	args = append(args, transportArgs(cfg)...)
+	if err := validateChecksums(cfg.Checksums, cfg.targetsCentral(releaseCtx.Version)); err != nil {
+		return nil, fmt.Errorf("invalid checksums: %w", err)
+	}
+	args = append(args, checksumArgs(cfg)...)
+	args = append(args, gpgArgs(cfg)...)
+	args = append(args, deployAtEndArgs(cfg)...)
+
+	if err := validateRerunFailingTestsCount(cfg.RerunFailingTestsCount); err != nil {
+		return nil, err
+	}
+	if !cfg.SkipTests {
+		args = append(args, rerunArgs(cfg)...)
+	}
+	timestampArgs, err := outputTimestampArgs(cfg)
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, timestampArgs...)
+
 	// Add skip tests flag.
 	if cfg.SkipTests {
 		args = append(args, "-DskipTests")
+	} else if cfg.SkipITs {
+		args = append(args, "-DskipITs")
 	}
 
 	// Add settings file if specified.
@@ -265,7 +1042,16 @@ func (p *MavenPlugin) buildMavenCommand(cfg *Config) ([]string, error) {
 			return nil, fmt.Errorf("invalid settings path: %w", err)
 		}
 		args = append(args, "-s", cfg.Settings)
+	} else if path := cfg.userSettings(); path != "" {
+		args = append(args, "-s", path)
+	}
+	args = append(args, cfg.isolationArgs(true)...)
+
+	// Fail dependency resolution on checksum mismatches.
+	if cfg.StrictChecksums {
+		args = append(args, "--strict-checksums")
 	}
+	args = append(args, failureStrategyArgs(cfg)...)
 
 	// Add profiles if specified.
 	if len(cfg.Profiles) > 0 {
@@ -277,10 +1063,105 @@ func (p *MavenPlugin) buildMavenCommand(cfg *Config) ([]string, error) {
 		args = append(args, "-P", strings.Join(cfg.Profiles, ","))
 	}
 
+	// Describe the release to the build.
+	args = append(args, releasePropertyArgs(releaseCtx)...)
+	if cfg.ManifestMetadata {
+		entries, err := manifestEntries(releaseCtx, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, manifestPropertyArgs(entries)...)
+	}
+
+	// Add user properties and extra arguments.
+	names := make([]string, 0, len(cfg.Properties))
+	for name := range cfg.Properties {
+		if err := validatePropertyName(name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		args = append(args, fmt.Sprintf("-D%s=%s", name, cfg.Properties[name]))
+	}
+	if err := validatePassEnvProperties(cfg.PassEnvProperties, cfg.Properties); err != nil {
+		return nil, fmt.Errorf("invalid pass_env_properties: %w", err)
+	}
+	args = append(args, envPropertyArgs(cfg)...)
+	for _, arg := range cfg.ExtraArgs {
+		if err := validateExtraArg(arg); err != nil {
+			return nil, err
+		}
+	}
+	args = append(args, cfg.ExtraArgs...)
+
+	// Let the deploy plugin retry failed uploads.
+	if err := validateDeployRetries(cfg.DeployRetries); err != nil {
+		return nil, err
+	}
+	if cfg.DeployRetries > 0 {
+		args = append(args, fmt.Sprintf("-DretryFailedDeploymentCount=%d", cfg.DeployRetries))
+	}
+
+	// Point the deploy plugin at the configured repositories.
+	if cfg.Repository != "" && cfg.redirectsDeploy() {
+		args = append(args, fmt.Sprintf("-DaltReleaseDeploymentRepository=%s::%s", cfg.RepositoryID, cfg.Repository))
+	}
+	if cfg.SnapshotRepository != "" {
+		args = append(args, fmt.Sprintf("-DaltSnapshotDeploymentRepository=%s::%s", cfg.SnapshotRepositoryID, cfg.SnapshotRepository))
+	}
+
+	// Add Nexus staging properties.
+	if err := validateStagingProfileID(cfg.StagingProfileID); err != nil {
+		return nil, err
+	}
+	if err := validateStagingProgressTimeout(cfg.StagingProgressTimeout); err != nil {
+		return nil, err
+	}
+	args = append(args, stagingArgs(cfg, releaseCtx)...)
+
 	return args, nil
 }
 
-func (p *MavenPlugin) deploy(ctx context.Context, cfg *Config, releaseCtx plugin.ReleaseContext, dryRun bool) (*plugin.ExecuteResponse, error) {
+func (p *MavenPlugin) deploy(ctx context.Context, cfg *Config, releaseCtx plugin.ReleaseContext, dryRun bool) (resp *plugin.ExecuteResponse, err error) {
+	// Record the outcome in the audit log; refuse to deploy without one.
+	if cfg.AuditLog != "" || cfg.AuditOutput {
+		if cfg.AuditLog != "" {
+			if err := checkAuditLog(cfg.AuditLog); err != nil {
+				return &plugin.ExecuteResponse{
+					Success: false,
+					Error:   fmt.Sprintf("audit log is not writable: %v", err),
+				}, nil
+			}
+		}
+		defer func() {
+			if err == nil {
+				resp = cfg.audit(releaseCtx, dryRun, resp)
+			}
+		}()
+	}
+	// Write the deploy report, including failed deploys.
+	var maven *mavenInfo
+	if cfg.ReportFile != "" {
+		started := time.Now()
+		defer func() {
+			if err == nil {
+				resp = p.report(ctx, cfg, releaseCtx, dryRun, resp, maven, started)
+			}
+		}()
+	}
+	// Keep the complete Maven output for post-mortems, including failed deploys.
+	if !dryRun && cfg.Deployer != deployerHTTP {
+		log := &mavenLog{}
+		ctx = withMavenLog(ctx, log)
+		defer func() {
+			if err == nil {
+				resp = cfg.writeMavenLog(log, resp)
+			}
+		}()
+	}
+
 	// Validate coordinates.
 	if err := validateMavenCoordinate(cfg.GroupID, "group_id"); err != nil {
 		return &plugin.ExecuteResponse{
@@ -297,131 +1178,1826 @@ func (p *MavenPlugin) deploy(ctx context.Context, cfg *Config, releaseCtx plugin
 	}
 
 	// Validate repository URL if provided.
-	if err := validateRepositoryURL(cfg.Repository); err != nil {
+	if err := validateDeployRepositoryURL(cfg.Repository, cfg.networkPolicy(), cfg.FileRepositoryBase); err != nil {
 		return &plugin.ExecuteResponse{
 			Success: false,
 			Error:   fmt.Sprintf("invalid repository URL: %v", err),
 		}, nil
 	}
 
-	// Build the command arguments.
-	args, err := p.buildMavenCommand(cfg)
-	if err != nil {
+	if err := validateDeployRepositoryURL(cfg.SnapshotRepository, cfg.networkPolicy(), cfg.FileRepositoryBase); err != nil {
 		return &plugin.ExecuteResponse{
 			Success: false,
-			Error:   err.Error(),
+			Error:   fmt.Sprintf("invalid snapshot repository URL: %v", err),
 		}, nil
 	}
 
-	if dryRun {
+	// Validate server IDs.
+	if err := validateRepositoryID(cfg.RepositoryID, "repository_id"); err != nil {
 		return &plugin.ExecuteResponse{
-			Success: true,
-			Message: "Would deploy Maven artifact",
-			Outputs: map[string]any{
-				"group_id":    cfg.GroupID,
-				"artifact_id": cfg.ArtifactID,
-				"version":     releaseCtx.Version,
-				"pom_path":    cfg.PomPath,
-				"command":     "mvn " + strings.Join(args, " "),
-				"skip_tests":  cfg.SkipTests,
-				"profiles":    cfg.Profiles,
-			},
+			Success: false,
+			Error:   err.Error(),
 		}, nil
 	}
 
-	// Execute the Maven deploy command.
-	executor := p.getExecutor()
-	output, err := executor.Run(ctx, "mvn", args...)
-	if err != nil {
+	if err := validateRepositoryID(cfg.SnapshotRepositoryID, "snapshot_repository_id"); err != nil {
 		return &plugin.ExecuteResponse{
 			Success: false,
-			Error:   fmt.Sprintf("Maven deploy failed: %v\nOutput: %s", err, string(output)),
+			Error:   err.Error(),
 		}, nil
 	}
 
-	return &plugin.ExecuteResponse{
-		Success: true,
-		Message: fmt.Sprintf("Deployed Maven artifact %s:%s:%s", cfg.GroupID, cfg.ArtifactID, releaseCtx.Version),
-		Outputs: map[string]any{
-			"group_id":    cfg.GroupID,
-			"artifact_id": cfg.ArtifactID,
-			"version":     releaseCtx.Version,
-		},
-	}, nil
-}
+	// Promote the staged release; nothing is built.
+	if cfg.PromoteFrom != "" {
+		if err := validateRepositoryURL(cfg.PromoteFrom, cfg.networkPolicy()); err != nil {
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   fmt.Sprintf("invalid promote_from URL: %v", err),
+			}, nil
+		}
+		return p.promote(ctx, cfg, releaseCtx, dryRun)
+	}
 
-// parseConfig parses the raw config map into a Config struct.
-func (p *MavenPlugin) parseConfig(raw map[string]any) *Config {
-	parser := helpers.NewConfigParser(raw)
+	// Check the Maven installation and the JDK it uses.
+	if cfg.needsMavenInfo() {
+		info, err := p.checkMaven(ctx, cfg)
+		if err != nil {
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   err.Error(),
+			}, nil
+		}
+		maven = info
+		if cfg.CheckJava {
+			if err := checkJava(cfg, info); err != nil {
+				return &plugin.ExecuteResponse{
+					Success: false,
+					Error:   fmt.Sprintf("Java check failed: %v", err),
+				}, nil
+			}
+		}
+	}
 
-	pomPath := parser.GetString("pom_path", "", "")
-	if pomPath == "" {
-		pomPath = "pom.xml"
+	var warnings []string
+
+	// Render the declared JDK toolchains for every Maven run of the deploy.
+	if len(cfg.Toolchains) > 0 && cfg.Deployer != deployerHTTP {
+		if err := cfg.checkToolchains(); err != nil {
+			if !dryRun {
+				return &plugin.ExecuteResponse{
+					Success: false,
+					Error:   fmt.Sprintf("toolchains: %v", err),
+				}, nil
+			}
+			warnings = append(warnings, fmt.Sprintf("toolchains: %v", err))
+		}
+		path, cleanup, err := cfg.writeToolchains(dryRun)
+		if err != nil {
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   fmt.Sprintf("toolchains: %v", err),
+			}, nil
+		}
+		defer cleanup()
+		cfg = cfg.withToolchainsFile(path)
 	}
 
-	return &Config{
-		GroupID:    parser.GetString("group_id", "", ""),
-		ArtifactID: parser.GetString("artifact_id", "", ""),
-		PomPath:    pomPath,
-		Username:   parser.GetString("username", "MAVEN_USERNAME", ""),
-		Password:   parser.GetString("password", "MAVEN_PASSWORD", ""),
-		Repository: parser.GetString("repository", "", ""),
-		SkipTests:  parser.GetBool("skip_tests", false),
-		Settings:   parser.GetString("settings", "", ""),
-		Profiles:   parser.GetStringSlice("profiles", nil),
+	// Check that CI-friendly versions are flattened in the published POMs.
+	if cfg.FlattenCheck != policyOff && validatePath(cfg.PomPath) == nil {
+		if pom, err := readPOM(cfg.PomPath); err == nil {
+			if err := checkFlatten(pom); err != nil {
+				if cfg.FlattenCheck == policyFail {
+					return &plugin.ExecuteResponse{
+						Success: false,
+						Error:   fmt.Sprintf("flatten check: %v", err),
+					}, nil
+				}
+				warnings = append(warnings, fmt.Sprintf("flatten check: %v", err))
+			}
+		}
 	}
-}
 
-// Validate validates the plugin configuration.
-func (p *MavenPlugin) Validate(_ context.Context, config map[string]any) (*plugin.ValidateResponse, error) {
-	vb := helpers.NewValidationBuilder()
-	parser := helpers.NewConfigParser(config)
+	// Check that the published POMs carry the elements Central requires.
+	if cfg.CentralPOMCheck != policyOff && cfg.targetsCentral(releaseCtx.Version) && validatePath(cfg.PomPath) == nil {
+		if err := cfg.checkCentralPOMs(); err != nil {
+			if cfg.CentralPOMCheck == policyFail {
+				return &plugin.ExecuteResponse{
+					Success: false,
+					Error:   fmt.Sprintf("central POM check: %v", err),
+				}, nil
+			}
+			warnings = append(warnings, fmt.Sprintf("central POM check: %v", err))
+		}
+	}
 
-	// Validate group_id.
-	groupID := parser.GetString("group_id", "", "")
-	if groupID == "" {
-		vb.AddError("group_id", "Maven group ID is required")
-	} else if err := validateMavenCoordinate(groupID, "group_id"); err != nil {
-		vb.AddError("group_id", err.Error())
+	// Check that the build plugins have pinned versions. Dry runs read the
+	// POMs instead of running the enforcer.
+	if cfg.PluginVersionCheck != policyOff && validatePath(cfg.PomPath) == nil {
+		var err error
+		if cfg.PluginVersionEnforcer && !dryRun {
+			err = p.enforcePluginVersions(ctx, cfg)
+		} else {
+			err = cfg.checkPluginVersions()
+		}
+		if err != nil {
+			if cfg.PluginVersionCheck == policyFail {
+				return &plugin.ExecuteResponse{
+					Success: false,
+					Error:   fmt.Sprintf("plugin version check: %v", err),
+				}, nil
+			}
+			warnings = append(warnings, fmt.Sprintf("plugin version check: %v", err))
+		}
 	}
 
-	// Validate artifact_id.
-	artifactID := parser.GetString("artifact_id", "", "")
-	if artifactID == "" {
-		vb.AddError("artifact_id", "Maven artifact ID is required")
-	} else if err := validateMavenCoordinate(artifactID, "artifact_id"); err != nil {
-		vb.AddError("artifact_id", err.Error())
+	// Check that the build does not resolve from plain HTTP repositories
+	// before Maven blocks them halfway through.
+	if cfg.InsecureRepositoryCheck != policyOff && cfg.Deployer != deployerHTTP && validatePath(cfg.PomPath) == nil {
+		if err := cfg.checkInsecureRepositories(); err != nil {
+			if cfg.InsecureRepositoryCheck == policyFail {
+				return &plugin.ExecuteResponse{
+					Success: false,
+					Error:   fmt.Sprintf("insecure repository check: %v", err),
+				}, nil
+			}
+			warnings = append(warnings, fmt.Sprintf("insecure repository check: %v", err))
+		}
 	}
 
-	// Validate pom_path if provided.
-	pomPath := parser.GetString("pom_path", "", "pom.xml")
-	if err := validatePath(pomPath); err != nil {
-		vb.AddError("pom_path", err.Error())
+	// Check that every dependency converges on one version.
+	if cfg.DependencyConvergenceCheck != policyOff && !dryRun && validatePath(cfg.PomPath) == nil {
+		if err := p.checkDependencyConvergence(ctx, cfg); err != nil {
+			if cfg.DependencyConvergenceCheck == policyFail {
+				return &plugin.ExecuteResponse{
+					Success: false,
+					Error:   fmt.Sprintf("dependency convergence check: %v", err),
+				}, nil
+			}
+			warnings = append(warnings, fmt.Sprintf("dependency convergence check: %v", err))
+		}
 	}
 
-	// Validate repository URL if provided.
-	repository := parser.GetString("repository", "", "")
-	if repository != "" {
-		if err := validateRepositoryURL(repository); err != nil {
-			vb.AddError("repository", err.Error())
+	// Check that every source file carries the license header.
+	if cfg.LicenseHeaderCheck != policyOff && !dryRun && validatePath(cfg.PomPath) == nil {
+		if err := p.checkLicenseHeaders(ctx, cfg); err != nil {
+			if cfg.LicenseHeaderCheck == policyFail {
+				return &plugin.ExecuteResponse{
+					Success: false,
+					Error:   fmt.Sprintf("license header check: %v", err),
+				}, nil
+			}
+			warnings = append(warnings, fmt.Sprintf("license header check: %v", err))
 		}
 	}
 
-	// Validate settings path if provided.
-	settings := parser.GetString("settings", "", "")
-	if settings != "" {
-		if err := validatePath(settings); err != nil {
-			vb.AddError("settings", err.Error())
+	// Run the static analysis tools and enforce their thresholds.
+	var analysis []staticAnalysisResult
+	if len(cfg.StaticAnalysis) > 0 && !dryRun && validatePath(cfg.PomPath) == nil {
+		results, err := p.runStaticAnalysis(ctx, cfg)
+		if err != nil {
+			failure := &plugin.ExecuteResponse{
+				Success: false,
+				Error:   fmt.Sprintf("static analysis: %v", err),
+			}
+			if results != nil {
+				failure.Outputs = map[string]any{"static_analysis": results}
+			}
+			return failure, nil
 		}
+		analysis = results
 	}
 
-	// Validate profiles if provided.
-	profiles := parser.GetStringSlice("profiles", nil)
-	for _, profile := range profiles {
-		if err := validateProfile(profile); err != nil {
-			vb.AddError("profiles", fmt.Sprintf("invalid profile '%s': %s", profile, err.Error()))
+	// Check that javadoc jars published to Central are not empty stubs.
+	if cfg.JavadocCheck != policyOff && cfg.targetsCentral(releaseCtx.Version) && validatePath(cfg.PomPath) == nil {
+		if err := cfg.checkJavadocJars(releaseCtx.Version); err != nil {
+			if cfg.JavadocCheck == policyFail {
+				return &plugin.ExecuteResponse{
+					Success: false,
+					Error:   fmt.Sprintf("javadoc check: %v", err),
+				}, nil
+			}
+			warnings = append(warnings, fmt.Sprintf("javadoc check: %v", err))
 		}
 	}
 
-	return vb.Build(), nil
+	// Check that the deploy is not skipped by the POM or the command line.
+	var skippedModules []string
+	if cfg.DeploySkipCheck != policyOff && cfg.Deployer != deployerHTTP && validatePath(cfg.PomPath) == nil {
+		skipped, err := cfg.checkDeploySkip()
+		if err != nil {
+			if cfg.DeploySkipCheck == policyFail {
+				return &plugin.ExecuteResponse{
+					Success: false,
+					Error:   fmt.Sprintf("deploy skip check: %v", err),
+				}, nil
+			}
+			warnings = append(warnings, fmt.Sprintf("deploy skip check: %v", err))
+		}
+		skippedModules = skipped
+	}
+
+	// Enforce the coverage thresholds. Dry runs only check an existing report.
+	var coverage *coverageResult
+	if cfg.coverageGate() {
+		gateCfg := *cfg
+		gateCfg.CoverageRun = cfg.CoverageRun && !dryRun
+		result, err := p.checkCoverage(ctx, &gateCfg)
+		switch {
+		case err != nil && dryRun && result == nil:
+			warnings = append(warnings, fmt.Sprintf("coverage gate: %v", err))
+		case err != nil:
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   fmt.Sprintf("coverage gate: %v", err),
+			}, nil
+		}
+		coverage = result
+	}
+
+	// Check the built artifacts against the size limits.
+	if cfg.MaxArtifactSize > 0 || cfg.MaxUploadSize > 0 {
+		out, err := cfg.checkArtifactSize(releaseCtx.Version)
+		switch {
+		case err != nil && out != nil && cfg.SizeLimitPolicy == policyFail:
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   fmt.Sprintf("size limit: %v", err),
+			}, nil
+		case err != nil:
+			warnings = append(warnings, fmt.Sprintf("size limit: %v", err))
+		}
+	}
+
+	// Check that the build does not run out of disk space halfway through.
+	if cfg.MinFreeDisk > 0 && cfg.Deployer != deployerHTTP {
+		if err := cfg.checkDiskSpace(); err != nil {
+			if !dryRun {
+				return &plugin.ExecuteResponse{
+					Success: false,
+					Error:   fmt.Sprintf("disk space: %v", err),
+				}, nil
+			}
+			warnings = append(warnings, fmt.Sprintf("disk space: %v", err))
+		}
+	}
+
+	// Check the release version against already published versions.
+	if cfg.DowngradePolicy != policyOff {
+		if err := p.checkDowngrade(ctx, cfg, releaseCtx.Version); err != nil {
+			if cfg.DowngradePolicy == policyFail {
+				return &plugin.ExecuteResponse{
+					Success: false,
+					Error:   fmt.Sprintf("downgrade protection: %v", err),
+				}, nil
+			}
+			warnings = append(warnings, fmt.Sprintf("downgrade protection: %v", err))
+		}
+	}
+
+	// Select the signing key for the repository and artifact.
+	if cfg.Deployer != deployerHTTP {
+		resolved, err := cfg.resolveSigningKey(releaseCtx.Version)
+		if err != nil {
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   fmt.Sprintf("signing key: %v", err),
+			}, nil
+		}
+		cfg = resolved
+	}
+
+	// Check that Central can find the signing key.
+	if cfg.KeyserverCheck != policyOff && cfg.GPGKey != "" && cfg.Deployer != deployerHTTP && cfg.targetsCentral(releaseCtx.Version) {
+		if err := p.checkKeyserver(ctx, cfg); err != nil {
+			if cfg.KeyserverCheck == policyFail {
+				return &plugin.ExecuteResponse{
+					Success: false,
+					Error:   fmt.Sprintf("keyserver check: %v", err),
+				}, nil
+			}
+			warnings = append(warnings, fmt.Sprintf("keyserver check: %v", err))
+		}
+	}
+
+	// Sign without a pinentry prompt when gpg needs loopback mode.
+	if cfg.Deployer != deployerHTTP {
+		resolved, err := p.resolveGPGLoopback(ctx, cfg)
+		if err != nil {
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   fmt.Sprintf("gpg loopback detection: %v", err),
+			}, nil
+		}
+		cfg = resolved
+	}
+
+	// Pin the build timestamp to the release commit.
+	if cfg.Deployer != deployerHTTP {
+		resolved, err := p.resolveOutputTimestamp(ctx, cfg, releaseCtx.CommitSHA)
+		if err != nil {
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   fmt.Sprintf("output_timestamp: %v", err),
+			}, nil
+		}
+		cfg = resolved
+	}
+
+	// Deploy into a temporary directory that is archived instead of uploaded.
+	// The outputs still describe the repository the archive is imported into.
+	deployCfg := cfg
+	var exportDir string
+	if cfg.ExportArchive != "" && cfg.Deployer != deployerHTTP {
+		dir, cleanup, err := exportDirectory(dryRun)
+		if err != nil {
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   fmt.Sprintf("export archive: %v", err),
+			}, nil
+		}
+		defer cleanup()
+		exportDir = dir
+		deployCfg = cfg.withExportRepository(exportDir)
+	}
+	// Build a multi-repository deploy into its stage once; every repository
+	// is deployed from the staged files.
+	reuseStage := cfg.stage.isBuilt()
+	if cfg.stage != nil {
+		deployCfg = cfg.withExportRepository(cfg.stage.dir)
+	}
+
+	// Build the command arguments.
+	args, err := p.buildMavenCommand(deployCfg, releaseCtx)
+	if err != nil {
+		return &plugin.ExecuteResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+	invocations, err := cfg.mavenInvocations(args)
+	if err != nil {
+		return &plugin.ExecuteResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+
+	// deployAtEnd only holds back the modules of a single Maven run.
+	if cfg.DeployAtEnd && cfg.Deployer != deployerHTTP {
+		if len(invocations) > 1 {
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   fmt.Sprintf("deploy_at_end: packaging_goals splits the deploy into %d Maven runs, so a failure would leave earlier runs published", len(invocations)),
+			}, nil
+		}
+		warning, err := cfg.checkDeployAtEnd()
+		if err != nil {
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   fmt.Sprintf("deploy_at_end: %v", err),
+			}, nil
+		}
+		if warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
+
+	if dryRun {
+		outputs := map[string]any{
+			"group_id":    cfg.GroupID,
+			"artifact_id": cfg.ArtifactID,
+			"version":     releaseCtx.Version,
+			"pom_path":    cfg.PomPath,
+			"command":     cfg.commandLine(withHookGoals(cfg, invocations, args)),
+			"skip_tests":  cfg.SkipTests,
+			"skip_its":    cfg.SkipITs,
+			"profiles":    cfg.Profiles,
+		}
+		if cfg.OutputTimestamp != "" {
+			outputs["output_timestamp"] = cfg.OutputTimestamp
+		}
+		if cfg.GPGKey != "" {
+			outputs["signing_key"] = cfg.GPGKey
+		}
+		if len(cfg.NativeArtifacts) > 0 {
+			nativeArgs := nativeDeployArgs(deployCfg, releaseCtx.Version, cfg.NativeArtifacts, nativeSources(cfg.NativeArtifacts), cfg.userSettings())
+			outputs["native_command"] = cfg.mavenCommand() + " " + shellJoin(nativeArgs)
+		}
+		if exportDir != "" {
+			outputs["export_archive"] = cfg.ExportArchive
+		}
+		if cfg.Deployer == deployerHTTP {
+			outputs["deployer"] = deployerHTTP
+			if cfg.BundlePath != "" {
+				outputs["bundle_path"] = cfg.BundlePath
+			}
+			if files, err := cfg.httpDeployFiles(releaseCtx.Version); err != nil {
+				warnings = append(warnings, fmt.Sprintf("http deploy files not listed: %v", err))
+			} else {
+				var remote []string
+				for _, f := range files {
+					remote = append(remote, f.Remote)
+				}
+				outputs["upload_files"] = remote
+			}
+		}
+		if coverage != nil {
+			outputs["coverage"] = coverage
+		}
+		if cfg.Deployer != deployerHTTP {
+			if modules := cfg.moduleStatuses(releaseCtx.Version); len(modules) > 0 {
+				outputs["modules"] = modules
+			}
+			outputs["settings_source"] = cfg.dryRunSettingsSource()
+		}
+		if cfg.POMMetadata != nil {
+			if _, elements, err := cfg.patchedPOM(cfg.deployedPOM()); err != nil {
+				warnings = append(warnings, fmt.Sprintf("pom_metadata: %v", err))
+			} else if len(elements) > 0 {
+				outputs["pom_metadata_injected"] = elements
+			}
+		}
+		if cfg.UserToken {
+			if tokenURL, err := cfg.userTokenURL(); err == nil {
+				outputs["user_token_url"] = redactURL(tokenURL)
+			}
+		}
+		if cfg.Tycho && cfg.P2DeployURL != "" {
+			outputs["p2_site_url"] = redactURL(cfg.p2SiteURL(releaseCtx.Version))
+		}
+		warnings = append(warnings, addPreview(cfg, releaseCtx, outputs)...)
+		if len(warnings) > 0 {
+			outputs["warnings"] = warnings
+		}
+		if cfg.Rehearsal {
+			return p.rehearse(ctx, cfg, releaseCtx, outputs)
+		}
+		return &plugin.ExecuteResponse{
+			Success: true,
+			Message: "Would deploy Maven artifact",
+			Outputs: outputs,
+		}, nil
+	}
+
+	// Keep other pipelines from deploying the same GAV at the same time.
+	if cfg.DeployLock {
+		release, err := acquireDeployLock(ctx, cfg, mavenVersion(releaseCtx.Version))
+		if err != nil {
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   fmt.Sprintf("deploy lock: %v", err),
+			}, nil
+		}
+		defer release()
+	}
+
+	// Replace the raw credentials with the user's token for the deploy.
+	if cfg.UserToken {
+		token, err := p.fetchUserToken(ctx, cfg)
+		if err != nil {
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   fmt.Sprintf("user token exchange failed: %v", err),
+			}, nil
+		}
+		cfg = cfg.withUserToken(token)
+	}
+
+	settingsFile := cfg.userSettings()
+	_, settingsSource := cfg.settingsLookup()
+	progress := &reactorProgress{}
+	var upload *httpDeployResult
+	var injected []string
+	if cfg.Deployer == deployerHTTP {
+		// Upload the built files directly; no Maven installation is needed.
+		upload, err = p.httpDeploy(ctx, cfg, releaseCtx)
+		if err != nil {
+			// Keep a pending Central Portal deployment for the on-error hook to drop.
+			if upload != nil && upload.DeploymentID != "" && !upload.Dropped {
+				p.recordDeploy(deployRecord{
+					GroupID:      cfg.GroupID,
+					ArtifactID:   cfg.ArtifactID,
+					Version:      releaseCtx.Version,
+					DeploymentID: upload.DeploymentID,
+				})
+			}
+			resp := &plugin.ExecuteResponse{
+				Success: false,
+				Error:   fmt.Sprintf("HTTP deploy failed: %v", err),
+				Outputs: upload.outputs(),
+			}
+			// Tell the operator whether the interrupted upload left files behind.
+			if ctx.Err() != nil {
+				if resp.Outputs == nil {
+					resp.Outputs = map[string]any{}
+				}
+				resp.Outputs["interrupted"] = ctx.Err().Error()
+				resp.Outputs["cleanup_required"] = upload != nil && len(upload.Uploaded) > 0
+			}
+			return resp, nil
+		}
+		// Nothing was published; the bundle is uploaded manually.
+		if upload.BundlePath != "" {
+			outputs := upload.outputs()
+			outputs["group_id"] = cfg.GroupID
+			outputs["artifact_id"] = cfg.ArtifactID
+			outputs["version"] = releaseCtx.Version
+			if len(warnings) > 0 {
+				outputs["warnings"] = warnings
+			}
+			return &plugin.ExecuteResponse{
+				Success: true,
+				Message: fmt.Sprintf("Wrote Central bundle for %s:%s:%s to %s", cfg.GroupID, cfg.ArtifactID, releaseCtx.Version, upload.BundlePath),
+				Outputs: outputs,
+			}, nil
+		}
+	} else {
+		// Add the configured metadata to the project POM for the build.
+		if cfg.POMMetadata != nil && !reuseStage {
+			elements, restore, err := cfg.injectProjectPOM()
+			if err != nil {
+				return &plugin.ExecuteResponse{
+					Success: false,
+					Error:   fmt.Sprintf("pom_metadata: %v", err),
+				}, nil
+			}
+			defer func() { _ = restore() }()
+			injected = elements
+		}
+
+		// Render the repository credentials into a private settings file, merging
+		// them into the user's settings file when one is found. An isolated
+		// configuration always gets one so ~/.m2/settings.xml is not read.
+		if servers := cfg.settingsServers(); len(servers) > 0 || len(cfg.ResolutionRepositories) > 0 || (cfg.mavenConfigDir() != "" && settingsFile == "") {
+			data, err := cfg.resolveSettings(servers)
+			if err != nil {
+				return &plugin.ExecuteResponse{
+					Success: false,
+					Error:   err.Error(),
+				}, nil
+			}
+			settingsPath, cleanup, err := writeSettings(data)
+			if err != nil {
+				return &plugin.ExecuteResponse{
+					Success: false,
+					Error:   err.Error(),
+				}, nil
+			}
+			defer cleanup()
+			for i := range invocations {
+				invocations[i] = withSettingsFile(invocations[i], settingsPath)
+			}
+			settingsFile = settingsPath
+			if settingsSource == settingsSourceNone {
+				settingsSource = settingsSourceGenerated
+			}
+		}
+
+		// Run the project-specific goals before the deploy.
+		if pre := goalInvocation(cfg.PreGoals, args); pre != nil && !reuseStage {
+			if settingsFile != "" {
+				pre = withSettingsFile(pre, settingsFile)
+			}
+			if err := p.runGoals(ctx, cfg, pre); err != nil {
+				return &plugin.ExecuteResponse{
+					Success: false,
+					Error:   fmt.Sprintf("pre_goals failed: %v", err),
+				}, nil
+			}
+		}
+
+		// Nothing is built again once the stage holds the build.
+		switch {
+		case reuseStage:
+			invocations = nil
+		case cfg.stage != nil:
+			if err := cfg.stage.reset(); err != nil {
+				return &plugin.ExecuteResponse{
+					Success: false,
+					Error:   fmt.Sprintf("staging directory: %v", err),
+				}, nil
+			}
+		}
+
+		// Execute the Maven deploy commands.
+		for _, args := range invocations {
+			output, retryWarnings, err := p.runMavenWithRetries(ctx, cfg, progress, args)
+			warnings = append(warnings, retryWarnings...)
+			if err != nil {
+				// Put the failed tests above the Maven output.
+				reason := describeExecError(cfg.mavenCommand(), err)
+				if summary := testFailureSummary(string(output), cfg.testReportDirs()); summary != "" {
+					reason = summary + "\n" + reason
+				}
+				// List every failed module of a build that kept going.
+				if cfg.continuesAfterFailure() {
+					if summary := moduleFailureSummary(progress.Summary); summary != "" {
+						reason = summary + "\n" + reason
+					}
+				}
+				oomHint := outOfMemoryHint(cfg, string(output), err, ctx.Err() != nil)
+				if oomHint != "" {
+					reason = oomHint + "\n" + reason
+				}
+				resp := &plugin.ExecuteResponse{
+					Success: false,
+					Error:   fmt.Sprintf("Maven deploy failed: %s\nOutput: %s", reason, cfg.errorOutput(output)),
+					Outputs: map[string]any{},
+				}
+				if len(progress.Summary) > 0 {
+					resp.Outputs["reactor_summary"] = progress.Summary
+					if failed, _ := moduleFailures(progress.Summary); len(failed) > 0 {
+						resp.Outputs["failed_modules"] = failed
+					}
+				}
+				if oomHint != "" {
+					resp.Outputs["failure"] = failureOutOfMemory
+				}
+				// Report what was completed before the release was cancelled.
+				if ctx.Err() != nil {
+					resp.Outputs = interruptedOutputs(ctx, progress, string(output))
+					resp.Error = fmt.Sprintf("Maven deploy interrupted (%v): %s\nOutput: %s", ctx.Err(), interruptedSummary(resp.Outputs), cfg.errorOutput(output))
+				}
+				return resp, nil
+			}
+		}
+	}
+
+	rec := deployRecord{
+		GroupID:    cfg.GroupID,
+		ArtifactID: cfg.ArtifactID,
+		Version:    mavenVersion(releaseCtx.Version),
+		Repository: cfg.targetRepository(releaseCtx.Version),
+	}
+	if upload != nil {
+		rec.DeploymentID = upload.DeploymentID
+	}
+	if exportDir == "" {
+		p.recordDeploy(rec)
+	}
+
+	// Attach the platform-specific native artifacts to the deployed GAV.
+	if len(cfg.NativeArtifacts) > 0 && !reuseStage {
+		files, cleanup, err := p.fetchNatives(ctx, cfg.NativeArtifacts)
+		if err != nil {
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   fmt.Sprintf("Maven native artifact deploy failed: %v", err),
+			}, nil
+		}
+		defer cleanup()
+
+		nativeArgs := nativeDeployArgs(deployCfg, releaseCtx.Version, cfg.NativeArtifacts, files, settingsFile)
+		output, err := p.runMavenCommand(ctx, cfg, nativeArgs...)
+		if err != nil {
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   fmt.Sprintf("Maven native artifact deploy failed: %s\nOutput: %s", describeExecError(cfg.mavenCommand(), err), cfg.errorOutput(output)),
+			}, nil
+		}
+	}
+
+	// Deploy the staged build to the repository.
+	if cfg.stage != nil {
+		if !reuseStage {
+			cfg.stage.built = true
+		}
+		stageWarnings, err := p.deployStaged(ctx, cfg, progress, releaseCtx.Version, settingsFile)
+		warnings = append(warnings, stageWarnings...)
+		if err != nil {
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   fmt.Sprintf("Maven deploy failed: %v", err),
+			}, nil
+		}
+	}
+
+	// Run the project-specific goals after the deploy.
+	if post := goalInvocation(cfg.PostGoals, args); post != nil && cfg.Deployer != deployerHTTP {
+		if settingsFile != "" {
+			post = withSettingsFile(post, settingsFile)
+		}
+		if err := p.runGoals(ctx, cfg, post); err != nil {
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   fmt.Sprintf("post_goals failed after the deploy: %v", err),
+			}, nil
+		}
+	}
+
+	outputs := map[string]any{
+		"group_id":    cfg.GroupID,
+		"artifact_id": cfg.ArtifactID,
+		"version":     releaseCtx.Version,
+	}
+	// Nothing was published; the archive is imported manually.
+	if exportDir != "" {
+		files, err := writeExportArchive(cfg.ExportArchive, exportDir)
+		if err != nil {
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   fmt.Sprintf("export archive: %v", err),
+			}, nil
+		}
+		outputs["export_archive"] = cfg.ExportArchive
+		outputs["export_files"] = files
+	}
+	if len(progress.Summary) > 0 {
+		outputs["reactor_summary"] = progress.Summary
+		// With fail-never Maven succeeds even when modules failed.
+		if failed, _ := moduleFailures(progress.Summary); len(failed) > 0 {
+			outputs["failed_modules"] = failed
+			warnings = append(warnings, fmt.Sprintf("failure strategy: %s", moduleFailureSummary(progress.Summary)))
+		}
+	}
+	// Verify and publish the p2 repository of Tycho builds.
+	if cfg.Tycho {
+		if err := p.publishP2(ctx, cfg, releaseCtx.Version, outputs); err != nil {
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   fmt.Sprintf("p2 repository publishing failed: %v", err),
+				Outputs: outputs,
+			}, nil
+		}
+	}
+	// Compare the uploaded files with the local build output.
+	if cfg.VerifyChecksums {
+		switch {
+		case strings.HasSuffix(releaseCtx.Version, "-SNAPSHOT"):
+			warnings = append(warnings, "checksum verification skipped: snapshot files are renamed with a deploy timestamp")
+		case isCentralRepository(cfg.targetRepository(releaseCtx.Version)):
+			warnings = append(warnings, "checksum verification skipped: Maven Central serves files only after the release is published")
+		default:
+			verified, err := p.verifyRemoteChecksums(ctx, cfg, releaseCtx.Version)
+			outputs["verified_files"] = verified
+			if err != nil {
+				return &plugin.ExecuteResponse{
+					Success: false,
+					Error:   fmt.Sprintf("remote checksum verification failed: %v", err),
+					Outputs: outputs,
+				}, nil
+			}
+		}
+	}
+	// Check that the repository metadata lists the release.
+	if cfg.VerifyMetadata {
+		if isCentralRepository(cfg.targetRepository(releaseCtx.Version)) {
+			warnings = append(warnings, "metadata verification skipped: Maven Central updates maven-metadata.xml only after the release is published")
+		} else {
+			report, err := p.verifyMetadata(ctx, cfg, releaseCtx.Version)
+			if err != nil {
+				return &plugin.ExecuteResponse{
+					Success: false,
+					Error:   fmt.Sprintf("maven-metadata.xml verification failed: %v", err),
+					Outputs: outputs,
+				}, nil
+			}
+			outputs["metadata"] = report.outputs()
+		}
+	}
+	// Resolve the release from the deploy repository as a consumer would.
+	if cfg.VerifyResolution {
+		if isCentralRepository(cfg.targetRepository(releaseCtx.Version)) {
+			warnings = append(warnings, "resolution verification skipped: Maven Central serves files only after the release is published")
+		} else {
+			if err := p.verifyResolution(ctx, cfg, releaseCtx.Version); err != nil {
+				return &plugin.ExecuteResponse{
+					Success: false,
+					Error:   fmt.Sprintf("resolution verification failed: %v", err),
+					Outputs: outputs,
+				}, nil
+			}
+			outputs["resolution_verified"] = true
+		}
+	}
+	// Build a consumer of the release against the deploy repository.
+	if cfg.CanaryBuild {
+		if isCentralRepository(cfg.targetRepository(releaseCtx.Version)) {
+			warnings = append(warnings, "canary build skipped: Maven Central serves files only after the release is published")
+		} else {
+			if err := p.canaryBuild(ctx, cfg, releaseCtx.Version); err != nil {
+				return &plugin.ExecuteResponse{
+					Success: false,
+					Error:   fmt.Sprintf("canary build failed: %v", err),
+					Outputs: outputs,
+				}, nil
+			}
+			outputs["canary_build"] = true
+		}
+	}
+	// Run the integration tests against the released version.
+	if cfg.InvokerTests != "" {
+		if isCentralRepository(cfg.targetRepository(releaseCtx.Version)) {
+			warnings = append(warnings, "invoker tests skipped: Maven Central serves files only after the release is published")
+		} else {
+			results, err := p.runInvokerTests(ctx, cfg, releaseCtx.Version)
+			if results != nil {
+				outputs["invoker_results"] = results
+			}
+			if err != nil {
+				return &plugin.ExecuteResponse{
+					Success: false,
+					Error:   fmt.Sprintf("invoker tests failed: %v", err),
+					Outputs: outputs,
+				}, nil
+			}
+		}
+	}
+	if len(skippedModules) > 0 {
+		outputs["skipped_modules"] = skippedModules
+	}
+	// Summarize the tests run by the release build.
+	if cfg.Deployer != deployerHTTP && !cfg.SkipTests {
+		results, err := readTestReports(cfg.testReportDirs())
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("test reports not summarized: %v", err))
+		} else if results != nil {
+			outputs["test_results"] = results
+			if len(results.Flaky) > 0 {
+				outputs["flaky_tests"] = results.flakyTests()
+			}
+		}
+	}
+	// Report the upgrades available for the dependencies and build plugins.
+	if cfg.UpdatesReport {
+		report, err := p.reportUpdates(ctx, cfg)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("updates report failed: %v", err))
+		} else if report != nil {
+			outputs["available_updates"] = report
+		}
+	}
+	outputs["dependency_snippets"] = renderDependencySnippets([]*Config{cfg}, releaseCtx.Version)
+	if cfg.Deployer != deployerHTTP {
+		outputs["settings_source"] = settingsSource
+		if cfg.GPGKey != "" {
+			outputs["signing_key"] = cfg.GPGKey
+		}
+	}
+	if cfg.Deployer != deployerHTTP {
+		if modules := cfg.moduleStatuses(releaseCtx.Version); len(modules) > 0 {
+			outputs["modules"] = modules
+		}
+	}
+	if len(injected) > 0 {
+		outputs["pom_metadata_injected"] = injected
+	}
+	if len(analysis) > 0 {
+		outputs["static_analysis"] = analysis
+	}
+	if upload != nil {
+		for k, v := range upload.outputs() {
+			outputs[k] = v
+		}
+	}
+	if coverage != nil {
+		outputs["coverage"] = coverage
+	}
+	if cfg.ScalaVersion != "" {
+		outputs["scala_version"] = cfg.ScalaVersion
+	} else if len(cfg.ScalaVersions) > 0 {
+		outputs["scala_artifact_ids"] = cfg.scalaArtifactIDs()
+	}
+	if len(cfg.NativeArtifacts) > 0 {
+		var classifiers []string
+		for _, native := range cfg.NativeArtifacts {
+			classifiers = append(classifiers, native.Classifier)
+		}
+		outputs["native_artifacts"] = classifiers
+	}
+	if files := cfg.builtFiles(releaseCtx.Version); len(files) > 0 {
+		outputs["built_files"] = files
+	}
+	if artifacts := cfg.deployedArtifacts(releaseCtx.Version); len(artifacts) > 0 {
+		entries := make([]map[string]any, 0, len(artifacts))
+		for _, artifact := range artifacts {
+			entries = append(entries, artifact.output())
+		}
+		outputs["artifacts"] = entries
+	}
+	central := cfg.publishesToCentral(releaseCtx.Version)
+	if central {
+		for k, v := range centralLinks(cfg.GroupID, cfg.ArtifactID, releaseCtx.Version) {
+			outputs[k] = v
+		}
+	}
+	// Snapshot file names carry a deploy timestamp, so only release URLs are predictable.
+	if base := cfg.downloadBaseURL(releaseCtx.Version); base != "" && !strings.HasSuffix(releaseCtx.Version, "-SNAPSHOT") {
+		packaging := ""
+		if pom, err := readPOM(cfg.PomPath); err == nil {
+			packaging = pom.Packaging
+		}
+		outputs["artifact_urls"] = artifactURLs(base, cfg.GroupID, cfg.ArtifactID, releaseCtx.Version, packaging, cfg.ArtifactClassifiers, central)
+	}
+	if len(warnings) > 0 {
+		outputs["warnings"] = warnings
+	}
+
+	message := fmt.Sprintf("Deployed Maven artifact %s:%s:%s", cfg.GroupID, cfg.ArtifactID, releaseCtx.Version)
+	if exportDir != "" {
+		message = fmt.Sprintf("Wrote deployment archive for %s:%s:%s to %s", cfg.GroupID, cfg.ArtifactID, releaseCtx.Version, cfg.ExportArchive)
+	}
+	return &plugin.ExecuteResponse{
+		Success: true,
+		Message: message,
+		Outputs: outputs,
+	}, nil
+}
+
+// commandLine renders Maven invocations as a single shell command line.
+func (cfg *Config) commandLine(invocations [][]string) string {
+	commands := make([]string, 0, len(invocations))
+	for _, args := range invocations {
+		commands = append(commands, cfg.mavenCommand()+" "+shellJoin(cfg.maskEnvProperties(args)))
+	}
+	return strings.Join(commands, " && ")
+}
+
+// shellJoin joins arguments into a command line that can be pasted into a
+// POSIX shell, single-quoting arguments such as "-P !tests" that need it.
+func shellJoin(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if shellSafePattern.MatchString(arg) {
+			quoted[i] = arg
+		} else {
+			quoted[i] = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+	}
+	return strings.Join(quoted, " ")
+}
+
+// withSettingsFile points the Maven arguments at the given settings file,
+// replacing a settings file that is already set.
+func withSettingsFile(args []string, path string) []string {
+	for i := 0; i+1 < len(args); i++ {
+		if args[i] == "-s" {
+			args[i+1] = path
+			return args
+		}
+	}
+	return append(args, "-s", path)
+}
+
+// parseProperties parses the properties mapping from the raw configuration.
+func parseProperties(raw map[string]any) map[string]string {
+	entries, ok := raw["properties"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	properties := make(map[string]string, len(entries))
+	for name, value := range entries {
+		if s, ok := value.(string); ok {
+			properties[name] = s
+		}
+	}
+	return properties
+}
+
+// parseConfig parses the raw config map into a Config struct.
+func (p *MavenPlugin) parseConfig(raw map[string]any) *Config {
+	parser := helpers.NewConfigParser(raw)
+
+	pomPath := parser.GetString("pom_path", "", "")
+	if pomPath == "" {
+		pomPath = "pom.xml"
+	}
+
+	requiredJava := parser.GetString("required_java", "", "")
+
+	var autoRelease *bool
+	if parser.Has("auto_release") {
+		v := parser.GetBool("auto_release", false)
+		autoRelease = &v
+	}
+
+	var centralLinks *bool
+	if parser.Has("central_links") {
+		v := parser.GetBool("central_links", false)
+		centralLinks = &v
+	}
+
+	return &Config{
+		GroupID:    parser.GetString("group_id", "", ""),
+		ArtifactID: parser.GetString("artifact_id", "", ""),
+		PomPath:    pomPath,
+		Username:   parser.GetString("username", "MAVEN_USERNAME", ""),
+		Password:   parser.GetString("password", "MAVEN_PASSWORD", ""),
+		Repository: parser.GetString("repository", "", ""),
+		SkipTests:  parser.GetBool("skip_tests", false),
+		Settings:   parser.GetString("settings", "", ""),
+		Profiles:   parser.GetStringSlice("profiles", nil),
+
+		SkipITs:                parser.GetBool("skip_its", false),
+		StrictChecksums:        parser.GetBool("strict_checksums", false),
+		FailureStrategy:        parser.GetString("failure_strategy", "", ""),
+		HeartbeatInterval:      parser.GetInt("heartbeat_interval", defaultHeartbeatInterval),
+		MaxHeap:                parser.GetString("max_heap", "", ""),
+		RerunFailingTestsCount: parser.GetInt("surefire_rerun_failing_tests_count", 0),
+		ManifestMetadata:       parser.GetBool("manifest_metadata", false),
+		OutputTimestamp:        parser.GetString("output_timestamp", "", ""),
+
+		RepositoryID:         parser.GetString("repository_id", "", defaultRepositoryID),
+		SnapshotRepository:   parser.GetString("snapshot_repository", "", ""),
+		SnapshotRepositoryID: parser.GetString("snapshot_repository_id", "", defaultSnapshotRepositoryID),
+		FileRepositoryBase:   parser.GetString("file_repository_base", "", ""),
+		ExportArchive:        parser.GetString("export_archive", "", ""),
+		SnapshotUsername:     parser.GetString("snapshot_username", "MAVEN_SNAPSHOT_USERNAME", ""),
+		SnapshotPassword:     parser.GetString("snapshot_password", "MAVEN_SNAPSHOT_PASSWORD", ""),
+		UserToken:            parser.GetBool("user_token", false),
+		UserTokenURL:         parser.GetString("user_token_url", "", ""),
+		redirectDeploy:       parser.Has("repository_id") || parser.Has("snapshot_repository"),
+
+		snapshotRepositoryIDSet:  parser.Has("snapshot_repository_id"),
+		MergeSettingsCredentials: parser.GetBool("merge_settings_credentials", false),
+
+		StagingProfileID:       parser.GetString("staging_profile_id", "", ""),
+		StagingProgressTimeout: parser.GetInt("staging_progress_timeout", 0),
+		AutoRelease:            autoRelease,
+
+		ConnectTimeout: parser.GetInt("connect_timeout", 0),
+		ReadTimeout:    parser.GetInt("read_timeout", 0),
+		DeployRetries:  parser.GetInt("deploy_retries", 0),
+		BuildRetries:   parser.GetInt("build_retries", 0),
+		DeployAtEnd:    parser.GetBool("deploy_at_end", false),
+		InstallAtEnd:   parser.GetBool("install_at_end", false),
+
+		RepositoryType:  parser.GetString("repository_type", "", repositoryTypeGeneric),
+		RollbackOnError: parser.GetBool("rollback_on_error", false),
+		PromoteFrom:     parser.GetString("promote_from", "", ""),
+
+		DowngradePolicy: parser.GetString("downgrade_policy", "", policyOff),
+		FlattenCheck:    parser.GetString("flatten_check", "", policyFail),
+		DeploySkipCheck: parser.GetString("deploy_skip_check", "", policyFail),
+		JavadocCheck:    parser.GetString("javadoc_check", "", policyFail),
+		CentralPOMCheck: parser.GetString("central_pom_check", "", policyFail),
+		POMMetadata:     parsePOMMetadata(raw),
+
+		PluginVersionCheck:    parser.GetString("plugin_version_check", "", policyOff),
+		PluginVersionEnforcer: parser.GetBool("plugin_version_enforcer", false),
+
+		InsecureRepositoryCheck:    parser.GetString("insecure_repository_check", "", policyWarn),
+		DependencyConvergenceCheck: parser.GetString("dependency_convergence_check", "", policyOff),
+
+		LicenseHeaderCheck: parser.GetString("license_header_check", "", policyOff),
+		LicenseHeader:      parser.GetString("license_header", "", ""),
+		StaticAnalysis:     parseStaticAnalysis(raw),
+
+		DeployLock:     parser.GetBool("deploy_lock", false),
+		LockDir:        parser.GetString("lock_dir", "", ""),
+		LockWait:       parser.GetInt("lock_wait", 0),
+		LockStaleAfter: parser.GetInt("lock_stale_after", defaultLockStaleAfter),
+
+		AuditLog:    parser.GetString("audit_log", "", ""),
+		AuditOutput: parser.GetBool("audit_output", false),
+		ReportFile:  parser.GetString("report_file", "", ""),
+		MavenLog:    parser.GetString("maven_log", "", ""),
+
+		MaxErrorOutputBytes: parser.GetInt("max_error_output_bytes", defaultMaxErrorOutputBytes),
+
+		VerifyChecksums:  parser.GetBool("verify_checksums", false),
+		Checksums:        parser.GetStringSlice("checksums", nil),
+		VerifyMetadata:   parser.GetBool("verify_metadata", false),
+		CanaryBuild:      parser.GetBool("canary_build", false),
+		VerifyResolution: parser.GetBool("verify_resolution", false),
+		InvokerTests:     parser.GetString("invoker_tests", "", ""),
+		UpdatesReport:    parser.GetBool("updates_report", false),
+
+		MaxArtifactSize: parseSizeOption(raw, "max_artifact_size"),
+		MaxUploadSize:   parseSizeOption(raw, "max_upload_size"),
+		SizeLimitPolicy: parser.GetString("size_limit_policy", "", policyFail),
+		MinFreeDisk:     parseSizeOption(raw, "min_free_disk"),
+
+		CoverageMinLine:   parser.GetFloat("coverage_min_line", 0),
+		CoverageMinBranch: parser.GetFloat("coverage_min_branch", 0),
+		CoverageReport:    parser.GetString("coverage_report", "", ""),
+		CoverageRun:       parser.GetBool("coverage_run", false),
+
+		MavenExecutable: parser.GetString("maven_executable", "", mavenExecutableMvn),
+		MinMavenVersion: parser.GetString("min_maven_version", "", ""),
+		MavenUserHome:   parser.GetString("maven_user_home", "", ""),
+		MavenConfig:     parser.GetString("maven_config", "", ""),
+		Toolchains:      parseToolchains(raw),
+
+		GPGLoopback:      parser.GetString("gpg_loopback", "", gpgLoopbackAuto),
+		GPGPassphraseEnv: parser.GetString("gpg_passphrase_env", "", defaultGPGPassphraseEnv),
+		GPGExecutable:    parser.GetString("gpg_executable", "", defaultGPGExecutable),
+		GPGKey:           normalizeFingerprint(parser.GetString("gpg_key", "", "")),
+		GPGKeys:          parseSigningKeys(raw),
+		KeyserverCheck:   parser.GetString("keyserver_check", "", policyFail),
+		Keyservers:       parser.GetStringSlice("keyservers", defaultKeyservers),
+		CheckJava:        parser.GetBool("check_java", false) || requiredJava != "",
+		RequiredJava:     requiredJava,
+
+		CheckPOM:        parser.GetBool("check_pom", false),
+		CheckSettings:   parser.GetBool("check_settings", true),
+		DNSPolicy:       parser.GetString("dns_policy", "", dnsPolicyStrict),
+		AllowedNetworks: parser.GetStringSlice("allowed_networks", nil),
+		BlockedNetworks: parser.GetStringSlice("blocked_networks", nil),
+
+		Artifacts:   parseArtifacts(raw),
+		Parallel:    parser.GetBool("parallel", false),
+		MaxParallel: parser.GetInt("max_parallel", defaultMaxParallel),
+
+		Properties: parseProperties(raw),
+		ExtraArgs:  parser.GetStringSlice("extra_args", nil),
+
+		PassEnvProperties: parsePassEnvProperties(raw),
+
+		SkipAggregators: parser.GetBool("skip_aggregators", false),
+		PackagingGoals:  parsePackagingGoals(raw),
+		PreGoals:        parser.GetStringSlice("pre_goals", nil),
+		PostGoals:       parser.GetStringSlice("post_goals", nil),
+
+		ScalaVersions:        parser.GetStringSlice("scala_versions", nil),
+		ScalaVersionProperty: parser.GetString("scala_version_property", "", ""),
+
+		Tycho:            parser.GetBool("tycho", false),
+		P2RepositoryPath: parser.GetString("p2_repository_path", "", ""),
+		P2DeployURL:      parser.GetString("p2_deploy_url", "", ""),
+
+		EnvOverrides: envOverrides(parser),
+		Rehearsal:    parser.GetBool("rehearsal", false),
+
+		DependencyDiff: parser.GetBool("dependency_diff", false),
+		TagPrefix:      parser.GetString("tag_prefix", "", ""),
+
+		InstallationNotes: parser.GetBool("installation_notes", false),
+
+		CentralLinks:        centralLinks,
+		ArtifactClassifiers: parser.GetStringSlice("artifact_classifiers", defaultArtifactClassifiers),
+
+		BOMUpdates:      parseBOMUpdates(raw),
+		PropertyUpdates: parsePropertyUpdates(raw),
+
+		NativeArtifacts: parseNativeArtifacts(raw),
+
+		Deployer:           parser.GetString("deployer", "", deployerMaven),
+		UploadRetries:      parser.GetInt("upload_retries", defaultUploadRetries),
+		CentralPortalURL:   parser.GetString("central_portal_url", "", ""),
+		CentralWaitTimeout: parser.GetInt("central_wait_timeout", defaultCentralWaitTimeout),
+		CentralDropFailed:  parser.GetBool("central_drop_failed", true),
+		BundlePath:         parser.GetString("bundle_path", "", ""),
+
+		Repositories:           parseRepositoryTargets(raw),
+		ResolutionRepositories: parseResolutionRepositories(raw),
+	}
+}
+
+// Validate validates the plugin configuration and the configuration of every
+// hook with overrides.
+func (p *MavenPlugin) Validate(ctx context.Context, config map[string]any) (*plugin.ValidateResponse, error) {
+	// The hooks section may come from the shared defaults.
+	if merged, err := withDefaults(config); err == nil {
+		config = merged
+	}
+	return p.validateHooks(ctx, config)
+}
+
+// validateConfig validates the configuration of a single hook.
+func (p *MavenPlugin) validateConfig(ctx context.Context, config map[string]any) (*plugin.ValidateResponse, error) {
+	vb := helpers.NewValidationBuilder()
+
+	// Shared defaults are validated together with the pipeline configuration.
+	if merged, err := withDefaults(config); err != nil {
+		vb.AddError("defaults_file", err.Error())
+	} else {
+		config = merged
+	}
+
+	// Environment variable references must resolve.
+	config, unset := interpolateConfig(config)
+	for _, option := range unsetOptions(unset) {
+		vb.AddError(option, unsetEnvError(unset[option]).Error())
+	}
+
+	// Templates are checked against placeholder values of the release context.
+	for _, option := range templatedOptions {
+		if _, err := expandValue(config[option], validationVariables); err != nil {
+			vb.AddError(option, err.Error())
+		}
+	}
+	if expanded, err := expandConfig(config, validationVariables); err == nil {
+		config = expanded
+	}
+	parser := helpers.NewConfigParser(config)
+
+	// The network policy applies to every repository URL below.
+	vb.ValidateOneOf(config, "dns_policy", dnsPolicies)
+	allowedNetworks, err := parseNetworks(parser.GetStringSlice("allowed_networks", nil))
+	if err != nil {
+		vb.AddError("allowed_networks", err.Error())
+	}
+	blockedNetworks, err := parseNetworks(parser.GetStringSlice("blocked_networks", nil))
+	if err != nil {
+		vb.AddError("blocked_networks", err.Error())
+	}
+	policy := networkPolicy{
+		DNS:     parser.GetString("dns_policy", "", dnsPolicyStrict),
+		Allowed: allowedNetworks,
+		Blocked: blockedNetworks,
+	}
+
+	// Coordinates may come from the artifacts list instead of the top level.
+	multiArtifact := parser.Has("artifacts")
+
+	// Validate group_id.
+	groupID := parser.GetString("group_id", "", "")
+	if groupID == "" {
+		if !multiArtifact {
+			vb.AddError("group_id", "Maven group ID is required")
+		}
+	} else if err := validateMavenCoordinate(groupID, "group_id"); err != nil {
+		vb.AddError("group_id", err.Error())
+	}
+
+	// Validate artifact_id.
+	artifactID := parser.GetString("artifact_id", "", "")
+	if artifactID == "" {
+		if !multiArtifact {
+			vb.AddError("artifact_id", "Maven artifact ID is required")
+		}
+	} else if err := validateMavenCoordinate(artifactID, "artifact_id"); err != nil {
+		vb.AddError("artifact_id", err.Error())
+	}
+
+	// Validate the artifacts of a multi-artifact release.
+	if multiArtifact {
+		validateArtifacts(vb, config, p.parseConfig(config))
+	}
+
+	// Validate the targets of a multi-repository deploy.
+	multiRepository := parser.Has("repositories")
+	if multiRepository {
+		validateRepositoryTargets(vb, config, policy)
+	}
+	for i, repository := range parseResolutionRepositories(config) {
+		if field, err := validateResolutionRepository(repository); err != nil {
+			vb.AddError(fmt.Sprintf("resolution_repositories[%d].%s", i, field), err.Error())
+		}
+	}
+
+	// Validate pom_path if provided.
+	pomPath := parser.GetString("pom_path", "", "pom.xml")
+	if err := validatePath(pomPath); err != nil {
+		vb.AddError("pom_path", err.Error())
+	} else if parser.GetBool("check_pom", false) {
+		if err := checkPOMFile(pomPath); err != nil {
+			vb.AddError("pom_path", err.Error())
+		}
+	}
+
+	// Validate repository URL if provided.
+	repository := parser.GetString("repository", "", "")
+	if repository != "" && !isFileRepository(repository) {
+		if err := validateRepositoryURL(repository, policy); err != nil {
+			vb.AddError("repository", err.Error())
+		}
+	}
+
+	// Validate snapshot repository URL if provided.
+	snapshotRepository := parser.GetString("snapshot_repository", "", "")
+	if snapshotRepository != "" && !isFileRepository(snapshotRepository) {
+		if err := validateRepositoryURL(snapshotRepository, policy); err != nil {
+			vb.AddError("snapshot_repository", err.Error())
+		}
+	}
+	validateFileRepositories(vb, config)
+	validateExportArchive(vb, config)
+
+	// Validate server IDs.
+	if err := validateRepositoryID(parser.GetString("repository_id", "", defaultRepositoryID), "repository_id"); err != nil {
+		vb.AddError("repository_id", err.Error())
+	}
+	if err := validateRepositoryID(parser.GetString("snapshot_repository_id", "", defaultSnapshotRepositoryID), "snapshot_repository_id"); err != nil {
+		vb.AddError("snapshot_repository_id", err.Error())
+	}
+
+	// Validate staging options.
+	if err := validateStagingProfileID(parser.GetString("staging_profile_id", "", "")); err != nil {
+		vb.AddError("staging_profile_id", err.Error())
+	}
+	if err := validateStagingProgressTimeout(parser.GetInt("staging_progress_timeout", 0)); err != nil {
+		vb.AddError("staging_progress_timeout", err.Error())
+	}
+
+	if err := validateOutputTimestamp(parser.GetString("output_timestamp", "", "")); err != nil {
+		vb.AddError("output_timestamp", err.Error())
+	}
+	if err := validateRerunFailingTestsCount(parser.GetInt("surefire_rerun_failing_tests_count", 0)); err != nil {
+		vb.AddError("surefire_rerun_failing_tests_count", err.Error())
+	}
+
+	// Validate transport timeouts.
+	for _, field := range []string{"connect_timeout", "read_timeout"} {
+		if err := validateTransportTimeout(parser.GetInt(field, 0), field); err != nil {
+			vb.AddError(field, err.Error())
+		}
+	}
+
+	if parser.GetInt("lock_wait", 0) < 0 {
+		vb.AddError("lock_wait", "lock_wait cannot be negative")
+	}
+	if parser.GetInt("lock_stale_after", defaultLockStaleAfter) < 1 {
+		vb.AddError("lock_stale_after", "lock_stale_after must be at least 1")
+	}
+	if err := validateDeployRetries(parser.GetInt("deploy_retries", 0)); err != nil {
+		vb.AddError("deploy_retries", err.Error())
+	}
+	if err := validateBuildRetries(parser.GetInt("build_retries", 0)); err != nil {
+		vb.AddError("build_retries", err.Error())
+	}
+	if err := validateMaxHeap(parser.GetString("max_heap", "", "")); err != nil {
+		vb.AddError("max_heap", err.Error())
+	}
+	if parser.GetInt("max_error_output_bytes", defaultMaxErrorOutputBytes) < 0 {
+		vb.AddError("max_error_output_bytes", "max_error_output_bytes cannot be negative")
+	}
+	if err := validateHeartbeatInterval(parser.GetInt("heartbeat_interval", defaultHeartbeatInterval)); err != nil {
+		vb.AddError("heartbeat_interval", err.Error())
+	}
+	if err := validateMaxParallel(parser.GetInt("max_parallel", defaultMaxParallel)); err != nil {
+		vb.AddError("max_parallel", err.Error())
+	}
+	if parser.GetBool("deploy_at_end", false) {
+		if parser.GetString("deployer", "", deployerMaven) == deployerHTTP {
+			vb.AddError("deploy_at_end", "deploy_at_end requires the maven deployer")
+		}
+		if arg := failureModeArg(parser.GetStringSlice("extra_args", nil)); arg != "" {
+			vb.AddError("deploy_at_end", fmt.Sprintf("deploy_at_end cannot be combined with %s: the modules built before a failure would still be deployed", arg))
+		}
+		if strategy := parser.GetString("failure_strategy", "", ""); strategy == failureStrategyFailAtEnd || strategy == failureStrategyFailNever {
+			vb.AddError("deploy_at_end", fmt.Sprintf("deploy_at_end cannot be combined with failure_strategy %s: the modules built before a failure would still be deployed", strategy))
+		}
+	}
+	vb.ValidateOneOf(config, "failure_strategy", failureStrategies)
+	if parser.GetString("failure_strategy", "", "") != "" {
+		if arg := failureFlagArg(parser.GetStringSlice("extra_args", nil)); arg != "" {
+			vb.AddError("failure_strategy", fmt.Sprintf("failure_strategy cannot be combined with %s in extra_args", arg))
+		}
+	}
+
+	// Validate repository type and rollback.
+	vb.ValidateOneOf(config, "repository_type", repositoryTypes)
+	if parser.GetBool("rollback_on_error", false) && !multiRepository {
+		repoType := parser.GetString("repository_type", "", repositoryTypeGeneric)
+		target := repository
+		if target == "" {
+			target = snapshotRepository
+		}
+		if err := validateRollback(repoType, target); err != nil {
+			vb.AddError("rollback_on_error", err.Error())
+		}
+	}
+	if promoteFrom := parser.GetString("promote_from", "", ""); promoteFrom != "" {
+		if err := validateRepositoryURL(promoteFrom, policy); err != nil {
+			vb.AddError("promote_from", fmt.Sprintf("invalid promote_from URL: %v", err))
+		}
+		if !multiRepository {
+			repoType := parser.GetString("repository_type", "", repositoryTypeGeneric)
+			target := repository
+			if target == "" {
+				target = snapshotRepository
+			}
+			if err := validatePromotion(repoType, target); err != nil {
+				vb.AddError("promote_from", err.Error())
+			}
+		}
+	}
+
+	// Validate downgrade protection.
+	vb.ValidateOneOf(config, "downgrade_policy", checkPolicies)
+	vb.ValidateOneOf(config, "flatten_check", checkPolicies)
+	vb.ValidateOneOf(config, "deploy_skip_check", checkPolicies)
+	vb.ValidateOneOf(config, "keyserver_check", checkPolicies)
+	for _, keyserver := range parser.GetStringSlice("keyservers", nil) {
+		if err := validateKeyserver(keyserver); err != nil {
+			vb.AddError("keyservers", err.Error())
+		}
+	}
+	vb.ValidateOneOf(config, "javadoc_check", checkPolicies)
+	vb.ValidateOneOf(config, "central_pom_check", checkPolicies)
+	vb.ValidateOneOf(config, "plugin_version_check", checkPolicies)
+	vb.ValidateOneOf(config, "insecure_repository_check", checkPolicies)
+	vb.ValidateOneOf(config, "dependency_convergence_check", checkPolicies)
+	vb.ValidateOneOf(config, "license_header_check", checkPolicies)
+	if header := parser.GetString("license_header", "", ""); header != "" {
+		if err := validatePath(header); err != nil {
+			vb.AddError("license_header", fmt.Sprintf("invalid license_header: %v", err))
+		} else if parser.GetString("license_header_check", "", policyOff) == policyOff {
+			vb.AddError("license_header", "license_header requires license_header_check")
+		}
+	}
+	if _, ok := config["pom_metadata"]; ok {
+		validatePOMMetadata(vb, config)
+	}
+
+	if err := validateChecksums(parser.GetStringSlice("checksums", nil), isCentralRepository(repository)); err != nil {
+		vb.AddError("checksums", err.Error())
+	}
+	if parser.GetBool("verify_checksums", false) && repository == "" && snapshotRepository == "" && !multiRepository {
+		vb.AddError("verify_checksums", "checksum verification requires a repository URL")
+	}
+	if parser.GetBool("verify_metadata", false) && repository == "" && snapshotRepository == "" && !multiRepository {
+		vb.AddError("verify_metadata", "metadata verification requires a repository URL")
+	}
+	if parser.GetBool("canary_build", false) && repository == "" && snapshotRepository == "" && !multiRepository {
+		vb.AddError("canary_build", "the canary build requires a repository URL")
+	}
+	if parser.GetBool("verify_resolution", false) && repository == "" && snapshotRepository == "" && !multiRepository {
+		vb.AddError("verify_resolution", "resolution verification requires a repository URL")
+	}
+	if err := validatePath(parser.GetString("report_file", "", "")); err != nil {
+		vb.AddError("report_file", fmt.Sprintf("invalid report_file: %v", err))
+	}
+	if err := validatePath(parser.GetString("maven_log", "", "")); err != nil {
+		vb.AddError("maven_log", fmt.Sprintf("invalid maven_log: %v", err))
+	}
+	if invokerTests := parser.GetString("invoker_tests", "", ""); invokerTests != "" {
+		if err := validatePath(invokerTests); err != nil {
+			vb.AddError("invoker_tests", fmt.Sprintf("invalid invoker_tests: %v", err))
+		}
+		if repository == "" && snapshotRepository == "" && !multiRepository {
+			vb.AddError("invoker_tests", "invoker tests require a repository URL")
+		}
+	}
+
+	// Validate the size limits.
+	for _, field := range []string{"max_artifact_size", "max_upload_size", "min_free_disk"} {
+		if err := validateSizeOption(config, field); err != nil {
+			vb.AddError(field, err.Error())
+		}
+	}
+	vb.ValidateOneOf(config, "size_limit_policy", []string{policyWarn, policyFail})
+
+	// Validate the coverage gate.
+	for _, field := range []string{"coverage_min_line", "coverage_min_branch"} {
+		if err := validateCoverageThreshold(parser.GetFloat(field, 0)); err != nil {
+			vb.AddError(field, err.Error())
+		}
+	}
+	if report := parser.GetString("coverage_report", "", ""); report != "" {
+		if err := validatePath(report); err != nil {
+			vb.AddError("coverage_report", err.Error())
+		}
+	}
+	if (parser.Has("coverage_report") || parser.GetBool("coverage_run", false)) &&
+		parser.GetFloat("coverage_min_line", 0) == 0 && parser.GetFloat("coverage_min_branch", 0) == 0 {
+		vb.AddError("coverage_min_line", "coverage_report and coverage_run require coverage_min_line or coverage_min_branch")
+	}
+	if policy := parser.GetString("downgrade_policy", "", policyOff); policy != policyOff && repository == "" && snapshotRepository == "" && !multiRepository {
+		vb.AddError("downgrade_policy", "downgrade protection requires a repository URL")
+	}
+
+	// Validate the isolated Maven configuration.
+	if parser.GetString("maven_user_home", "", "") != "" && parser.GetString("maven_config", "", "") != "" {
+		vb.AddError("maven_config", "maven_config cannot be combined with maven_user_home")
+	}
+	for _, field := range []string{"maven_user_home", "maven_config"} {
+		if path := parser.GetString(field, "", ""); path != "" {
+			if err := validateMavenConfigDir(path); err != nil {
+				vb.AddError(field, err.Error())
+			}
+		}
+	}
+
+	// Validate the signing options.
+	vb.ValidateOneOf(config, "gpg_loopback", gpgLoopbackModes)
+	if env := parser.GetString("gpg_passphrase_env", "", ""); env != "" && !envVarPattern.MatchString(env) {
+		vb.AddError("gpg_passphrase_env", fmt.Sprintf("invalid environment variable name %q", env))
+	}
+	if key := parser.GetString("gpg_key", "", ""); key != "" {
+		if err := validateFingerprint(normalizeFingerprint(key)); err != nil {
+			vb.AddError("gpg_key", err.Error())
+		}
+	}
+	for i, key := range parseSigningKeys(config) {
+		if field, err := validateSigningKey(key); err != nil {
+			vb.AddError(fmt.Sprintf("gpg_keys[%d].%s", i, field), err.Error())
+		}
+	}
+
+	// Validate the declared JDK toolchains.
+	if parser.Has("toolchains") {
+		if _, ok := config["toolchains"].([]any); !ok {
+			vb.AddError("toolchains", "toolchains must be a list of objects")
+		}
+		seen := map[string]bool{}
+		for i, toolchain := range parseToolchains(config) {
+			if field, err := validateToolchain(toolchain); err != nil {
+				vb.AddError(fmt.Sprintf("toolchains[%d].%s", i, field), err.Error())
+			} else if seen[toolchain.Version+"/"+toolchain.Vendor] {
+				vb.AddError(fmt.Sprintf("toolchains[%d].version", i), fmt.Sprintf("duplicate toolchain for JDK %s %s", toolchain.Version, toolchain.Vendor))
+			}
+			seen[toolchain.Version+"/"+toolchain.Vendor] = true
+		}
+	}
+
+	// Validate the Maven installation and JDK when checks are requested.
+	vb.ValidateOneOf(config, "maven_executable", []string{mavenExecutableMvn, mavenExecutableWrapper})
+	minMavenVersion := parser.GetString("min_maven_version", "", "")
+	minVersionErr := validateMinVersion(minMavenVersion, "min_maven_version")
+	if minVersionErr != nil {
+		vb.AddError("min_maven_version", minVersionErr.Error())
+	}
+	requiredJava := parser.GetString("required_java", "", "")
+	requiredJavaErr := validateRequiredJava(requiredJava)
+	if requiredJavaErr != nil {
+		vb.AddError("required_java", requiredJavaErr.Error())
+	}
+	if minVersionErr == nil && requiredJavaErr == nil {
+		cfg := &Config{
+			PomPath:         pomPath,
+			MavenExecutable: parser.GetString("maven_executable", "", mavenExecutableMvn),
+			MinMavenVersion: minMavenVersion,
+			CheckJava:       parser.GetBool("check_java", false) || requiredJava != "",
+			RequiredJava:    requiredJava,
+		}
+		if cfg.needsMavenInfo() {
+			info, err := p.checkMaven(ctx, cfg)
+			switch {
+			case info == nil:
+				vb.AddError("maven_executable", err.Error())
+			case err != nil:
+				vb.AddError("min_maven_version", err.Error())
+			case cfg.CheckJava:
+				if err := checkJava(cfg, info); err != nil {
+					vb.AddError("required_java", err.Error())
+				}
+			}
+		}
+	}
+
+	// Validate settings path if provided.
+	settings := parser.GetString("settings", "", "")
+	if settings != "" {
+		if err := validatePath(settings); err != nil {
+			vb.AddError("settings", err.Error())
+		} else if parser.GetBool("check_settings", true) {
+			p.validateSettingsServers(vb, config, settings)
+		}
+	}
+
+	// Validate the Scala cross versions.
+	if scalaVersions := parser.GetStringSlice("scala_versions", nil); len(scalaVersions) > 0 {
+		if err := validateScalaVersions(scalaVersions, artifactID); err != nil {
+			vb.AddError("scala_versions", err.Error())
+		}
+	}
+	if scalaProperty := parser.GetString("scala_version_property", "", ""); scalaProperty != "" {
+		if !versionPropertyPattern.MatchString(scalaProperty) {
+			vb.AddError("scala_version_property", fmt.Sprintf("invalid property name %q", scalaProperty))
+		}
+		if len(parser.GetStringSlice("scala_versions", nil)) == 0 {
+			vb.AddError("scala_version_property", "scala_version_property requires scala_versions")
+		}
+	}
+
+	// Validate the Tycho options.
+	if p2Path := parser.GetString("p2_repository_path", "", ""); p2Path != "" {
+		if err := validatePath(p2Path); err != nil {
+			vb.AddError("p2_repository_path", err.Error())
+		}
+	}
+	if p2URL := parser.GetString("p2_deploy_url", "", ""); p2URL != "" {
+		if err := validateRepositoryURL(p2URL, policy); err != nil {
+			vb.AddError("p2_deploy_url", err.Error())
+		}
+	}
+	if !parser.GetBool("tycho", false) && (parser.Has("p2_repository_path") || parser.Has("p2_deploy_url")) {
+		vb.AddError("tycho", "p2_repository_path and p2_deploy_url require tycho to be enabled")
+	}
+
+	// Validate user properties and extra arguments.
+	if parser.Has("properties") {
+		entries, ok := config["properties"].(map[string]any)
+		if !ok {
+			vb.AddError("properties", "properties must map property names to values")
+		}
+		for name, value := range entries {
+			if err := validatePropertyName(name); err != nil {
+				vb.AddError("properties."+name, err.Error())
+			} else if _, ok := value.(string); !ok {
+				vb.AddError("properties."+name, "property value must be a string")
+			}
+		}
+	}
+	for i, arg := range parser.GetStringSlice("extra_args", nil) {
+		if err := validateExtraArg(arg); err != nil {
+			vb.AddError(fmt.Sprintf("extra_args[%d]", i), err.Error())
+		}
+	}
+	if parser.Has("pass_env_properties") {
+		switch config["pass_env_properties"].(type) {
+		case []any, map[string]any:
+			if err := validatePassEnvProperties(parsePassEnvProperties(config), parseProperties(config)); err != nil {
+				vb.AddError("pass_env_properties", err.Error())
+			}
+		default:
+			vb.AddError("pass_env_properties", "pass_env_properties must be a list of environment variables or map them to property names")
+		}
+	}
+
+	// Validate per-packaging goals.
+	if parser.Has("packaging_goals") {
+		entries, ok := config["packaging_goals"].(map[string]any)
+		if !ok {
+			vb.AddError("packaging_goals", "packaging_goals must map packaging types to lists of goals")
+		}
+		for packaging, goals := range entries {
+			field := fmt.Sprintf("packaging_goals.%s", packaging)
+			if err := validateMavenCoordinate(packaging, "packaging"); err != nil {
+				vb.AddError(field, err.Error())
+			} else if _, ok := goals.([]any); !ok {
+				vb.AddError(field, "goals must be a list of strings")
+			} else if err := validateGoals(parsePackagingGoals(config)[packaging]); err != nil {
+				vb.AddError(field, err.Error())
+			}
+		}
+	}
+
+	// Validate the goals run before and after the deploy.
+	for _, field := range []string{"pre_goals", "post_goals"} {
+		if !parser.Has(field) {
+			continue
+		}
+		if _, ok := config[field].([]any); !ok {
+			vb.AddError(field, fmt.Sprintf("%s must be a list of strings", field))
+		} else if err := validateGoals(parser.GetStringSlice(field, nil)); err != nil {
+			vb.AddError(field, err.Error())
+		}
+	}
+
+	// Validate native artifacts.
+	if parser.Has("native_artifacts") {
+		if _, ok := config["native_artifacts"].([]any); !ok {
+			vb.AddError("native_artifacts", "native_artifacts must be a list of objects")
+		}
+		seen := map[string]bool{}
+		for i, native := range parseNativeArtifacts(config) {
+			if field, err := validateNativeArtifact(native, policy); err != nil {
+				vb.AddError(fmt.Sprintf("native_artifacts[%d].%s", i, field), err.Error())
+			} else if seen[native.Classifier+"."+native.Type] {
+				vb.AddError(fmt.Sprintf("native_artifacts[%d].classifier", i), fmt.Sprintf("duplicate classifier %q", native.Classifier))
+			}
+			seen[native.Classifier+"."+native.Type] = true
+		}
+		if repository == "" && snapshotRepository == "" && !multiRepository {
+			vb.AddError("native_artifacts", "native artifacts require a repository URL")
+		}
+	}
+
+	// Validate the deployer.
+	vb.ValidateOneOf(config, "deployer", deployers)
+	if parser.GetString("deployer", "", deployerMaven) == deployerHTTP {
+		for _, field := range []string{"native_artifacts", "packaging_goals", "tycho", "coverage_run", "min_maven_version", "check_java", "deploy_retries", "build_retries", "max_heap", "plugin_version_enforcer", "updates_report", "pre_goals", "post_goals", "export_archive", "toolchains", "maven_log"} {
+			if parser.Has(field) && config[field] != false {
+				vb.AddError(field, fmt.Sprintf("%s requires the maven deployer", field))
+			}
+		}
+		for _, field := range []string{"dependency_convergence_check", "license_header_check"} {
+			if parser.GetString(field, "", policyOff) != policyOff {
+				vb.AddError(field, fmt.Sprintf("%s requires the maven deployer", field))
+			}
+		}
+		if parser.Has("static_analysis") {
+			vb.AddError("static_analysis", "static_analysis requires the maven deployer")
+		}
+		portalURL := parser.GetString("central_portal_url", "", "")
+		bundlePath := parser.GetString("bundle_path", "", "")
+		if bundlePath != "" {
+			if err := validatePath(bundlePath); err != nil {
+				vb.AddError("bundle_path", err.Error())
+			} else if !strings.HasSuffix(bundlePath, ".zip") {
+				vb.AddError("bundle_path", "bundle_path must name a .zip file")
+			}
+			if portalURL != "" {
+				vb.AddError("bundle_path", "bundle_path cannot be combined with central_portal_url")
+			}
+		} else if portalURL != "" {
+			if err := validateRepositoryURL(portalURL, policy); err != nil {
+				vb.AddError("central_portal_url", err.Error())
+			}
+		} else if repository == "" && snapshotRepository == "" && !multiRepository {
+			vb.AddError("deployer", "the http deployer requires a repository URL, central_portal_url or bundle_path")
+		}
+	} else if parser.Has("central_portal_url") {
+		vb.AddError("central_portal_url", "central_portal_url requires the http deployer")
+	} else if parser.Has("bundle_path") {
+		vb.AddError("bundle_path", "bundle_path requires the http deployer")
+	}
+	if parser.GetInt("upload_retries", defaultUploadRetries) < 1 {
+		vb.AddError("upload_retries", "upload_retries must be at least 1")
+	}
+	if err := validateCentralWaitTimeout(parser.GetInt("central_wait_timeout", defaultCentralWaitTimeout)); err != nil {
+		vb.AddError("central_wait_timeout", err.Error())
+	}
+
+	// Validate downstream BOM updates.
+	if parser.Has("bom_updates") {
+		if _, ok := config["bom_updates"].([]any); !ok {
+			vb.AddError("bom_updates", "bom_updates must be a list of objects")
+		}
+		for i, update := range parseBOMUpdates(config) {
+			if field, err := validateBOMUpdate(update); err != nil {
+				vb.AddError(fmt.Sprintf("bom_updates[%d].%s", i, field), err.Error())
+			}
+		}
+	}
+
+	// Validate the project property updates.
+	if parser.Has("property_updates") {
+		if _, ok := config["property_updates"].([]any); !ok {
+			vb.AddError("property_updates", "property_updates must be a list of objects")
+		}
+		for i, update := range parsePropertyUpdates(config) {
+			if field, err := validatePropertyUpdate(update); err != nil {
+				vb.AddError(fmt.Sprintf("property_updates[%d].%s", i, field), err.Error())
+			}
+		}
+	}
+
+	// Validate the static analysis tools.
+	if parser.Has("static_analysis") {
+		if _, ok := config["static_analysis"].([]any); !ok {
+			vb.AddError("static_analysis", "static_analysis must be a list of objects")
+		}
+		seen := map[string]bool{}
+		for i, tool := range parseStaticAnalysis(config) {
+			if field, err := validateStaticAnalysis(tool); err != nil {
+				vb.AddError(fmt.Sprintf("static_analysis[%d].%s", i, field), err.Error())
+			} else if seen[tool.Tool] {
+				vb.AddError(fmt.Sprintf("static_analysis[%d].tool", i), fmt.Sprintf("duplicate tool %q", tool.Tool))
+			}
+			seen[tool.Tool] = true
+		}
+	}
+
+	// Validate profiles if provided.
+	profiles := parser.GetStringSlice("profiles", nil)
+	for _, profile := range profiles {
+		if err := validateProfile(profile); err != nil {
+			vb.AddError("profiles", fmt.Sprintf("invalid profile '%s': %s", profile, err.Error()))
+		}
+	}
+
+	// The user token endpoint is contacted with the raw credentials.
+	if tokenURL := parser.GetString("user_token_url", "", ""); tokenURL != "" {
+		if err := validateRepositoryURL(tokenURL, policy); err != nil {
+			vb.AddError("user_token_url", err.Error())
+		}
+	}
+	if parser.GetBool("user_token", false) {
+		if parser.GetString("deployer", "", deployerMaven) == deployerHTTP && parser.Has("central_portal_url") {
+			vb.AddError("user_token", "user_token is not supported for the Central Portal; generate a Portal token instead")
+		} else if repository == "" && snapshotRepository == "" && !multiRepository && !parser.Has("user_token_url") {
+			vb.AddError("user_token", "user_token requires a repository or user_token_url")
+		}
+	}
+
+	// Check that the repositories are reachable with the configured credentials.
+	if parser.GetBool("deep_validate", false) {
+		p.deepValidate(ctx, vb, p.parseConfig(config))
+	}
+
+	return vb.Build(), nil
+}
+
+// validateSettingsServers checks that a user-supplied settings file has server
+// entries for the explicitly configured repository IDs. Servers the plugin injects
+// from its own credentials count as present.
+func (p *MavenPlugin) validateSettingsServers(vb *helpers.ValidationBuilder, config map[string]any, settingsPath string) {
+	parser := helpers.NewConfigParser(config)
+	cfg := p.parseConfig(config)
+
+	injected := map[string]bool{}
+	for _, server := range cfg.settingsServers() {
+		injected[server.ID] = true
+	}
+
+	checks := map[string]string{}
+	if parser.Has("repository_id") && !injected[cfg.RepositoryID] {
+		checks["repository_id"] = cfg.RepositoryID
+	}
+	if parser.Has("snapshot_repository_id") && !injected[cfg.SnapshotRepositoryID] {
+		checks["snapshot_repository_id"] = cfg.SnapshotRepositoryID
+	}
+	if len(checks) == 0 {
+		return
+	}
+
+	settings, err := readSettings(settingsPath)
+	if err != nil {
+		vb.AddError("settings", err.Error())
+		return
+	}
+	for _, field := range []string{"repository_id", "snapshot_repository_id"} {
+		id, ok := checks[field]
+		if !ok {
+			continue
+		}
+		if err := checkServerCredentials(settings, id); err != nil {
+			vb.AddError(field, err.Error())
+		}
+	}
 }
@@ -4,8 +4,10 @@ package main
 import (
 	"context"
 	"errors"
+	"net"
 	"os"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
@@ -15,6 +17,8 @@ import (
 type MockCommandExecutor struct {
 	RunFunc func(ctx context.Context, name string, args ...string) ([]byte, error)
 	Calls   []MockCall
+
+	mu sync.Mutex
 }
 
 // MockCall records a call to the executor.
@@ -25,13 +29,30 @@ type MockCall struct {
 
 // Run implements CommandExecutor.
 func (m *MockCommandExecutor) Run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	m.mu.Lock()
 	m.Calls = append(m.Calls, MockCall{Name: name, Args: args})
+	m.mu.Unlock()
 	if m.RunFunc != nil {
 		return m.RunFunc(ctx, name, args...)
 	}
 	return []byte("success"), nil
 }
 
+// TestMain runs the tests in a scratch workspace, where deploys write their
+// Maven logs.
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "plugin-maven-test-")
+	if err != nil {
+		panic(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		panic(err)
+	}
+	code := m.Run()
+	_ = os.RemoveAll(dir)
+	os.Exit(code)
+}
+
 func TestGetInfo(t *testing.T) {
 	p := &MavenPlugin{}
 	info := p.GetInfo()
@@ -499,7 +520,7 @@ func TestExecuteDryRun(t *testing.T) {
 			expectedArtifactID: "my-app",
 			expectedVersion:    "v1.2.3",
 			expectedPomPath:    "pom.xml",
-			expectedCommand:    "mvn deploy -f pom.xml",
+			expectedCommand:    "mvn deploy -f pom.xml -Drelicta.version=1.2.3 -Drelicta.prerelease=false",
 		},
 		{
 			name: "with custom pom path",
@@ -515,7 +536,7 @@ func TestExecuteDryRun(t *testing.T) {
 			expectedArtifactID: "my-lib",
 			expectedVersion:    "v2.0.0",
 			expectedPomPath:    "module/pom.xml",
-			expectedCommand:    "mvn deploy -f module/pom.xml",
+			expectedCommand:    "mvn deploy -f module/pom.xml -Drelicta.version=2.0.0 -Drelicta.prerelease=false",
 		},
 		{
 			name: "with skip tests",
@@ -531,7 +552,7 @@ func TestExecuteDryRun(t *testing.T) {
 			expectedArtifactID: "my-app",
 			expectedVersion:    "v1.0.0",
 			expectedPomPath:    "pom.xml",
-			expectedCommand:    "mvn deploy -f pom.xml -DskipTests",
+			expectedCommand:    "mvn deploy -f pom.xml -DskipTests -Drelicta.version=1.0.0 -Drelicta.prerelease=false",
 		},
 		{
 			name: "with settings file",
@@ -547,7 +568,7 @@ func TestExecuteDryRun(t *testing.T) {
 			expectedArtifactID: "my-app",
 			expectedVersion:    "v1.0.0",
 			expectedPomPath:    "pom.xml",
-			expectedCommand:    "mvn deploy -f pom.xml -s custom-settings.xml",
+			expectedCommand:    "mvn deploy -f pom.xml -s custom-settings.xml -Drelicta.version=1.0.0 -Drelicta.prerelease=false",
 		},
 		{
 			name: "with profiles",
@@ -563,7 +584,7 @@ func TestExecuteDryRun(t *testing.T) {
 			expectedArtifactID: "my-app",
 			expectedVersion:    "v1.0.0",
 			expectedPomPath:    "pom.xml",
-			expectedCommand:    "mvn deploy -f pom.xml -P release,gpg-sign",
+			expectedCommand:    "mvn deploy -f pom.xml -P release,gpg-sign -Drelicta.version=1.0.0 -Drelicta.prerelease=false",
 		},
 		{
 			name: "with all options",
@@ -583,7 +604,7 @@ func TestExecuteDryRun(t *testing.T) {
 			expectedArtifactID: "sample-artifact",
 			expectedVersion:    "v0.1.0",
 			expectedPomPath:    "custom/pom.xml",
-			expectedCommand:    "mvn deploy -f custom/pom.xml -DskipTests -s .mvn/settings.xml -P ossrh,sign",
+			expectedCommand:    "mvn deploy -f custom/pom.xml -DskipTests -s .mvn/settings.xml -P ossrh,sign -Drelicta.version=0.1.0 -Drelicta.prerelease=false",
 		},
 	}
 
@@ -735,7 +756,7 @@ func TestExecuteWithMockExecutor(t *testing.T) {
 			executorFunc: func(_ context.Context, _ string, _ ...string) ([]byte, error) {
 				return []byte("[INFO] BUILD SUCCESS"), nil
 			},
-			expectedArgs: []string{"deploy", "-f", "pom.xml"},
+			expectedArgs: []string{"deploy", "-f", "pom.xml", "-Drelicta.version=1.0.0", "-Drelicta.prerelease=false"},
 			wantSuccess:  true,
 		},
 		{
@@ -751,7 +772,56 @@ func TestExecuteWithMockExecutor(t *testing.T) {
 			executorFunc: func(_ context.Context, _ string, _ ...string) ([]byte, error) {
 				return []byte("[INFO] BUILD SUCCESS"), nil
 			},
-			expectedArgs: []string{"deploy", "-f", "pom.xml", "-DskipTests"},
+			expectedArgs: []string{"deploy", "-f", "pom.xml", "-DskipTests", "-Drelicta.version=1.0.0", "-Drelicta.prerelease=false"},
+			wantSuccess:  true,
+		},
+		{
+			name: "deploy with skip integration tests",
+			config: map[string]any{
+				"group_id":    "com.example",
+				"artifact_id": "my-app",
+				"skip_its":    true,
+			},
+			releaseCtx: plugin.ReleaseContext{
+				Version: "v1.0.0",
+			},
+			executorFunc: func(_ context.Context, _ string, _ ...string) ([]byte, error) {
+				return []byte("[INFO] BUILD SUCCESS"), nil
+			},
+			expectedArgs: []string{"deploy", "-f", "pom.xml", "-DskipITs", "-Drelicta.version=1.0.0", "-Drelicta.prerelease=false"},
+			wantSuccess:  true,
+		},
+		{
+			name: "deploy with strict checksums",
+			config: map[string]any{
+				"group_id":         "com.example",
+				"artifact_id":      "my-app",
+				"strict_checksums": true,
+			},
+			releaseCtx: plugin.ReleaseContext{
+				Version: "v1.0.0",
+			},
+			executorFunc: func(_ context.Context, _ string, _ ...string) ([]byte, error) {
+				return []byte("[INFO] BUILD SUCCESS"), nil
+			},
+			expectedArgs: []string{"deploy", "-f", "pom.xml", "--strict-checksums", "-Drelicta.version=1.0.0", "-Drelicta.prerelease=false"},
+			wantSuccess:  true,
+		},
+		{
+			name: "skip tests covers integration tests",
+			config: map[string]any{
+				"group_id":    "com.example",
+				"artifact_id": "my-app",
+				"skip_tests":  true,
+				"skip_its":    true,
+			},
+			releaseCtx: plugin.ReleaseContext{
+				Version: "v1.0.0",
+			},
+			executorFunc: func(_ context.Context, _ string, _ ...string) ([]byte, error) {
+				return []byte("[INFO] BUILD SUCCESS"), nil
+			},
+			expectedArgs: []string{"deploy", "-f", "pom.xml", "-DskipTests", "-Drelicta.version=1.0.0", "-Drelicta.prerelease=false"},
 			wantSuccess:  true,
 		},
 		{
@@ -767,7 +837,7 @@ func TestExecuteWithMockExecutor(t *testing.T) {
 			executorFunc: func(_ context.Context, _ string, _ ...string) ([]byte, error) {
 				return []byte("[INFO] BUILD SUCCESS"), nil
 			},
-			expectedArgs: []string{"deploy", "-f", "pom.xml", "-s", "custom-settings.xml"},
+			expectedArgs: []string{"deploy", "-f", "pom.xml", "-s", "custom-settings.xml", "-Drelicta.version=1.0.0", "-Drelicta.prerelease=false"},
 			wantSuccess:  true,
 		},
 		{
@@ -783,7 +853,7 @@ func TestExecuteWithMockExecutor(t *testing.T) {
 			executorFunc: func(_ context.Context, _ string, _ ...string) ([]byte, error) {
 				return []byte("[INFO] BUILD SUCCESS"), nil
 			},
-			expectedArgs: []string{"deploy", "-f", "pom.xml", "-P", "release,sign"},
+			expectedArgs: []string{"deploy", "-f", "pom.xml", "-P", "release,sign", "-Drelicta.version=1.0.0", "-Drelicta.prerelease=false"},
 			wantSuccess:  true,
 		},
 		{
@@ -817,7 +887,7 @@ func TestExecuteWithMockExecutor(t *testing.T) {
 			executorFunc: func(_ context.Context, _ string, _ ...string) ([]byte, error) {
 				return []byte("[INFO] BUILD SUCCESS"), nil
 			},
-			expectedArgs: []string{"deploy", "-f", "submodule/pom.xml", "-DskipTests", "-s", ".mvn/settings.xml", "-P", "ossrh,gpg"},
+			expectedArgs: []string{"deploy", "-f", "submodule/pom.xml", "-DskipTests", "-s", ".mvn/settings.xml", "-P", "ossrh,gpg", "-Drelicta.version=2.0.0", "-Drelicta.prerelease=false"},
 			wantSuccess:  true,
 		},
 	}
@@ -1154,6 +1224,17 @@ func TestValidateProfile(t *testing.T) {
 			profile: "jdk21",
 			wantErr: false,
 		},
+		{
+			name:    "deactivated profile",
+			profile: "!integration-tests",
+			wantErr: false,
+		},
+		{
+			name:    "profile with misplaced bang",
+			profile: "integration!",
+			wantErr: true,
+			errMsg:  "alphanumeric with dashes or underscores",
+		},
 		{
 			name:    "empty profile",
 			profile: "",
@@ -1200,6 +1281,7 @@ func TestValidateRepositoryURL(t *testing.T) {
 	tests := []struct {
 		name    string
 		url     string
+		policy  string
 		wantErr bool
 		errMsg  string
 	}{
@@ -1235,11 +1317,44 @@ func TestValidateRepositoryURL(t *testing.T) {
 			wantErr: true,
 			errMsg:  "only HTTPS URLs are allowed",
 		},
+		{
+			name:    "private IP literal",
+			url:     "https://10.0.0.5/repository",
+			wantErr: true,
+			errMsg:  "private networks",
+		},
+		{
+			name:    "unresolvable host with strict policy",
+			url:     "https://repo.invalid/maven2",
+			wantErr: true,
+			errMsg:  "failed to resolve hostname",
+		},
+		{
+			name:   "unresolvable host with offline policy",
+			url:    "https://repo.invalid/maven2",
+			policy: dnsPolicyOffline,
+		},
+		{
+			name:   "unresolvable host with skip_dns policy",
+			url:    "https://repo.invalid/maven2",
+			policy: dnsPolicySkipDNS,
+		},
+		{
+			name:    "private IP literal with skip_dns policy",
+			url:     "https://192.168.1.10/repository",
+			policy:  dnsPolicySkipDNS,
+			wantErr: true,
+			errMsg:  "private networks",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validateRepositoryURL(tt.url)
+			policy := tt.policy
+			if policy == "" {
+				policy = dnsPolicyStrict
+			}
+			err := validateRepositoryURL(tt.url, networkPolicy{DNS: policy})
 			if tt.wantErr {
 				if err == nil {
 					t.Errorf("expected error, got nil")
@@ -1268,7 +1383,7 @@ func TestBuildMavenCommand(t *testing.T) {
 			config: &Config{
 				PomPath: "pom.xml",
 			},
-			expectedArgs: []string{"deploy", "-f", "pom.xml"},
+			expectedArgs: []string{"deploy", "-f", "pom.xml", "-Drelicta.version=1.0.0", "-Drelicta.prerelease=false"},
 			wantErr:      false,
 		},
 		{
@@ -1277,7 +1392,7 @@ func TestBuildMavenCommand(t *testing.T) {
 				PomPath:   "pom.xml",
 				SkipTests: true,
 			},
-			expectedArgs: []string{"deploy", "-f", "pom.xml", "-DskipTests"},
+			expectedArgs: []string{"deploy", "-f", "pom.xml", "-DskipTests", "-Drelicta.version=1.0.0", "-Drelicta.prerelease=false"},
 			wantErr:      false,
 		},
 		{
@@ -1286,7 +1401,7 @@ func TestBuildMavenCommand(t *testing.T) {
 				PomPath:  "pom.xml",
 				Settings: "custom-settings.xml",
 			},
-			expectedArgs: []string{"deploy", "-f", "pom.xml", "-s", "custom-settings.xml"},
+			expectedArgs: []string{"deploy", "-f", "pom.xml", "-s", "custom-settings.xml", "-Drelicta.version=1.0.0", "-Drelicta.prerelease=false"},
 			wantErr:      false,
 		},
 		{
@@ -1295,7 +1410,7 @@ func TestBuildMavenCommand(t *testing.T) {
 				PomPath:  "pom.xml",
 				Profiles: []string{"release", "sign"},
 			},
-			expectedArgs: []string{"deploy", "-f", "pom.xml", "-P", "release,sign"},
+			expectedArgs: []string{"deploy", "-f", "pom.xml", "-P", "release,sign", "-Drelicta.version=1.0.0", "-Drelicta.prerelease=false"},
 			wantErr:      false,
 		},
 		{
@@ -1306,9 +1421,49 @@ func TestBuildMavenCommand(t *testing.T) {
 				Settings:  ".mvn/settings.xml",
 				Profiles:  []string{"ossrh", "gpg"},
 			},
-			expectedArgs: []string{"deploy", "-f", "submodule/pom.xml", "-DskipTests", "-s", ".mvn/settings.xml", "-P", "ossrh,gpg"},
+			expectedArgs: []string{"deploy", "-f", "submodule/pom.xml", "-DskipTests", "-s", ".mvn/settings.xml", "-P", "ossrh,gpg", "-Drelicta.version=1.0.0", "-Drelicta.prerelease=false"},
 			wantErr:      false,
 		},
+		{
+			name: "with release and snapshot repositories",
+			config: &Config{
+				PomPath:              "pom.xml",
+				Repository:           "https://repo.example.com/releases",
+				RepositoryID:         "internal",
+				SnapshotRepository:   "https://repo.example.com/snapshots",
+				SnapshotRepositoryID: "internal-snapshots",
+			},
+			expectedArgs: []string{
+				"deploy", "-f", "pom.xml", "-Drelicta.version=1.0.0", "-Drelicta.prerelease=false",
+				"-DaltReleaseDeploymentRepository=internal::https://repo.example.com/releases",
+				"-DaltSnapshotDeploymentRepository=internal-snapshots::https://repo.example.com/snapshots",
+			},
+			wantErr: false,
+		},
+		{
+			name: "repository without repository_id keeps the POM's distributionManagement",
+			config: &Config{
+				PomPath:      "pom.xml",
+				Repository:   "https://repo.example.com/releases",
+				RepositoryID: "releases",
+			},
+			expectedArgs: []string{"deploy", "-f", "pom.xml", "-Drelicta.version=1.0.0", "-Drelicta.prerelease=false"},
+			wantErr:      false,
+		},
+		{
+			name: "repository with repository_id",
+			config: &Config{
+				PomPath:        "pom.xml",
+				Repository:     "https://repo.example.com/releases",
+				RepositoryID:   "internal",
+				redirectDeploy: true,
+			},
+			expectedArgs: []string{
+				"deploy", "-f", "pom.xml", "-Drelicta.version=1.0.0", "-Drelicta.prerelease=false",
+				"-DaltReleaseDeploymentRepository=internal::https://repo.example.com/releases",
+			},
+			wantErr: false,
+		},
 		{
 			name: "invalid pom path",
 			config: &Config{
@@ -1335,11 +1490,36 @@ func TestBuildMavenCommand(t *testing.T) {
 			wantErr:     true,
 			errContains: "invalid profile",
 		},
+		{
+			name: "with deactivated profile",
+			config: &Config{
+				PomPath:  "pom.xml",
+				Profiles: []string{"release", "!integration-tests"},
+			},
+			expectedArgs: []string{"deploy", "-f", "pom.xml", "-P", "release,!integration-tests", "-Drelicta.version=1.0.0", "-Drelicta.prerelease=false"},
+		},
+		{
+			name: "with deploy retries",
+			config: &Config{
+				PomPath:       "pom.xml",
+				DeployRetries: 3,
+			},
+			expectedArgs: []string{"deploy", "-f", "pom.xml", "-Drelicta.version=1.0.0", "-Drelicta.prerelease=false", "-DretryFailedDeploymentCount=3"},
+		},
+		{
+			name: "too many deploy retries",
+			config: &Config{
+				PomPath:       "pom.xml",
+				DeployRetries: 11,
+			},
+			wantErr:     true,
+			errContains: "deploy_retries",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			args, err := p.buildMavenCommand(tt.config)
+			args, err := p.buildMavenCommand(tt.config, plugin.ReleaseContext{Version: "1.0.0"})
 
 			if tt.wantErr {
 				if err == nil {
@@ -1409,11 +1589,41 @@ func TestIsPrivateIP(t *testing.T) {
 			ip:       "169.254.0.1",
 			expected: true,
 		},
+		{
+			name:     "IPv6 unique local",
+			ip:       "fd12:3456:789a::1",
+			expected: true,
+		},
+		{
+			name:     "public IPv6",
+			ip:       "2001:4860:4860::8888",
+			expected: false,
+		},
+		{
+			name:     "IPv4-mapped private",
+			ip:       "::ffff:10.0.0.1",
+			expected: true,
+		},
+		{
+			name:     "NAT64 private",
+			ip:       "64:ff9b::a00:1",
+			expected: true,
+		},
+		{
+			name:     "NAT64 public",
+			ip:       "64:ff9b::808:808",
+			expected: false,
+		},
+		{
+			name:     "local-use NAT64",
+			ip:       "64:ff9b:1::1",
+			expected: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ip := parseIP(tt.ip)
+			ip := net.ParseIP(tt.ip)
 			if ip == nil {
 				t.Fatalf("failed to parse IP: %s", tt.ip)
 			}
@@ -1426,25 +1636,10 @@ func TestIsPrivateIP(t *testing.T) {
 	}
 }
 
-// parseIP is a helper function for testing.
-func parseIP(s string) []byte {
-	var ip [4]byte
-	parts := strings.Split(s, ".")
-	if len(parts) != 4 {
-		return nil
-	}
-	for i, p := range parts {
-		var n int
-		for _, c := range p {
-			if c < '0' || c > '9' {
-				return nil
-			}
-			n = n*10 + int(c-'0')
-		}
-		if n > 255 {
-			return nil
-		}
-		ip[i] = byte(n)
+func TestShellJoin(t *testing.T) {
+	got := shellJoin([]string{"deploy", "-P", "release,!integration-tests", "-Dname=it's", "-DaltReleaseDeploymentRepository=releases::https://repo.example.com/maven2"})
+	want := `deploy -P 'release,!integration-tests' '-Dname=it'\''s' -DaltReleaseDeploymentRepository=releases::https://repo.example.com/maven2`
+	if got != want {
+		t.Errorf("expected %s, got %s", want, got)
 	}
-	return ip[:]
 }
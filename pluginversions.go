@@ -0,0 +1,144 @@
+// Package main implements checking that the build plugins of the release have pinned versions.
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// enforcerPlugin is the Maven Enforcer Plugin running rules given on the command line.
+const enforcerPlugin = "org.apache.maven.plugins:maven-enforcer-plugin:3.5.0"
+
+// defaultPluginGroupID is the groupId of a plugin declared without one.
+const defaultPluginGroupID = "org.apache.maven.plugins"
+
+// enforcerOffenderPattern matches a plugin requirePluginVersions reports:
+// "org.apache.maven.plugins:maven-compiler-plugin. The version currently in use is 3.11.0".
+var enforcerOffenderPattern = regexp.MustCompile(`([\w.-]+:[\w-]+(?:\.[\w-]+)*)\.?\s+The version currently in use is ([^\s.]+(?:\.[^\s.]+)*)`)
+
+// pluginKey returns groupId:artifactId of a plugin.
+func pluginKey(p pomPlugin) string {
+	groupID := strings.TrimSpace(p.GroupID)
+	if groupID == "" {
+		groupID = defaultPluginGroupID
+	}
+	return groupID + ":" + strings.TrimSpace(p.ArtifactID)
+}
+
+// unpinnedVersion describes why a plugin version does not pin a release of
+// the plugin, or returns "" when it does.
+func unpinnedVersion(version string) string {
+	switch {
+	case version == "":
+		return "has no version"
+	case strings.Contains(version, "${"):
+		return fmt.Sprintf("version %s is not defined", version)
+	case version == "LATEST" || version == "RELEASE":
+		return fmt.Sprintf("version %s changes with every plugin release", version)
+	case strings.HasSuffix(version, "-SNAPSHOT"):
+		return fmt.Sprintf("version %s is a snapshot", version)
+	case strings.ContainsAny(version, "[](),"):
+		return fmt.Sprintf("version %s is a range", version)
+	}
+	return ""
+}
+
+// managedPluginVersion returns the version pluginManagement in the chain
+// declares for a plugin, or "".
+func managedPluginVersion(key string, chain []*pomModel) string {
+	for _, pom := range chain {
+		for _, managed := range pom.ManagedPlugins {
+			if pluginKey(managed) == key && managed.Version != "" {
+				return resolvePOMValue(managed.Version, chain)
+			}
+		}
+	}
+	return ""
+}
+
+// unpinnedPlugins lists the build plugins of a POM and its parents whose
+// version is missing or not fixed. When the chain ends in a parent that is
+// not available locally, plugins without a version may be managed by it and
+// only explicit versions are checked.
+func unpinnedPlugins(chain []*pomModel) []string {
+	remoteParent := chain[len(chain)-1].Parent.ArtifactID != ""
+	var problems []string
+	seen := map[string]bool{}
+	for _, pom := range chain {
+		for _, plugin := range pom.BuildPlugins {
+			key := pluginKey(plugin)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			version := resolvePOMValue(plugin.Version, chain)
+			if version == "" {
+				version = managedPluginVersion(key, chain)
+			}
+			if version == "" && remoteParent {
+				continue
+			}
+			if problem := unpinnedVersion(version); problem != "" {
+				problems = append(problems, key+" "+problem)
+			}
+		}
+	}
+	return problems
+}
+
+// checkPluginVersions checks the build plugins declared by the POM of every
+// deployed module and its local parents. Modules whose POM cannot be read are
+// skipped; Maven reports those itself.
+func (cfg *Config) checkPluginVersions() error {
+	var problems []string
+	for _, module := range cfg.deployedModules() {
+		chain, err := readPOMChain(module.projectPOM())
+		if err != nil || len(chain) == 0 {
+			continue
+		}
+		for _, problem := range unpinnedPlugins(chain) {
+			problems = append(problems, fmt.Sprintf("%s:%s: %s", module.GroupID, module.ArtifactID, problem))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("build plugins without a pinned version make the release non-reproducible: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// enforcerArgs returns the Maven arguments running an enforcer rule.
+func enforcerArgs(cfg *Config, rule string) []string {
+	args := []string{"-B", "-f", cfg.PomPath}
+	if path := cfg.userSettings(); path != "" {
+		args = append(args, "-s", path)
+	}
+	args = append(args, cfg.isolationArgs(true)...)
+	return append(args, enforcerPlugin+":enforce", "-Denforcer.rules="+rule)
+}
+
+// enforcerOffenders returns the plugins requirePluginVersions reported, with
+// the version Maven resolved for them.
+func enforcerOffenders(output string) []string {
+	var offenders []string
+	for _, m := range enforcerOffenderPattern.FindAllStringSubmatch(ansiPattern.ReplaceAllString(output, ""), -1) {
+		offenders = append(offenders, fmt.Sprintf("%s (currently %s)", m[1], m[2]))
+	}
+	return offenders
+}
+
+// enforcePluginVersions runs the Maven Enforcer requirePluginVersions rule,
+// which also reports the plugins bound by the default lifecycle that the POM
+// does not declare.
+func (p *MavenPlugin) enforcePluginVersions(ctx context.Context, cfg *Config) error {
+	output, err := p.runMavenCommand(ctx, cfg, enforcerArgs(cfg, "requirePluginVersions")...)
+	if err == nil {
+		return nil
+	}
+	if offenders := enforcerOffenders(string(output)); len(offenders) > 0 {
+		return fmt.Errorf("build plugins without a pinned version make the release non-reproducible: %s", strings.Join(offenders, "; "))
+	}
+	return fmt.Errorf("requirePluginVersions failed: %s\nOutput: %s", describeExecError(cfg.mavenCommand(), err), cfg.errorOutput(output))
+}
@@ -0,0 +1,172 @@
+// Package main provides tests for checking that the build plugins of the release have pinned versions.
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestUnpinnedPlugins(t *testing.T) {
+	tests := []struct {
+		name string
+		poms map[string]string
+		want string
+	}{
+		{
+			name: "pinned",
+			poms: map[string]string{"pom.xml": `<project><artifactId>my-app</artifactId><properties><surefire.version>3.2.5</surefire.version></properties><build><plugins>
+<plugin><artifactId>maven-compiler-plugin</artifactId><version>3.13.0</version></plugin>
+<plugin><artifactId>maven-surefire-plugin</artifactId><version>${surefire.version}</version></plugin>
+</plugins></build></project>`},
+		},
+		{
+			name: "unpinned",
+			poms: map[string]string{"pom.xml": `<project><artifactId>my-app</artifactId><build><plugins>
+<plugin><artifactId>maven-compiler-plugin</artifactId></plugin>
+<plugin><groupId>org.codehaus.mojo</groupId><artifactId>exec-maven-plugin</artifactId><version>[3.0,)</version></plugin>
+<plugin><groupId>com.example</groupId><artifactId>build-plugin</artifactId><version>1.0-SNAPSHOT</version></plugin>
+<plugin><artifactId>maven-jar-plugin</artifactId><version>LATEST</version></plugin>
+<plugin><artifactId>maven-source-plugin</artifactId><version>${source.version}</version></plugin>
+</plugins></build></project>`},
+			want: "org.apache.maven.plugins:maven-compiler-plugin has no version; " +
+				"org.codehaus.mojo:exec-maven-plugin version [3.0,) is a range; " +
+				"com.example:build-plugin version 1.0-SNAPSHOT is a snapshot; " +
+				"org.apache.maven.plugins:maven-jar-plugin version LATEST changes with every plugin release; " +
+				"org.apache.maven.plugins:maven-source-plugin version ${source.version} is not defined",
+		},
+		{
+			name: "managed by local parent",
+			poms: map[string]string{
+				"pom.xml":        `<project><artifactId>parent</artifactId><build><pluginManagement><plugins><plugin><artifactId>maven-compiler-plugin</artifactId><version>3.13.0</version></plugin></plugins></pluginManagement></build></project>`,
+				"my-app/pom.xml": `<project><parent><artifactId>parent</artifactId></parent><artifactId>my-app</artifactId><build><plugins><plugin><artifactId>maven-compiler-plugin</artifactId></plugin></plugins></build></project>`,
+			},
+		},
+		{
+			name: "parent not available locally",
+			poms: map[string]string{
+				"my-app/pom.xml": `<project><parent><artifactId>external-parent</artifactId></parent><artifactId>my-app</artifactId><build><plugins>
+<plugin><artifactId>maven-compiler-plugin</artifactId></plugin>
+<plugin><artifactId>maven-jar-plugin</artifactId><version>RELEASE</version></plugin>
+</plugins></build></project>`,
+			},
+			want: "org.apache.maven.plugins:maven-jar-plugin version RELEASE changes with every plugin release",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.poms {
+				path := filepath.Join(dir, filepath.FromSlash(name))
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			pomPath := filepath.Join(dir, "my-app", "pom.xml")
+			if _, ok := tt.poms["my-app/pom.xml"]; !ok {
+				pomPath = filepath.Join(dir, "pom.xml")
+			}
+
+			chain, err := readPOMChain(pomPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := strings.Join(unpinnedPlugins(chain), "; "); got != tt.want {
+				t.Errorf("unpinnedPlugins() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnforcerOffenders(t *testing.T) {
+	output := `[ERROR] Rule 0: org.apache.maven.enforcer.rules.RequirePluginVersions failed with message:
+Some plugins are missing valid versions or depend on Maven 3.9.6 defaults (LATEST, RELEASE as well as SNAPSHOT are not allowed)
+   org.apache.maven.plugins:maven-compiler-plugin.     The version currently in use is 3.11.0 via default lifecycle bindings
+   org.apache.maven.plugins:maven-jar-plugin.     The version currently in use is 3.3.0 via default lifecycle bindings
+`
+	got := strings.Join(enforcerOffenders(output), "; ")
+	want := "org.apache.maven.plugins:maven-compiler-plugin (currently 3.11.0); org.apache.maven.plugins:maven-jar-plugin (currently 3.3.0)"
+	if got != want {
+		t.Errorf("enforcerOffenders() = %q, want %q", got, want)
+	}
+}
+
+func TestExecutePluginVersionCheck(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+	if err := os.WriteFile("pom.xml", []byte(`<project><artifactId>my-app</artifactId><version>1.0.0</version><build><plugins><plugin><artifactId>maven-compiler-plugin</artifactId></plugin></plugins></build></project>`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		config  map[string]any
+		wantErr string
+		wantRun bool
+	}{
+		{name: "off"},
+		{
+			name:    "fail",
+			config:  map[string]any{"plugin_version_check": "fail"},
+			wantErr: "plugin version check: build plugins without a pinned version make the release non-reproducible: com.example:my-app: org.apache.maven.plugins:maven-compiler-plugin has no version",
+		},
+		{
+			name:    "enforcer",
+			config:  map[string]any{"plugin_version_check": "fail", "plugin_version_enforcer": true},
+			wantErr: "plugin version check: build plugins without a pinned version make the release non-reproducible: org.apache.maven.plugins:maven-compiler-plugin (currently 3.11.0)",
+			wantRun: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var enforced bool
+			mockExec := &MockCommandExecutor{
+				RunFunc: func(ctx context.Context, name string, args ...string) ([]byte, error) {
+					if strings.Contains(strings.Join(args, " "), enforcerPlugin+":enforce -Denforcer.rules=requirePluginVersions") {
+						enforced = true
+						return []byte("   org.apache.maven.plugins:maven-compiler-plugin.     The version currently in use is 3.11.0 via default lifecycle bindings\n"), errors.New("exit status 1")
+					}
+					return []byte("[INFO] BUILD SUCCESS"), nil
+				},
+			}
+			config := map[string]any{
+				"group_id":    "com.example",
+				"artifact_id": "my-app",
+				"repository":  "http://localhost:8081/repository/maven-releases",
+			}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+			resp, err := (&MavenPlugin{executor: mockExec}).Execute(context.Background(), plugin.ExecuteRequest{
+				Hook:    plugin.HookPostPublish,
+				Config:  config,
+				Context: plugin.ReleaseContext{Version: "1.0.0"},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if enforced != tt.wantRun {
+				t.Errorf("expected enforcer run %v, got %v", tt.wantRun, enforced)
+			}
+			if tt.wantErr == "" {
+				if !resp.Success {
+					t.Errorf("expected success, got error: %s", resp.Error)
+				}
+				return
+			}
+			if resp.Success || resp.Error != tt.wantErr {
+				t.Errorf("expected error %q, got %q", tt.wantErr, resp.Error)
+			}
+		})
+	}
+}
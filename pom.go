@@ -0,0 +1,182 @@
+// Package main implements POM reading for the Maven plugin.
+package main
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// maxPOMSize bounds the size of POM files the plugin will parse.
+const maxPOMSize = 10 << 20
+
+// supportedModelVersion is the only POM model version Maven 3 accepts.
+const supportedModelVersion = "4.0.0"
+
+// pomProperties holds the <properties> section of a POM.
+type pomProperties map[string]string
+
+// UnmarshalXML decodes arbitrary property elements into the map.
+func (p *pomProperties) UnmarshalXML(d *xml.Decoder, _ xml.StartElement) error {
+	props := pomProperties{}
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			var value string
+			if err := d.DecodeElement(&value, &t); err != nil {
+				return err
+			}
+			props[t.Name.Local] = value
+		case xml.EndElement:
+			*p = props
+			return nil
+		}
+	}
+}
+
+// pomDependency is a <dependency> entry of a POM.
+type pomDependency struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	Classifier string `xml:"classifier"`
+	Scope      string `xml:"scope"`
+}
+
+// pomParent is the <parent> reference of a POM.
+type pomParent struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	// RelativePath locates the parent POM; empty means ../pom.xml.
+	RelativePath *string `xml:"relativePath"`
+}
+
+// pomExecution is an <execution> of a build plugin.
+type pomExecution struct {
+	ID    string   `xml:"id"`
+	Phase string   `xml:"phase"`
+	Goals []string `xml:"goals>goal"`
+}
+
+// pomPlugin is a build <plugin> of a POM. Only top-level configuration
+// values are kept.
+type pomPlugin struct {
+	GroupID       string         `xml:"groupId"`
+	ArtifactID    string         `xml:"artifactId"`
+	Version       string         `xml:"version"`
+	Configuration pomProperties  `xml:"configuration"`
+	Executions    []pomExecution `xml:"executions>execution"`
+}
+
+// pomRepository is a <repository> or <pluginRepository> of a POM.
+type pomRepository struct {
+	ID  string `xml:"id"`
+	URL string `xml:"url"`
+}
+
+// pomProfile is a <profile> of a POM. Only its repositories are kept.
+type pomProfile struct {
+	ID                 string          `xml:"id"`
+	Repositories       []pomRepository `xml:"repositories>repository"`
+	PluginRepositories []pomRepository `xml:"pluginRepositories>pluginRepository"`
+}
+
+// pomLicense is a <license> of a POM.
+type pomLicense struct {
+	Name string `xml:"name,omitempty"`
+	URL  string `xml:"url,omitempty"`
+}
+
+// pomDeveloper is a <developer> of a POM.
+type pomDeveloper struct {
+	ID    string `xml:"id,omitempty"`
+	Name  string `xml:"name,omitempty"`
+	Email string `xml:"email,omitempty"`
+}
+
+// pomSCM is the <scm> section of a POM.
+type pomSCM struct {
+	Connection          string `xml:"connection,omitempty"`
+	DeveloperConnection string `xml:"developerConnection,omitempty"`
+	URL                 string `xml:"url,omitempty"`
+}
+
+// pomModel is the subset of the Maven POM model the plugin reads.
+type pomModel struct {
+	XMLName      xml.Name      `xml:"project"`
+	ModelVersion string        `xml:"modelVersion"`
+	GroupID      string        `xml:"groupId"`
+	ArtifactID   string        `xml:"artifactId"`
+	Version      string        `xml:"version"`
+	Packaging    string        `xml:"packaging"`
+	Properties   pomProperties `xml:"properties"`
+	Parent       pomParent     `xml:"parent"`
+	Modules      []string      `xml:"modules>module"`
+
+	Name        string         `xml:"name"`
+	Description string         `xml:"description"`
+	URL         string         `xml:"url"`
+	Licenses    []pomLicense   `xml:"licenses>license"`
+	Developers  []pomDeveloper `xml:"developers>developer"`
+	SCM         *pomSCM        `xml:"scm"`
+
+	Dependencies        []pomDependency `xml:"dependencies>dependency"`
+	ManagedDependencies []pomDependency `xml:"dependencyManagement>dependencies>dependency"`
+
+	BuildPlugins   []pomPlugin `xml:"build>plugins>plugin"`
+	ManagedPlugins []pomPlugin `xml:"build>pluginManagement>plugins>plugin"`
+	FinalName      string      `xml:"build>finalName"`
+
+	Repositories       []pomRepository `xml:"repositories>repository"`
+	PluginRepositories []pomRepository `xml:"pluginRepositories>pluginRepository"`
+	Profiles           []pomProfile    `xml:"profiles>profile"`
+}
+
+// parsePOM parses POM content.
+func parsePOM(r io.Reader) (*pomModel, error) {
+	var model pomModel
+	if err := xml.NewDecoder(io.LimitReader(r, maxPOMSize)).Decode(&model); err != nil {
+		return nil, fmt.Errorf("failed to parse POM: %w", err)
+	}
+	return &model, nil
+}
+
+// readPOM reads and parses the POM at path.
+func readPOM(path string) (*pomModel, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open POM: %w", err)
+	}
+	defer f.Close()
+	return parsePOM(f)
+}
+
+// checkPOMFile verifies that the POM at path exists and is a well-formed 4.0.0 model.
+func checkPOMFile(path string) error {
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("POM file not found: %s", path)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot access POM file: %w", err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("POM path is a directory, not a file: %s", path)
+	}
+
+	pom, err := readPOM(path)
+	if err != nil {
+		return err
+	}
+	if pom.ModelVersion != supportedModelVersion {
+		return fmt.Errorf("unsupported POM modelVersion %q (expected %s)", pom.ModelVersion, supportedModelVersion)
+	}
+	return nil
+}
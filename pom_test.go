@@ -0,0 +1,168 @@
+// Package main provides tests for POM reading.
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const samplePOM = `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0"
+         xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"
+         xsi:schemaLocation="http://maven.apache.org/POM/4.0.0 https://maven.apache.org/xsd/maven-4.0.0.xsd">
+  <modelVersion>4.0.0</modelVersion>
+  <groupId>com.example</groupId>
+  <artifactId>my-app</artifactId>
+  <version>1.0.0</version>
+  <packaging>jar</packaging>
+  <properties>
+    <maven.compiler.release>17</maven.compiler.release>
+    <project.build.sourceEncoding>UTF-8</project.build.sourceEncoding>
+  </properties>
+</project>`
+
+// writePOM writes POM content to a temporary directory and returns its path.
+func writePOM(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "pom.xml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write POM: %v", err)
+	}
+	return path
+}
+
+// chdir changes the working directory for the duration of the test.
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("failed to change directory: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+}
+
+func TestParsePOM(t *testing.T) {
+	pom, err := parsePOM(strings.NewReader(samplePOM))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if pom.ModelVersion != "4.0.0" {
+		t.Errorf("modelVersion: expected '4.0.0', got '%s'", pom.ModelVersion)
+	}
+	if pom.GroupID != "com.example" || pom.ArtifactID != "my-app" || pom.Version != "1.0.0" {
+		t.Errorf("unexpected coordinates %s:%s:%s", pom.GroupID, pom.ArtifactID, pom.Version)
+	}
+	if pom.Packaging != "jar" {
+		t.Errorf("packaging: expected 'jar', got '%s'", pom.Packaging)
+	}
+	if got := pom.Properties["maven.compiler.release"]; got != "17" {
+		t.Errorf("maven.compiler.release: expected '17', got '%s'", got)
+	}
+	if got := pom.Properties["project.build.sourceEncoding"]; got != "UTF-8" {
+		t.Errorf("project.build.sourceEncoding: expected 'UTF-8', got '%s'", got)
+	}
+}
+
+func TestParsePOMInvalid(t *testing.T) {
+	if _, err := parsePOM(strings.NewReader("<project><modelVersion>")); err == nil {
+		t.Error("expected error for malformed POM")
+	}
+}
+
+func TestReadPOM(t *testing.T) {
+	pom, err := readPOM(writePOM(t, samplePOM))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pom.ArtifactID != "my-app" {
+		t.Errorf("expected artifactId 'my-app', got '%s'", pom.ArtifactID)
+	}
+
+	if _, err := readPOM(filepath.Join(t.TempDir(), "missing.xml")); err == nil {
+		t.Error("expected error for missing POM")
+	}
+}
+
+func TestCheckPOMFile(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		path    string
+		content string
+		errMsg  string
+	}{
+		{name: "valid POM", path: "pom.xml", content: samplePOM},
+		{name: "missing POM", path: "missing.xml", errMsg: "POM file not found"},
+		{name: "malformed POM", path: "broken.xml", content: "<project><modelVersion>4.0.0", errMsg: "failed to parse POM"},
+		{name: "wrong model version", path: "old.xml", content: "<project><modelVersion>3.0.0</modelVersion></project>", errMsg: `unsupported POM modelVersion "3.0.0"`},
+		{name: "directory", path: "", errMsg: "is a directory"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.path)
+			if tt.content != "" {
+				if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+					t.Fatalf("failed to write POM: %v", err)
+				}
+			}
+
+			err := checkPOMFile(path)
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("expected error containing '%s', got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestValidateCheckPOM(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "pom.xml"), []byte(samplePOM), 0o600); err != nil {
+		t.Fatalf("failed to write POM: %v", err)
+	}
+	chdir(t, dir)
+
+	p := &MavenPlugin{}
+
+	resp, _ := p.Validate(context.Background(), map[string]any{
+		"group_id":    "com.example",
+		"artifact_id": "my-app",
+		"check_pom":   true,
+	})
+	if !resp.Valid {
+		t.Errorf("expected valid config, got %+v", resp.Errors)
+	}
+
+	resp, _ = p.Validate(context.Background(), map[string]any{
+		"group_id":    "com.example",
+		"artifact_id": "my-app",
+		"pom_path":    "modules/pom.xml",
+		"check_pom":   true,
+	})
+	if resp.Valid || resp.Errors[0].Field != "pom_path" {
+		t.Errorf("expected pom_path error, got %+v", resp)
+	}
+
+	resp, _ = p.Validate(context.Background(), map[string]any{
+		"group_id":    "com.example",
+		"artifact_id": "my-app",
+		"pom_path":    "modules/pom.xml",
+	})
+	if !resp.Valid {
+		t.Errorf("expected POM check to be skipped when disabled, got %+v", resp.Errors)
+	}
+}
@@ -0,0 +1,257 @@
+// Package main implements injecting missing project metadata into the published POM.
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+)
+
+// POMMetadata is project metadata injected into the published POM when the
+// POM and its local parents lack it.
+type POMMetadata struct {
+	Description string
+	URL         string
+	Licenses    []pomLicense
+	Developers  []pomDeveloper
+	SCM         *pomSCM
+}
+
+// parsePOMMetadata parses the pom_metadata option from the raw configuration.
+func parsePOMMetadata(raw map[string]any) *POMMetadata {
+	entry, ok := raw["pom_metadata"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	parser := helpers.NewConfigParser(entry)
+	meta := &POMMetadata{
+		Description: parser.GetString("description", "", ""),
+		URL:         parser.GetString("url", "", ""),
+	}
+	licenses, _ := entry["licenses"].([]any)
+	for _, item := range licenses {
+		lp := helpers.NewConfigParser(asMap(item))
+		meta.Licenses = append(meta.Licenses, pomLicense{Name: lp.GetString("name", "", ""), URL: lp.GetString("url", "", "")})
+	}
+	developers, _ := entry["developers"].([]any)
+	for _, item := range developers {
+		dp := helpers.NewConfigParser(asMap(item))
+		meta.Developers = append(meta.Developers, pomDeveloper{
+			ID:    dp.GetString("id", "", ""),
+			Name:  dp.GetString("name", "", ""),
+			Email: dp.GetString("email", "", ""),
+		})
+	}
+	if scm, ok := entry["scm"].(map[string]any); ok {
+		sp := helpers.NewConfigParser(scm)
+		meta.SCM = &pomSCM{
+			Connection:          sp.GetString("connection", "", ""),
+			DeveloperConnection: sp.GetString("developer_connection", "", ""),
+			URL:                 sp.GetString("url", "", ""),
+		}
+	}
+	return meta
+}
+
+// asMap returns the value as a map, or an empty map.
+func asMap(v any) map[string]any {
+	m, _ := v.(map[string]any)
+	return m
+}
+
+// validatePOMMetadata validates the pom_metadata option.
+func validatePOMMetadata(vb *helpers.ValidationBuilder, config map[string]any) {
+	entry, ok := config["pom_metadata"].(map[string]any)
+	if !ok {
+		vb.AddError("pom_metadata", "pom_metadata must be an object")
+		return
+	}
+	for _, field := range []string{"licenses", "developers"} {
+		if v, ok := entry[field]; ok {
+			if _, ok := v.([]any); !ok {
+				vb.AddError("pom_metadata."+field, field+" must be a list of objects")
+			}
+		}
+	}
+	if v, ok := entry["scm"]; ok {
+		if _, ok := v.(map[string]any); !ok {
+			vb.AddError("pom_metadata.scm", "scm must be an object")
+		}
+	}
+
+	meta := parsePOMMetadata(config)
+	for i, license := range meta.Licenses {
+		if license.Name == "" {
+			vb.AddError(fmt.Sprintf("pom_metadata.licenses[%d].name", i), "license name is required")
+		}
+	}
+	for i, developer := range meta.Developers {
+		if developer.ID == "" && developer.Name == "" {
+			vb.AddError(fmt.Sprintf("pom_metadata.developers[%d]", i), "developer id or name is required")
+		}
+	}
+	if meta.SCM != nil && meta.SCM.URL == "" && meta.SCM.Connection == "" {
+		vb.AddError("pom_metadata.scm", "scm url or connection is required")
+	}
+}
+
+// provides reports whether the metadata supplies a required element named as
+// in centralPOMProblems. It is safe to call on nil metadata.
+func (m *POMMetadata) provides(element string) bool {
+	if m == nil {
+		return false
+	}
+	switch element {
+	case "description":
+		return m.Description != ""
+	case "url":
+		return m.URL != ""
+	case "licenses":
+		return len(m.Licenses) > 0
+	case "developers":
+		return len(m.Developers) > 0
+	case "scm/connection":
+		return m.SCM != nil && m.SCM.Connection != ""
+	case "scm/developerConnection":
+		return m.SCM != nil && m.SCM.DeveloperConnection != ""
+	case "scm/url":
+		return m.SCM != nil && m.SCM.URL != ""
+	}
+	return false
+}
+
+// missing returns the configured top-level elements absent from every POM of
+// the chain. A partial <scm> section is left alone.
+func (m *POMMetadata) missing(chain []*pomModel) []string {
+	var elements []string
+	add := func(element string, configured bool, has func(*pomModel) bool) {
+		if configured && !chainHas(chain, has) {
+			elements = append(elements, element)
+		}
+	}
+	add("description", m.Description != "", func(p *pomModel) bool { return strings.TrimSpace(p.Description) != "" })
+	add("url", m.URL != "", func(p *pomModel) bool { return strings.TrimSpace(p.URL) != "" })
+	add("licenses", len(m.Licenses) > 0, func(p *pomModel) bool { return len(p.Licenses) > 0 })
+	add("developers", len(m.Developers) > 0, func(p *pomModel) bool { return len(p.Developers) > 0 })
+	add("scm", m.SCM != nil, func(p *pomModel) bool { return p.SCM != nil })
+	return elements
+}
+
+// render returns the XML of the given elements, indented for a child of <project>.
+func (m *POMMetadata) render(elements []string) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, element := range elements {
+		var v any
+		switch element {
+		case "description":
+			v = struct {
+				XMLName xml.Name `xml:"description"`
+				Value   string   `xml:",chardata"`
+			}{Value: m.Description}
+		case "url":
+			v = struct {
+				XMLName xml.Name `xml:"url"`
+				Value   string   `xml:",chardata"`
+			}{Value: m.URL}
+		case "licenses":
+			v = struct {
+				XMLName  xml.Name     `xml:"licenses"`
+				Licenses []pomLicense `xml:"license"`
+			}{Licenses: m.Licenses}
+		case "developers":
+			v = struct {
+				XMLName    xml.Name       `xml:"developers"`
+				Developers []pomDeveloper `xml:"developer"`
+			}{Developers: m.Developers}
+		case "scm":
+			v = struct {
+				XMLName xml.Name `xml:"scm"`
+				pomSCM
+			}{pomSCM: *m.SCM}
+		}
+		data, err := xml.MarshalIndent(v, "  ", "  ")
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// injectPOMMetadata inserts rendered elements before the closing </project> tag.
+func injectPOMMetadata(content, fragment []byte) ([]byte, error) {
+	i := bytes.LastIndex(content, []byte("</project>"))
+	if i < 0 {
+		return nil, fmt.Errorf("no </project> element found")
+	}
+	var out bytes.Buffer
+	out.Write(content[:i])
+	if i > 0 && content[i-1] != '\n' {
+		out.WriteByte('\n')
+	}
+	out.Write(fragment)
+	out.Write(content[i:])
+	return out.Bytes(), nil
+}
+
+// patchedPOM returns the POM at path with the missing configured metadata
+// injected, and the injected elements. It returns no content when nothing is
+// missing.
+func (cfg *Config) patchedPOM(path string) ([]byte, []string, error) {
+	if cfg.POMMetadata == nil {
+		return nil, nil, nil
+	}
+	chain, err := publishedPOMChain(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	elements := cfg.POMMetadata.missing(chain)
+	if len(elements) == 0 {
+		return nil, nil, nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read POM: %w", err)
+	}
+	fragment, err := cfg.POMMetadata.render(elements)
+	if err != nil {
+		return nil, nil, err
+	}
+	patched, err := injectPOMMetadata(content, fragment)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return patched, elements, nil
+}
+
+// injectProjectPOM writes the missing metadata into the project POM for the
+// Maven deploy and returns a function restoring the original POM.
+func (cfg *Config) injectProjectPOM() ([]string, func() error, error) {
+	path := cfg.projectPOM()
+	patched, elements, err := cfg.patchedPOM(path)
+	if err != nil || patched == nil {
+		return nil, func() error { return nil }, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	original, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := os.WriteFile(path, patched, info.Mode().Perm()); err != nil {
+		return nil, nil, fmt.Errorf("failed to write POM: %w", err)
+	}
+	restore := func() error {
+		return os.WriteFile(path, original, info.Mode().Perm())
+	}
+	return elements, restore, nil
+}
@@ -0,0 +1,195 @@
+// Package main provides tests for injecting POM metadata.
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func pomMetadataConfig() map[string]any {
+	return map[string]any{
+		"description": "An example & more",
+		"url":         "https://example.com/my-app",
+		"licenses":    []any{map[string]any{"name": "Apache-2.0", "url": "https://www.apache.org/licenses/LICENSE-2.0"}},
+		"developers":  []any{map[string]any{"id": "jdoe", "name": "J. Doe"}},
+		"scm":         map[string]any{"connection": "scm:git:https://github.com/example/my-app.git", "url": "https://github.com/example/my-app"},
+	}
+}
+
+func TestPatchedPOM(t *testing.T) {
+	dir := t.TempDir()
+	module := filepath.Join(dir, "app")
+	if err := os.MkdirAll(module, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	parent := `<project><artifactId>parent</artifactId><url>https://example.com</url><licenses><license><name>MIT</name></license></licenses></project>`
+	child := `<project>
+  <parent><artifactId>parent</artifactId></parent>
+  <artifactId>my-app</artifactId>
+  <scm><url>https://example.com/scm</url></scm>
+</project>
+`
+	if err := os.WriteFile(filepath.Join(dir, "pom.xml"), []byte(parent), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	pomPath := filepath.Join(module, "pom.xml")
+	if err := os.WriteFile(pomPath, []byte(child), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := (&MavenPlugin{}).parseConfig(map[string]any{"pom_metadata": pomMetadataConfig()})
+	patched, elements, err := cfg.patchedPOM(pomPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(elements, ",") != "description,developers" {
+		t.Fatalf("expected only the missing elements to be injected, got %v", elements)
+	}
+
+	pom, err := parsePOM(strings.NewReader(string(patched)))
+	if err != nil {
+		t.Fatalf("patched POM does not parse: %v\n%s", err, patched)
+	}
+	if pom.Description != "An example & more" || len(pom.Developers) != 1 || pom.Developers[0].ID != "jdoe" {
+		t.Errorf("unexpected patched POM:\n%s", patched)
+	}
+	if pom.URL != "" || pom.SCM.URL != "https://example.com/scm" {
+		t.Errorf("expected existing and inherited elements to be kept, got:\n%s", patched)
+	}
+	if !strings.Contains(string(patched), "  <developers>\n    <developer>\n      <id>jdoe</id>") {
+		t.Errorf("expected indented elements, got:\n%s", patched)
+	}
+
+	cfg.POMMetadata.Developers = nil
+	cfg.POMMetadata.Description = ""
+	if patched, elements, err := cfg.patchedPOM(pomPath); err != nil || patched != nil || elements != nil {
+		t.Errorf("expected nothing to inject, got %v, %v", elements, err)
+	}
+}
+
+func TestCheckCentralPOMsWithMetadata(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+	if err := os.WriteFile("pom.xml", []byte(`<project><artifactId>my-app</artifactId><name>My App</name></project>`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	config := map[string]any{"group_id": "com.example", "artifact_id": "my-app", "pom_metadata": pomMetadataConfig()}
+	err := (&MavenPlugin{}).parseConfig(config).checkCentralPOMs()
+	if err == nil || !strings.Contains(err.Error(), "is missing scm/developerConnection") || strings.Contains(err.Error(), "licenses") {
+		t.Errorf("expected only the elements not configured to be reported, got %v", err)
+	}
+}
+
+func TestExecutePOMMetadata(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+	original := `<project><artifactId>my-app</artifactId></project>`
+	if err := os.WriteFile("pom.xml", []byte(original), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var built string
+	mockExec := &MockCommandExecutor{
+		RunFunc: func(_ context.Context, _ string, _ ...string) ([]byte, error) {
+			data, err := os.ReadFile("pom.xml")
+			built = string(data)
+			return []byte("[INFO] BUILD SUCCESS"), err
+		},
+	}
+	p := &MavenPlugin{executor: mockExec}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":     "com.example",
+			"artifact_id":  "my-app",
+			"repository":   "http://localhost:8081/repository/maven-releases",
+			"pom_metadata": map[string]any{"description": "An example"},
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+	if !strings.Contains(built, "<description>An example</description>") {
+		t.Errorf("expected the metadata in the POM during the build, got %s", built)
+	}
+	if data, _ := os.ReadFile("pom.xml"); string(data) != original {
+		t.Errorf("expected the POM to be restored, got %s", data)
+	}
+	if injected, _ := resp.Outputs["pom_metadata_injected"].([]string); strings.Join(injected, ",") != "description" {
+		t.Errorf("unexpected outputs: %v", resp.Outputs)
+	}
+}
+
+func TestHTTPDeployFilesPOMMetadata(t *testing.T) {
+	writeHTTPDeployProject(t)
+	config := map[string]any{"group_id": "com.example", "artifact_id": "my-app", "pom_metadata": map[string]any{"url": "https://example.com"}}
+	cfg := (&MavenPlugin{}).parseConfig(config)
+
+	files, err := cfg.httpDeployFiles("1.0.0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if files[0].Local != "" || !strings.Contains(string(files[0].Data), "<url>https://example.com</url>") {
+		t.Errorf("expected the patched POM to be uploaded, got %+v", files[0])
+	}
+
+	if err := os.WriteFile(filepath.Join("target", "my-app-1.0.0.pom.asc"), []byte("signature"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cfg.httpDeployFiles("1.0.0"); err == nil || !strings.Contains(err.Error(), "cannot change the signed POM") {
+		t.Errorf("expected an error for a signed POM, got %v", err)
+	}
+}
+
+func TestValidatePOMMetadata(t *testing.T) {
+	tests := []struct {
+		name      string
+		metadata  any
+		wantField string
+	}{
+		{name: "valid", metadata: pomMetadataConfig()},
+		{name: "not an object", metadata: "Apache-2.0", wantField: "pom_metadata"},
+		{name: "license without name", metadata: map[string]any{"licenses": []any{map[string]any{"url": "https://example.com"}}}, wantField: "pom_metadata.licenses[0].name"},
+		{name: "developer without id or name", metadata: map[string]any{"developers": []any{map[string]any{"email": "a@example.com"}}}, wantField: "pom_metadata.developers[0]"},
+		{name: "empty scm", metadata: map[string]any{"scm": map[string]any{}}, wantField: "pom_metadata.scm"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &MavenPlugin{}
+			resp, err := p.Validate(context.Background(), map[string]any{
+				"group_id":     "com.example",
+				"artifact_id":  "my-app",
+				"pom_metadata": tt.metadata,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantField == "" {
+				if !resp.Valid {
+					t.Errorf("expected valid config, got errors: %v", resp.Errors)
+				}
+				return
+			}
+			found := false
+			for _, e := range resp.Errors {
+				if e.Field == tt.wantField {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("expected error on field %q, got %v", tt.wantField, resp.Errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,258 @@
+// Package main implements Central Portal deployment status tracking.
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// centralPortalStatusPath is the Central Portal Publisher API deployment status endpoint.
+const centralPortalStatusPath = "/api/v1/publisher/status"
+
+// centralPortalDeploymentPath is the Central Portal Publisher API endpoint
+// dropping a deployment; the deployment ID is appended.
+const centralPortalDeploymentPath = "/api/v1/publisher/deployment/"
+
+// Central Portal deployment states. Deployments pass through PENDING,
+// VALIDATING, VALIDATED, and PUBLISHING before they are PUBLISHED.
+const (
+	deploymentStateValidated = "VALIDATED"
+	deploymentStatePublished = "PUBLISHED"
+	deploymentStateFailed    = "FAILED"
+)
+
+// defaultCentralWaitTimeout is the default time in minutes to wait for a
+// Central Portal deployment to be validated or published.
+const defaultCentralWaitTimeout = 30
+
+// portalPollInterval is the delay between deployment status requests.
+var portalPollInterval = 5 * time.Second
+
+// portalDeployment is the status of a Central Portal deployment.
+type portalDeployment struct {
+	ID    string   `json:"deploymentId"`
+	Name  string   `json:"deploymentName"`
+	State string   `json:"deploymentState"`
+	PURLs []string `json:"purls"`
+	// Errors maps components to their validation errors.
+	Errors map[string]any `json:"errors"`
+}
+
+// validationErrors flattens the validation errors of a deployment into
+// "component: message" lines, sorted by component.
+func (d *portalDeployment) validationErrors() []string {
+	components := make([]string, 0, len(d.Errors))
+	for component := range d.Errors {
+		components = append(components, component)
+	}
+	sort.Strings(components)
+
+	var lines []string
+	for _, component := range components {
+		var messages []string
+		switch v := d.Errors[component].(type) {
+		case string:
+			messages = append(messages, v)
+		case []any:
+			for _, m := range v {
+				messages = append(messages, fmt.Sprint(m))
+			}
+		default:
+			messages = append(messages, fmt.Sprint(v))
+		}
+		for _, m := range messages {
+			lines = append(lines, fmt.Sprintf("%s: %s", component, m))
+		}
+	}
+	return lines
+}
+
+// portalAuthorization returns the Authorization header for the Publisher API,
+// which takes the user token as a bearer token.
+func (cfg *Config) portalAuthorization() string {
+	return "Bearer " + base64.StdEncoding.EncodeToString([]byte(cfg.Username+":"+cfg.Password))
+}
+
+// centralDeploymentStatus fetches the status of a Central Portal deployment.
+func (p *MavenPlugin) centralDeploymentStatus(ctx context.Context, cfg *Config, deploymentID string) (*portalDeployment, error) {
+	statusURL := strings.TrimSuffix(cfg.CentralPortalURL, "/") + centralPortalStatusPath + "?id=" + url.QueryEscape(deploymentID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, statusURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", cfg.portalAuthorization())
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.getHTTPClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("deployment status request to %s failed: %w", redactURL(cfg.CentralPortalURL), err)
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if resp.StatusCode != http.StatusOK {
+		return nil, &statusError{op: "deployment status request", status: resp.StatusCode, text: strings.TrimSpace(resp.Status + " " + string(data))}
+	}
+
+	var deployment portalDeployment
+	if err := json.Unmarshal(data, &deployment); err != nil {
+		return nil, fmt.Errorf("failed to parse deployment status: %w", err)
+	}
+	return &deployment, nil
+}
+
+// waitForCentralDeployment polls a Central Portal deployment until it reaches
+// the target state: PUBLISHED for automatic publishing, VALIDATED otherwise.
+// A failed deployment returns its validation errors. Transient status
+// request failures are retried until the timeout.
+func (p *MavenPlugin) waitForCentralDeployment(ctx context.Context, cfg *Config, deploymentID string) (*portalDeployment, error) {
+	target := deploymentStateValidated
+	if cfg.AutoRelease != nil && *cfg.AutoRelease {
+		target = deploymentStatePublished
+	}
+
+	deadline := time.Now().Add(time.Duration(cfg.CentralWaitTimeout) * time.Minute)
+	var deployment *portalDeployment
+	for {
+		status, err := p.centralDeploymentStatus(ctx, cfg, deploymentID)
+		switch {
+		case err != nil && !retryable(err):
+			return deployment, err
+		case err == nil:
+			deployment = status
+			switch deployment.State {
+			case target, deploymentStatePublished:
+				return deployment, nil
+			case deploymentStateFailed:
+				reasons := deployment.validationErrors()
+				if len(reasons) == 0 {
+					return deployment, fmt.Errorf("Central Portal deployment %s failed", deploymentID)
+				}
+				return deployment, fmt.Errorf("Central Portal deployment %s failed validation:\n%s", deploymentID, strings.Join(reasons, "\n"))
+			}
+		}
+
+		if !time.Now().Before(deadline) {
+			state := "unknown"
+			if deployment != nil {
+				state = deployment.State
+			}
+			return deployment, fmt.Errorf("timed out after %d minutes waiting for Central Portal deployment %s (state %s)", cfg.CentralWaitTimeout, deploymentID, state)
+		}
+		select {
+		case <-ctx.Done():
+			return deployment, ctx.Err()
+		case <-time.After(portalPollInterval):
+		}
+	}
+}
+
+// droppable reports whether a deployment in the state can be dropped. Only
+// deployments that are validated but not published, or failed, can be.
+func droppable(state string) bool {
+	return state == deploymentStateValidated || state == deploymentStateFailed
+}
+
+// dropCentralDeployment drops a pending or failed Central Portal deployment.
+func (p *MavenPlugin) dropCentralDeployment(ctx context.Context, cfg *Config, deploymentID string) error {
+	dropURL := strings.TrimSuffix(cfg.CentralPortalURL, "/") + centralPortalDeploymentPath + url.PathEscape(deploymentID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, dropURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", cfg.portalAuthorization())
+
+	resp, err := p.getHTTPClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("drop request to %s failed: %w", redactURL(cfg.CentralPortalURL), err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<16))
+		return &statusError{op: "drop request", status: resp.StatusCode, text: strings.TrimSpace(resp.Status + " " + string(data))}
+	}
+	return nil
+}
+
+// dropFailedDeployment drops the deployment recorded by this run for the
+// release after the release failed. Published deployments cannot be dropped
+// and are reported instead.
+func (p *MavenPlugin) dropFailedDeployment(ctx context.Context, cfg *Config, releaseCtx plugin.ReleaseContext, dryRun bool) (*plugin.ExecuteResponse, error) {
+	outputs := map[string]any{
+		"group_id":    cfg.GroupID,
+		"artifact_id": cfg.ArtifactID,
+		"version":     releaseCtx.Version,
+	}
+
+	rec, ok := p.findDeploy(cfg.GroupID, cfg.ArtifactID, mavenVersion(releaseCtx.Version))
+	if !ok || rec.DeploymentID == "" {
+		outputs["central_deployment_dropped"] = false
+		return &plugin.ExecuteResponse{
+			Success: true,
+			Message: "No Central Portal deployment from this release to drop",
+			Outputs: outputs,
+		}, nil
+	}
+	outputs["central_deployment_id"] = rec.DeploymentID
+
+	if dryRun {
+		return &plugin.ExecuteResponse{
+			Success: true,
+			Message: "Would drop Central Portal deployment " + rec.DeploymentID,
+			Outputs: outputs,
+		}, nil
+	}
+
+	deployment, err := p.centralDeploymentStatus(ctx, cfg, rec.DeploymentID)
+	if err != nil {
+		return &plugin.ExecuteResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Central Portal drop failed: %v", err),
+			Outputs: outputs,
+		}, nil
+	}
+	outputs["central_deployment_state"] = deployment.State
+	if !droppable(deployment.State) {
+		outputs["central_deployment_dropped"] = false
+		return &plugin.ExecuteResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Central Portal deployment %s cannot be dropped in state %s", rec.DeploymentID, deployment.State),
+			Outputs: outputs,
+		}, nil
+	}
+	if err := p.dropCentralDeployment(ctx, cfg, rec.DeploymentID); err != nil {
+		return &plugin.ExecuteResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Central Portal drop failed: %v", err),
+			Outputs: outputs,
+		}, nil
+	}
+
+	p.forgetDeploy(cfg.GroupID, cfg.ArtifactID, releaseCtx.Version)
+	outputs["central_deployment_dropped"] = true
+	return &plugin.ExecuteResponse{
+		Success: true,
+		Message: "Dropped Central Portal deployment " + rec.DeploymentID,
+		Outputs: outputs,
+	}, nil
+}
+
+// validateCentralWaitTimeout validates the Central Portal wait timeout in minutes.
+func validateCentralWaitTimeout(minutes int) error {
+	if minutes < 0 {
+		return fmt.Errorf("central_wait_timeout cannot be negative")
+	}
+	if minutes > 24*60 {
+		return fmt.Errorf("central_wait_timeout too large (max 1440 minutes)")
+	}
+	return nil
+}
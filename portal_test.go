@@ -0,0 +1,238 @@
+// Package main provides tests for Central Portal deployment status tracking.
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestWaitForCentralDeployment(t *testing.T) {
+	portalPollInterval = time.Millisecond
+	t.Cleanup(func() { portalPollInterval = 5 * time.Second })
+
+	tests := []struct {
+		name        string
+		autoRelease bool
+		responses   []string
+		wantState   string
+		errMsg      []string
+	}{
+		{
+			name: "validated",
+			responses: []string{
+				`{"deploymentState":"PENDING"}`,
+				`{"deploymentState":"VALIDATING"}`,
+				`{"deploymentState":"VALIDATED","purls":["pkg:maven/com.example/my-app@1.0.0"]}`,
+			},
+			wantState: "VALIDATED",
+		},
+		{
+			name:        "published",
+			autoRelease: true,
+			responses: []string{
+				`{"deploymentState":"VALIDATED"}`,
+				`{"deploymentState":"PUBLISHING"}`,
+				`{"deploymentState":"PUBLISHED"}`,
+			},
+			wantState: "PUBLISHED",
+		},
+		{
+			name: "failed validation",
+			responses: []string{
+				`{"deploymentState":"VALIDATING"}`,
+				`{"deploymentState":"FAILED","errors":{"pkg:maven/com.example/my-app@1.0.0":["Javadocs must be provided but not found in entries","Invalid signature for file: my-app-1.0.0.jar"],"common":["Namespace 'com.example' is not allowed"]}}`,
+			},
+			wantState: "FAILED",
+			errMsg: []string{
+				"failed validation",
+				"pkg:maven/com.example/my-app@1.0.0: Javadocs must be provided",
+				"pkg:maven/com.example/my-app@1.0.0: Invalid signature",
+				"common: Namespace 'com.example' is not allowed",
+			},
+		},
+		{
+			name:      "failed without reasons",
+			responses: []string{`{"deploymentState":"FAILED"}`},
+			wantState: "FAILED",
+			errMsg:    []string{"deployment abc failed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != centralPortalStatusPath || r.URL.Query().Get("id") != "abc" {
+					http.NotFound(w, r)
+					return
+				}
+				if r.Header.Get("Authorization") != "Bearer dG9rZW4tdXNlcjp0b2tlbi1wYXNz" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				response := tt.responses[min(requests, len(tt.responses)-1)]
+				requests++
+				_, _ = w.Write([]byte(response))
+			}))
+			defer server.Close()
+
+			autoRelease := tt.autoRelease
+			cfg := &Config{
+				Username:           "token-user",
+				Password:           "token-pass",
+				CentralPortalURL:   server.URL,
+				CentralWaitTimeout: 1,
+				AutoRelease:        &autoRelease,
+			}
+			p := &MavenPlugin{httpClient: server.Client()}
+
+			deployment, err := p.waitForCentralDeployment(context.Background(), cfg, "abc")
+			if deployment == nil || deployment.State != tt.wantState {
+				t.Errorf("expected state %s, got %+v", tt.wantState, deployment)
+			}
+			if requests != len(tt.responses) {
+				t.Errorf("expected %d status requests, got %d", len(tt.responses), requests)
+			}
+			if len(tt.errMsg) == 0 {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			for _, msg := range tt.errMsg {
+				if !strings.Contains(err.Error(), msg) {
+					t.Errorf("expected error containing %q, got %v", msg, err)
+				}
+			}
+		})
+	}
+}
+
+func TestWaitForCentralDeploymentErrors(t *testing.T) {
+	portalPollInterval = time.Millisecond
+	t.Cleanup(func() { portalPollInterval = 5 * time.Second })
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("id") == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"deploymentState":"VALIDATING"}`))
+	}))
+	defer server.Close()
+	p := &MavenPlugin{httpClient: server.Client()}
+
+	cfg := &Config{CentralPortalURL: server.URL}
+	if _, err := p.waitForCentralDeployment(context.Background(), cfg, "abc"); err == nil || !strings.Contains(err.Error(), "timed out after 0 minutes") || !strings.Contains(err.Error(), "state VALIDATING") {
+		t.Errorf("expected timeout error, got %v", err)
+	}
+
+	cfg.CentralWaitTimeout = 1
+	if _, err := p.waitForCentralDeployment(context.Background(), cfg, "missing"); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("expected status error, got %v", err)
+	}
+}
+
+// portalServer serves deployment status responses in order and records the
+// dropped deployments.
+func portalServer(t *testing.T, responses []string, dropped *[]string) *httptest.Server {
+	t.Helper()
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == centralPortalUploadPath:
+			_, _ = w.Write([]byte("abc"))
+		case r.Method == http.MethodPost && r.URL.Path == centralPortalStatusPath:
+			_, _ = w.Write([]byte(responses[min(requests, len(responses)-1)]))
+			requests++
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, centralPortalDeploymentPath):
+			*dropped = append(*dropped, strings.TrimPrefix(r.URL.Path, centralPortalDeploymentPath))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestHTTPDeployDropsFailedDeployment(t *testing.T) {
+	writeHTTPDeployProject(t)
+	portalPollInterval = time.Millisecond
+	t.Cleanup(func() { portalPollInterval = 5 * time.Second })
+
+	for _, drop := range []bool{true, false} {
+		var dropped []string
+		server := portalServer(t, []string{`{"deploymentState":"FAILED","errors":{"common":["Invalid signature"]}}`}, &dropped)
+		p := &MavenPlugin{httpClient: server.Client()}
+		cfg := p.parseConfig(map[string]any{
+			"group_id":            "com.example",
+			"artifact_id":         "my-app",
+			"deployer":            "http",
+			"central_portal_url":  server.URL,
+			"central_drop_failed": drop,
+		})
+
+		result, err := p.httpDeploy(context.Background(), cfg, plugin.ReleaseContext{Version: "1.0.0"})
+		if err == nil || !strings.Contains(err.Error(), "Invalid signature") {
+			t.Errorf("expected validation error, got %v", err)
+		}
+		if result.Dropped != drop || (drop && (len(dropped) != 1 || dropped[0] != "abc")) || (!drop && len(dropped) != 0) {
+			t.Errorf("drop=%v: unexpected drops %v (dropped %v)", drop, dropped, result.Dropped)
+		}
+	}
+}
+
+func TestExecuteOnErrorDropsDeployment(t *testing.T) {
+	tests := []struct {
+		name        string
+		state       string
+		record      bool
+		wantSuccess bool
+		wantDropped bool
+	}{
+		{name: "validated", state: "VALIDATED", record: true, wantSuccess: true, wantDropped: true},
+		{name: "published", state: "PUBLISHED", record: true},
+		{name: "nothing deployed", state: "VALIDATED", wantSuccess: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var dropped []string
+			server := portalServer(t, []string{`{"deploymentState":"` + tt.state + `"}`}, &dropped)
+			p := &MavenPlugin{httpClient: server.Client()}
+			if tt.record {
+				p.recordDeploy(deployRecord{GroupID: "com.example", ArtifactID: "my-app", Version: "1.0.0", DeploymentID: "abc"})
+			}
+
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook: plugin.HookOnError,
+				Config: map[string]any{
+					"group_id":           "com.example",
+					"artifact_id":        "my-app",
+					"deployer":           "http",
+					"central_portal_url": server.URL,
+				},
+				Context: plugin.ReleaseContext{Version: "1.0.0"},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Success != tt.wantSuccess {
+				t.Errorf("expected success=%v, got %+v", tt.wantSuccess, resp)
+			}
+			if (len(dropped) == 1) != tt.wantDropped || resp.Outputs["central_deployment_dropped"] != tt.wantDropped {
+				t.Errorf("expected dropped=%v, got %v and outputs %v", tt.wantDropped, dropped, resp.Outputs)
+			}
+		})
+	}
+}
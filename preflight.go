@@ -0,0 +1,43 @@
+// Package main implements pre-deploy safety checks for the Maven plugin.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Policies for checks that can be disabled, reported, or enforced.
+const (
+	policyOff  = "off"
+	policyWarn = "warn"
+	policyFail = "fail"
+)
+
+// checkPolicies lists the accepted values of check policy options.
+var checkPolicies = []string{policyOff, policyWarn, policyFail}
+
+// checkDowngrade compares the release version against the versions already in
+// the target repository and reports an error if it sorts lower than the latest.
+func (p *MavenPlugin) checkDowngrade(ctx context.Context, cfg *Config, version string) error {
+	repository := cfg.targetRepository(version)
+	if repository == "" {
+		return fmt.Errorf("downgrade check requires a repository URL")
+	}
+
+	username, password := cfg.targetCredentials(version)
+	client := newRepositoryClient(p.getHTTPClient(), repository, username, password)
+	metadata, err := client.fetchMetadata(ctx, cfg.GroupID, cfg.ArtifactID)
+	if errors.Is(err, errComponentNotFound) {
+		return nil // First release of this artifact.
+	}
+	if err != nil {
+		return fmt.Errorf("failed to query published versions: %w", err)
+	}
+
+	latest := latestMavenVersion(metadata.Versioning.Versions)
+	if latest != "" && compareMavenVersions(mavenVersion(version), latest) < 0 {
+		return fmt.Errorf("version %s is lower than the latest published version %s", mavenVersion(version), latest)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+// Package main provides tests for pre-deploy safety checks.
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// newMetadataServer serves an artifact-level maven-metadata.xml listing the given versions.
+func newMetadataServer(t *testing.T, path string, versions ...string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var b strings.Builder
+		b.WriteString("<metadata><groupId>com.example</groupId><artifactId>my-app</artifactId><versioning><versions>")
+		for _, v := range versions {
+			b.WriteString("<version>" + v + "</version>")
+		}
+		b.WriteString("</versions></versioning></metadata>")
+		_, _ = w.Write([]byte(b.String()))
+	}))
+}
+
+func TestCheckDowngrade(t *testing.T) {
+	server := newMetadataServer(t, "/releases/com/example/my-app/maven-metadata.xml", "1.0.0", "1.10.0", "1.9.0")
+	defer server.Close()
+
+	p := &MavenPlugin{httpClient: server.Client()}
+
+	tests := []struct {
+		name       string
+		repository string
+		version    string
+		errMsg     string
+	}{
+		{name: "newer version", repository: server.URL + "/releases", version: "1.11.0"},
+		{name: "tag prefix is ignored", repository: server.URL + "/releases", version: "v2.0.0"},
+		{name: "same version", repository: server.URL + "/releases", version: "1.10.0"},
+		{name: "first release", repository: server.URL + "/empty", version: "0.1.0"},
+		{
+			name:       "older version",
+			repository: server.URL + "/releases",
+			version:    "1.9.5",
+			errMsg:     "lower than the latest published version 1.10.0",
+		},
+		{
+			name:    "missing repository",
+			version: "1.0.0",
+			errMsg:  "requires a repository URL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{GroupID: "com.example", ArtifactID: "my-app", Repository: tt.repository}
+			err := p.checkDowngrade(context.Background(), cfg, tt.version)
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("expected error containing '%s', got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestCheckDowngradeSnapshotCredentials(t *testing.T) {
+	var users []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, _, _ := r.BasicAuth()
+		users = append(users, user)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cfg := &Config{
+		GroupID:            "com.example",
+		ArtifactID:         "my-app",
+		Repository:         server.URL + "/releases",
+		Username:           "deployer",
+		Password:           "secret",
+		SnapshotRepository: server.URL + "/snapshots",
+		SnapshotUsername:   "snapshot-deployer",
+		SnapshotPassword:   "snapshot-secret",
+	}
+	p := &MavenPlugin{httpClient: server.Client()}
+	for _, version := range []string{"1.1.0-SNAPSHOT", "1.1.0"} {
+		if err := p.checkDowngrade(context.Background(), cfg, version); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if len(users) != 2 || users[0] != "snapshot-deployer" || users[1] != "deployer" {
+		t.Errorf("expected the snapshot then the release credentials, got %v", users)
+	}
+}
+
+func TestExecuteDowngradePolicy(t *testing.T) {
+	server := newMetadataServer(t, "/releases/com/example/my-app/maven-metadata.xml", "2.0.0")
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		policy      string
+		wantSuccess bool
+		wantWarning bool
+		wantDeploy  bool
+	}{
+		{name: "off", policy: "off", wantSuccess: true, wantDeploy: true},
+		{name: "warn", policy: "warn", wantSuccess: true, wantWarning: true, wantDeploy: true},
+		{name: "fail", policy: "fail", wantSuccess: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockExec := &MockCommandExecutor{}
+			p := &MavenPlugin{executor: mockExec, httpClient: server.Client()}
+
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook: plugin.HookPostPublish,
+				Config: map[string]any{
+					"group_id":         "com.example",
+					"artifact_id":      "my-app",
+					"repository":       server.URL + "/releases",
+					"downgrade_policy": tt.policy,
+				},
+				Context: plugin.ReleaseContext{Version: "1.5.0"},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if resp.Success != tt.wantSuccess {
+				t.Fatalf("expected success=%v, got %+v", tt.wantSuccess, resp)
+			}
+			if !tt.wantSuccess && !strings.Contains(resp.Error, "downgrade protection") {
+				t.Errorf("expected downgrade protection error, got '%s'", resp.Error)
+			}
+			if _, ok := resp.Outputs["warnings"]; ok != tt.wantWarning {
+				t.Errorf("expected warnings present=%v, got outputs %v", tt.wantWarning, resp.Outputs)
+			}
+			if (len(mockExec.Calls) > 0) != tt.wantDeploy {
+				t.Errorf("expected deploy=%v, got %d calls", tt.wantDeploy, len(mockExec.Calls))
+			}
+		})
+	}
+}
+
+func TestValidateDowngradePolicy(t *testing.T) {
+	p := &MavenPlugin{}
+
+	resp, _ := p.Validate(context.Background(), map[string]any{
+		"group_id":         "com.example",
+		"artifact_id":      "my-app",
+		"downgrade_policy": "fail",
+	})
+	if resp.Valid {
+		t.Error("expected downgrade policy without repository to be invalid")
+	}
+
+	resp, _ = p.Validate(context.Background(), map[string]any{
+		"group_id":         "com.example",
+		"artifact_id":      "my-app",
+		"downgrade_policy": "sometimes",
+		"repository":       "http://localhost:8081/repository/maven-releases",
+	})
+	if resp.Valid {
+		t.Error("expected unknown downgrade policy to be invalid")
+	}
+}
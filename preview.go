@@ -0,0 +1,69 @@
+// Package main implements the dry-run preview of what a deploy will execute.
+package main
+
+import (
+	"fmt"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// credentialEnvVars maps credential options to their environment variable fallbacks.
+var credentialEnvVars = []struct {
+	Key    string
+	EnvKey string
+}{
+	{"username", "MAVEN_USERNAME"},
+	{"password", "MAVEN_PASSWORD"},
+	{"snapshot_username", "MAVEN_SNAPSHOT_USERNAME"},
+	{"snapshot_password", "MAVEN_SNAPSHOT_PASSWORD"},
+}
+
+// envOverrides returns the credential options whose values come from the
+// environment, mapped to the environment variable that supplied them.
+func envOverrides(parser *helpers.ConfigParser) map[string]string {
+	overrides := map[string]string{}
+	for _, v := range credentialEnvVars {
+		if parser.GetString(v.Key, "", "") == "" && parser.GetString(v.Key, v.EnvKey, "") != "" {
+			overrides[v.Key] = v.EnvKey
+		}
+	}
+	return overrides
+}
+
+// addPreview adds the resolved repositories, environment overrides, and the
+// masked settings.xml to dry-run outputs. It returns warnings for details that
+// cannot be resolved.
+func addPreview(cfg *Config, releaseCtx plugin.ReleaseContext, outputs map[string]any) []string {
+	repositories := map[string]string{}
+	if cfg.Repository != "" {
+		repositories["release"] = redactURL(cfg.Repository)
+	}
+	if cfg.SnapshotRepository != "" {
+		repositories["snapshot"] = redactURL(cfg.SnapshotRepository)
+	}
+	if target := cfg.targetRepository(releaseCtx.Version); target != "" {
+		repositories["target"] = redactURL(target)
+	}
+	if len(repositories) > 0 {
+		outputs["repository_urls"] = repositories
+	}
+
+	if len(cfg.EnvOverrides) > 0 {
+		outputs["environment"] = cfg.EnvOverrides
+	}
+
+	servers := cfg.settingsServers()
+	if len(servers) == 0 && len(cfg.ResolutionRepositories) == 0 {
+		if path := cfg.userSettings(); path != "" {
+			outputs["settings_file"] = path
+		}
+		return nil
+	}
+	data, err := cfg.resolveSettings(servers)
+	if err != nil {
+		return []string{fmt.Sprintf("cannot preview settings: %v", err)}
+	}
+	outputs["settings"] = string(maskSettings(data))
+	return nil
+}
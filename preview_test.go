@@ -0,0 +1,96 @@
+// Package main provides tests for the dry-run preview.
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestEnvOverrides(t *testing.T) {
+	t.Setenv("MAVEN_USERNAME", "env-user")
+	t.Setenv("MAVEN_PASSWORD", "env-secret")
+	t.Setenv("MAVEN_SNAPSHOT_USERNAME", "")
+
+	overrides := envOverrides(helpers.NewConfigParser(map[string]any{"password": "config-secret"}))
+	if len(overrides) != 1 || overrides["username"] != "MAVEN_USERNAME" {
+		t.Errorf("expected only username to come from the environment, got %v", overrides)
+	}
+}
+
+func TestExecuteDryRunPreview(t *testing.T) {
+	t.Setenv("MAVEN_PASSWORD", "env-secret")
+
+	mockExec := &MockCommandExecutor{}
+	p := &MavenPlugin{executor: mockExec}
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":            "com.example",
+			"artifact_id":         "my-app",
+			"username":            "deployer",
+			"repository":          "http://localhost:8081/repository/maven-releases",
+			"snapshot_repository": "http://localhost:8081/repository/maven-snapshots",
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0-SNAPSHOT"},
+		DryRun:  true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+	if len(mockExec.Calls) != 0 {
+		t.Errorf("expected no commands in dry run, got %d", len(mockExec.Calls))
+	}
+
+	settings, _ := resp.Outputs["settings"].(string)
+	if strings.Contains(settings, "env-secret") || !strings.Contains(settings, "<password>********</password>") {
+		t.Errorf("expected masked settings, got:\n%s", settings)
+	}
+	if !strings.Contains(settings, "<id>releases</id>") || !strings.Contains(settings, "<id>snapshots</id>") {
+		t.Errorf("expected release and snapshot servers, got:\n%s", settings)
+	}
+
+	repositories, _ := resp.Outputs["repository_urls"].(map[string]string)
+	if repositories["target"] != "http://localhost:8081/repository/maven-snapshots" || repositories["release"] == "" {
+		t.Errorf("unexpected repository URLs: %v", repositories)
+	}
+
+	environment, _ := resp.Outputs["environment"].(map[string]string)
+	if environment["password"] != "MAVEN_PASSWORD" {
+		t.Errorf("expected password to be reported as an environment override, got %v", environment)
+	}
+}
+
+func TestExecuteDryRunPreviewUserSettings(t *testing.T) {
+	p := &MavenPlugin{executor: &MockCommandExecutor{}}
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":                   "com.example",
+			"artifact_id":                "my-app",
+			"username":                   "deployer",
+			"settings":                   "missing-settings.xml",
+			"merge_settings_credentials": true,
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+		DryRun:  true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+	warnings, _ := resp.Outputs["warnings"].([]string)
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "cannot preview settings") {
+		t.Errorf("expected a settings preview warning, got %v", warnings)
+	}
+}
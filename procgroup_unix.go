@@ -0,0 +1,20 @@
+//go:build !windows
+
+// Package main implements terminating the process group of a cancelled command on Unix.
+package main
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup starts the command in its own process group and makes
+// cancelling its context kill the whole group, including the Surefire JVMs
+// and gpg processes Maven forks.
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+	cmd.WaitDelay = processWaitDelay
+}
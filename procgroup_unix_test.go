@@ -0,0 +1,56 @@
+//go:build !windows
+
+// Package main provides tests for terminating the process group of a cancelled command on Unix.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// processAlive reports whether a process runs; a killed process that was not
+// reaped yet is a zombie.
+func processAlive(pid int) bool {
+	if syscall.Kill(pid, 0) != nil {
+		return false
+	}
+	stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return true
+	}
+	_, state, _ := strings.Cut(string(stat), ") ")
+	return !strings.HasPrefix(state, "Z")
+}
+
+func TestRunKillsProcessGroup(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	// The shell forks a child that outlives it unless the group is killed.
+	started := time.Now()
+	output, err := (&RealCommandExecutor{}).Run(ctx, "sh", "-c", "sleep 30 & echo $!; wait")
+	if err == nil {
+		t.Fatal("expected the cancelled command to fail")
+	}
+	if elapsed := time.Since(started); elapsed > 5*time.Second {
+		t.Errorf("expected the command to stop on cancellation, took %s", elapsed)
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(output)))
+	if err != nil {
+		t.Fatalf("unexpected output %q", output)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for processAlive(pid) {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the forked process %d to be killed", pid)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,187 @@
+// Package main implements reporting the progress of a Maven reactor build.
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StreamingCommandExecutor is a CommandExecutor that also passes each line of
+// output to onLine while the command runs.
+type StreamingCommandExecutor interface {
+	CommandExecutor
+	RunStreaming(ctx context.Context, onLine func(string), name string, args ...string) ([]byte, error)
+}
+
+// RunStreaming executes a command, passing each line of combined output to
+// onLine, and returns the combined output.
+func (e *RealCommandExecutor) RunStreaming(ctx context.Context, onLine func(string), name string, args ...string) ([]byte, error) {
+	var output bytes.Buffer
+	lines := &lineWriter{onLine: onLine}
+	w := io.MultiWriter(&output, lines)
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	applyCommandEnv(ctx, cmd)
+	setProcessGroup(cmd)
+	cmd.Stdout = w
+	cmd.Stderr = w
+	err := cmd.Run()
+	lines.flush()
+	return output.Bytes(), err
+}
+
+// lineWriter splits written bytes into lines.
+type lineWriter struct {
+	onLine  func(string)
+	pending []byte
+}
+
+// Write passes every completed line to onLine and keeps the remainder.
+func (w *lineWriter) Write(data []byte) (int, error) {
+	w.pending = append(w.pending, data...)
+	for {
+		idx := bytes.IndexByte(w.pending, '\n')
+		if idx < 0 {
+			break
+		}
+		w.onLine(strings.TrimRight(string(w.pending[:idx]), "\r"))
+		w.pending = w.pending[idx+1:]
+	}
+	return len(data), nil
+}
+
+// flush passes an unterminated last line to onLine.
+func (w *lineWriter) flush() {
+	if len(w.pending) > 0 {
+		w.onLine(strings.TrimRight(string(w.pending), "\r"))
+		w.pending = nil
+	}
+}
+
+// Patterns of the Maven build log.
+var (
+	// ansiPattern matches terminal color sequences.
+	ansiPattern = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+	// buildingPattern matches the header of a module: "Building name version [n/N]",
+	// without the counter in a single-module build.
+	buildingPattern = regexp.MustCompile(`^\[INFO\] Building (.+?)(?:\s+\[(\d+)/(\d+)\])?$`)
+	// mojoPattern matches the header of a plugin goal execution: "--- plugin:version:goal (execution) @ module ---".
+	mojoPattern = regexp.MustCompile(`^\[INFO\] --- (\S+) \(([^)]*)\) @ (\S+) ---$`)
+	// summaryPattern matches a module line of the reactor summary: "name ..... SUCCESS [ 1.2 s]".
+	summaryPattern = regexp.MustCompile(`^\[INFO\] (.+?) \.+ ?(SUCCESS|FAILURE|SKIPPED)(?: \[\s*([^\]]+?)\s*\])?$`)
+)
+
+// reactorModuleResult is one module of the reactor summary.
+type reactorModuleResult struct {
+	Module   string `json:"module"`
+	Status   string `json:"status"`
+	Duration string `json:"duration,omitempty"`
+}
+
+// reactorProgress follows the build log of Maven runs.
+type reactorProgress struct {
+	// Module is the module being built, with its version.
+	Module string
+	// Index and Total place Module in the reactor; Total is 0 for a single-module build.
+	Index int
+	Total int
+	// Goal is the plugin goal running in Module.
+	Goal string
+	// Summary collects the reactor summaries of the runs.
+	Summary []reactorModuleResult
+	// Built lists the modules whose build finished, known before the reactor
+	// summary is logged.
+	Built []string
+
+	inSummary bool
+}
+
+// observe reads one line of the build log and reports whether the module or
+// goal being run changed.
+func (r *reactorProgress) observe(line string) bool {
+	line = strings.TrimSpace(ansiPattern.ReplaceAllString(line, ""))
+
+	// Packaging plugins log "Building jar: path" too.
+	if m := buildingPattern.FindStringSubmatch(line); m != nil && !strings.Contains(m[1], ": ") {
+		if r.Module != "" && !slices.Contains(r.Built, r.Module) {
+			r.Built = append(r.Built, r.Module)
+		}
+		r.Module = m[1]
+		r.Index, _ = strconv.Atoi(m[2])
+		r.Total, _ = strconv.Atoi(m[3])
+		r.Goal = ""
+		r.inSummary = false
+		return true
+	}
+	if m := mojoPattern.FindStringSubmatch(line); m != nil {
+		r.Goal = m[1]
+		return true
+	}
+
+	switch {
+	case strings.HasPrefix(line, "[INFO] Reactor Summary"):
+		r.inSummary = true
+	case r.inSummary:
+		if m := summaryPattern.FindStringSubmatch(line); m != nil {
+			r.Summary = append(r.Summary, reactorModuleResult{Module: m[1], Status: m[2], Duration: m[3]})
+		} else if strings.HasPrefix(line, "[INFO] BUILD ") || strings.HasPrefix(line, "[INFO] ---") {
+			r.inSummary = false
+		}
+	}
+	return false
+}
+
+// String renders the current position, e.g. "module 2 of 5: core 1.0.0 (maven-deploy-plugin:3.1.1:deploy)".
+func (r *reactorProgress) String() string {
+	var b strings.Builder
+	if r.Total > 0 {
+		fmt.Fprintf(&b, "module %d of %d: ", r.Index, r.Total)
+	}
+	b.WriteString(r.Module)
+	if r.Goal != "" {
+		fmt.Fprintf(&b, " (%s)", r.Goal)
+	}
+	return b.String()
+}
+
+// runMaven runs a Maven invocation and follows its build log. When the
+// executor streams output each change of module or goal is logged to the
+// plugin's stderr, which the Relicta host forwards to its log; otherwise the
+// log is read once the run finishes. A heartbeat is logged every
+// heartbeat_interval while the build runs.
+func (p *MavenPlugin) runMaven(ctx context.Context, cfg *Config, progress *reactorProgress, args []string) ([]byte, error) {
+	ctx = withCommandEnv(ctx, append(sourceDateEpochEnv(cfg), mavenOptsEnv(cfg)...))
+	executor := p.getExecutor()
+	hb := newHeartbeat(p.getProgressLog(), progress)
+	stop := hb.start(time.Duration(cfg.HeartbeatInterval) * time.Second)
+	defer stop()
+	if streaming, ok := executor.(StreamingCommandExecutor); ok {
+		output, err := streaming.RunStreaming(ctx, hb.observe, cfg.mavenCommand(), args...)
+		recordMavenOutput(ctx, cfg, args, output)
+		return output, err
+	}
+
+	output, err := p.runMavenCommand(ctx, cfg, args...)
+	stop()
+	for _, line := range strings.Split(string(output), "\n") {
+		progress.observe(line)
+	}
+	return output, err
+}
+
+// getProgressLog returns the writer for progress lines, defaulting to stderr.
+func (p *MavenPlugin) getProgressLog() io.Writer {
+	if p.progressLog != nil {
+		return p.progressLog
+	}
+	return os.Stderr
+}
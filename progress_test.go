@@ -0,0 +1,176 @@
+// Package main provides tests for reactor progress reporting.
+package main
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// reactorLog is the build log of a three-module deploy whose last module fails.
+const reactorLog = `[INFO] Scanning for projects...
+[INFO] ------------------------------------------------------------------------
+[INFO] Reactor Build Order:
+[INFO]
+[INFO] parent                                                             [pom]
+[INFO] core                                                               [jar]
+[INFO] app                                                                [jar]
+[INFO]
+[INFO] --------------------------< com.example:parent >--------------------------
+[INFO] Building parent 1.0.0                                              [1/3]
+[INFO]   from pom.xml
+[INFO] --------------------------------[ pom ]---------------------------------
+[INFO]
+[INFO] --- deploy:3.1.1:deploy (default-deploy) @ parent ---
+[INFO]
+[INFO] ---------------------------< com.example:core >---------------------------
+[INFO] Building core 1.0.0                                                [2/3]
+[INFO] --- maven-jar-plugin:3.3.0:jar (default-jar) @ core ---
+[INFO] Building jar: /work/core/target/core-1.0.0.jar
+[INFO] --- maven-deploy-plugin:3.1.1:deploy (default-deploy) @ core ---
+[INFO] ----------------------------< com.example:app >---------------------------
+[INFO] Building app 1.0.0                                                 [3/3]
+[INFO] --- maven-compiler-plugin:3.13.0:compile (default-compile) @ app ---
+[INFO] ------------------------------------------------------------------------
+[INFO] Reactor Summary for parent 1.0.0:
+[INFO]
+[INFO] parent ............................................. SUCCESS [  0.412 s]
+[INFO] core ............................................... SUCCESS [  3.021 s]
+[INFO] app ................................................ FAILURE [  1.100 s]
+[INFO] ------------------------------------------------------------------------
+[INFO] BUILD FAILURE
+[INFO] ------------------------------------------------------------------------
+`
+
+func TestReactorProgress(t *testing.T) {
+	progress := &reactorProgress{}
+	var changes []string
+	for _, line := range strings.Split(reactorLog, "\n") {
+		if progress.observe(line) {
+			changes = append(changes, progress.String())
+		}
+	}
+
+	wantChanges := []string{
+		"module 1 of 3: parent 1.0.0",
+		"module 1 of 3: parent 1.0.0 (deploy:3.1.1:deploy)",
+		"module 2 of 3: core 1.0.0",
+		"module 2 of 3: core 1.0.0 (maven-jar-plugin:3.3.0:jar)",
+		"module 2 of 3: core 1.0.0 (maven-deploy-plugin:3.1.1:deploy)",
+		"module 3 of 3: app 1.0.0",
+		"module 3 of 3: app 1.0.0 (maven-compiler-plugin:3.13.0:compile)",
+	}
+	if !reflect.DeepEqual(changes, wantChanges) {
+		t.Errorf("changes = %q, want %q", changes, wantChanges)
+	}
+
+	wantSummary := []reactorModuleResult{
+		{Module: "parent", Status: "SUCCESS", Duration: "0.412 s"},
+		{Module: "core", Status: "SUCCESS", Duration: "3.021 s"},
+		{Module: "app", Status: "FAILURE", Duration: "1.100 s"},
+	}
+	if !reflect.DeepEqual(progress.Summary, wantSummary) {
+		t.Errorf("summary = %+v, want %+v", progress.Summary, wantSummary)
+	}
+}
+
+func TestReactorProgressSingleModule(t *testing.T) {
+	progress := &reactorProgress{}
+	progress.observe("\x1b[1;34m[INFO]\x1b[m Building my-app 1.0.0")
+	progress.observe("[INFO] --- maven-deploy-plugin:3.1.1:deploy (default-deploy) @ my-app ---")
+
+	if got := progress.String(); got != "my-app 1.0.0 (maven-deploy-plugin:3.1.1:deploy)" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestLineWriter(t *testing.T) {
+	var lines []string
+	w := &lineWriter{onLine: func(line string) { lines = append(lines, line) }}
+	_, _ = w.Write([]byte("first\r\nsec"))
+	_, _ = w.Write([]byte("ond\nlast"))
+	w.flush()
+
+	if want := []string{"first", "second", "last"}; !reflect.DeepEqual(lines, want) {
+		t.Errorf("lines = %q, want %q", lines, want)
+	}
+}
+
+// streamingExecutor is a StreamingCommandExecutor replaying a build log.
+type streamingExecutor struct {
+	MockCommandExecutor
+	log string
+	err error
+}
+
+// RunStreaming implements StreamingCommandExecutor.
+func (e *streamingExecutor) RunStreaming(ctx context.Context, onLine func(string), name string, args ...string) ([]byte, error) {
+	_, _ = e.Run(ctx, name, args...)
+	for _, line := range strings.Split(e.log, "\n") {
+		onLine(line)
+	}
+	return []byte(e.log), e.err
+}
+
+func TestExecuteReactorProgress(t *testing.T) {
+	var log strings.Builder
+	exec := &streamingExecutor{log: reactorLog, err: errors.New("exit status 1")}
+	p := &MavenPlugin{executor: exec, progressLog: &log}
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":    "com.example",
+			"artifact_id": "parent",
+			"repository":  "http://localhost:8081/repository/maven-releases",
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Success {
+		t.Fatal("expected failure")
+	}
+
+	if !strings.Contains(log.String(), "maven: module 3 of 3: app 1.0.0 (maven-compiler-plugin:3.13.0:compile)\n") {
+		t.Errorf("expected progress lines, got:\n%s", log.String())
+	}
+	summary, ok := resp.Outputs["reactor_summary"].([]reactorModuleResult)
+	if !ok || len(summary) != 3 || summary[2].Status != "FAILURE" {
+		t.Errorf("unexpected reactor_summary %v", resp.Outputs["reactor_summary"])
+	}
+}
+
+func TestExecuteReactorSummary(t *testing.T) {
+	mockExec := &MockCommandExecutor{
+		RunFunc: func(ctx context.Context, name string, args ...string) ([]byte, error) {
+			return []byte(strings.ReplaceAll(reactorLog, "FAILURE", "SUCCESS")), nil
+		},
+	}
+	p := &MavenPlugin{executor: mockExec}
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":    "com.example",
+			"artifact_id": "parent",
+			"repository":  "http://localhost:8081/repository/maven-releases",
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+	summary, ok := resp.Outputs["reactor_summary"].([]reactorModuleResult)
+	if !ok || len(summary) != 3 {
+		t.Errorf("unexpected reactor_summary %v", resp.Outputs["reactor_summary"])
+	}
+}
@@ -0,0 +1,206 @@
+// Package main implements promoting a staged release without rebuilding it.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// artifactoryLocation splits an Artifactory repository URL into the server
+// base URL and the repository key, the last path segment.
+func artifactoryLocation(repoURL string) (string, string, error) {
+	parsedURL, err := url.Parse(repoURL)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid repository URL: %w", err)
+	}
+	path := strings.TrimSuffix(parsedURL.Path, "/")
+	idx := strings.LastIndex(path, "/")
+	if idx < 0 || path[idx+1:] == "" {
+		return "", "", fmt.Errorf("cannot determine Artifactory repository key from URL")
+	}
+
+	base := *parsedURL
+	base.Path = path[:idx]
+	base.RawQuery = ""
+	base.Fragment = ""
+	return strings.TrimSuffix(base.String(), "/"), path[idx+1:], nil
+}
+
+// nexusMoveResponse is the subset of the Nexus staging move response the plugin uses.
+type nexusMoveResponse struct {
+	Data struct {
+		ComponentsMoved []json.RawMessage `json:"components moved"`
+	} `json:"data"`
+}
+
+// promoteComponent moves or copies the GAV from the source repository into
+// the client's repository on the same repository manager. Nexus moves the
+// component through the staging API; Artifactory copies the version folder.
+func (c *repositoryClient) promoteComponent(ctx context.Context, repoType, sourceURL, groupID, artifactID, version string) error {
+	switch repoType {
+	case repositoryTypeNexus:
+		return c.moveNexusComponent(ctx, sourceURL, groupID, artifactID, version)
+	case repositoryTypeArtifactory:
+		return c.copyArtifactoryFolder(ctx, sourceURL, groupID, artifactID, version)
+	default:
+		return fmt.Errorf("promotion is not supported for repository type %q", repoType)
+	}
+}
+
+// moveNexusComponent moves the GAV with the Nexus staging move API.
+func (c *repositoryClient) moveNexusComponent(ctx context.Context, sourceURL, groupID, artifactID, version string) error {
+	base, destination, err := nexusLocation(c.url)
+	if err != nil {
+		return err
+	}
+	sourceBase, source, err := nexusLocation(sourceURL)
+	if err != nil {
+		return fmt.Errorf("promote_from: %w", err)
+	}
+	if sourceBase != base {
+		return fmt.Errorf("promote_from and repository must be on the same Nexus server")
+	}
+
+	query := url.Values{}
+	query.Set("repository", source)
+	query.Set("maven.groupId", groupID)
+	query.Set("maven.artifactId", artifactID)
+	query.Set("version", version)
+
+	resp, err := c.do(ctx, http.MethodPost, base+"/service/rest/v1/staging/move/"+url.PathEscape(destination)+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return errComponentNotFound
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("nexus staging move returned %s", resp.Status)
+	}
+
+	var result nexusMoveResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 10<<20)).Decode(&result); err != nil {
+		return fmt.Errorf("failed to parse nexus staging move response: %w", err)
+	}
+	if len(result.Data.ComponentsMoved) == 0 {
+		return errComponentNotFound
+	}
+	return nil
+}
+
+// copyArtifactoryFolder copies the GAV version folder with the Artifactory copy API.
+func (c *repositoryClient) copyArtifactoryFolder(ctx context.Context, sourceURL, groupID, artifactID, version string) error {
+	base, destination, err := artifactoryLocation(c.url)
+	if err != nil {
+		return err
+	}
+	sourceBase, source, err := artifactoryLocation(sourceURL)
+	if err != nil {
+		return fmt.Errorf("promote_from: %w", err)
+	}
+	if sourceBase != base {
+		return fmt.Errorf("promote_from and repository must be on the same Artifactory server")
+	}
+
+	path := gavPath(groupID, artifactID, version)
+	query := url.Values{}
+	query.Set("to", "/"+destination+"/"+path)
+	target := base + "/api/copy/" + url.PathEscape(source) + "/" + path + "?" + query.Encode()
+
+	resp, err := c.do(ctx, http.MethodPost, target, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return errComponentNotFound
+	case resp.StatusCode >= 300:
+		return fmt.Errorf("artifactory copy returned %s", resp.Status)
+	}
+	return nil
+}
+
+// validatePromotion checks that promotion can be performed for the configuration.
+func validatePromotion(repoType, repository string) error {
+	if repoType != repositoryTypeNexus && repoType != repositoryTypeArtifactory {
+		return fmt.Errorf("promote_from requires repository_type to be %q or %q", repositoryTypeNexus, repositoryTypeArtifactory)
+	}
+	if repository == "" {
+		return fmt.Errorf("promote_from requires a repository URL to promote into")
+	}
+	if isCentralRepository(repository) {
+		return fmt.Errorf("promotion into Maven Central is not supported")
+	}
+	return nil
+}
+
+// promote publishes the release by promoting the artifacts already deployed
+// to the promote_from repository, so what was tested is what is released.
+func (p *MavenPlugin) promote(ctx context.Context, cfg *Config, releaseCtx plugin.ReleaseContext, dryRun bool) (*plugin.ExecuteResponse, error) {
+	version := mavenVersion(releaseCtx.Version)
+	repository := cfg.targetRepository(version)
+	if err := validatePromotion(cfg.RepositoryType, repository); err != nil {
+		return &plugin.ExecuteResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+
+	outputs := map[string]any{
+		"group_id":      cfg.GroupID,
+		"artifact_id":   cfg.ArtifactID,
+		"version":       version,
+		"promoted_from": redactURL(cfg.PromoteFrom),
+		"repository":    redactURL(repository),
+	}
+	if dryRun {
+		return &plugin.ExecuteResponse{
+			Success: true,
+			Message: "Would promote Maven artifact",
+			Outputs: outputs,
+		}, nil
+	}
+
+	username, password := cfg.targetCredentials(version)
+	client := newRepositoryClient(p.getHTTPClient(), repository, username, password)
+	err := client.promoteComponent(ctx, cfg.RepositoryType, cfg.PromoteFrom, cfg.GroupID, cfg.ArtifactID, version)
+	if errors.Is(err, errComponentNotFound) {
+		err = fmt.Errorf("%s:%s:%s was not found in %s", cfg.GroupID, cfg.ArtifactID, version, redactURL(cfg.PromoteFrom))
+	}
+	if err != nil {
+		return &plugin.ExecuteResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Maven promotion failed: %v", err),
+			Outputs: outputs,
+		}, nil
+	}
+
+	// Rolling back deletes the release from the repository. A Nexus move
+	// already removed it from promote_from, so only copies are rolled back.
+	if cfg.RepositoryType != repositoryTypeNexus {
+		p.recordDeploy(deployRecord{
+			GroupID:    cfg.GroupID,
+			ArtifactID: cfg.ArtifactID,
+			Version:    version,
+			Repository: repository,
+		})
+	}
+	outputs["promoted"] = true
+	return &plugin.ExecuteResponse{
+		Success: true,
+		Message: fmt.Sprintf("Promoted Maven artifact %s:%s:%s", cfg.GroupID, cfg.ArtifactID, version),
+		Outputs: outputs,
+	}, nil
+}
@@ -0,0 +1,151 @@
+// Package main provides tests for promoting staged releases.
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestArtifactoryLocation(t *testing.T) {
+	base, key, err := artifactoryLocation("https://artifacts.example.com/artifactory/libs-release-local/")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if base != "https://artifacts.example.com/artifactory" || key != "libs-release-local" {
+		t.Errorf("unexpected location %s, %s", base, key)
+	}
+
+	if _, _, err := artifactoryLocation("https://artifacts.example.com"); err == nil {
+		t.Error("expected an error without a repository key")
+	}
+}
+
+func TestExecutePromoteNexus(t *testing.T) {
+	var requests []string
+	moved := `{"status":200,"message":"Move Successful","data":{"destination":"maven-releases","components moved":[{"name":"my-app","group":"com.example","version":"1.0.0"}]}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path+"?"+r.URL.RawQuery)
+		if r.URL.Query().Get("version") != "1.0.0" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(moved))
+	}))
+	defer server.Close()
+
+	config := map[string]any{
+		"group_id":        "com.example",
+		"artifact_id":     "my-app",
+		"repository":      server.URL + "/repository/maven-releases",
+		"promote_from":    server.URL + "/repository/maven-staging",
+		"repository_type": "nexus",
+	}
+	mockExec := &MockCommandExecutor{}
+	p := &MavenPlugin{executor: mockExec, httpClient: server.Client()}
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:    plugin.HookPostPublish,
+		Config:  config,
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success || resp.Outputs["promoted"] != true {
+		t.Fatalf("expected promotion, got %+v", resp)
+	}
+	if len(mockExec.Calls) != 0 {
+		t.Errorf("expected no build, got %d commands", len(mockExec.Calls))
+	}
+	if len(requests) != 1 || !strings.HasPrefix(requests[0], "POST /service/rest/v1/staging/move/maven-releases?") || !strings.Contains(requests[0], "repository=maven-staging") {
+		t.Errorf("unexpected requests: %v", requests)
+	}
+	if _, ok := p.findDeploy("com.example", "my-app", "1.0.0"); ok {
+		t.Error("expected the moved release not to be recorded for rollback")
+	}
+
+	resp, _ = p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:    plugin.HookPostPublish,
+		Config:  config,
+		Context: plugin.ReleaseContext{Version: "2.0.0"},
+	})
+	if resp.Success || !strings.Contains(resp.Error, "was not found in") {
+		t.Errorf("expected a missing staged release to fail, got %+v", resp)
+	}
+}
+
+func TestExecutePromoteArtifactory(t *testing.T) {
+	var request string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request = r.Method + " " + r.URL.Path + "?" + r.URL.Query().Get("to")
+		_, _ = w.Write([]byte(`{"messages":[{"level":"INFO","message":"copy completed"}]}`))
+	}))
+	defer server.Close()
+
+	p := &MavenPlugin{httpClient: server.Client()}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":        "com.example",
+			"artifact_id":     "my-app",
+			"repository":      server.URL + "/artifactory/libs-release-local",
+			"promote_from":    server.URL + "/artifactory/libs-staging-local",
+			"repository_type": "artifactory",
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+	want := "POST /artifactory/api/copy/libs-staging-local/com/example/my-app/1.0.0?/libs-release-local/com/example/my-app/1.0.0"
+	if request != want {
+		t.Errorf("expected %q, got %q", want, request)
+	}
+	if _, ok := p.findDeploy("com.example", "my-app", "1.0.0"); !ok {
+		t.Error("expected the copied release to be recorded for rollback")
+	}
+}
+
+func TestPromoteDifferentServers(t *testing.T) {
+	client := newRepositoryClient(http.DefaultClient, "http://localhost:8081/repository/maven-releases", "", "")
+	err := client.promoteComponent(context.Background(), repositoryTypeNexus, "http://localhost:8082/repository/maven-staging", "com.example", "my-app", "1.0.0")
+	if err == nil || !strings.Contains(err.Error(), "same Nexus server") {
+		t.Errorf("expected an error for different servers, got %v", err)
+	}
+}
+
+func TestValidatePromotion(t *testing.T) {
+	tests := []struct {
+		name       string
+		repoType   string
+		repository string
+		errMsg     string
+	}{
+		{name: "nexus", repoType: repositoryTypeNexus, repository: "https://nexus.example.com/repository/maven-releases"},
+		{name: "generic", repoType: repositoryTypeGeneric, repository: "https://nexus.example.com/repository/maven-releases", errMsg: "requires repository_type"},
+		{name: "no repository", repoType: repositoryTypeArtifactory, errMsg: "requires a repository URL"},
+		{name: "central", repoType: repositoryTypeNexus, repository: "https://repo1.maven.org/maven2", errMsg: "Maven Central"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePromotion(tt.repoType, tt.repository)
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("expected error containing %q, got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
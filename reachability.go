@@ -0,0 +1,79 @@
+// Package main implements the repository reachability check of deep validation.
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+)
+
+// probeTimeout bounds each repository probe of deep validation.
+const probeTimeout = 15 * time.Second
+
+// probe checks that the repository answers and accepts the credentials. HEAD
+// is tried first, falling back to OPTIONS for servers that reject it.
+// Repositories that do not serve their root still pass unless they reject
+// the credentials or fail.
+func (c *repositoryClient) probe(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	var status int
+	for _, method := range []string{http.MethodHead, http.MethodOptions} {
+		resp, err := c.do(ctx, method, c.url+"/", nil)
+		if err != nil {
+			return fmt.Errorf("repository is unreachable: %w", err)
+		}
+		resp.Body.Close()
+		status = resp.StatusCode
+		if status != http.StatusMethodNotAllowed && status != http.StatusNotImplemented {
+			break
+		}
+	}
+
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		if c.username == "" && c.password == "" {
+			return fmt.Errorf("repository requires credentials (%d %s)", status, http.StatusText(status))
+		}
+		return fmt.Errorf("repository rejected the credentials (%d %s)", status, http.StatusText(status))
+	case status >= http.StatusInternalServerError:
+		return fmt.Errorf("repository returned %d %s", status, http.StatusText(status))
+	}
+	return nil
+}
+
+// deepValidate probes every configured repository with its credentials.
+// Repositories whose URL failed validation are not contacted.
+func (p *MavenPlugin) deepValidate(ctx context.Context, vb *helpers.ValidationBuilder, cfg *Config) {
+	type target struct {
+		field, url, username, password string
+	}
+	var targets []target
+	if cfg.Repository != "" {
+		targets = append(targets, target{"repository", cfg.Repository, cfg.Username, cfg.Password})
+	}
+	if cfg.SnapshotRepository != "" && cfg.SnapshotRepository != cfg.Repository {
+		username, password := cfg.SnapshotUsername, cfg.SnapshotPassword
+		if username == "" && password == "" {
+			username, password = cfg.Username, cfg.Password
+		}
+		targets = append(targets, target{"snapshot_repository", cfg.SnapshotRepository, username, password})
+	}
+	for i, repo := range cfg.Repositories {
+		targets = append(targets, target{fmt.Sprintf("repositories[%d].url", i), repo.URL, repo.Username, repo.Password})
+	}
+
+	for _, t := range targets {
+		if validateRepositoryURL(t.url, cfg.networkPolicy()) != nil {
+			continue
+		}
+		client := newRepositoryClient(p.getHTTPClient(), t.url, t.username, t.password)
+		if err := client.probe(ctx); err != nil {
+			vb.AddError(t.field, err.Error())
+		}
+	}
+}
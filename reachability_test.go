@@ -0,0 +1,104 @@
+// Package main provides tests for the deep validation reachability check.
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDeepValidate(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		if r.Method == http.MethodHead && strings.Contains(r.URL.Path, "no-head") {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if user, pass, ok := r.BasicAuth(); !ok || user != "deployer" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if strings.Contains(r.URL.Path, "broken") {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		path     string
+		password string
+		errMsg   string
+		methods  []string
+	}{
+		{name: "reachable", path: "/releases", password: "secret", methods: []string{"HEAD"}},
+		{name: "head not allowed", path: "/no-head", password: "secret", methods: []string{"HEAD", "OPTIONS"}},
+		{name: "wrong credentials", path: "/releases", password: "wrong", errMsg: "rejected the credentials (401 Unauthorized)"},
+		{name: "missing credentials", path: "/releases", errMsg: "requires credentials"},
+		{name: "server error", path: "/broken", password: "secret", errMsg: "returned 502"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			methods = nil
+			config := map[string]any{
+				"group_id":      "com.example",
+				"artifact_id":   "my-app",
+				"repository":    server.URL + tt.path,
+				"deep_validate": true,
+			}
+			if tt.password != "" {
+				config["username"] = "deployer"
+				config["password"] = tt.password
+			}
+
+			p := &MavenPlugin{httpClient: server.Client()}
+			resp, err := p.Validate(context.Background(), config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.errMsg == "" {
+				if !resp.Valid {
+					t.Fatalf("expected valid config, got errors: %v", resp.Errors)
+				}
+				if strings.Join(methods, ",") != strings.Join(tt.methods, ",") {
+					t.Errorf("expected methods %v, got %v", tt.methods, methods)
+				}
+				return
+			}
+			found := false
+			for _, e := range resp.Errors {
+				if e.Field == "repository" && strings.Contains(e.Message, tt.errMsg) {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("expected repository error containing %q, got %v", tt.errMsg, resp.Errors)
+			}
+		})
+	}
+
+	t.Run("unreachable", func(t *testing.T) {
+		closed := httptest.NewServer(http.NotFoundHandler())
+		closed.Close()
+
+		p := &MavenPlugin{}
+		resp, err := p.Validate(context.Background(), map[string]any{
+			"group_id":      "com.example",
+			"artifact_id":   "my-app",
+			"repository":    closed.URL,
+			"deep_validate": true,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Valid || !strings.Contains(resp.Errors[0].Message, "unreachable") {
+			t.Errorf("expected unreachable error, got %v", resp.Errors)
+		}
+	})
+}
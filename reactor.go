@@ -0,0 +1,109 @@
+// Package main implements reading the module structure of a Maven reactor.
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// pomPackaging is the packaging of parent and aggregator POMs.
+const pomPackaging = "pom"
+
+// reactorModule is a project of a multi-module reactor.
+type reactorModule struct {
+	// Dir is the module directory relative to the reactor root.
+	Dir string
+	POM *pomModel
+}
+
+// key returns the groupId:artifactId selector of the module.
+func (m reactorModule) key() string {
+	return pomKey(m.POM)
+}
+
+// readReactor reads the POM at pomPath and every module it aggregates, recursively.
+func readReactor(pomPath string) ([]reactorModule, error) {
+	rootDir := filepath.Dir(pomPath)
+	var modules []reactorModule
+	seen := map[string]bool{}
+
+	var walk func(dir, path string) error
+	walk = func(dir, path string) error {
+		if seen[path] {
+			return nil
+		}
+		seen[path] = true
+
+		pom, err := readPOM(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		rel, err := filepath.Rel(rootDir, dir)
+		if err != nil {
+			return err
+		}
+		modules = append(modules, reactorModule{Dir: filepath.ToSlash(rel), POM: pom})
+
+		for _, module := range pom.Modules {
+			moduleDir := filepath.Join(dir, filepath.FromSlash(module))
+			modulePath := filepath.Join(moduleDir, "pom.xml")
+			if strings.HasSuffix(module, ".xml") {
+				modulePath = moduleDir
+				moduleDir = filepath.Dir(moduleDir)
+			}
+			if err := walk(moduleDir, modulePath); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := walk(rootDir, pomPath); err != nil {
+		return nil, err
+	}
+	return modules, nil
+}
+
+// aggregatorModules returns the selectors of the reactor's aggregator-only
+// modules: pom-packaged projects that list modules but are not the parent of
+// any project, so nothing needs their POM at resolution time.
+func aggregatorModules(modules []reactorModule) []string {
+	parents := map[string]bool{}
+	for _, m := range modules {
+		if m.POM.Parent.ArtifactID != "" {
+			parents[m.POM.Parent.GroupID+":"+m.POM.Parent.ArtifactID] = true
+		}
+	}
+
+	var aggregators []string
+	for _, m := range modules {
+		if m.POM.Packaging == pomPackaging && len(m.POM.Modules) > 0 && !parents[m.key()] {
+			aggregators = append(aggregators, m.key())
+		}
+	}
+	return aggregators
+}
+
+// projectSelection returns the -pl value for the deploy: the configured module
+// plus exclusions for aggregator-only modules when they are skipped.
+func (cfg *Config) projectSelection(pomPath string) (string, error) {
+	var projects []string
+	if cfg.Module != "" {
+		if err := validatePath(cfg.Module); err != nil {
+			return "", fmt.Errorf("invalid module: %w", err)
+		}
+		projects = append(projects, cfg.Module)
+	}
+
+	if cfg.SkipAggregators {
+		modules, err := readReactor(pomPath)
+		if err != nil {
+			return "", fmt.Errorf("failed to read reactor: %w", err)
+		}
+		for _, key := range aggregatorModules(modules) {
+			projects = append(projects, "!"+key)
+		}
+	}
+	return strings.Join(projects, ","), nil
+}
@@ -0,0 +1,121 @@
+// Package main provides tests for reading Maven reactors.
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// writeReactor writes POM files keyed by path relative to a new reactor root
+// and returns the root directory.
+func writeReactor(t *testing.T, poms map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for rel, content := range poms {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+// sampleReactor is an aggregator root whose modules share a separate parent
+// POM, with a nested aggregator for the examples.
+var sampleReactor = map[string]string{
+	"pom.xml": `<project><modelVersion>4.0.0</modelVersion>
+  <groupId>com.example</groupId><artifactId>my-app-aggregator</artifactId><version>1.0.0</version>
+  <packaging>pom</packaging>
+  <modules><module>parent</module><module>core</module><module>examples</module></modules>
+</project>`,
+	"parent/pom.xml": `<project><modelVersion>4.0.0</modelVersion>
+  <groupId>com.example</groupId><artifactId>my-app-parent</artifactId><version>1.0.0</version>
+  <packaging>pom</packaging>
+</project>`,
+	"core/pom.xml": `<project><modelVersion>4.0.0</modelVersion>
+  <parent><groupId>com.example</groupId><artifactId>my-app-parent</artifactId><version>1.0.0</version></parent>
+  <artifactId>my-app</artifactId>
+</project>`,
+	"examples/pom.xml": `<project><modelVersion>4.0.0</modelVersion>
+  <parent><groupId>com.example</groupId><artifactId>my-app-parent</artifactId><version>1.0.0</version></parent>
+  <artifactId>my-app-examples</artifactId>
+  <packaging>pom</packaging>
+  <modules><module>basic/pom.xml</module></modules>
+</project>`,
+	"examples/basic/pom.xml": `<project><modelVersion>4.0.0</modelVersion>
+  <parent><groupId>com.example</groupId><artifactId>my-app-parent</artifactId><version>1.0.0</version></parent>
+  <artifactId>my-app-example-basic</artifactId>
+</project>`,
+}
+
+func TestReadReactor(t *testing.T) {
+	root := writeReactor(t, sampleReactor)
+
+	modules, err := readReactor(filepath.Join(root, "pom.xml"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var dirs []string
+	for _, m := range modules {
+		dirs = append(dirs, m.Dir)
+	}
+	want := []string{".", "parent", "core", "examples", "examples/basic"}
+	if !slices.Equal(dirs, want) {
+		t.Errorf("expected modules %v, got %v", want, dirs)
+	}
+
+	aggregators := aggregatorModules(modules)
+	wantAggregators := []string{"com.example:my-app-aggregator", "com.example:my-app-examples"}
+	if !slices.Equal(aggregators, wantAggregators) {
+		t.Errorf("expected aggregators %v, got %v", wantAggregators, aggregators)
+	}
+}
+
+func TestReadReactorMissingModule(t *testing.T) {
+	root := writeReactor(t, map[string]string{
+		"pom.xml": `<project><modelVersion>4.0.0</modelVersion><artifactId>root</artifactId>
+  <packaging>pom</packaging><modules><module>missing</module></modules></project>`,
+	})
+	if _, err := readReactor(filepath.Join(root, "pom.xml")); err == nil {
+		t.Error("expected an error for a missing module")
+	}
+}
+
+func TestExecuteSkipAggregators(t *testing.T) {
+	root := writeReactor(t, sampleReactor)
+	chdir(t, root)
+
+	mockExec := &MockCommandExecutor{}
+	p := &MavenPlugin{executor: mockExec}
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":         "com.example",
+			"artifact_id":      "my-app",
+			"repository":       "http://localhost:8081/repository/maven-releases",
+			"skip_aggregators": true,
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+
+	args := strings.Join(mockExec.Calls[0].Args, " ")
+	if !strings.Contains(args, "-pl !com.example:my-app-aggregator,!com.example:my-app-examples") {
+		t.Errorf("expected aggregator exclusions, got %s", args)
+	}
+}
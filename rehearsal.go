@@ -0,0 +1,212 @@
+// Package main implements rehearsal deploys into a temporary file repository.
+package main
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// rehearsalRepositoryID is the server ID of the temporary rehearsal repository.
+const rehearsalRepositoryID = "rehearsal"
+
+// checksumAlgorithms maps checksum file extensions to their hash functions.
+var checksumAlgorithms = map[string]func() hash.Hash{
+	".md5":    md5.New,
+	".sha1":   sha1.New,
+	".sha256": sha256.New,
+	".sha512": sha512.New,
+}
+
+// requiredChecksums lists the checksums Maven publishes for each file unless
+// the checksums option selects others.
+var requiredChecksums = []string{".md5", ".sha1"}
+
+// rehearsalReport describes the files found in a rehearsal repository.
+type rehearsalReport struct {
+	Files  []string
+	Signed bool
+}
+
+// fileRepositoryURL returns the file:// URL of a local directory.
+func fileRepositoryURL(dir string) string {
+	path := filepath.ToSlash(dir)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path // Windows drive paths.
+	}
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
+
+// isChecksumFile reports whether a file name is a checksum.
+func isChecksumFile(name string) bool {
+	_, ok := checksumAlgorithms[filepath.Ext(name)]
+	return ok
+}
+
+// verifyChecksum checks a checksum file against the file it describes.
+func verifyChecksum(path, checksumPath string, newHash func() hash.Hash) error {
+	expected, err := os.ReadFile(checksumPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", filepath.Base(checksumPath), err)
+	}
+	fields := strings.Fields(string(expected))
+	if len(fields) == 0 {
+		return fmt.Errorf("%s is empty", filepath.Base(checksumPath))
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", filepath.Base(path), err)
+	}
+	h := newHash()
+	h.Write(data)
+	if actual := hex.EncodeToString(h.Sum(nil)); !strings.EqualFold(fields[0], actual) {
+		return fmt.Errorf("checksum mismatch for %s", filepath.Base(checksumPath))
+	}
+	return nil
+}
+
+// verifyRehearsal checks the files deployed for a GAV into a rehearsal repository:
+// a POM must be present, every file needs valid checksums, and signatures, when
+// produced, must cover every artifact.
+func verifyRehearsal(repoDir, groupID, artifactID, version string, required []string) (*rehearsalReport, error) {
+	dir := filepath.Join(repoDir, filepath.FromSlash(gavPath(groupID, artifactID, version)))
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("nothing was deployed for %s:%s:%s", groupID, artifactID, version)
+	}
+
+	present := map[string]bool{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			present[entry.Name()] = true
+		}
+	}
+
+	report := &rehearsalReport{}
+	var artifacts []string
+	hasPOM := false
+	for name := range present {
+		if name == "maven-metadata.xml" || strings.HasPrefix(name, "maven-metadata.xml.") {
+			continue
+		}
+		report.Files = append(report.Files, name)
+		if isChecksumFile(name) {
+			continue
+		}
+		if strings.HasSuffix(name, ".asc") {
+			report.Signed = true
+		} else {
+			artifacts = append(artifacts, name)
+		}
+		if strings.HasSuffix(name, ".pom") {
+			hasPOM = true
+		}
+	}
+	sort.Strings(report.Files)
+	sort.Strings(artifacts)
+
+	if !hasPOM {
+		return nil, fmt.Errorf("no POM was deployed for %s:%s:%s", groupID, artifactID, version)
+	}
+
+	var problems []string
+	for _, name := range report.Files {
+		if isChecksumFile(name) {
+			base := strings.TrimSuffix(name, filepath.Ext(name))
+			if !present[base] {
+				problems = append(problems, fmt.Sprintf("checksum %s has no matching file", name))
+				continue
+			}
+			if err := verifyChecksum(filepath.Join(dir, base), filepath.Join(dir, name), checksumAlgorithms[filepath.Ext(name)]); err != nil {
+				problems = append(problems, err.Error())
+			}
+			continue
+		}
+		for _, ext := range required {
+			if !present[name+ext] {
+				problems = append(problems, fmt.Sprintf("missing %s checksum for %s", ext, name))
+			}
+		}
+	}
+	if report.Signed {
+		for _, name := range artifacts {
+			if !present[name+".asc"] {
+				problems = append(problems, fmt.Sprintf("missing signature for %s", name))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return report, fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return report, nil
+}
+
+// rehearse runs the full deploy into a temporary file repository and verifies
+// the result. The remote repositories are never contacted for the deploy.
+func (p *MavenPlugin) rehearse(ctx context.Context, cfg *Config, releaseCtx plugin.ReleaseContext, outputs map[string]any) (*plugin.ExecuteResponse, error) {
+	repoDir, err := os.MkdirTemp("", "relicta-maven-rehearsal-*")
+	if err != nil {
+		return &plugin.ExecuteResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to create rehearsal repository: %v", err),
+		}, nil
+	}
+	defer func() { _ = os.RemoveAll(repoDir) }()
+
+	rehearsalCfg := *cfg
+	rehearsalCfg.RepositoryID = rehearsalRepositoryID
+	rehearsalCfg.Repository = fileRepositoryURL(repoDir)
+	rehearsalCfg.SnapshotRepositoryID = rehearsalRepositoryID
+	rehearsalCfg.SnapshotRepository = rehearsalCfg.Repository
+	rehearsalCfg.StagingProfileID = ""
+
+	args, err := p.buildMavenCommand(&rehearsalCfg, releaseCtx)
+	if err != nil {
+		return &plugin.ExecuteResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+
+	output, err := p.runMavenCommand(ctx, cfg, args...)
+	if err != nil {
+		return &plugin.ExecuteResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Maven rehearsal deploy failed: %s\nOutput: %s", describeExecError(cfg.mavenCommand(), err), cfg.errorOutput(output)),
+			Outputs: outputs,
+		}, nil
+	}
+
+	report, err := verifyRehearsal(repoDir, cfg.GroupID, cfg.ArtifactID, releaseCtx.Version, cfg.checksumExtensions(requiredChecksums))
+	if report != nil {
+		outputs["rehearsal_files"] = report.Files
+		outputs["rehearsal_signed"] = report.Signed
+	}
+	if err != nil {
+		return &plugin.ExecuteResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Maven rehearsal verification failed: %v", err),
+			Outputs: outputs,
+		}, nil
+	}
+
+	return &plugin.ExecuteResponse{
+		Success: true,
+		Message: fmt.Sprintf("Rehearsed Maven deploy of %s:%s:%s", cfg.GroupID, cfg.ArtifactID, releaseCtx.Version),
+		Outputs: outputs,
+	}, nil
+}
@@ -0,0 +1,247 @@
+// Package main provides tests for rehearsal deploys.
+package main
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// writeDeployedFile writes a file and its checksums into a GAV directory.
+func writeDeployedFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	md5Sum := md5.Sum([]byte(content))
+	sha1Sum := sha1.Sum([]byte(content))
+	files := map[string]string{
+		name:           content,
+		name + ".md5":  hex.EncodeToString(md5Sum[:]),
+		name + ".sha1": hex.EncodeToString(sha1Sum[:]),
+	}
+	for file, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, file), []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// gavDir creates the GAV directory for com.example:my-app:1.0.0 in a repository.
+func gavDir(t *testing.T, repoDir string) string {
+	t.Helper()
+	dir := filepath.Join(repoDir, "com", "example", "my-app", "1.0.0")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestVerifyRehearsal(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func(t *testing.T, dir string)
+		wantSigned bool
+		errMsg     string
+	}{
+		{
+			name: "complete unsigned deploy",
+			setup: func(t *testing.T, dir string) {
+				writeDeployedFile(t, dir, "my-app-1.0.0.pom", "<project/>")
+				writeDeployedFile(t, dir, "my-app-1.0.0.jar", "jar")
+			},
+		},
+		{
+			name: "complete signed deploy",
+			setup: func(t *testing.T, dir string) {
+				writeDeployedFile(t, dir, "my-app-1.0.0.pom", "<project/>")
+				writeDeployedFile(t, dir, "my-app-1.0.0.pom.asc", "sig")
+				writeDeployedFile(t, dir, "my-app-1.0.0.jar", "jar")
+				writeDeployedFile(t, dir, "my-app-1.0.0.jar.asc", "sig")
+			},
+			wantSigned: true,
+		},
+		{
+			name: "missing signature",
+			setup: func(t *testing.T, dir string) {
+				writeDeployedFile(t, dir, "my-app-1.0.0.pom", "<project/>")
+				writeDeployedFile(t, dir, "my-app-1.0.0.pom.asc", "sig")
+				writeDeployedFile(t, dir, "my-app-1.0.0.jar", "jar")
+			},
+			errMsg: "missing signature for my-app-1.0.0.jar",
+		},
+		{
+			name: "checksum mismatch",
+			setup: func(t *testing.T, dir string) {
+				writeDeployedFile(t, dir, "my-app-1.0.0.pom", "<project/>")
+				writeDeployedFile(t, dir, "my-app-1.0.0.jar", "jar")
+				if err := os.WriteFile(filepath.Join(dir, "my-app-1.0.0.jar.sha1"), []byte("0000"), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			},
+			errMsg: "checksum mismatch for my-app-1.0.0.jar.sha1",
+		},
+		{
+			name: "missing checksum",
+			setup: func(t *testing.T, dir string) {
+				writeDeployedFile(t, dir, "my-app-1.0.0.pom", "<project/>")
+				if err := os.WriteFile(filepath.Join(dir, "my-app-1.0.0.jar"), []byte("jar"), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			},
+			errMsg: "missing .md5 checksum for my-app-1.0.0.jar",
+		},
+		{
+			name: "missing pom",
+			setup: func(t *testing.T, dir string) {
+				writeDeployedFile(t, dir, "my-app-1.0.0.jar", "jar")
+			},
+			errMsg: "no POM was deployed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoDir := t.TempDir()
+			tt.setup(t, gavDir(t, repoDir))
+
+			report, err := verifyRehearsal(repoDir, "com.example", "my-app", "1.0.0", requiredChecksums)
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Errorf("expected error containing %q, got %v", tt.errMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if report.Signed != tt.wantSigned {
+				t.Errorf("expected signed=%v, got %v", tt.wantSigned, report.Signed)
+			}
+		})
+	}
+
+	if _, err := verifyRehearsal(t.TempDir(), "com.example", "my-app", "1.0.0", requiredChecksums); err == nil || !strings.Contains(err.Error(), "nothing was deployed") {
+		t.Errorf("expected error for an empty repository, got %v", err)
+	}
+}
+
+func TestFileRepositoryURL(t *testing.T) {
+	got := fileRepositoryURL(filepath.FromSlash("/tmp/rehearsal repo"))
+	if got != "file:///tmp/rehearsal%20repo" {
+		t.Errorf("unexpected URL: %s", got)
+	}
+}
+
+// rehearsalExecutor returns an executor that deploys into the rehearsal repository
+// named in the Maven arguments using the given setup function.
+func rehearsalExecutor(t *testing.T, setup func(t *testing.T, dir string)) *MockCommandExecutor {
+	return &MockCommandExecutor{
+		RunFunc: func(_ context.Context, _ string, args ...string) ([]byte, error) {
+			for _, arg := range args {
+				value, ok := strings.CutPrefix(arg, "-DaltReleaseDeploymentRepository="+rehearsalRepositoryID+"::")
+				if !ok {
+					continue
+				}
+				repoURL, err := url.Parse(value)
+				if err != nil {
+					return nil, err
+				}
+				setup(t, gavDir(t, filepath.FromSlash(repoURL.Path)))
+				return []byte("[INFO] BUILD SUCCESS"), nil
+			}
+			return nil, errors.New("no rehearsal repository in arguments")
+		},
+	}
+}
+
+func TestExecuteRehearsal(t *testing.T) {
+	config := map[string]any{
+		"group_id":      "com.example",
+		"artifact_id":   "my-app",
+		"repository":    "http://localhost:8081/repository/maven-releases",
+		"username":      "deployer",
+		"password":      "secret",
+		"repository_id": "releases",
+		"rehearsal":     true,
+	}
+
+	t.Run("verified", func(t *testing.T) {
+		mockExec := rehearsalExecutor(t, func(t *testing.T, dir string) {
+			writeDeployedFile(t, dir, "my-app-1.0.0.pom", "<project/>")
+			writeDeployedFile(t, dir, "my-app-1.0.0.jar", "jar")
+		})
+		p := &MavenPlugin{executor: mockExec}
+
+		resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+			Hook:    plugin.HookPostPublish,
+			Config:  config,
+			Context: plugin.ReleaseContext{Version: "1.0.0"},
+			DryRun:  true,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !resp.Success {
+			t.Fatalf("expected success, got error: %s", resp.Error)
+		}
+
+		args := strings.Join(mockExec.Calls[0].Args, " ")
+		if strings.Contains(args, "localhost:8081") || strings.Contains(args, "-s ") {
+			t.Errorf("expected the rehearsal to avoid the remote repository and credentials, got %s", args)
+		}
+		files, _ := resp.Outputs["rehearsal_files"].([]string)
+		if len(files) != 6 {
+			t.Errorf("expected 6 rehearsal files, got %v", files)
+		}
+		if command, _ := resp.Outputs["command"].(string); !strings.Contains(command, "localhost:8081") {
+			t.Errorf("expected the real deploy command in outputs, got %s", command)
+		}
+	})
+
+	t.Run("verification failure", func(t *testing.T) {
+		mockExec := rehearsalExecutor(t, func(t *testing.T, dir string) {
+			writeDeployedFile(t, dir, "my-app-1.0.0.jar", "jar")
+		})
+		p := &MavenPlugin{executor: mockExec}
+
+		resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+			Hook:    plugin.HookPostPublish,
+			Config:  config,
+			Context: plugin.ReleaseContext{Version: "1.0.0"},
+			DryRun:  true,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Success || !strings.Contains(resp.Error, "rehearsal verification failed") {
+			t.Errorf("expected verification failure, got %+v", resp)
+		}
+	})
+
+	t.Run("not a dry run", func(t *testing.T) {
+		mockExec := &MockCommandExecutor{}
+		p := &MavenPlugin{executor: mockExec}
+
+		resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+			Hook:    plugin.HookPostPublish,
+			Config:  config,
+			Context: plugin.ReleaseContext{Version: "1.0.0"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !resp.Success {
+			t.Fatalf("expected success, got error: %s", resp.Error)
+		}
+		if args := strings.Join(mockExec.Calls[0].Args, " "); !strings.Contains(args, "localhost:8081") {
+			t.Errorf("expected a real deploy outside dry runs, got %s", args)
+		}
+	})
+}
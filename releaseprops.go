@@ -0,0 +1,38 @@
+// Package main implements the relicta.* release properties passed to the build.
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// isPrerelease reports whether a release version is a pre-release: a
+// SNAPSHOT or a semantic version with a pre-release suffix, e.g. 1.0.0-rc.1.
+func isPrerelease(version string) bool {
+	version = mavenVersion(version)
+	if core, _, found := strings.Cut(version, "+"); found {
+		version = core
+	}
+	return strings.Contains(version, "-")
+}
+
+// releasePropertyArgs returns the -Drelicta.* arguments describing the
+// release, so POMs and build plugins can read its metadata as
+// ${relicta.version}, ${relicta.tag}, ${relicta.commit} and
+// ${relicta.prerelease}. Properties without a value are omitted; user
+// properties of the same name take precedence as they come later.
+func releasePropertyArgs(releaseCtx plugin.ReleaseContext) []string {
+	if releaseCtx.Version == "" {
+		return nil
+	}
+	args := []string{"-Drelicta.version=" + mavenVersion(releaseCtx.Version)}
+	if releaseCtx.TagName != "" {
+		args = append(args, "-Drelicta.tag="+releaseCtx.TagName)
+	}
+	if releaseCtx.CommitSHA != "" {
+		args = append(args, "-Drelicta.commit="+releaseCtx.CommitSHA)
+	}
+	return append(args, "-Drelicta.prerelease="+strconv.FormatBool(isPrerelease(releaseCtx.Version)))
+}
@@ -0,0 +1,103 @@
+// Package main provides tests for the relicta.* release properties.
+package main
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestIsPrerelease(t *testing.T) {
+	tests := map[string]bool{
+		"1.0.0":            false,
+		"v2.1.0":           false,
+		"1.0.0+build.5":    false,
+		"1.0.0-rc.1":       true,
+		"v1.0.0-beta":      true,
+		"1.0.0-SNAPSHOT":   true,
+		"1.0.0-alpha+meta": true,
+	}
+	for version, want := range tests {
+		if got := isPrerelease(version); got != want {
+			t.Errorf("isPrerelease(%q) = %v, want %v", version, got, want)
+		}
+	}
+}
+
+func TestReleasePropertyArgs(t *testing.T) {
+	tests := []struct {
+		name       string
+		releaseCtx plugin.ReleaseContext
+		want       []string
+	}{
+		{
+			name:       "full context",
+			releaseCtx: plugin.ReleaseContext{Version: "v1.2.0-rc.1", TagName: "v1.2.0-rc.1", CommitSHA: "abc123"},
+			want: []string{
+				"-Drelicta.version=1.2.0-rc.1",
+				"-Drelicta.tag=v1.2.0-rc.1",
+				"-Drelicta.commit=abc123",
+				"-Drelicta.prerelease=true",
+			},
+		},
+		{
+			name:       "version only",
+			releaseCtx: plugin.ReleaseContext{Version: "1.2.0"},
+			want:       []string{"-Drelicta.version=1.2.0", "-Drelicta.prerelease=false"},
+		},
+		{
+			name: "no version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := releasePropertyArgs(tt.releaseCtx); !slices.Equal(got, tt.want) {
+				t.Errorf("releasePropertyArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildMavenCommandReleasePropertiesOverridden(t *testing.T) {
+	p := &MavenPlugin{}
+	cfg := &Config{PomPath: "pom.xml", Properties: map[string]string{"relicta.tag": "custom"}}
+	args, err := p.buildMavenCommand(cfg, plugin.ReleaseContext{Version: "1.0.0", TagName: "v1.0.0"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Maven keeps the last definition of a property.
+	release := slices.Index(args, "-Drelicta.tag=v1.0.0")
+	user := slices.Index(args, "-Drelicta.tag=custom")
+	if release < 0 || user < release {
+		t.Errorf("expected the user property after the release property, got %v", args)
+	}
+}
+
+func TestExecuteReleaseProperties(t *testing.T) {
+	chdir(t, t.TempDir())
+	mockExec := &MockCommandExecutor{}
+	p := &MavenPlugin{executor: mockExec}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":    "com.example",
+			"artifact_id": "my-app",
+			"repository":  "http://localhost:8081/repository/maven-releases",
+		},
+		Context: plugin.ReleaseContext{Version: "v3.0.0", TagName: "v3.0.0", CommitSHA: "0123abcd"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+	for _, arg := range []string{"-Drelicta.version=3.0.0", "-Drelicta.tag=v3.0.0", "-Drelicta.commit=0123abcd", "-Drelicta.prerelease=false"} {
+		if !slices.Contains(mockExec.Calls[0].Args, arg) {
+			t.Errorf("expected %s in %v", arg, mockExec.Calls[0].Args)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+// Package main implements the machine-readable deploy report file.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// deployReportSchemaVersion is incremented on incompatible report changes.
+const deployReportSchemaVersion = 1
+
+// deployReportFile is a file of the report with its digest.
+type deployReportFile struct {
+	GroupID    string `json:"group_id"`
+	ArtifactID string `json:"artifact_id"`
+	Version    string `json:"version"`
+	Classifier string `json:"classifier,omitempty"`
+	Extension  string `json:"extension"`
+	File       string `json:"file"`
+	Size       int64  `json:"size"`
+	SHA256     string `json:"sha256"`
+}
+
+// deployReport is the JSON deploy report ingested by compliance tooling.
+type deployReport struct {
+	SchemaVersion int                `json:"schema_version"`
+	GroupID       string             `json:"group_id"`
+	ArtifactID    string             `json:"artifact_id"`
+	Version       string             `json:"version"`
+	Repository    string             `json:"repository,omitempty"`
+	Tag           string             `json:"tag,omitempty"`
+	Commit        string             `json:"commit,omitempty"`
+	DryRun        bool               `json:"dry_run"`
+	Status        string             `json:"status"`
+	Error         string             `json:"error,omitempty"`
+	StartedAt     string             `json:"started_at"`
+	FinishedAt    string             `json:"finished_at"`
+	DurationMS    int64              `json:"duration_ms"`
+	MavenVersion  string             `json:"maven_version,omitempty"`
+	JavaVersion   string             `json:"java_version,omitempty"`
+	JavaVendor    string             `json:"java_vendor,omitempty"`
+	Modules       []moduleStatus     `json:"modules,omitempty"`
+	Files         []deployReportFile `json:"files"`
+}
+
+// newDeployReport describes the outcome of a deploy that started at started
+// and finished at finished. Files are listed for successful deploys only.
+func newDeployReport(cfg *Config, releaseCtx plugin.ReleaseContext, dryRun bool, resp *plugin.ExecuteResponse, maven *mavenInfo, started, finished time.Time) *deployReport {
+	report := &deployReport{
+		SchemaVersion: deployReportSchemaVersion,
+		GroupID:       cfg.GroupID,
+		ArtifactID:    cfg.ArtifactID,
+		Version:       mavenVersion(releaseCtx.Version),
+		Repository:    redactURL(cfg.targetRepository(releaseCtx.Version)),
+		Tag:           releaseCtx.TagName,
+		Commit:        releaseCtx.CommitSHA,
+		DryRun:        dryRun,
+		Status:        auditResultFailure,
+		StartedAt:     started.UTC().Format(time.RFC3339),
+		FinishedAt:    finished.UTC().Format(time.RFC3339),
+		DurationMS:    finished.Sub(started).Milliseconds(),
+		Files:         []deployReportFile{},
+	}
+	if maven != nil {
+		report.MavenVersion = maven.Version
+		report.JavaVersion = maven.JavaVersion
+		report.JavaVendor = maven.JavaVendor
+	}
+	if cfg.Deployer != deployerHTTP {
+		report.Modules = cfg.moduleStatuses(releaseCtx.Version)
+	}
+	if resp == nil || !resp.Success {
+		if resp != nil {
+			report.Error = resp.Error
+		}
+		return report
+	}
+
+	report.Status = auditResultSuccess
+	for _, artifact := range cfg.deployedArtifacts(releaseCtx.Version) {
+		report.Files = append(report.Files, deployReportFile{
+			GroupID:    artifact.GroupID,
+			ArtifactID: artifact.ArtifactID,
+			Version:    artifact.Version,
+			Classifier: artifact.Classifier,
+			Extension:  artifact.Extension,
+			File:       artifact.File,
+			Size:       artifact.Size,
+			SHA256:     artifact.SHA256,
+		})
+	}
+	return report
+}
+
+// writeDeployReport writes the report as indented JSON, creating the parent directory.
+func writeDeployReport(path string, report *deployReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// report writes the deploy report to report_file and references it in the
+// outputs. Maven is detected for the report when the deploy did not already.
+// A report that cannot be written fails the response, like the audit log.
+func (p *MavenPlugin) report(ctx context.Context, cfg *Config, releaseCtx plugin.ReleaseContext, dryRun bool, resp *plugin.ExecuteResponse, maven *mavenInfo, started time.Time) *plugin.ExecuteResponse {
+	if maven == nil && cfg.Deployer != deployerHTTP {
+		maven, _ = p.detectMaven(ctx, cfg)
+	}
+	report := newDeployReport(cfg, releaseCtx, dryRun, resp, maven, started, time.Now())
+	if err := writeDeployReport(cfg.ReportFile, report); err != nil {
+		msg := fmt.Sprintf("failed to write deploy report: %v", err)
+		if resp == nil {
+			return &plugin.ExecuteResponse{Success: false, Error: msg}
+		}
+		if resp.Success {
+			resp.Error = msg
+		} else {
+			resp.Error += "; " + msg
+		}
+		resp.Success = false
+		return resp
+	}
+	if resp != nil {
+		if resp.Outputs == nil {
+			resp.Outputs = map[string]any{}
+		}
+		resp.Outputs["report_file"] = cfg.ReportFile
+	}
+	return resp
+}
@@ -0,0 +1,355 @@
+// Package main implements repository manager REST API access for the Maven plugin.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// Supported repository manager types.
+const (
+	repositoryTypeGeneric     = "generic"
+	repositoryTypeNexus       = "nexus"
+	repositoryTypeArtifactory = "artifactory"
+)
+
+// repositoryTypes lists the supported repository manager types.
+var repositoryTypes = []string{repositoryTypeGeneric, repositoryTypeNexus, repositoryTypeArtifactory}
+
+// defaultHTTPTimeout bounds individual repository API requests.
+const defaultHTTPTimeout = 60 * time.Second
+
+// errComponentNotFound is returned when the repository holds no matching component.
+var errComponentNotFound = errors.New("component not found in repository")
+
+// centralHosts lists hosts serving Maven Central, which never allows deletion.
+var centralHosts = []string{
+	"repo1.maven.org",
+	"repo.maven.apache.org",
+	"oss.sonatype.org",
+	"s01.oss.sonatype.org",
+	"central.sonatype.com",
+}
+
+// HTTPClient abstracts HTTP requests for testability.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// getHTTPClient returns the HTTP client, defaulting to http.Client with a timeout.
+func (p *MavenPlugin) getHTTPClient() HTTPClient {
+	if p.httpClient != nil {
+		return p.httpClient
+	}
+	return &http.Client{Timeout: defaultHTTPTimeout}
+}
+
+// isCentralRepository reports whether a repository URL points at Maven Central.
+func isCentralRepository(rawURL string) bool {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(parsedURL.Hostname())
+	for _, central := range centralHosts {
+		if host == central {
+			return true
+		}
+	}
+	return false
+}
+
+// gaPath returns the repository layout path of an artifact directory.
+func gaPath(groupID, artifactID string) string {
+	segments := strings.Split(groupID, ".")
+	segments = append(segments, artifactID)
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+// gavPath returns the repository layout path of a GAV version directory.
+func gavPath(groupID, artifactID, version string) string {
+	return gaPath(groupID, artifactID) + "/" + url.PathEscape(version)
+}
+
+// repositoryClient talks to a Maven repository and its manager's REST API.
+type repositoryClient struct {
+	http     HTTPClient
+	url      string
+	username string
+	password string
+}
+
+// newRepositoryClient creates a repository client for the given repository URL.
+func newRepositoryClient(httpClient HTTPClient, repoURL, username, password string) *repositoryClient {
+	return &repositoryClient{
+		http:     httpClient,
+		url:      strings.TrimSuffix(repoURL, "/"),
+		username: username,
+		password: password,
+	}
+}
+
+// do sends an authenticated request and returns the response.
+// The caller must close the response body.
+func (c *repositoryClient) do(ctx context.Context, method, rawURL string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if c.username != "" || c.password != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s failed: %w", method, redactURL(rawURL), err)
+	}
+	return resp, nil
+}
+
+// deleteComponent removes every file of a GAV from the repository.
+func (c *repositoryClient) deleteComponent(ctx context.Context, repoType, groupID, artifactID, version string) error {
+	switch repoType {
+	case repositoryTypeNexus:
+		return c.deleteNexusComponent(ctx, groupID, artifactID, version)
+	case repositoryTypeArtifactory:
+		return c.deleteArtifactoryFolder(ctx, groupID, artifactID, version)
+	default:
+		return fmt.Errorf("deleting components is not supported for repository type %q", repoType)
+	}
+}
+
+// deleteArtifactoryFolder deletes the GAV version folder through the Artifactory repository URL.
+func (c *repositoryClient) deleteArtifactoryFolder(ctx context.Context, groupID, artifactID, version string) error {
+	target := c.url + "/" + gavPath(groupID, artifactID, version) + "/"
+
+	resp, err := c.do(ctx, http.MethodDelete, target, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return errComponentNotFound
+	case resp.StatusCode >= 300:
+		return fmt.Errorf("artifactory delete returned %s", resp.Status)
+	}
+	return nil
+}
+
+// nexusLocation splits a Nexus 3 repository URL into the server base URL and repository name.
+func nexusLocation(repoURL string) (string, string, error) {
+	parsedURL, err := url.Parse(repoURL)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid repository URL: %w", err)
+	}
+
+	const marker = "/repository/"
+	idx := strings.Index(parsedURL.Path, marker)
+	if idx < 0 {
+		return "", "", fmt.Errorf("repository URL is not a Nexus 3 repository URL (expected .../repository/<name>)")
+	}
+
+	name := strings.Trim(parsedURL.Path[idx+len(marker):], "/")
+	if name == "" || strings.Contains(name, "/") {
+		return "", "", fmt.Errorf("cannot determine Nexus repository name from URL")
+	}
+
+	base := *parsedURL
+	base.Path = parsedURL.Path[:idx]
+	base.RawQuery = ""
+	base.Fragment = ""
+	return strings.TrimSuffix(base.String(), "/"), name, nil
+}
+
+// nexusSearchResponse is the subset of the Nexus search API response the plugin uses.
+type nexusSearchResponse struct {
+	Items []struct {
+		ID string `json:"id"`
+	} `json:"items"`
+}
+
+// deleteNexusComponent finds the GAV through the Nexus search API and deletes it.
+func (c *repositoryClient) deleteNexusComponent(ctx context.Context, groupID, artifactID, version string) error {
+	base, repository, err := nexusLocation(c.url)
+	if err != nil {
+		return err
+	}
+
+	query := url.Values{}
+	query.Set("repository", repository)
+	query.Set("maven.groupId", groupID)
+	query.Set("maven.artifactId", artifactID)
+	query.Set("version", version)
+
+	resp, err := c.do(ctx, http.MethodGet, base+"/service/rest/v1/search?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("nexus search returned %s", resp.Status)
+	}
+
+	var result nexusSearchResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 10<<20)).Decode(&result); err != nil {
+		return fmt.Errorf("failed to parse nexus search response: %w", err)
+	}
+	if len(result.Items) == 0 {
+		return errComponentNotFound
+	}
+
+	for _, item := range result.Items {
+		delResp, err := c.do(ctx, http.MethodDelete, base+"/service/rest/v1/components/"+url.PathEscape(item.ID), nil)
+		if err != nil {
+			return err
+		}
+		_ = delResp.Body.Close()
+		if delResp.StatusCode >= 300 && delResp.StatusCode != http.StatusNotFound {
+			return fmt.Errorf("nexus component delete returned %s", delResp.Status)
+		}
+	}
+	return nil
+}
+
+// mavenMetadata is the subset of an artifact-level maven-metadata.xml the plugin uses.
+type mavenMetadata struct {
+	XMLName    xml.Name `xml:"metadata"`
+	GroupID    string   `xml:"groupId"`
+	ArtifactID string   `xml:"artifactId"`
+	Versioning struct {
+		Latest      string   `xml:"latest"`
+		Release     string   `xml:"release"`
+		Versions    []string `xml:"versions>version"`
+		LastUpdated string   `xml:"lastUpdated,omitempty"`
+	} `xml:"versioning"`
+}
+
+// fetchMetadata downloads the artifact-level maven-metadata.xml for a GA.
+// It returns errComponentNotFound when the artifact has never been published.
+func (c *repositoryClient) fetchMetadata(ctx context.Context, groupID, artifactID string) (*mavenMetadata, error) {
+	target := c.url + "/" + gaPath(groupID, artifactID) + "/maven-metadata.xml"
+
+	resp, err := c.do(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, errComponentNotFound
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("fetching maven-metadata.xml returned %s", resp.Status)
+	}
+
+	var metadata mavenMetadata
+	if err := xml.NewDecoder(io.LimitReader(resp.Body, 10<<20)).Decode(&metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse maven-metadata.xml: %w", err)
+	}
+	return &metadata, nil
+}
+
+// redactURL strips user info from a URL for use in messages.
+func redactURL(rawURL string) string {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil || parsedURL.User == nil {
+		return rawURL
+	}
+	parsedURL.User = nil
+	return parsedURL.String()
+}
+
+// escapePath escapes each segment of a slash-separated path.
+func escapePath(relPath string) string {
+	segments := strings.Split(relPath, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+// uploadFile uploads a local file to a path below the repository URL.
+func (c *repositoryClient) uploadFile(ctx context.Context, relPath, localPath string) error {
+	f, err := os.Open(localPath)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", localPath, err)
+	}
+	defer f.Close()
+
+	resp, err := c.do(ctx, http.MethodPut, c.url+"/"+escapePath(relPath), f)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &statusError{op: "upload of " + relPath, status: resp.StatusCode, text: resp.Status}
+	}
+	return nil
+}
+
+// uploadBytes uploads in-memory content to a path below the repository URL.
+func (c *repositoryClient) uploadBytes(ctx context.Context, relPath string, data []byte) error {
+	resp, err := c.do(ctx, http.MethodPut, c.url+"/"+escapePath(relPath), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &statusError{op: "upload of " + relPath, status: resp.StatusCode, text: resp.Status}
+	}
+	return nil
+}
+
+// statusError is an unexpected HTTP response status.
+type statusError struct {
+	op     string
+	status int
+	text   string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("%s returned %s", e.op, e.text)
+}
+
+// fetchFile downloads a file below the repository URL. It returns
+// errComponentNotFound when the file does not exist. The caller must close
+// the returned body.
+func (c *repositoryClient) fetchFile(ctx context.Context, relPath string) (io.ReadCloser, error) {
+	resp, err := c.do(ctx, http.MethodGet, c.url+"/"+relPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		resp.Body.Close()
+		return nil, errComponentNotFound
+	case resp.StatusCode != http.StatusOK:
+		resp.Body.Close()
+		return nil, fmt.Errorf("fetching %s returned %s", relPath, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// redirectsDeploy reports whether the deploy overrides the distributionManagement
+// of the POM with -DaltReleaseDeploymentRepository. Without repository_id or
+// snapshot_repository, repository only describes the POM's release repository;
+// file:// repositories and the repositories the plugin picks itself (targets,
+// rehearsals, export archives) always redirect.
+func (cfg *Config) redirectsDeploy() bool {
+	return cfg.redirectDeploy || cfg.SnapshotRepository != "" || isFileRepository(cfg.Repository)
+}
@@ -0,0 +1,121 @@
+// Package main implements pinning the build timestamp for reproducible artifacts.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// outputTimestampCommit pins the build timestamp to the release commit.
+const outputTimestampCommit = "commit"
+
+// parseOutputTimestamp parses a pinned build timestamp given as an RFC 3339
+// date or as seconds since the epoch, like SOURCE_DATE_EPOCH.
+func parseOutputTimestamp(value string) (time.Time, error) {
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return time.Time{}, fmt.Errorf("output_timestamp must not be before the epoch")
+		}
+		return time.Unix(seconds, 0).UTC(), nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("output_timestamp must be %q, an RFC 3339 date or seconds since the epoch", outputTimestampCommit)
+	}
+	return t.UTC(), nil
+}
+
+// validateOutputTimestamp validates the output_timestamp option.
+func validateOutputTimestamp(value string) error {
+	if value == "" || value == outputTimestampCommit {
+		return nil
+	}
+	_, err := parseOutputTimestamp(value)
+	return err
+}
+
+// commitTimestamp returns the committer date of a commit, or of HEAD when
+// the release does not name one.
+func (p *MavenPlugin) commitTimestamp(ctx context.Context, commitSHA string) (time.Time, error) {
+	revision := commitSHA
+	if revision == "" {
+		revision = "HEAD"
+	}
+	output, err := p.getExecutor().Run(ctx, "git", "show", "-s", "--format=%ct", revision)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("git show %s failed: %w: %s", revision, err, strings.TrimSpace(string(output)))
+	}
+	seconds, err := strconv.ParseInt(strings.TrimSpace(string(output)), 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unexpected commit timestamp %q", strings.TrimSpace(string(output)))
+	}
+	return time.Unix(seconds, 0).UTC(), nil
+}
+
+// resolveOutputTimestamp replaces output_timestamp "commit" by the
+// timestamp of the release commit.
+func (p *MavenPlugin) resolveOutputTimestamp(ctx context.Context, cfg *Config, commitSHA string) (*Config, error) {
+	if cfg.OutputTimestamp != outputTimestampCommit {
+		return cfg, nil
+	}
+	t, err := p.commitTimestamp(ctx, commitSHA)
+	if err != nil {
+		return nil, err
+	}
+	resolved := *cfg
+	resolved.OutputTimestamp = t.Format(time.RFC3339)
+	return &resolved, nil
+}
+
+// outputTimestampArgs returns the argument pinning project.build.outputTimestamp,
+// which the archiver plugins use for the entries of the built archives.
+func outputTimestampArgs(cfg *Config) ([]string, error) {
+	if cfg.OutputTimestamp == "" {
+		return nil, nil
+	}
+	t, err := parseOutputTimestamp(cfg.OutputTimestamp)
+	if err != nil {
+		return nil, err
+	}
+	return []string{"-Dproject.build.outputTimestamp=" + t.Format(time.RFC3339)}, nil
+}
+
+// sourceDateEpochEnv returns the SOURCE_DATE_EPOCH variable of a pinned
+// build timestamp, read by tools that do not use the Maven property.
+func sourceDateEpochEnv(cfg *Config) []string {
+	t, err := parseOutputTimestamp(cfg.OutputTimestamp)
+	if cfg.OutputTimestamp == "" || err != nil {
+		return nil
+	}
+	return []string{"SOURCE_DATE_EPOCH=" + strconv.FormatInt(t.Unix(), 10)}
+}
+
+// commandEnvKey is the context key of the extra environment of commands.
+type commandEnvKey struct{}
+
+// withCommandEnv returns a context whose commands run with the given
+// NAME=value variables added to the plugin's environment.
+func withCommandEnv(ctx context.Context, env []string) context.Context {
+	if len(env) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, commandEnvKey{}, append(commandEnv(ctx), env...))
+}
+
+// commandEnv returns the extra environment of the commands run with ctx.
+func commandEnv(ctx context.Context) []string {
+	env, _ := ctx.Value(commandEnvKey{}).([]string)
+	return env
+}
+
+// applyCommandEnv adds the extra environment of ctx to a command.
+func applyCommandEnv(ctx context.Context, cmd *exec.Cmd) {
+	if env := commandEnv(ctx); len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+}
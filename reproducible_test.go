@@ -0,0 +1,137 @@
+// Package main provides tests for pinning the build timestamp.
+package main
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestParseOutputTimestamp(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "1709296200", want: time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)},
+		{value: "2024-03-01T13:30:00+01:00", want: time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)},
+		{value: "2024-03-01", wantErr: true},
+		{value: "-1", wantErr: true},
+		{value: "yesterday", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseOutputTimestamp(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseOutputTimestamp() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseOutputTimestamp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if err := validateOutputTimestamp(outputTimestampCommit); err != nil {
+		t.Errorf("validateOutputTimestamp(commit) = %v", err)
+	}
+}
+
+func TestWithCommandEnv(t *testing.T) {
+	ctx := withCommandEnv(context.Background(), nil)
+	if env := commandEnv(ctx); env != nil {
+		t.Errorf("expected no environment, got %v", env)
+	}
+	ctx = withCommandEnv(ctx, []string{"A=1"})
+	ctx = withCommandEnv(ctx, []string{"B=2"})
+	if env := commandEnv(ctx); !slices.Equal(env, []string{"A=1", "B=2"}) {
+		t.Errorf("unexpected environment %v", env)
+	}
+}
+
+func TestExecuteOutputTimestampCommit(t *testing.T) {
+	chdir(t, t.TempDir())
+	var mavenEnv []string
+	mockExec := &MockCommandExecutor{
+		RunFunc: func(ctx context.Context, name string, args ...string) ([]byte, error) {
+			if name == "git" {
+				if strings.Join(args, " ") != "show -s --format=%ct 0123abcd" {
+					t.Errorf("unexpected git args %v", args)
+				}
+				return []byte("1709296200\n"), nil
+			}
+			mavenEnv = commandEnv(ctx)
+			return []byte("[INFO] BUILD SUCCESS"), nil
+		},
+	}
+	p := &MavenPlugin{executor: mockExec}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":         "com.example",
+			"artifact_id":      "my-app",
+			"repository":       "http://localhost:8081/repository/maven-releases",
+			"output_timestamp": "commit",
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0", CommitSHA: "0123abcd"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+	if !slices.Contains(mockExec.Calls[1].Args, "-Dproject.build.outputTimestamp=2024-03-01T12:30:00Z") {
+		t.Errorf("expected the pinned timestamp in %v", mockExec.Calls[1].Args)
+	}
+	if !slices.Equal(mavenEnv, []string{"SOURCE_DATE_EPOCH=1709296200"}) {
+		t.Errorf("unexpected Maven environment %v", mavenEnv)
+	}
+}
+
+func TestExecuteOutputTimestampGitFailure(t *testing.T) {
+	chdir(t, t.TempDir())
+	mockExec := &MockCommandExecutor{
+		RunFunc: func(ctx context.Context, name string, args ...string) ([]byte, error) {
+			return []byte("fatal: not a git repository"), errors.New("exit status 128")
+		},
+	}
+	p := &MavenPlugin{executor: mockExec}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":         "com.example",
+			"artifact_id":      "my-app",
+			"repository":       "http://localhost:8081/repository/maven-releases",
+			"output_timestamp": "commit",
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Success || !strings.Contains(resp.Error, "output_timestamp: git show HEAD failed") {
+		t.Errorf("unexpected response %+v", resp)
+	}
+	if len(mockExec.Calls) != 1 {
+		t.Errorf("expected Maven not to run, got %d calls", len(mockExec.Calls))
+	}
+}
+
+func TestBuildMavenCommandFixedOutputTimestamp(t *testing.T) {
+	p := &MavenPlugin{}
+	args, err := p.buildMavenCommand(&Config{PomPath: "pom.xml", OutputTimestamp: "1709296200"}, plugin.ReleaseContext{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Contains(args, "-Dproject.build.outputTimestamp=2024-03-01T12:30:00Z") {
+		t.Errorf("expected the pinned timestamp in %v", args)
+	}
+	if env := sourceDateEpochEnv(&Config{OutputTimestamp: "1709296200"}); !slices.Equal(env, []string{"SOURCE_DATE_EPOCH=1709296200"}) {
+		t.Errorf("unexpected environment %v", env)
+	}
+}
@@ -0,0 +1,176 @@
+// Package main implements the repositories the release build resolves parent POMs and plugins from.
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+)
+
+// resolutionProfileID is the settings profile declaring the resolution repositories.
+const resolutionProfileID = "relicta-resolution"
+
+// ResolutionRepository is a repository the release build resolves
+// dependencies, parent POMs and plugins from.
+type ResolutionRepository struct {
+	// ID is the repository ID, also used for the credentials' <server>.
+	ID  string
+	URL string
+	// Username and Password authenticate against the repository, read from
+	// the variables named by username_env and password_env when not given.
+	Username string
+	Password string
+	// Snapshots enables resolving snapshot versions.
+	Snapshots bool
+	// Plugins also declares the repository as a plugin repository.
+	Plugins bool
+}
+
+// parseResolutionRepositories parses the resolution_repositories list from the raw configuration.
+func parseResolutionRepositories(raw map[string]any) []ResolutionRepository {
+	items, ok := raw["resolution_repositories"].([]any)
+	if !ok {
+		return nil
+	}
+	repositories := make([]ResolutionRepository, 0, len(items))
+	for _, item := range items {
+		entry, _ := item.(map[string]any)
+		parser := helpers.NewConfigParser(entry)
+		repository := ResolutionRepository{
+			ID:        parser.GetString("id", "", ""),
+			URL:       parser.GetString("url", "", ""),
+			Username:  parser.GetString("username", "", ""),
+			Password:  parser.GetString("password", "", ""),
+			Snapshots: parser.GetBool("snapshots", false),
+			Plugins:   parser.GetBool("plugins", true),
+		}
+		if env := parser.GetString("username_env", "", ""); repository.Username == "" && env != "" {
+			repository.Username = os.Getenv(env)
+		}
+		if env := parser.GetString("password_env", "", ""); repository.Password == "" && env != "" {
+			repository.Password = os.Getenv(env)
+		}
+		repositories = append(repositories, repository)
+	}
+	return repositories
+}
+
+// validateResolutionRepository validates a single resolution_repositories
+// entry and returns the offending field on error. Internal hosts are
+// expected, so the network policy of the deploy repositories does not apply.
+func validateResolutionRepository(repository ResolutionRepository) (string, error) {
+	if err := validateRepositoryID(repository.ID, "id"); err != nil {
+		return "id", err
+	}
+	u, err := url.Parse(repository.URL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return "url", fmt.Errorf("url must be an http or https URL")
+	}
+	return "", nil
+}
+
+// settingsRepositoryPolicy is the <releases> or <snapshots> policy of a repository.
+type settingsRepositoryPolicy struct {
+	Enabled bool `xml:"enabled"`
+}
+
+// settingsRepository is a <repository> or <pluginRepository> of a settings profile.
+type settingsRepository struct {
+	ID        string                   `xml:"id"`
+	URL       string                   `xml:"url"`
+	Releases  settingsRepositoryPolicy `xml:"releases"`
+	Snapshots settingsRepositoryPolicy `xml:"snapshots"`
+}
+
+// settingsProfile is a <profile> of a generated settings.xml.
+type settingsProfile struct {
+	ID                 string               `xml:"id"`
+	Repositories       []settingsRepository `xml:"repositories>repository,omitempty"`
+	PluginRepositories []settingsRepository `xml:"pluginRepositories>pluginRepository,omitempty"`
+}
+
+// resolutionProfile returns the settings profile declaring the resolution
+// repositories, or nil when none is configured.
+func (cfg *Config) resolutionProfile() *settingsProfile {
+	if len(cfg.ResolutionRepositories) == 0 {
+		return nil
+	}
+	profile := &settingsProfile{ID: resolutionProfileID}
+	for _, r := range cfg.ResolutionRepositories {
+		repository := settingsRepository{
+			ID:        r.ID,
+			URL:       r.URL,
+			Releases:  settingsRepositoryPolicy{Enabled: true},
+			Snapshots: settingsRepositoryPolicy{Enabled: r.Snapshots},
+		}
+		profile.Repositories = append(profile.Repositories, repository)
+		if r.Plugins {
+			profile.PluginRepositories = append(profile.PluginRepositories, repository)
+		}
+	}
+	return profile
+}
+
+// resolutionServers returns the server entries of the resolution repositories with credentials.
+func (cfg *Config) resolutionServers() []settingsServer {
+	var servers []settingsServer
+	for _, r := range cfg.ResolutionRepositories {
+		if r.Username == "" && r.Password == "" {
+			continue
+		}
+		servers = append(servers, settingsServer{
+			ID:            r.ID,
+			Username:      r.Username,
+			Password:      r.Password,
+			Configuration: cfg.serverConfiguration(),
+		})
+	}
+	return servers
+}
+
+// injectProfile adds an active profile to existing settings.xml content. A
+// profile with the same ID in the user's settings is left untouched.
+func injectProfile(content []byte, profile *settingsProfile) ([]byte, error) {
+	var existing struct {
+		Profiles []settingsProfile `xml:"profiles>profile"`
+	}
+	if err := xml.Unmarshal(content, &existing); err != nil {
+		return nil, fmt.Errorf("failed to parse settings file: %w", err)
+	}
+	for _, p := range existing.Profiles {
+		if p.ID == profile.ID {
+			return content, nil
+		}
+	}
+
+	data, err := xml.MarshalIndent(struct {
+		XMLName xml.Name `xml:"profile"`
+		*settingsProfile
+	}{settingsProfile: profile}, "    ", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to render settings: %w", err)
+	}
+	block := "\n    " + string(bytes.TrimLeft(data, " ")) + "\n  "
+	content, err = insertSettingsElement(content, "profiles", block)
+	if err != nil {
+		return nil, err
+	}
+	return insertSettingsElement(content, "activeProfiles", "\n    <activeProfile>"+profile.ID+"</activeProfile>\n  ")
+}
+
+// insertSettingsElement inserts content at the end of the named top-level
+// element, creating the element when the settings lack it.
+func insertSettingsElement(content []byte, element, block string) ([]byte, error) {
+	if idx := bytes.LastIndex(content, []byte("</"+element+">")); idx >= 0 {
+		return bytes.Join([][]byte{content[:idx], []byte(block), content[idx:]}, nil), nil
+	}
+	if idx := bytes.LastIndex(content, []byte("</settings>")); idx >= 0 {
+		wrapped := "  <" + element + ">" + block + "</" + element + ">\n"
+		return bytes.Join([][]byte{content[:idx], []byte(wrapped), content[idx:]}, nil), nil
+	}
+	return nil, fmt.Errorf("failed to inject %s: settings file has no closing </settings> element", element)
+}
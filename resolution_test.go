@@ -0,0 +1,172 @@
+// Package main provides tests for the resolution repositories of the release build.
+package main
+
+import (
+	"context"
+	"encoding/xml"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// resolutionConfig declares an internal repository with credentials and a
+// public one for dependencies only.
+func resolutionConfig() map[string]any {
+	return map[string]any{
+		"group_id":    "com.example",
+		"artifact_id": "my-app",
+		"repository":  "http://localhost:8081/repository/maven-releases",
+		"resolution_repositories": []any{
+			map[string]any{
+				"id":           "internal",
+				"url":          "https://nexus.example.com/repository/maven-public",
+				"username_env": "TEST_INTERNAL_USERNAME",
+				"password_env": "TEST_INTERNAL_PASSWORD",
+				"snapshots":    true,
+			},
+			map[string]any{
+				"id":      "partner",
+				"url":     "https://repo.partner.example.com/maven2",
+				"plugins": false,
+			},
+		},
+	}
+}
+
+func TestParseResolutionRepositories(t *testing.T) {
+	t.Setenv("TEST_INTERNAL_USERNAME", "reader")
+	t.Setenv("TEST_INTERNAL_PASSWORD", "reader-secret")
+
+	repositories := parseResolutionRepositories(resolutionConfig())
+	want := []ResolutionRepository{
+		{ID: "internal", URL: "https://nexus.example.com/repository/maven-public", Username: "reader", Password: "reader-secret", Snapshots: true, Plugins: true},
+		{ID: "partner", URL: "https://repo.partner.example.com/maven2"},
+	}
+	if len(repositories) != len(want) {
+		t.Fatalf("expected %d repositories, got %+v", len(want), repositories)
+	}
+	for i := range want {
+		if repositories[i] != want[i] {
+			t.Errorf("repository %d = %+v, want %+v", i, repositories[i], want[i])
+		}
+	}
+}
+
+func TestValidateResolutionRepository(t *testing.T) {
+	tests := []struct {
+		name       string
+		repository ResolutionRepository
+		wantField  string
+	}{
+		{name: "valid", repository: ResolutionRepository{ID: "internal", URL: "http://10.0.0.5:8081/repository/maven-public"}},
+		{name: "missing id", repository: ResolutionRepository{URL: "https://repo.example.com"}, wantField: "id"},
+		{name: "bad id", repository: ResolutionRepository{ID: "a b", URL: "https://repo.example.com"}, wantField: "id"},
+		{name: "file url", repository: ResolutionRepository{ID: "local", URL: "file:///tmp/repo"}, wantField: "url"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if field, _ := validateResolutionRepository(tt.repository); field != tt.wantField {
+				t.Errorf("validateResolutionRepository() field = %q, want %q", field, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestInjectProfile(t *testing.T) {
+	t.Setenv("TEST_INTERNAL_USERNAME", "reader")
+	t.Setenv("TEST_INTERNAL_PASSWORD", "reader-secret")
+	profile := (&MavenPlugin{}).parseConfig(resolutionConfig()).resolutionProfile()
+
+	data, err := injectProfile([]byte(userSettings), profile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var settings struct {
+		Profiles []struct {
+			ID                 string   `xml:"id"`
+			Repositories       []string `xml:"repositories>repository>id"`
+			PluginRepositories []string `xml:"pluginRepositories>pluginRepository>id"`
+			SnapshotsEnabled   []bool   `xml:"repositories>repository>snapshots>enabled"`
+		} `xml:"profiles>profile"`
+		ActiveProfiles []string `xml:"activeProfiles>activeProfile"`
+		Mirrors        []string `xml:"mirrors>mirror>id"`
+	}
+	if err := xml.Unmarshal(data, &settings); err != nil {
+		t.Fatalf("injected settings do not parse: %v\n%s", err, data)
+	}
+	if len(settings.Profiles) != 1 || settings.Profiles[0].ID != resolutionProfileID {
+		t.Fatalf("unexpected profiles: %+v", settings.Profiles)
+	}
+	got := settings.Profiles[0]
+	if strings.Join(got.Repositories, ",") != "internal,partner" || strings.Join(got.PluginRepositories, ",") != "internal" {
+		t.Errorf("unexpected repositories: %+v", got)
+	}
+	if len(got.SnapshotsEnabled) != 2 || !got.SnapshotsEnabled[0] || got.SnapshotsEnabled[1] {
+		t.Errorf("unexpected snapshot policies: %v", got.SnapshotsEnabled)
+	}
+	if len(settings.ActiveProfiles) != 1 || settings.ActiveProfiles[0] != resolutionProfileID {
+		t.Errorf("unexpected active profiles: %v", settings.ActiveProfiles)
+	}
+	if len(settings.Mirrors) != 1 {
+		t.Errorf("expected the user's mirrors to be kept, got %v", settings.Mirrors)
+	}
+
+	again, err := injectProfile(data, profile)
+	if err != nil || string(again) != string(data) {
+		t.Errorf("expected an existing profile to be left untouched, got %v", err)
+	}
+}
+
+func TestExecuteResolutionRepositories(t *testing.T) {
+	chdir(t, t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("TEST_INTERNAL_USERNAME", "reader")
+	t.Setenv("TEST_INTERNAL_PASSWORD", "reader-secret")
+
+	var settingsContent string
+	mockExec := &MockCommandExecutor{
+		RunFunc: func(_ context.Context, _ string, args ...string) ([]byte, error) {
+			for i, arg := range args {
+				if arg == "-s" && i+1 < len(args) {
+					data, err := os.ReadFile(args[i+1])
+					if err != nil {
+						return nil, err
+					}
+					settingsContent = string(data)
+				}
+			}
+			return []byte("[INFO] BUILD SUCCESS"), nil
+		},
+	}
+	p := &MavenPlugin{executor: mockExec}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:    plugin.HookPostPublish,
+		Config:  resolutionConfig(),
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+	if resp.Outputs["settings_source"] != settingsSourceGenerated {
+		t.Errorf("unexpected settings_source %v", resp.Outputs["settings_source"])
+	}
+	for _, want := range []string{
+		"<id>internal</id>",
+		"<username>reader</username>",
+		"<url>https://repo.partner.example.com/maven2</url>",
+		"<activeProfile>relicta-resolution</activeProfile>",
+	} {
+		if !strings.Contains(settingsContent, want) {
+			t.Errorf("expected settings to contain %q, got:\n%s", want, settingsContent)
+		}
+	}
+	if strings.Count(settingsContent, "<server>") != 1 {
+		t.Errorf("expected a server only for the repository with credentials, got:\n%s", settingsContent)
+	}
+}
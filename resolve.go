@@ -0,0 +1,59 @@
+// Package main implements verifying that a deployed release resolves for consumers.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// resolveRepositoryID is the server ID of the deploy repository during the resolution check.
+const resolveRepositoryID = "relicta-resolve"
+
+// resolutionArgs returns the Maven arguments resolving the GAV and its
+// transitive dependencies from the repository into the local repository.
+func resolutionArgs(groupID, artifactID, version, packaging, repoURL, localRepo string) []string {
+	artifact := groupID + ":" + artifactID + ":" + version
+	if typ := canaryDependencyType(packaging); typ != "" {
+		artifact += ":" + typ
+	}
+	return []string{
+		"-B",
+		"-Dmaven.repo.local=" + localRepo,
+		"dependency:get",
+		"-Dartifact=" + artifact,
+		"-DremoteRepositories=" + resolveRepositoryID + "::default::" + repoURL,
+		"-Dtransitive=true",
+	}
+}
+
+// verifyResolution runs `mvn dependency:get` for the released GAV against the
+// deploy repository with an empty local repository, so the release, its parent
+// POMs, and its imported BOMs must all resolve remotely.
+func (p *MavenPlugin) verifyResolution(ctx context.Context, cfg *Config, version string) error {
+	version = mavenVersion(version)
+	localRepo, err := os.MkdirTemp("", "relicta-maven-resolve-*")
+	if err != nil {
+		return fmt.Errorf("failed to create local repository: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(localRepo) }()
+
+	args := resolutionArgs(cfg.GroupID, cfg.ArtifactID, version, cfg.packaging(), cfg.targetRepository(version), filepath.Join(localRepo, "repository"))
+	args = append(args, cfg.isolationArgs(false)...)
+	settingsPath, cleanup, err := cfg.consumerSettings(resolveRepositoryID, version)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	if settingsPath != "" {
+		args = append([]string{"-s", settingsPath}, args...)
+	}
+
+	output, err := p.runMavenCommand(ctx, cfg, args...)
+	if err != nil {
+		return fmt.Errorf("%s:%s:%s does not resolve: %s\nOutput: %s",
+			cfg.GroupID, cfg.ArtifactID, version, describeExecError(cfg.mavenCommand(), err), cfg.errorOutput(output))
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+// Package main provides tests for consumer resolution verification.
+package main
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestResolutionArgs(t *testing.T) {
+	args := strings.Join(resolutionArgs("com.example", "my-bom", "1.0.0", "pom", "http://localhost:8081/repository/maven-releases", "/tmp/repo"), " ")
+	for _, want := range []string{
+		"-Dmaven.repo.local=/tmp/repo",
+		"dependency:get",
+		"-Dartifact=com.example:my-bom:1.0.0:pom",
+		"-DremoteRepositories=relicta-resolve::default::http://localhost:8081/repository/maven-releases",
+	} {
+		if !strings.Contains(args, want) {
+			t.Errorf("expected %q in %s", want, args)
+		}
+	}
+
+	if args := strings.Join(resolutionArgs("com.example", "my-app", "1.0.0", "jar", "http://localhost:8081", "/tmp/repo"), " "); !strings.Contains(args, "-Dartifact=com.example:my-app:1.0.0 ") {
+		t.Errorf("expected the default type for jars, got %s", args)
+	}
+}
+
+func TestExecuteVerifyResolution(t *testing.T) {
+	config := map[string]any{
+		"group_id":          "com.example",
+		"artifact_id":       "my-app",
+		"repository":        "http://localhost:8081/repository/maven-releases",
+		"verify_resolution": true,
+	}
+
+	run := func(t *testing.T, resolveErr error) (*plugin.ExecuteResponse, *MockCommandExecutor) {
+		t.Helper()
+		mockExec := &MockCommandExecutor{
+			RunFunc: func(_ context.Context, _ string, args ...string) ([]byte, error) {
+				if slices.Contains(args, "dependency:get") && resolveErr != nil {
+					return []byte("[ERROR] Failed to read artifact descriptor"), resolveErr
+				}
+				return []byte("[INFO] BUILD SUCCESS"), nil
+			},
+		}
+		p := &MavenPlugin{executor: mockExec}
+		resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+			Hook:    plugin.HookPostPublish,
+			Config:  config,
+			Context: plugin.ReleaseContext{Version: "1.0.0"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return resp, mockExec
+	}
+
+	t.Run("resolved", func(t *testing.T) {
+		resp, mockExec := run(t, nil)
+		if !resp.Success || resp.Outputs["resolution_verified"] != true {
+			t.Fatalf("expected verified resolution, got %+v", resp)
+		}
+		if len(mockExec.Calls) != 2 || slices.Contains(mockExec.Calls[1].Args, "-s") {
+			t.Errorf("expected a resolution run without settings, got %+v", mockExec.Calls)
+		}
+	})
+
+	t.Run("unresolvable", func(t *testing.T) {
+		resp, _ := run(t, errors.New("exit status 1"))
+		if resp.Success || !strings.Contains(resp.Error, "resolution verification failed") || !strings.Contains(resp.Error, "does not resolve") {
+			t.Errorf("expected a resolution failure, got %+v", resp)
+		}
+	})
+}
@@ -0,0 +1,92 @@
+// Package main implements retrying a failed reactor build from the failed module.
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// maxBuildRetries bounds build_retries.
+const maxBuildRetries = 5
+
+// resumeFromPattern matches the resume hint Maven logs after a module failed:
+// "[ERROR]   mvn <args> -rf :core".
+var resumeFromPattern = regexp.MustCompile(`^\[ERROR\]\s+mvn\b.*\s-rf (\S+)$`)
+
+// validateBuildRetries validates the number of reruns of a failed build.
+func validateBuildRetries(retries int) error {
+	if retries < 0 || retries > maxBuildRetries {
+		return fmt.Errorf("build_retries must be between 0 and %d", maxBuildRetries)
+	}
+	return nil
+}
+
+// resumeFrom returns the module Maven suggests resuming a failed build from,
+// e.g. ":core", or "" when the build did not fail in a module.
+func resumeFrom(output string) string {
+	module := ""
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(ansiPattern.ReplaceAllString(line, ""))
+		if m := resumeFromPattern.FindStringSubmatch(line); m != nil {
+			module = m[1]
+		}
+	}
+	return module
+}
+
+// withResumeFrom returns the arguments resuming the reactor from module,
+// replacing a resume point of an earlier retry.
+func withResumeFrom(args []string, module string) []string {
+	resumed := make([]string, 0, len(args)+2)
+	for i := 0; i < len(args); i++ {
+		if args[i] == "-rf" || args[i] == "--resume-from" {
+			i++
+			continue
+		}
+		resumed = append(resumed, args[i])
+	}
+	return append(resumed, "-rf", module)
+}
+
+// builtBefore returns the modules of a reactor summary that succeeded before
+// the first failed or skipped module; a resumed build does not rebuild them.
+func builtBefore(summary []reactorModuleResult) []reactorModuleResult {
+	for i, result := range summary {
+		if result.Status != "SUCCESS" {
+			return summary[:i]
+		}
+	}
+	return summary
+}
+
+// runMavenWithRetries runs a Maven invocation and reruns it up to
+// build_retries times when it fails. A multi-module build resumes from the
+// module that failed, as the modules before it were already deployed, except
+// with deploy_at_end where nothing was deployed. Test failures and builds out
+// of memory are not retried. It returns a warning for each retry.
+func (p *MavenPlugin) runMavenWithRetries(ctx context.Context, cfg *Config, progress *reactorProgress, args []string) ([]byte, []string, error) {
+	mark := len(progress.Summary)
+	output, err := p.runMaven(ctx, cfg, progress, args)
+
+	var warnings []string
+	for retry := 1; err != nil && retry <= cfg.BuildRetries; retry++ {
+		if ctx.Err() != nil || testFailurePattern.Match(output) || outOfMemoryPattern.Match(output) {
+			break
+		}
+		retryArgs := args
+		warning := fmt.Sprintf("build retry %d: rebuilt the reactor after: %s", retry, describeExecError(cfg.mavenCommand(), err))
+		if module := resumeFrom(string(output)); module != "" && !cfg.DeployAtEnd {
+			retryArgs = withResumeFrom(args, module)
+			warning = fmt.Sprintf("build retry %d: resumed from %s after: %s", retry, module, describeExecError(cfg.mavenCommand(), err))
+			progress.Summary = append(progress.Summary[:mark], builtBefore(progress.Summary[mark:])...)
+		} else {
+			progress.Summary = progress.Summary[:mark]
+		}
+		fmt.Fprintf(p.getProgressLog(), "maven: %s\n", warning)
+		warnings = append(warnings, warning)
+		output, err = p.runMaven(ctx, cfg, progress, retryArgs)
+	}
+	return output, warnings, err
+}
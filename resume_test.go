@@ -0,0 +1,127 @@
+// Package main provides tests for retrying a failed reactor build from the failed module.
+package main
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// moduleFailureLog is the end of the log of a build that failed in the core module.
+const moduleFailureLog = `[INFO] Reactor Summary for parent 1.0.0:
+[INFO]
+[INFO] parent ............................................. SUCCESS [  0.412 s]
+[INFO] core ............................................... FAILURE [  3.021 s]
+[INFO] app ................................................ SKIPPED
+[INFO] ------------------------------------------------------------------------
+[INFO] BUILD FAILURE
+[INFO] ------------------------------------------------------------------------
+[ERROR] Failed to execute goal org.apache.maven.plugins:maven-deploy-plugin:3.1.1:deploy (default-deploy) on project core: Connection reset -> [Help 1]
+[ERROR]
+[ERROR] After correcting the problems, you can resume the build with the command
+[ERROR]   mvn <args> -rf :core
+`
+
+// resumedLog is the log of the build resumed from the core module.
+const resumedLog = `[INFO] Reactor Summary for parent 1.0.0:
+[INFO]
+[INFO] core ............................................... SUCCESS [  2.950 s]
+[INFO] app ................................................ SUCCESS [  1.100 s]
+[INFO] ------------------------------------------------------------------------
+[INFO] BUILD SUCCESS
+`
+
+func TestResumeFrom(t *testing.T) {
+	if got := resumeFrom(moduleFailureLog); got != ":core" {
+		t.Errorf("resumeFrom() = %q, want :core", got)
+	}
+	if got := resumeFrom("[ERROR] Non-resolvable parent POM"); got != "" {
+		t.Errorf("resumeFrom() = %q, want empty", got)
+	}
+}
+
+func TestWithResumeFrom(t *testing.T) {
+	got := withResumeFrom([]string{"-B", "-rf", ":core", "deploy"}, ":app")
+	want := []string{"-B", "deploy", "-rf", ":app"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("withResumeFrom() = %v, want %v", got, want)
+	}
+}
+
+func TestExecuteBuildRetries(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     map[string]any
+		firstLog   string
+		wantCalls  int
+		wantResume bool
+	}{
+		{name: "resumes from the failed module", firstLog: moduleFailureLog, wantCalls: 2, wantResume: true},
+		{name: "deploy at end rebuilds the reactor", config: map[string]any{"deploy_at_end": true}, firstLog: moduleFailureLog, wantCalls: 2},
+		{name: "test failures are not retried", firstLog: "[ERROR] There are test failures.\n", wantCalls: 1},
+		{name: "disabled", config: map[string]any{"build_retries": 0}, firstLog: moduleFailureLog, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deploys int
+			mockExec := &MockCommandExecutor{
+				RunFunc: func(ctx context.Context, name string, args ...string) ([]byte, error) {
+					if !strings.Contains(strings.Join(args, " "), "deploy") {
+						return nil, nil
+					}
+					deploys++
+					if deploys == 1 {
+						return []byte(tt.firstLog), errors.New("exit status 1")
+					}
+					return []byte(resumedLog), nil
+				},
+			}
+			p := &MavenPlugin{executor: mockExec, progressLog: &strings.Builder{}}
+
+			config := map[string]any{
+				"group_id":      "com.example",
+				"artifact_id":   "parent",
+				"repository":    "http://localhost:8081/repository/maven-releases",
+				"build_retries": 2,
+			}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook:    plugin.HookPostPublish,
+				Config:  config,
+				Context: plugin.ReleaseContext{Version: "1.0.0"},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if deploys != tt.wantCalls {
+				t.Fatalf("expected %d deploy runs, got %d", tt.wantCalls, deploys)
+			}
+			if tt.wantCalls == 1 {
+				if resp.Success {
+					t.Error("expected failure")
+				}
+				return
+			}
+			if !resp.Success {
+				t.Fatalf("expected success, got error: %s", resp.Error)
+			}
+			last := strings.Join(mockExec.Calls[len(mockExec.Calls)-1].Args, " ")
+			if strings.HasSuffix(last, "-rf :core") != tt.wantResume {
+				t.Errorf("unexpected resume point in %s", last)
+			}
+			if tt.wantResume {
+				summary, _ := resp.Outputs["reactor_summary"].([]reactorModuleResult)
+				if len(summary) != 3 || summary[0].Module != "parent" || summary[1].Status != "SUCCESS" {
+					t.Errorf("unexpected reactor_summary %v", summary)
+				}
+			}
+		})
+	}
+}
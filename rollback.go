@@ -0,0 +1,140 @@
+// Package main implements rollback of published artifacts when a release fails.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// deployRecord describes a successful deploy performed by this plugin instance.
+type deployRecord struct {
+	GroupID    string
+	ArtifactID string
+	Version    string
+	Repository string
+	// DeploymentID is the Central Portal deployment of the release, if any.
+	DeploymentID string
+}
+
+// recordDeploy remembers a successful deploy so it can be rolled back on error.
+func (p *MavenPlugin) recordDeploy(rec deployRecord) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.deployed = append(p.deployed, rec)
+}
+
+// findDeploy returns the recorded deploy for a GAV, if any.
+func (p *MavenPlugin) findDeploy(groupID, artifactID, version string) (deployRecord, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, rec := range p.deployed {
+		if rec.GroupID == groupID && rec.ArtifactID == artifactID && rec.Version == version {
+			return rec, true
+		}
+	}
+	return deployRecord{}, false
+}
+
+// forgetDeploy drops the recorded deploy for a GAV after it has been rolled back.
+func (p *MavenPlugin) forgetDeploy(groupID, artifactID, version string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	kept := p.deployed[:0]
+	for _, rec := range p.deployed {
+		if rec.GroupID != groupID || rec.ArtifactID != artifactID || rec.Version != version {
+			kept = append(kept, rec)
+		}
+	}
+	p.deployed = kept
+}
+
+// targetRepository returns the repository URL a version is deployed to.
+func (cfg *Config) targetRepository(version string) string {
+	if strings.HasSuffix(version, "-SNAPSHOT") && cfg.SnapshotRepository != "" {
+		return cfg.SnapshotRepository
+	}
+	return cfg.Repository
+}
+
+// validateRollback checks that rollback can be performed for the configuration.
+func validateRollback(repoType, repository string) error {
+	if repoType != repositoryTypeNexus && repoType != repositoryTypeArtifactory {
+		return fmt.Errorf("rollback_on_error requires repository_type to be %q or %q", repositoryTypeNexus, repositoryTypeArtifactory)
+	}
+	if repository == "" {
+		return fmt.Errorf("rollback_on_error requires a repository URL")
+	}
+	if isCentralRepository(repository) {
+		return fmt.Errorf("rollback is never performed against Maven Central")
+	}
+	return nil
+}
+
+// rollback deletes the component published by this run from the repository manager.
+func (p *MavenPlugin) rollback(ctx context.Context, cfg *Config, releaseCtx plugin.ReleaseContext, dryRun bool) (*plugin.ExecuteResponse, error) {
+	version := mavenVersion(releaseCtx.Version)
+	repository := cfg.targetRepository(version)
+
+	if err := validateRollback(cfg.RepositoryType, repository); err != nil {
+		return &plugin.ExecuteResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+
+	outputs := map[string]any{
+		"group_id":    cfg.GroupID,
+		"artifact_id": cfg.ArtifactID,
+		"version":     version,
+		"repository":  redactURL(repository),
+	}
+
+	if dryRun {
+		return &plugin.ExecuteResponse{
+			Success: true,
+			Message: "Would roll back Maven artifact",
+			Outputs: outputs,
+		}, nil
+	}
+
+	// Only remove what this run published; a pre-existing release must never be deleted.
+	if _, ok := p.findDeploy(cfg.GroupID, cfg.ArtifactID, version); !ok {
+		outputs["rolled_back"] = false
+		return &plugin.ExecuteResponse{
+			Success: true,
+			Message: "No Maven deploy from this release to roll back",
+			Outputs: outputs,
+		}, nil
+	}
+
+	username, password := cfg.Username, cfg.Password
+	if repository == cfg.SnapshotRepository && (cfg.SnapshotUsername != "" || cfg.SnapshotPassword != "") {
+		username, password = cfg.SnapshotUsername, cfg.SnapshotPassword
+	}
+
+	client := newRepositoryClient(p.getHTTPClient(), repository, username, password)
+	err := client.deleteComponent(ctx, cfg.RepositoryType, cfg.GroupID, cfg.ArtifactID, version)
+	if err != nil && !errors.Is(err, errComponentNotFound) {
+		return &plugin.ExecuteResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Maven rollback failed: %v", err),
+		}, nil
+	}
+
+	p.forgetDeploy(cfg.GroupID, cfg.ArtifactID, version)
+	outputs["rolled_back"] = err == nil
+
+	message := fmt.Sprintf("Rolled back Maven artifact %s:%s:%s", cfg.GroupID, cfg.ArtifactID, version)
+	if err != nil {
+		message = fmt.Sprintf("Nothing to roll back: %s:%s:%s was not found in the repository", cfg.GroupID, cfg.ArtifactID, version)
+	}
+	return &plugin.ExecuteResponse{
+		Success: true,
+		Message: message,
+		Outputs: outputs,
+	}, nil
+}
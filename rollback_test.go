@@ -0,0 +1,223 @@
+// Package main provides tests for rollback on release failure.
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestValidateRollback(t *testing.T) {
+	tests := []struct {
+		name       string
+		repoType   string
+		repository string
+		errMsg     string
+	}{
+		{
+			name:       "nexus repository",
+			repoType:   repositoryTypeNexus,
+			repository: "https://nexus.example.com/repository/maven-releases",
+		},
+		{
+			name:       "generic repository type",
+			repoType:   repositoryTypeGeneric,
+			repository: "https://nexus.example.com/repository/maven-releases",
+			errMsg:     "requires repository_type",
+		},
+		{
+			name:     "missing repository",
+			repoType: repositoryTypeArtifactory,
+			errMsg:   "requires a repository URL",
+		},
+		{
+			name:       "maven central",
+			repoType:   repositoryTypeNexus,
+			repository: "https://oss.sonatype.org/service/local/staging/deploy/maven2",
+			errMsg:     "never performed against Maven Central",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRollback(tt.repoType, tt.repository)
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("expected error containing '%s', got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestExecuteOnErrorRollback(t *testing.T) {
+	var deletes int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			deletes++
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := map[string]any{
+		"group_id":          "com.example",
+		"artifact_id":       "my-app",
+		"repository":        server.URL + "/artifactory/libs-release-local",
+		"repository_type":   "artifactory",
+		"rollback_on_error": true,
+	}
+	releaseCtx := plugin.ReleaseContext{Version: "1.0.0"}
+
+	p := &MavenPlugin{executor: &MockCommandExecutor{}, httpClient: server.Client()}
+	ctx := context.Background()
+
+	// Nothing was deployed by this instance yet, so nothing is removed.
+	resp, err := p.Execute(ctx, plugin.ExecuteRequest{Hook: plugin.HookOnError, Config: config, Context: releaseCtx})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success || resp.Outputs["rolled_back"] != false {
+		t.Fatalf("expected no-op rollback, got %+v", resp)
+	}
+	if deletes != 0 {
+		t.Fatalf("expected no delete requests, got %d", deletes)
+	}
+
+	resp, err = p.Execute(ctx, plugin.ExecuteRequest{Hook: plugin.HookPostPublish, Config: config, Context: releaseCtx})
+	if err != nil || !resp.Success {
+		t.Fatalf("expected deploy to succeed, got %v / %+v", err, resp)
+	}
+
+	resp, err = p.Execute(ctx, plugin.ExecuteRequest{Hook: plugin.HookOnError, Config: config, Context: releaseCtx})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected rollback to succeed, got error: %s", resp.Error)
+	}
+	if resp.Outputs["rolled_back"] != true {
+		t.Errorf("expected rolled_back output to be true, got %v", resp.Outputs["rolled_back"])
+	}
+	if deletes != 1 {
+		t.Errorf("expected one delete request, got %d", deletes)
+	}
+
+	// The record is consumed, so a second OnError does not delete again.
+	resp, _ = p.Execute(ctx, plugin.ExecuteRequest{Hook: plugin.HookOnError, Config: config, Context: releaseCtx})
+	if deletes != 1 || resp.Outputs["rolled_back"] != false {
+		t.Errorf("expected rollback to happen only once, got %d deletes", deletes)
+	}
+}
+
+func TestExecuteOnErrorRollbackFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	config := map[string]any{
+		"group_id":          "com.example",
+		"artifact_id":       "my-app",
+		"repository":        server.URL + "/artifactory/libs-release-local",
+		"repository_type":   "artifactory",
+		"rollback_on_error": true,
+	}
+	releaseCtx := plugin.ReleaseContext{Version: "1.0.0"}
+
+	p := &MavenPlugin{executor: &MockCommandExecutor{}, httpClient: server.Client()}
+	p.recordDeploy(deployRecord{GroupID: "com.example", ArtifactID: "my-app", Version: "1.0.0"})
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{Hook: plugin.HookOnError, Config: config, Context: releaseCtx})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Success || !strings.Contains(resp.Error, "Maven rollback failed") {
+		t.Errorf("expected rollback failure, got %+v", resp)
+	}
+
+	config["repository"] = "https://repo1.maven.org/maven2"
+	resp, _ = p.Execute(context.Background(), plugin.ExecuteRequest{Hook: plugin.HookOnError, Config: config, Context: releaseCtx})
+	if resp.Success || !strings.Contains(resp.Error, "Maven Central") {
+		t.Errorf("expected rollback against Central to be refused, got %+v", resp)
+	}
+}
+
+func TestExecuteOnErrorRollbackDryRun(t *testing.T) {
+	p := &MavenPlugin{}
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookOnError,
+		Config: map[string]any{
+			"group_id":          "com.example",
+			"artifact_id":       "my-app",
+			"repository":        "http://localhost:8081/repository/maven-releases",
+			"repository_type":   "nexus",
+			"rollback_on_error": true,
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+		DryRun:  true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success || resp.Message != "Would roll back Maven artifact" {
+		t.Errorf("expected dry run rollback message, got %+v", resp)
+	}
+}
+
+func TestExecuteOnErrorRollbackNotFound(t *testing.T) {
+	var deleted string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			deleted = r.URL.Path
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := map[string]any{
+		"group_id":          "com.example",
+		"artifact_id":       "my-app",
+		"repository":        server.URL + "/artifactory/libs-release-local",
+		"repository_type":   "artifactory",
+		"rollback_on_error": true,
+	}
+	releaseCtx := plugin.ReleaseContext{Version: "v1.0.0"}
+
+	p := &MavenPlugin{executor: &MockCommandExecutor{}, httpClient: server.Client()}
+	ctx := context.Background()
+	resp, err := p.Execute(ctx, plugin.ExecuteRequest{Hook: plugin.HookPostPublish, Config: config, Context: releaseCtx})
+	if err != nil || !resp.Success {
+		t.Fatalf("expected deploy to succeed, got %v / %+v", err, resp)
+	}
+
+	resp, err = p.Execute(ctx, plugin.ExecuteRequest{Hook: plugin.HookOnError, Config: config, Context: releaseCtx})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success || resp.Outputs["rolled_back"] != false {
+		t.Fatalf("expected a no-op rollback, got %+v", resp)
+	}
+	if !strings.Contains(resp.Message, "Nothing to roll back") {
+		t.Errorf("expected the message to say nothing was rolled back, got %q", resp.Message)
+	}
+	if !strings.HasSuffix(deleted, "/my-app/1.0.0/") {
+		t.Errorf("expected the Maven version without the v prefix to be deleted, got %q", deleted)
+	}
+	if resp.Outputs["version"] != "1.0.0" {
+		t.Errorf("expected version output 1.0.0, got %v", resp.Outputs["version"])
+	}
+}
@@ -0,0 +1,91 @@
+// Package main implements Scala cross-version artifact handling.
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// scalaBinaryVersionPattern matches Scala binary versions such as 2.13 or 3.
+var scalaBinaryVersionPattern = regexp.MustCompile(`^\d+(\.\d+)?$`)
+
+// scalaArtifactID returns the artifact ID with the Scala binary-version suffix.
+func scalaArtifactID(artifactID, scalaVersion string) string {
+	return artifactID + "_" + scalaVersion
+}
+
+// scalaArtifactIDs returns the cross-version artifact IDs of the configuration.
+func (cfg *Config) scalaArtifactIDs() []string {
+	ids := make([]string, 0, len(cfg.ScalaVersions))
+	for _, v := range cfg.ScalaVersions {
+		ids = append(ids, scalaArtifactID(cfg.ArtifactID, v))
+	}
+	return ids
+}
+
+// crossBuilds reports whether the deploy runs once per Scala version.
+func (cfg *Config) crossBuilds() bool {
+	return len(cfg.ScalaVersions) > 0 && cfg.ScalaVersionProperty != ""
+}
+
+// multiArtifact reports whether the release deploys more than one artifact
+// configuration, either from the artifacts list or from Scala cross builds.
+func (cfg *Config) multiArtifact() bool {
+	return len(cfg.Artifacts) > 0 || cfg.crossBuilds()
+}
+
+// deployConfigs returns one configuration per deploy: every artifact, and for
+// Scala cross builds every artifact once per Scala version.
+func (cfg *Config) deployConfigs() []*Config {
+	if !cfg.crossBuilds() {
+		return cfg.artifactConfigs()
+	}
+	return cfg.expandScala(cfg.artifactConfigs())
+}
+
+// expandScala returns the configurations once per Scala version, with the
+// suffixed artifact ID and the Scala version set.
+func (cfg *Config) expandScala(configs []*Config) []*Config {
+	if len(cfg.ScalaVersions) == 0 {
+		return configs
+	}
+
+	crossConfigs := make([]*Config, 0, len(configs)*len(cfg.ScalaVersions))
+	for _, c := range configs {
+		for _, v := range cfg.ScalaVersions {
+			cross := *c
+			cross.ScalaVersions = nil
+			cross.ScalaVersion = v
+			cross.ArtifactID = scalaArtifactID(c.ArtifactID, v)
+			crossConfigs = append(crossConfigs, &cross)
+		}
+	}
+	return crossConfigs
+}
+
+// scalaArgs returns the Maven arguments selecting the Scala version of a cross build.
+func scalaArgs(cfg *Config) []string {
+	if cfg.ScalaVersion == "" || cfg.ScalaVersionProperty == "" {
+		return nil
+	}
+	return []string{fmt.Sprintf("-D%s=%s", cfg.ScalaVersionProperty, cfg.ScalaVersion)}
+}
+
+// validateScalaVersions validates the Scala binary versions for an artifact ID.
+func validateScalaVersions(versions []string, artifactID string) error {
+	seen := map[string]bool{}
+	for _, v := range versions {
+		if !scalaBinaryVersionPattern.MatchString(v) {
+			return fmt.Errorf("invalid Scala binary version %q (expected e.g. 2.13 or 3)", v)
+		}
+		if seen[v] {
+			return fmt.Errorf("duplicate Scala version %q", v)
+		}
+		seen[v] = true
+		if strings.HasSuffix(artifactID, "_"+v) {
+			return fmt.Errorf("artifact_id %q already has the _%s suffix; use the base artifact ID", artifactID, v)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+// Package main provides tests for Scala cross-version artifacts.
+package main
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func scalaConfig() map[string]any {
+	return map[string]any{
+		"group_id":       "com.example",
+		"artifact_id":    "my-lib",
+		"repository":     "http://localhost:8081/repository/maven-releases",
+		"scala_versions": []any{"2.13", "3"},
+	}
+}
+
+func TestExecuteScalaCrossBuild(t *testing.T) {
+	mockExec := &MockCommandExecutor{}
+	p := &MavenPlugin{executor: mockExec}
+
+	config := scalaConfig()
+	config["scala_version_property"] = "scala.binary.version"
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:    plugin.HookPostPublish,
+		Config:  config,
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success || resp.Message != "Deployed 2 Maven artifacts" {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	if len(mockExec.Calls) != 2 {
+		t.Fatalf("expected one deploy per Scala version, got %d", len(mockExec.Calls))
+	}
+	for i, want := range []string{"-Dscala.binary.version=2.13", "-Dscala.binary.version=3"} {
+		if !slices.Contains(mockExec.Calls[i].Args, want) {
+			t.Errorf("deploy %d: expected %s, got %v", i, want, mockExec.Calls[i].Args)
+		}
+	}
+
+	artifacts := resp.Outputs["artifacts"].([]map[string]any)
+	if artifacts[0]["artifact_id"] != "my-lib_2.13" || artifacts[1]["artifact_id"] != "my-lib_3" {
+		t.Errorf("expected suffixed artifact IDs, got %v", artifacts)
+	}
+}
+
+func TestExecuteScalaSingleBuild(t *testing.T) {
+	mockExec := &MockCommandExecutor{}
+	p := &MavenPlugin{executor: mockExec}
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:    plugin.HookPostPublish,
+		Config:  scalaConfig(),
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+	if len(mockExec.Calls) != 1 {
+		t.Fatalf("expected a single deploy without a version property, got %d", len(mockExec.Calls))
+	}
+	ids, _ := resp.Outputs["scala_artifact_ids"].([]string)
+	if !slices.Equal(ids, []string{"my-lib_2.13", "my-lib_3"}) {
+		t.Errorf("unexpected scala_artifact_ids: %v", ids)
+	}
+
+	notes, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:    plugin.HookPostNotes,
+		Config:  map[string]any{"group_id": "com.example", "artifact_id": "my-lib", "scala_versions": []any{"3"}, "installation_notes": true},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if text, _ := notes.Outputs["installation_notes"].(string); !strings.Contains(text, "<artifactId>my-lib_3</artifactId>") {
+		t.Errorf("expected the suffixed artifact ID in installation notes, got %s", text)
+	}
+}
+
+func TestValidateScalaVersions(t *testing.T) {
+	tests := []struct {
+		name       string
+		versions   []string
+		artifactID string
+		wantErr    bool
+	}{
+		{name: "valid", versions: []string{"2.12", "2.13", "3"}, artifactID: "my-lib"},
+		{name: "full version", versions: []string{"2.13.12"}, artifactID: "my-lib", wantErr: true},
+		{name: "duplicate", versions: []string{"3", "3"}, artifactID: "my-lib", wantErr: true},
+		{name: "suffixed artifact ID", versions: []string{"2.13"}, artifactID: "my-lib_2.13", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateScalaVersions(tt.versions, tt.artifactID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	p := &MavenPlugin{}
+	resp, err := p.Validate(context.Background(), map[string]any{
+		"group_id":               "com.example",
+		"artifact_id":            "my-lib",
+		"scala_version_property": "scala.binary.version",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Valid || resp.Errors[0].Field != "scala_version_property" {
+		t.Errorf("expected an error for scala_version_property without versions, got %v", resp.Errors)
+	}
+}
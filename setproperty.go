@@ -0,0 +1,128 @@
+// Package main implements bumping version properties with versions:set-property.
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// versionsPlugin is the Versions Maven Plugin setting version properties and
+// reporting available updates.
+const versionsPlugin = "org.codehaus.mojo:versions-maven-plugin:2.17.1"
+
+// PropertyUpdate describes a version property of the project POMs set before the deploy.
+type PropertyUpdate struct {
+	// Property is the POM property, e.g. platform.version.
+	Property string
+	// Value is the new value; empty sets the release version.
+	Value string
+}
+
+// propertyUpdateResult reports a property set by versions:set-property.
+type propertyUpdateResult struct {
+	Property string `json:"property"`
+	Value    string `json:"value"`
+}
+
+// parsePropertyUpdates parses the property_updates list from the raw configuration.
+func parsePropertyUpdates(raw map[string]any) []PropertyUpdate {
+	items, ok := raw["property_updates"].([]any)
+	if !ok {
+		return nil
+	}
+	updates := make([]PropertyUpdate, 0, len(items))
+	for _, item := range items {
+		entry, _ := item.(map[string]any)
+		parser := helpers.NewConfigParser(entry)
+		updates = append(updates, PropertyUpdate{
+			Property: parser.GetString("property", "", ""),
+			Value:    parser.GetString("value", "", ""),
+		})
+	}
+	return updates
+}
+
+// validatePropertyUpdate validates a single property update entry.
+func validatePropertyUpdate(update PropertyUpdate) (string, error) {
+	if !versionPropertyPattern.MatchString(update.Property) {
+		return "property", fmt.Errorf("property must be a POM property name")
+	}
+	if update.Value != "" && !mavenCoordinatePattern.MatchString(update.Value) {
+		return "value", fmt.Errorf("value must be a version")
+	}
+	return "", nil
+}
+
+// setPropertyArgs returns the Maven arguments setting the property in every
+// project of the reactor that declares it, without backup POMs.
+func setPropertyArgs(cfg *Config, property, value string) []string {
+	args := []string{"-B", "-f", cfg.PomPath}
+	if path := cfg.userSettings(); path != "" {
+		args = append(args, "-s", path)
+	}
+	args = append(args, cfg.isolationArgs(true)...)
+	return append(args,
+		versionsPlugin+":set-property",
+		"-Dproperty="+property,
+		"-DnewVersion="+value,
+		"-DgenerateBackupPoms=false",
+	)
+}
+
+// updateProperties sets the configured version properties in the project
+// POMs before the deploy builds them.
+func (p *MavenPlugin) updateProperties(ctx context.Context, cfg *Config, releaseCtx plugin.ReleaseContext, dryRun bool) (*plugin.ExecuteResponse, error) {
+	if err := validatePath(cfg.PomPath); err != nil {
+		return &plugin.ExecuteResponse{
+			Success: false,
+			Error:   fmt.Sprintf("invalid pom_path: %v", err),
+		}, nil
+	}
+
+	var results []propertyUpdateResult
+	var commands [][]string
+	for _, update := range cfg.PropertyUpdates {
+		if _, err := validatePropertyUpdate(update); err != nil {
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   fmt.Sprintf("invalid property update: %v", err),
+			}, nil
+		}
+		value := update.Value
+		if value == "" {
+			value = mavenVersion(releaseCtx.Version)
+		}
+		results = append(results, propertyUpdateResult{Property: update.Property, Value: value})
+		commands = append(commands, setPropertyArgs(cfg, update.Property, value))
+	}
+
+	outputs := map[string]any{
+		"property_updates": results,
+		"command":          cfg.commandLine(commands),
+	}
+	if dryRun {
+		return &plugin.ExecuteResponse{
+			Success: true,
+			Message: fmt.Sprintf("Would set %d POM properties", len(results)),
+			Outputs: outputs,
+		}, nil
+	}
+
+	for i, args := range commands {
+		output, err := p.runMavenCommand(ctx, cfg, args...)
+		if err != nil {
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   fmt.Sprintf("versions:set-property failed for %s: %s\nOutput: %s", results[i].Property, describeExecError(cfg.mavenCommand(), err), cfg.errorOutput(output)),
+			}, nil
+		}
+	}
+	return &plugin.ExecuteResponse{
+		Success: true,
+		Message: fmt.Sprintf("Set %d POM properties", len(results)),
+		Outputs: outputs,
+	}, nil
+}
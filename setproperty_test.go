@@ -0,0 +1,148 @@
+// Package main provides tests for setting version properties with versions:set-property.
+package main
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestExecuteUpdateProperties(t *testing.T) {
+	mockExec := &MockCommandExecutor{}
+	p := &MavenPlugin{executor: mockExec}
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPrePublish,
+		Config: map[string]any{
+			"group_id":    "com.example",
+			"artifact_id": "my-app",
+			"property_updates": []any{
+				map[string]any{"property": "platform.version"},
+				map[string]any{"property": "bom.version", "value": "2024.1.0"},
+			},
+		},
+		Context: plugin.ReleaseContext{Version: "v1.2.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+
+	if len(mockExec.Calls) != 2 {
+		t.Fatalf("expected 2 Maven runs, got %d", len(mockExec.Calls))
+	}
+	want := []string{
+		"-B", "-f", "pom.xml",
+		versionsPlugin + ":set-property",
+		"-Dproperty=platform.version",
+		"-DnewVersion=1.2.0",
+		"-DgenerateBackupPoms=false",
+	}
+	if !slices.Equal(mockExec.Calls[0].Args, want) {
+		t.Errorf("args = %v, want %v", mockExec.Calls[0].Args, want)
+	}
+	if !slices.Contains(mockExec.Calls[1].Args, "-DnewVersion=2024.1.0") {
+		t.Errorf("expected the configured value, got %v", mockExec.Calls[1].Args)
+	}
+	results, _ := resp.Outputs["property_updates"].([]propertyUpdateResult)
+	if len(results) != 2 || results[0].Value != "1.2.0" {
+		t.Errorf("unexpected property_updates output: %v", resp.Outputs["property_updates"])
+	}
+}
+
+func TestExecuteUpdatePropertiesDryRun(t *testing.T) {
+	mockExec := &MockCommandExecutor{}
+	p := &MavenPlugin{executor: mockExec}
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPrePublish,
+		Config: map[string]any{
+			"property_updates": []any{map[string]any{"property": "platform.version"}},
+		},
+		Context: plugin.ReleaseContext{Version: "1.2.0"},
+		DryRun:  true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+	if len(mockExec.Calls) != 0 {
+		t.Errorf("expected no Maven runs, got %d", len(mockExec.Calls))
+	}
+	command, _ := resp.Outputs["command"].(string)
+	if !strings.Contains(command, "-Dproperty=platform.version -DnewVersion=1.2.0") {
+		t.Errorf("unexpected command %q", command)
+	}
+}
+
+func TestExecuteUpdatePropertiesFailure(t *testing.T) {
+	mockExec := &MockCommandExecutor{
+		RunFunc: func(ctx context.Context, name string, args ...string) ([]byte, error) {
+			return []byte("[ERROR] BUILD FAILURE"), errors.New("exit status 1")
+		},
+	}
+	p := &MavenPlugin{executor: mockExec}
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPrePublish,
+		Config: map[string]any{
+			"property_updates": []any{map[string]any{"property": "platform.version"}},
+		},
+		Context: plugin.ReleaseContext{Version: "1.2.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Success || !strings.Contains(resp.Error, "versions:set-property failed for platform.version") {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+}
+
+func TestValidatePropertyUpdates(t *testing.T) {
+	tests := []struct {
+		name      string
+		updates   any
+		wantField string
+	}{
+		{name: "valid", updates: []any{map[string]any{"property": "platform.version", "value": "1.0.0"}}},
+		{name: "not a list", updates: "platform.version", wantField: "property_updates"},
+		{name: "missing property", updates: []any{map[string]any{"value": "1.0.0"}}, wantField: "property_updates[0].property"},
+		{name: "invalid value", updates: []any{map[string]any{"property": "platform.version", "value": "1.0 && rm"}}, wantField: "property_updates[0].value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := (&MavenPlugin{}).Validate(context.Background(), map[string]any{
+				"group_id":         "com.example",
+				"artifact_id":      "my-app",
+				"property_updates": tt.updates,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var fields []string
+			for _, e := range resp.Errors {
+				if strings.HasPrefix(e.Field, "property_updates") {
+					fields = append(fields, e.Field)
+				}
+			}
+			if tt.wantField == "" {
+				if len(fields) > 0 {
+					t.Errorf("unexpected errors: %v", fields)
+				}
+				return
+			}
+			if !slices.Contains(fields, tt.wantField) {
+				t.Errorf("expected error on %s, got %v", tt.wantField, fields)
+			}
+		})
+	}
+}
@@ -0,0 +1,285 @@
+// Package main implements generation of the Maven settings.xml used for deploys.
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// maskedValue replaces secrets in previews.
+const maskedValue = "********"
+
+// secretElementPattern matches settings.xml elements holding secrets.
+var secretElementPattern = regexp.MustCompile(`(?s)<(password|passphrase)>.*?</(?:password|passphrase)>`)
+
+// Default server IDs used when the configuration does not name them.
+const (
+	defaultRepositoryID         = "releases"
+	defaultSnapshotRepositoryID = "snapshots"
+)
+
+// projectSettingsFile is the project-local settings file, relative to the POM directory.
+const projectSettingsFile = ".mvn/local-settings.xml"
+
+// Sources of the Maven user settings, reported in the settings_source output.
+const (
+	settingsSourceConfig    = "config"
+	settingsSourceProject   = "project"
+	settingsSourceMavenHome = "maven_home"
+	settingsSourceUserHome  = "user_home"
+	settingsSourceGenerated = "generated"
+	settingsSourceNone      = "none"
+)
+
+// settingsLookup returns the user settings file and its source, trying in
+// order the settings option, the project's .mvn/local-settings.xml, the
+// settings.xml of the isolated configuration directory or, when Maven is not
+// isolated, the runner user's ~/.m2/settings.xml. It returns "" and
+// settingsSourceNone when none exists; the deploy then generates one for the
+// credentials.
+func (cfg *Config) settingsLookup() (string, string) {
+	if cfg.Settings != "" {
+		return cfg.Settings, settingsSourceConfig
+	}
+	if path := filepath.Join(filepath.Dir(cfg.PomPath), filepath.FromSlash(projectSettingsFile)); fileExists(path) {
+		return path, settingsSourceProject
+	}
+	if dir := cfg.mavenConfigDir(); dir != "" {
+		if path := filepath.Join(dir, "settings.xml"); fileExists(path) {
+			return path, settingsSourceMavenHome
+		}
+		return "", settingsSourceNone
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		if path := filepath.Join(home, ".m2", "settings.xml"); fileExists(path) {
+			return path, settingsSourceUserHome
+		}
+	}
+	return "", settingsSourceNone
+}
+
+// dryRunSettingsSource returns the settings source a deploy would report.
+func (cfg *Config) dryRunSettingsSource() string {
+	_, source := cfg.settingsLookup()
+	if source == settingsSourceNone && (len(cfg.settingsServers()) > 0 || len(cfg.ResolutionRepositories) > 0 || cfg.mavenConfigDir() != "") {
+		return settingsSourceGenerated
+	}
+	return source
+}
+
+// settingsServer is a <server> entry in a generated settings.xml.
+type settingsServer struct {
+	ID         string `xml:"id"`
+	Username   string `xml:"username,omitempty"`
+	Password   string `xml:"password,omitempty"`
+	PrivateKey string `xml:"privateKey,omitempty"`
+	// Configuration carries the transport timeouts.
+	Configuration *serverConfiguration `xml:"configuration,omitempty"`
+}
+
+// mavenSettings is the subset of the settings.xml model the plugin generates.
+type mavenSettings struct {
+	XMLName        xml.Name         `xml:"settings"`
+	Xmlns          string           `xml:"xmlns,attr"`
+	XmlnsXSI       string           `xml:"xmlns:xsi,attr"`
+	SchemaLocation string           `xml:"xsi:schemaLocation,attr"`
+	Servers        []settingsServer `xml:"servers>server"`
+}
+
+// settingsServers returns the server entries required for the configured repositories.
+// Snapshot credentials fall back to the release credentials when not set, and
+// none are added to a configured settings file without
+// merge_settings_credentials. The
+// snapshot entry is also written without snapshot_repository, for a snapshot
+// repository of the POM's distributionManagement, when snapshot credentials or
+// snapshot_repository_id are set.
+func (cfg *Config) settingsServers() []settingsServer {
+	var servers []settingsServer
+	// A configured settings file keeps its own servers unless asked to merge.
+	if cfg.Settings != "" && !cfg.MergeSettingsCredentials {
+		return cfg.resolutionServers()
+	}
+
+	if cfg.Username != "" || cfg.Password != "" {
+		servers = append(servers, settingsServer{
+			ID:            cfg.RepositoryID,
+			Username:      cfg.Username,
+			Password:      cfg.Password,
+			Configuration: cfg.serverConfiguration(),
+		})
+	}
+
+	snapshotServer := cfg.SnapshotRepository != "" || cfg.SnapshotUsername != "" || cfg.SnapshotPassword != "" || cfg.snapshotRepositoryIDSet
+	if snapshotServer && cfg.SnapshotRepositoryID != cfg.RepositoryID {
+		username, password := cfg.SnapshotUsername, cfg.SnapshotPassword
+		if username == "" && password == "" {
+			username, password = cfg.Username, cfg.Password
+		}
+		if username != "" || password != "" {
+			servers = append(servers, settingsServer{
+				ID:            cfg.SnapshotRepositoryID,
+				Username:      username,
+				Password:      password,
+				Configuration: cfg.serverConfiguration(),
+			})
+		}
+	}
+
+	defined := make(map[string]bool, len(servers))
+	for _, server := range servers {
+		defined[server.ID] = true
+	}
+	for _, server := range cfg.resolutionServers() {
+		if !defined[server.ID] {
+			servers = append(servers, server)
+		}
+	}
+	return servers
+}
+
+// renderSettings renders a settings.xml document containing the given servers.
+func renderSettings(servers []settingsServer) ([]byte, error) {
+	doc := mavenSettings{
+		Xmlns:          "http://maven.apache.org/SETTINGS/1.0.0",
+		XmlnsXSI:       "http://www.w3.org/2001/XMLSchema-instance",
+		SchemaLocation: "http://maven.apache.org/SETTINGS/1.0.0 https://maven.apache.org/xsd/settings-1.0.0.xsd",
+		Servers:        servers,
+	}
+
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to render settings: %w", err)
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+// writeSettings writes settings content to a private temporary file.
+// The returned cleanup function removes the file.
+func writeSettings(data []byte) (string, func(), error) {
+	f, err := os.CreateTemp("", "relicta-maven-settings-*.xml")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create settings file: %w", err)
+	}
+	cleanup := func() { _ = os.Remove(f.Name()) }
+
+	// CreateTemp already uses 0600, but be explicit since the file holds credentials.
+	if err := f.Chmod(0o600); err != nil {
+		_ = f.Close()
+		cleanup()
+		return "", nil, fmt.Errorf("failed to secure settings file: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		cleanup()
+		return "", nil, fmt.Errorf("failed to write settings file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("failed to write settings file: %w", err)
+	}
+
+	return f.Name(), cleanup, nil
+}
+
+// resolveSettings renders servers and the resolution repositories into a
+// settings document, merged into the user's settings file when one is found.
+func (cfg *Config) resolveSettings(servers []settingsServer) ([]byte, error) {
+	var data []byte
+	var err error
+	if path, _ := cfg.settingsLookup(); path == "" {
+		data, err = renderSettings(servers)
+	} else {
+		userSettings, readErr := os.ReadFile(path)
+		if readErr != nil {
+			return nil, fmt.Errorf("failed to read settings file: %w", readErr)
+		}
+		data, err = injectServers(userSettings, servers)
+	}
+	profile := cfg.resolutionProfile()
+	if err != nil || profile == nil {
+		return data, err
+	}
+	return injectProfile(data, profile)
+}
+
+// maskSettings replaces every password and passphrase in a settings document.
+func maskSettings(data []byte) []byte {
+	return secretElementPattern.ReplaceAll(data, []byte("<$1>"+maskedValue+"</$1>"))
+}
+
+// readSettings reads and parses a settings.xml file.
+func readSettings(path string) (*mavenSettings, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open settings file: %w", err)
+	}
+	defer f.Close()
+
+	var settings mavenSettings
+	if err := xml.NewDecoder(io.LimitReader(f, 10<<20)).Decode(&settings); err != nil {
+		return nil, fmt.Errorf("failed to parse settings file: %w", err)
+	}
+	return &settings, nil
+}
+
+// checkServerCredentials verifies that settings contain a <server> with credentials for id.
+func checkServerCredentials(settings *mavenSettings, id string) error {
+	for _, server := range settings.Servers {
+		if server.ID != id {
+			continue
+		}
+		if server.Username == "" && server.PrivateKey == "" {
+			return fmt.Errorf("server %q in settings has no credentials (username or privateKey)", id)
+		}
+		return nil
+	}
+	return fmt.Errorf("no <server> with id %q found in settings; deploys to this repository will fail with 401", id)
+}
+
+// injectServers adds server entries to existing settings.xml content, skipping
+// IDs the user already defines so their entries take precedence.
+func injectServers(content []byte, servers []settingsServer) ([]byte, error) {
+	var existing mavenSettings
+	if err := xml.Unmarshal(content, &existing); err != nil {
+		return nil, fmt.Errorf("failed to parse settings file: %w", err)
+	}
+
+	defined := make(map[string]bool, len(existing.Servers))
+	for _, server := range existing.Servers {
+		defined[server.ID] = true
+	}
+
+	var buf bytes.Buffer
+	for _, server := range servers {
+		if defined[server.ID] {
+			continue
+		}
+		data, err := xml.MarshalIndent(struct {
+			XMLName xml.Name `xml:"server"`
+			settingsServer
+		}{settingsServer: server}, "    ", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to render settings: %w", err)
+		}
+		buf.WriteString("\n    ")
+		buf.Write(bytes.TrimLeft(data, " "))
+	}
+	if buf.Len() == 0 {
+		return content, nil
+	}
+	buf.WriteString("\n  ")
+
+	if idx := bytes.LastIndex(content, []byte("</servers>")); idx >= 0 {
+		return bytes.Join([][]byte{content[:idx], buf.Bytes(), content[idx:]}, nil), nil
+	}
+	if idx := bytes.LastIndex(content, []byte("</settings>")); idx >= 0 {
+		block := "  <servers>" + buf.String() + "</servers>\n"
+		return bytes.Join([][]byte{content[:idx], []byte(block), content[idx:]}, nil), nil
+	}
+	return nil, fmt.Errorf("failed to inject servers: settings file has no closing </settings> element")
+}
@@ -0,0 +1,663 @@
+// Package main provides tests for settings.xml generation.
+package main
+
+import (
+	"context"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestSettingsServers(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *Config
+		expected []settingsServer
+	}{
+		{
+			name: "no credentials",
+			config: &Config{
+				RepositoryID:         "releases",
+				SnapshotRepositoryID: "snapshots",
+			},
+			expected: nil,
+		},
+		{
+			name: "release credentials only",
+			config: &Config{
+				Username:             "deployer",
+				Password:             "secret",
+				RepositoryID:         "releases",
+				SnapshotRepositoryID: "snapshots",
+			},
+			expected: []settingsServer{
+				{ID: "releases", Username: "deployer", Password: "secret"},
+			},
+		},
+		{
+			name: "snapshot credentials for the POM's snapshot repository",
+			config: &Config{
+				RepositoryID:         "releases",
+				SnapshotRepositoryID: "snapshots",
+				SnapshotUsername:     "snapshot-deployer",
+				SnapshotPassword:     "snapshot-secret",
+			},
+			expected: []settingsServer{
+				{ID: "snapshots", Username: "snapshot-deployer", Password: "snapshot-secret"},
+			},
+		},
+		{
+			name: "snapshot_repository_id without snapshot_repository",
+			config: &Config{
+				Username:                "deployer",
+				Password:                "secret",
+				RepositoryID:            "releases",
+				SnapshotRepositoryID:    "internal-snapshots",
+				snapshotRepositoryIDSet: true,
+			},
+			expected: []settingsServer{
+				{ID: "releases", Username: "deployer", Password: "secret"},
+				{ID: "internal-snapshots", Username: "deployer", Password: "secret"},
+			},
+		},
+		{
+			name: "snapshot repository falls back to release credentials",
+			config: &Config{
+				Username:             "deployer",
+				Password:             "secret",
+				RepositoryID:         "releases",
+				SnapshotRepository:   "https://repo.example.com/snapshots",
+				SnapshotRepositoryID: "snapshots",
+			},
+			expected: []settingsServer{
+				{ID: "releases", Username: "deployer", Password: "secret"},
+				{ID: "snapshots", Username: "deployer", Password: "secret"},
+			},
+		},
+		{
+			name: "separate snapshot credentials",
+			config: &Config{
+				Username:             "deployer",
+				Password:             "secret",
+				RepositoryID:         "releases",
+				SnapshotRepository:   "https://repo.example.com/snapshots",
+				SnapshotRepositoryID: "snapshots",
+				SnapshotUsername:     "snap-deployer",
+				SnapshotPassword:     "snap-secret",
+			},
+			expected: []settingsServer{
+				{ID: "releases", Username: "deployer", Password: "secret"},
+				{ID: "snapshots", Username: "snap-deployer", Password: "snap-secret"},
+			},
+		},
+		{
+			name: "shared server ID produces a single entry",
+			config: &Config{
+				Username:             "deployer",
+				Password:             "secret",
+				RepositoryID:         "nexus",
+				SnapshotRepository:   "https://repo.example.com/snapshots",
+				SnapshotRepositoryID: "nexus",
+				SnapshotUsername:     "snap-deployer",
+			},
+			expected: []settingsServer{
+				{ID: "nexus", Username: "deployer", Password: "secret"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			servers := tt.config.settingsServers()
+			if len(servers) != len(tt.expected) {
+				t.Fatalf("expected servers %v, got %v", tt.expected, servers)
+			}
+			for i, server := range servers {
+				if server != tt.expected[i] {
+					t.Errorf("server[%d]: expected %v, got %v", i, tt.expected[i], server)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderSettings(t *testing.T) {
+	data, err := renderSettings([]settingsServer{
+		{ID: "releases", Username: "deployer", Password: "p<a>ss&word"},
+		{ID: "snapshots", Username: "snap", Password: "snap-secret"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content := string(data)
+	for _, want := range []string{
+		`<?xml version="1.0" encoding="UTF-8"?>`,
+		`<settings xmlns="http://maven.apache.org/SETTINGS/1.0.0"`,
+		"<id>releases</id>",
+		"<username>deployer</username>",
+		"<password>p&lt;a&gt;ss&amp;word</password>",
+		"<id>snapshots</id>",
+		"<username>snap</username>",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected settings to contain %q, got:\n%s", want, content)
+		}
+	}
+}
+
+func TestWriteSettings(t *testing.T) {
+	path, cleanup, err := writeSettings([]byte("<settings/>"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("expected settings file to exist: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm&0o077 != 0 {
+		t.Errorf("expected private permissions, got %o", perm)
+	}
+
+	cleanup()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected settings file to be removed, got err=%v", err)
+	}
+}
+
+func TestParseConfigSnapshotCredentials(t *testing.T) {
+	p := &MavenPlugin{}
+
+	t.Setenv("MAVEN_SNAPSHOT_USERNAME", "env-snap")
+	t.Setenv("MAVEN_SNAPSHOT_PASSWORD", "env-snap-secret")
+
+	cfg := p.parseConfig(map[string]any{
+		"snapshot_repository": "https://repo.example.com/snapshots",
+	})
+
+	if cfg.RepositoryID != defaultRepositoryID {
+		t.Errorf("repository_id: expected '%s', got '%s'", defaultRepositoryID, cfg.RepositoryID)
+	}
+	if cfg.SnapshotRepositoryID != defaultSnapshotRepositoryID {
+		t.Errorf("snapshot_repository_id: expected '%s', got '%s'", defaultSnapshotRepositoryID, cfg.SnapshotRepositoryID)
+	}
+	if cfg.SnapshotUsername != "env-snap" {
+		t.Errorf("snapshot_username: expected 'env-snap', got '%s'", cfg.SnapshotUsername)
+	}
+	if cfg.SnapshotPassword != "env-snap-secret" {
+		t.Errorf("snapshot_password: expected 'env-snap-secret', got '%s'", cfg.SnapshotPassword)
+	}
+
+	cfg = p.parseConfig(map[string]any{
+		"snapshot_username": "config-snap",
+	})
+	if cfg.SnapshotUsername != "config-snap" {
+		t.Errorf("snapshot_username: expected config to override env, got '%s'", cfg.SnapshotUsername)
+	}
+}
+
+func TestExecuteGeneratesSettings(t *testing.T) {
+	var settingsContent string
+	var settingsPath string
+
+	mockExec := &MockCommandExecutor{
+		RunFunc: func(_ context.Context, _ string, args ...string) ([]byte, error) {
+			for i, arg := range args {
+				if arg == "-s" && i+1 < len(args) {
+					settingsPath = args[i+1]
+					data, err := os.ReadFile(settingsPath)
+					if err != nil {
+						return nil, err
+					}
+					settingsContent = string(data)
+				}
+			}
+			return []byte("[INFO] BUILD SUCCESS"), nil
+		},
+	}
+	p := &MavenPlugin{executor: mockExec}
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":            "com.example",
+			"artifact_id":         "my-app",
+			"username":            "deployer",
+			"password":            "secret",
+			"repository":          "http://localhost:8081/repository/maven-releases",
+			"snapshot_repository": "http://localhost:8081/repository/maven-snapshots",
+			"snapshot_username":   "snap-deployer",
+			"snapshot_password":   "snap-secret",
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+
+	if settingsContent == "" {
+		t.Fatal("expected generated settings to be passed to Maven")
+	}
+	for _, want := range []string{
+		"<id>releases</id>",
+		"<username>deployer</username>",
+		"<id>snapshots</id>",
+		"<username>snap-deployer</username>",
+		"<password>snap-secret</password>",
+	} {
+		if !strings.Contains(settingsContent, want) {
+			t.Errorf("expected settings to contain %q, got:\n%s", want, settingsContent)
+		}
+	}
+
+	if _, err := os.Stat(settingsPath); !os.IsNotExist(err) {
+		t.Errorf("expected generated settings to be removed after deploy, got err=%v", err)
+	}
+}
+
+const userSettings = `<?xml version="1.0" encoding="UTF-8"?>
+<settings>
+  <mirrors>
+    <mirror>
+      <id>corp</id>
+      <url>https://mirror.example.com/maven2</url>
+      <mirrorOf>*</mirrorOf>
+    </mirror>
+  </mirrors>
+  <servers>
+    <server>
+      <id>releases</id>
+      <username>user-deployer</username>
+      <password>user-secret</password>
+    </server>
+  </servers>
+</settings>
+`
+
+func TestExecuteMergesUserSettings(t *testing.T) {
+	chdir(t, t.TempDir())
+	if err := os.WriteFile("settings.xml", []byte(userSettings), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var settingsContent string
+	var settingsPath string
+	mockExec := &MockCommandExecutor{
+		RunFunc: func(_ context.Context, _ string, args ...string) ([]byte, error) {
+			for i, arg := range args {
+				if arg == "-s" && i+1 < len(args) {
+					settingsPath = args[i+1]
+					data, err := os.ReadFile(settingsPath)
+					if err != nil {
+						return nil, err
+					}
+					settingsContent = string(data)
+				}
+			}
+			return []byte("[INFO] BUILD SUCCESS"), nil
+		},
+	}
+	p := &MavenPlugin{executor: mockExec}
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":                   "com.example",
+			"artifact_id":                "my-app",
+			"username":                   "deployer",
+			"password":                   "secret",
+			"snapshot_repository":        "http://localhost:8081/repository/maven-snapshots",
+			"settings":                   "settings.xml",
+			"merge_settings_credentials": true,
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+
+	args := strings.Join(mockExec.Calls[0].Args, " ")
+	if strings.Count(args, "-s ") != 1 || strings.Contains(args, "-s settings.xml") {
+		t.Errorf("expected a single merged settings file, got %s", args)
+	}
+	for _, want := range []string{
+		"<mirrorOf>*</mirrorOf>",
+		"<username>user-deployer</username>",
+		"<id>snapshots</id>",
+		"<username>deployer</username>",
+	} {
+		if !strings.Contains(settingsContent, want) {
+			t.Errorf("expected merged settings to contain %q, got:\n%s", want, settingsContent)
+		}
+	}
+	if strings.Count(settingsContent, "<id>releases</id>") != 1 {
+		t.Errorf("expected the user's releases server to take precedence, got:\n%s", settingsContent)
+	}
+	if _, err := os.Stat(settingsPath); !os.IsNotExist(err) {
+		t.Errorf("expected merged settings to be removed after deploy, got err=%v", err)
+	}
+}
+
+func TestExecuteKeepsUserSettings(t *testing.T) {
+	chdir(t, t.TempDir())
+	if err := os.WriteFile("settings.xml", []byte(userSettings), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	mockExec := &MockCommandExecutor{}
+	resp, err := (&MavenPlugin{executor: mockExec}).Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":    "com.example",
+			"artifact_id": "my-app",
+			"username":    "deployer",
+			"password":    "secret",
+			"settings":    "settings.xml",
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+	if args := strings.Join(mockExec.Calls[0].Args, " "); strings.Count(args, "-s ") != 1 || !strings.Contains(args, "-s settings.xml") {
+		t.Errorf("expected the user's settings file without merged credentials, got %s", args)
+	}
+}
+
+func TestInjectServers(t *testing.T) {
+	servers := []settingsServer{{ID: "snapshots", Username: "deployer", Password: "secret"}}
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{name: "existing servers section", content: userSettings},
+		{name: "no servers section", content: "<settings>\n  <offline>false</offline>\n</settings>\n"},
+		{name: "invalid xml", content: "<settings>", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := injectServers([]byte(tt.content), servers)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var settings mavenSettings
+			if err := xml.Unmarshal(data, &settings); err != nil {
+				t.Fatalf("merged settings do not parse: %v\n%s", err, data)
+			}
+			if err := checkServerCredentials(&settings, "snapshots"); err != nil {
+				t.Errorf("expected injected server, got %v\n%s", err, data)
+			}
+		})
+	}
+}
+
+func TestCheckServerCredentials(t *testing.T) {
+	settings := &mavenSettings{Servers: []settingsServer{
+		{ID: "releases", Username: "deployer", Password: "secret"},
+		{ID: "ssh", PrivateKey: "/home/ci/.ssh/id_rsa"},
+		{ID: "empty"},
+	}}
+
+	tests := []struct {
+		id      string
+		wantErr string
+	}{
+		{id: "releases"},
+		{id: "ssh"},
+		{id: "empty", wantErr: "has no credentials"},
+		{id: "snapshots", wantErr: "no <server> with id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			err := checkServerCredentials(settings, tt.id)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateSettingsServers(t *testing.T) {
+	chdir(t, t.TempDir())
+	if err := os.WriteFile("settings.xml", []byte(userSettings), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		config    map[string]any
+		wantField string
+	}{
+		{
+			name:   "server present",
+			config: map[string]any{"repository_id": "releases"},
+		},
+		{
+			name:      "server missing",
+			config:    map[string]any{"repository_id": "nexus"},
+			wantField: "repository_id",
+		},
+		{
+			name:   "server injected from plugin credentials",
+			config: map[string]any{"repository_id": "nexus", "username": "deployer", "merge_settings_credentials": true},
+		},
+		{
+			name: "snapshot server missing",
+			config: map[string]any{
+				"snapshot_repository":    "http://localhost:8081/repository/maven-snapshots",
+				"snapshot_repository_id": "nexus-snapshots",
+			},
+			wantField: "snapshot_repository_id",
+		},
+		{
+			name:   "repository IDs not configured",
+			config: map[string]any{},
+		},
+		{
+			name:      "settings file missing",
+			config:    map[string]any{"repository_id": "releases", "settings": "missing.xml"},
+			wantField: "settings",
+		},
+		{
+			name:   "settings file not read without check_settings",
+			config: map[string]any{"repository_id": "nexus", "settings": "missing.xml", "check_settings": false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]any{
+				"group_id":    "com.example",
+				"artifact_id": "my-app",
+				"settings":    "settings.xml",
+			}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+
+			p := &MavenPlugin{}
+			resp, err := p.Validate(context.Background(), config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantField == "" {
+				if !resp.Valid {
+					t.Errorf("expected valid config, got errors: %v", resp.Errors)
+				}
+				return
+			}
+			found := false
+			for _, e := range resp.Errors {
+				if e.Field == tt.wantField {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("expected error on field %q, got %v", tt.wantField, resp.Errors)
+			}
+		})
+	}
+}
+
+func TestMaskSettings(t *testing.T) {
+	masked := string(maskSettings([]byte(userSettings + "<proxy><password>proxy-secret</password></proxy><passphrase>\n key \n</passphrase>")))
+
+	for _, secret := range []string{"user-secret", "proxy-secret", "key"} {
+		if strings.Contains(masked, secret) {
+			t.Errorf("expected %q to be masked, got:\n%s", secret, masked)
+		}
+	}
+	if !strings.Contains(masked, "<username>user-deployer</username>") || !strings.Contains(masked, "<password>********</password>") {
+		t.Errorf("expected usernames kept and passwords masked, got:\n%s", masked)
+	}
+	if !strings.Contains(masked, "<passphrase>********</passphrase>") {
+		t.Errorf("expected passphrase masked, got:\n%s", masked)
+	}
+}
+
+func TestSettingsLookup(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	chdir(t, t.TempDir())
+
+	writeFile := func(path string) string {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(userSettings), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	lookup := func(cfg *Config) string {
+		path, source := cfg.settingsLookup()
+		return source + " " + path
+	}
+	cfg := &Config{PomPath: "pom.xml"}
+
+	if got := lookup(cfg); got != "none " {
+		t.Errorf("without settings: got %q", got)
+	}
+	userHome := writeFile(filepath.Join(home, ".m2", "settings.xml"))
+	if got := lookup(cfg); got != "user_home "+userHome {
+		t.Errorf("with ~/.m2/settings.xml: got %q", got)
+	}
+	if got := cfg.userSettings(); got != "" {
+		t.Errorf("~/.m2/settings.xml is read by Maven itself, got %q", got)
+	}
+	isolated := &Config{PomPath: "pom.xml", MavenConfig: "m2"}
+	if got := lookup(isolated); got != "none " {
+		t.Errorf("isolated configuration must not use ~/.m2/settings.xml: got %q", got)
+	}
+	project := writeFile(filepath.FromSlash(".mvn/local-settings.xml"))
+	if got := lookup(cfg); got != "project "+project {
+		t.Errorf("with .mvn/local-settings.xml: got %q", got)
+	}
+	if got := cfg.userSettings(); got != project {
+		t.Errorf("expected -s %s, got %q", project, got)
+	}
+	if got := lookup(&Config{PomPath: "pom.xml", Settings: "ci-settings.xml"}); got != "config ci-settings.xml" {
+		t.Errorf("with settings option: got %q", got)
+	}
+}
+
+func TestExecuteSettingsSource(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   map[string]any
+		userHome bool
+		want     string
+	}{
+		{name: "no settings", want: "none"},
+		{name: "generated for credentials", config: map[string]any{"username": "deployer", "password": "secret"}, want: "generated"},
+		{name: "user home", userHome: true, want: "user_home"},
+		{name: "user home with credentials", config: map[string]any{"username": "deployer", "password": "secret"}, userHome: true, want: "user_home"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv("HOME", home)
+			chdir(t, t.TempDir())
+			if tt.userHome {
+				if err := os.MkdirAll(filepath.Join(home, ".m2"), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(home, ".m2", "settings.xml"), []byte(userSettings), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var settings string
+			mockExec := &MockCommandExecutor{
+				RunFunc: func(ctx context.Context, name string, args ...string) ([]byte, error) {
+					for i, arg := range args {
+						if arg == "-s" && i+1 < len(args) {
+							data, _ := os.ReadFile(args[i+1])
+							settings = string(data)
+						}
+					}
+					return []byte("success"), nil
+				},
+			}
+			config := map[string]any{
+				"group_id":    "com.example",
+				"artifact_id": "my-app",
+				"repository":  "http://localhost:8081/repository/maven-releases",
+			}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+			resp, err := (&MavenPlugin{executor: mockExec}).Execute(context.Background(), plugin.ExecuteRequest{
+				Hook:    plugin.HookPostPublish,
+				Config:  config,
+				Context: plugin.ReleaseContext{Version: "1.0.0"},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !resp.Success {
+				t.Fatalf("expected success, got error: %s", resp.Error)
+			}
+			if resp.Outputs["settings_source"] != tt.want {
+				t.Errorf("settings_source = %v, want %s", resp.Outputs["settings_source"], tt.want)
+			}
+			if tt.userHome && tt.config != nil && !strings.Contains(settings, "<id>releases</id>") {
+				t.Errorf("expected the generated settings to keep ~/.m2/settings.xml, got:\n%s", settings)
+			}
+		})
+	}
+}
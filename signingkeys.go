@@ -0,0 +1,152 @@
+// Package main implements selecting the GPG signing key by repository and artifact.
+package main
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+)
+
+// fingerprintPattern matches a normalized OpenPGP v4 or v5 key fingerprint.
+var fingerprintPattern = regexp.MustCompile(`^(?:[0-9A-F]{40}|[0-9A-F]{64})$`)
+
+// SigningKey is a GPG key of gpg_keys and the deploys it signs.
+type SigningKey struct {
+	// Fingerprint identifies the key, passed to maven-gpg-plugin as gpg.keyname.
+	Fingerprint string
+	// Repositories are the repository IDs or URLs the key signs for; empty
+	// matches every repository.
+	Repositories []string
+	// Artifacts are groupId:artifactId patterns, e.g. com.example:*, the key
+	// signs; empty matches every artifact.
+	Artifacts []string
+	// PassphraseEnv names the variable holding the key's passphrase; empty
+	// keeps gpg_passphrase_env.
+	PassphraseEnv string
+}
+
+// normalizeFingerprint strips the spaces and 0x prefix of a fingerprint and
+// upper-cases it.
+func normalizeFingerprint(fingerprint string) string {
+	fingerprint = strings.ReplaceAll(strings.TrimSpace(fingerprint), " ", "")
+	if len(fingerprint) > 2 && (fingerprint[:2] == "0x" || fingerprint[:2] == "0X") {
+		fingerprint = fingerprint[2:]
+	}
+	return strings.ToUpper(fingerprint)
+}
+
+// parseSigningKeys parses the gpg_keys list from the raw configuration.
+func parseSigningKeys(raw map[string]any) []SigningKey {
+	items, ok := raw["gpg_keys"].([]any)
+	if !ok {
+		return nil
+	}
+	keys := make([]SigningKey, 0, len(items))
+	for _, item := range items {
+		entry, _ := item.(map[string]any)
+		parser := helpers.NewConfigParser(entry)
+		keys = append(keys, SigningKey{
+			Fingerprint:   normalizeFingerprint(parser.GetString("fingerprint", "", "")),
+			Repositories:  parser.GetStringSlice("repositories", nil),
+			Artifacts:     parser.GetStringSlice("artifacts", nil),
+			PassphraseEnv: parser.GetString("passphrase_env", "", ""),
+		})
+	}
+	return keys
+}
+
+// validateFingerprint validates a normalized key fingerprint.
+func validateFingerprint(fingerprint string) error {
+	if !fingerprintPattern.MatchString(fingerprint) {
+		return fmt.Errorf("fingerprint must be the 40 or 64 hex digits of a key fingerprint")
+	}
+	return nil
+}
+
+// validateSigningKey validates a single gpg_keys entry and returns the
+// offending field on error.
+func validateSigningKey(key SigningKey) (string, error) {
+	if err := validateFingerprint(key.Fingerprint); err != nil {
+		return "fingerprint", err
+	}
+	for _, repository := range key.Repositories {
+		if strings.TrimSpace(repository) == "" {
+			return "repositories", fmt.Errorf("repositories must not contain empty entries")
+		}
+	}
+	for _, pattern := range key.Artifacts {
+		if _, err := path.Match(pattern, ""); err != nil || !strings.Contains(pattern, ":") {
+			return "artifacts", fmt.Errorf("invalid artifact pattern %q, expected groupId:artifactId", pattern)
+		}
+	}
+	if key.PassphraseEnv != "" && !envVarPattern.MatchString(key.PassphraseEnv) {
+		return "passphrase_env", fmt.Errorf("invalid environment variable name %q", key.PassphraseEnv)
+	}
+	return "", nil
+}
+
+// matchesRepository reports whether a key signs for the repository with the
+// given ID and URL.
+func (k SigningKey) matchesRepository(id, url string) bool {
+	if len(k.Repositories) == 0 {
+		return true
+	}
+	for _, repository := range k.Repositories {
+		if repository == id || (url != "" && strings.TrimSuffix(repository, "/") == strings.TrimSuffix(url, "/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesArtifact reports whether a key signs for the artifact.
+func (k SigningKey) matchesArtifact(groupID, artifactID string) bool {
+	if len(k.Artifacts) == 0 {
+		return true
+	}
+	for _, pattern := range k.Artifacts {
+		if ok, _ := path.Match(pattern, groupID+":"+artifactID); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// targetRepositoryID returns the server ID of the repository the version is
+// deployed to.
+func (cfg *Config) targetRepositoryID(version string) string {
+	if cfg.targetRepository(version) == cfg.SnapshotRepository && cfg.SnapshotRepository != "" {
+		return cfg.SnapshotRepositoryID
+	}
+	return cfg.RepositoryID
+}
+
+// resolveSigningKey selects the first gpg_keys entry matching the target
+// repository and the artifact, so the old and new keys can both be in use
+// during a key rotation. Without a match the deploy falls back to gpg_key,
+// or fails when that is not set either. The returned configuration signs
+// with the selected key.
+func (cfg *Config) resolveSigningKey(version string) (*Config, error) {
+	if len(cfg.GPGKeys) == 0 {
+		return cfg, nil
+	}
+	id, url := cfg.targetRepositoryID(version), cfg.targetRepository(version)
+	for _, key := range cfg.GPGKeys {
+		if !key.matchesRepository(id, url) || !key.matchesArtifact(cfg.GroupID, cfg.ArtifactID) {
+			continue
+		}
+		c := *cfg
+		c.GPGKey = key.Fingerprint
+		if key.PassphraseEnv != "" {
+			c.GPGPassphraseEnv = key.PassphraseEnv
+		}
+		return &c, nil
+	}
+	if cfg.GPGKey != "" {
+		return cfg, nil
+	}
+	return nil, fmt.Errorf("no gpg_keys entry matches repository %s and artifact %s:%s", id, cfg.GroupID, cfg.ArtifactID)
+}
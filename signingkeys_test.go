@@ -0,0 +1,180 @@
+// Package main provides tests for selecting the GPG signing key.
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+const (
+	oldKey = "0123456789ABCDEF0123456789ABCDEF01234567"
+	newKey = "89ABCDEF0123456789ABCDEF0123456789ABCDEF"
+)
+
+func TestNormalizeFingerprint(t *testing.T) {
+	got := normalizeFingerprint("0x0123 4567 89ab cdef 0123  4567 89ab cdef 0123 4567")
+	if got != oldKey {
+		t.Errorf("normalizeFingerprint() = %s, want %s", got, oldKey)
+	}
+	if err := validateFingerprint(got); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := validateFingerprint("89ABCDEF01234567"); err == nil {
+		t.Error("expected a long key ID to be rejected")
+	}
+}
+
+func TestValidateSigningKey(t *testing.T) {
+	tests := []struct {
+		name      string
+		key       SigningKey
+		wantField string
+	}{
+		{name: "valid", key: SigningKey{Fingerprint: oldKey, Repositories: []string{"central"}, Artifacts: []string{"com.example:*"}, PassphraseEnv: "OLD_KEY_PASSPHRASE"}},
+		{name: "bad fingerprint", key: SigningKey{Fingerprint: "ABC"}, wantField: "fingerprint"},
+		{name: "empty repository", key: SigningKey{Fingerprint: oldKey, Repositories: []string{" "}}, wantField: "repositories"},
+		{name: "artifact without group", key: SigningKey{Fingerprint: oldKey, Artifacts: []string{"my-app"}}, wantField: "artifacts"},
+		{name: "malformed pattern", key: SigningKey{Fingerprint: oldKey, Artifacts: []string{"com.example:[app"}}, wantField: "artifacts"},
+		{name: "bad passphrase env", key: SigningKey{Fingerprint: oldKey, PassphraseEnv: "1X"}, wantField: "passphrase_env"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field, err := validateSigningKey(tt.key)
+			if field != tt.wantField || (err != nil) != (tt.wantField != "") {
+				t.Errorf("validateSigningKey() = %q, %v, want field %q", field, err, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestResolveSigningKey(t *testing.T) {
+	keys := []SigningKey{
+		{Fingerprint: newKey, Repositories: []string{"central"}, PassphraseEnv: "NEW_KEY_PASSPHRASE"},
+		{Fingerprint: oldKey, Artifacts: []string{"com.example:legacy-*"}},
+	}
+	tests := []struct {
+		name           string
+		cfg            Config
+		version        string
+		wantKey        string
+		wantPassphrase string
+		wantErr        bool
+	}{
+		{
+			name:           "repository by id",
+			cfg:            Config{GroupID: "com.example", ArtifactID: "my-app", RepositoryID: "central"},
+			version:        "1.0.0",
+			wantKey:        newKey,
+			wantPassphrase: "NEW_KEY_PASSPHRASE",
+		},
+		{
+			name:           "artifact pattern",
+			cfg:            Config{GroupID: "com.example", ArtifactID: "legacy-client", RepositoryID: "internal"},
+			version:        "1.0.0",
+			wantKey:        oldKey,
+			wantPassphrase: defaultGPGPassphraseEnv,
+		},
+		{
+			name:           "snapshot repository",
+			cfg:            Config{GroupID: "com.example", ArtifactID: "my-app", RepositoryID: "internal", SnapshotRepositoryID: "central", SnapshotRepository: "https://central.sonatype.com/repository/maven-snapshots/"},
+			version:        "1.0.0-SNAPSHOT",
+			wantKey:        newKey,
+			wantPassphrase: "NEW_KEY_PASSPHRASE",
+		},
+		{
+			name:           "fallback to gpg_key",
+			cfg:            Config{GroupID: "com.example", ArtifactID: "my-app", RepositoryID: "internal", GPGKey: oldKey},
+			version:        "1.0.0",
+			wantKey:        oldKey,
+			wantPassphrase: defaultGPGPassphraseEnv,
+		},
+		{
+			name:    "no match",
+			cfg:     Config{GroupID: "com.example", ArtifactID: "my-app", RepositoryID: "internal"},
+			version: "1.0.0",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			cfg.GPGKeys = keys
+			cfg.GPGPassphraseEnv = defaultGPGPassphraseEnv
+			resolved, err := cfg.resolveSigningKey(tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveSigningKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if resolved.GPGKey != tt.wantKey || resolved.GPGPassphraseEnv != tt.wantPassphrase {
+				t.Errorf("resolveSigningKey() = %s, %s, want %s, %s", resolved.GPGKey, resolved.GPGPassphraseEnv, tt.wantKey, tt.wantPassphrase)
+			}
+		})
+	}
+}
+
+func TestExecuteMultiRepositorySigningKeys(t *testing.T) {
+	t.Setenv(defaultGPGPassphraseEnv, "")
+	mockExec := &MockCommandExecutor{
+		RunFunc: func(_ context.Context, _ string, args ...string) ([]byte, error) {
+			return []byte("[INFO] BUILD SUCCESS"), stageRelease(args)
+		},
+	}
+	p := &MavenPlugin{executor: mockExec}
+
+	config := multiRepositoryConfig()
+	config["gpg_key"] = oldKey
+	config["gpg_keys"] = []any{
+		map[string]any{"fingerprint": strings.ToLower(newKey), "repositories": []any{"http://localhost:8083/maven2/"}},
+	}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:    plugin.HookPostPublish,
+		Config:  config,
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+	// Each key signs its own build, which is deployed to its repository.
+	if len(mockExec.Calls) != 4 {
+		t.Fatalf("expected a build and a deploy for every repository, got %d", len(mockExec.Calls))
+	}
+	if args := strings.Join(mockExec.Calls[0].Args, " "); !strings.Contains(args, "-Dgpg.keyname="+oldKey) {
+		t.Errorf("expected the fallback key for internal, got %s", args)
+	}
+	if args := strings.Join(mockExec.Calls[2].Args, " "); !strings.Contains(args, "-Dgpg.keyname="+newKey) {
+		t.Errorf("expected the new key for central, got %s", args)
+	}
+}
+
+func TestValidateSigningKeys(t *testing.T) {
+	p := &MavenPlugin{}
+	resp, err := p.Validate(context.Background(), map[string]any{
+		"group_id":    "com.example",
+		"artifact_id": "my-app",
+		"gpg_key":     "not-a-key",
+		"gpg_keys": []any{
+			map[string]any{"fingerprint": newKey},
+			map[string]any{"fingerprint": oldKey, "artifacts": []any{"my-app"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fields := map[string]bool{}
+	for _, e := range resp.Errors {
+		fields[e.Field] = true
+	}
+	if !fields["gpg_key"] || !fields["gpg_keys[1].artifacts"] || fields["gpg_keys[0].fingerprint"] {
+		t.Errorf("unexpected validation errors: %+v", resp.Errors)
+	}
+}
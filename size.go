@@ -0,0 +1,187 @@
+// Package main implements the artifact size limit check.
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// sizePattern matches sizes such as 600MB, 1.5 GiB, or 1048576.
+var sizePattern = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([a-zA-Z]*)$`)
+
+// sizeUnits maps size units to their number of bytes.
+var sizeUnits = map[string]float64{
+	"":    1,
+	"b":   1,
+	"kb":  1e3,
+	"mb":  1e6,
+	"gb":  1e9,
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+}
+
+// parseSize parses a size with an optional decimal (KB, MB, GB) or binary
+// (KiB, MiB, GiB) unit into bytes.
+func parseSize(s string) (int64, error) {
+	m := sizePattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return 0, fmt.Errorf("invalid size %q (expected e.g. 50MB or 1GiB)", s)
+	}
+	unit, ok := sizeUnits[strings.ToLower(m[2])]
+	if !ok {
+		return 0, fmt.Errorf("invalid size unit %q in %q", m[2], s)
+	}
+	value, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q: %w", s, err)
+	}
+	return int64(value * unit), nil
+}
+
+// parseSizeOption reads a size option given as a string with a unit or as a
+// number of bytes. Invalid values are reported by validation and read as 0.
+func parseSizeOption(raw map[string]any, key string) int64 {
+	switch v := raw[key].(type) {
+	case string:
+		n, _ := parseSize(v)
+		return n
+	case int:
+		return int64(v)
+	case int64:
+		return v
+	case float64:
+		return int64(v)
+	default:
+		return 0
+	}
+}
+
+// validateSizeOption validates a size option.
+func validateSizeOption(raw map[string]any, key string) error {
+	switch v := raw[key].(type) {
+	case nil:
+		return nil
+	case string:
+		_, err := parseSize(v)
+		return err
+	case int, int64:
+		if parseSizeOption(raw, key) < 0 {
+			return fmt.Errorf("size cannot be negative")
+		}
+		return nil
+	case float64:
+		if v < 0 {
+			return fmt.Errorf("size cannot be negative")
+		}
+		return nil
+	default:
+		return fmt.Errorf("size must be a number of bytes or a string such as 50MB")
+	}
+}
+
+// formatSize renders a byte count for messages.
+func formatSize(n int64) string {
+	switch {
+	case n >= 1e9:
+		return fmt.Sprintf("%.1f GB", float64(n)/1e9)
+	case n >= 1e6:
+		return fmt.Sprintf("%.1f MB", float64(n)/1e6)
+	case n >= 1e3:
+		return fmt.Sprintf("%.1f KB", float64(n)/1e3)
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
+
+// buildOutput describes the built files of an artifact in the build directory.
+type buildOutput struct {
+	// Main is the main artifact; empty for pom-packaged projects.
+	Main     string
+	MainSize int64
+	// Total is the size of every file that will be uploaded for the GAV.
+	Total int64
+	// Files lists the paths of the built files of the GAV.
+	Files []string
+	// FinalName is the base name of the built files.
+	FinalName string
+}
+
+// projectPOM returns the POM of the deployed project: the configured module's
+// POM, or the reactor POM.
+func (cfg *Config) projectPOM() string {
+	if cfg.Module == "" {
+		return cfg.PomPath
+	}
+	return filepath.Join(filepath.Dir(cfg.PomPath), filepath.FromSlash(cfg.Module), "pom.xml")
+}
+
+// artifactBuildDir returns the build directory of the deployed project.
+func (cfg *Config) artifactBuildDir() string {
+	dir := filepath.Dir(cfg.PomPath)
+	if cfg.Module != "" {
+		dir = filepath.Join(dir, filepath.FromSlash(cfg.Module))
+	}
+	return filepath.Join(dir, "target")
+}
+
+// readBuildOutput finds the built files of the artifact. Files are named after
+// the POM's finalName, defaulting to artifactId-version.
+func (cfg *Config) readBuildOutput(version string) (*buildOutput, error) {
+	packaging, finalName := "", cfg.ArtifactID+"-"+mavenVersion(version)
+	if pom, err := readPOM(cfg.projectPOM()); err == nil {
+		packaging = pom.Packaging
+		if pom.FinalName != "" && !strings.Contains(pom.FinalName, "${") {
+			finalName = pom.FinalName
+		}
+	}
+
+	dir := cfg.artifactBuildDir()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("no build output in %s; build the project before deploying", dir)
+	}
+
+	out := &buildOutput{FinalName: finalName}
+	if ext := packagingExtension(packaging); ext != "" {
+		out.Main = filepath.Join(dir, finalName+"."+ext)
+		info, err := os.Stat(out.Main)
+		if err != nil {
+			return nil, fmt.Errorf("main artifact %s not found; build the project before deploying", out.Main)
+		}
+		out.MainSize = info.Size()
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, finalName) {
+			continue
+		}
+		if rest := strings.TrimPrefix(name, finalName); !strings.HasPrefix(rest, ".") && !strings.HasPrefix(rest, "-") {
+			continue
+		}
+		if info, err := entry.Info(); err == nil {
+			out.Total += info.Size()
+			out.Files = append(out.Files, filepath.Join(dir, name))
+		}
+	}
+	return out, nil
+}
+
+// checkArtifactSize compares the built artifact against the configured limits.
+func (cfg *Config) checkArtifactSize(version string) (*buildOutput, error) {
+	out, err := cfg.readBuildOutput(version)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.MaxArtifactSize > 0 && out.MainSize > cfg.MaxArtifactSize {
+		return out, fmt.Errorf("main artifact %s is %s, above the limit of %s", filepath.Base(out.Main), formatSize(out.MainSize), formatSize(cfg.MaxArtifactSize))
+	}
+	if cfg.MaxUploadSize > 0 && out.Total > cfg.MaxUploadSize {
+		return out, fmt.Errorf("total upload is %s, above the limit of %s", formatSize(out.Total), formatSize(cfg.MaxUploadSize))
+	}
+	return out, nil
+}
@@ -0,0 +1,148 @@
+// Package main provides tests for the artifact size limit check.
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{in: "1048576", want: 1048576},
+		{in: "600MB", want: 600_000_000},
+		{in: "1.5 GiB", want: 1610612736},
+		{in: "10kib", want: 10240},
+		{in: "12 parsecs", wantErr: true},
+		{in: "-5MB", wantErr: true},
+		{in: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseSize(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseSize(%q): expected error, got %d", tt.in, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("parseSize(%q): expected %d, got %d (%v)", tt.in, tt.want, got, err)
+		}
+	}
+}
+
+// writeBuildOutput writes files of the given sizes into dir/target.
+func writeBuildOutput(t *testing.T, dir string, files map[string]int) {
+	t.Helper()
+	target := filepath.Join(dir, "target")
+	if err := os.MkdirAll(target, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, size := range files {
+		if err := os.WriteFile(filepath.Join(target, name), make([]byte, size), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCheckArtifactSize(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+	writeBuildOutput(t, dir, map[string]int{
+		"my-app-1.0.0.jar":         2000,
+		"my-app-1.0.0-sources.jar": 500,
+		"my-app-1.0.0.pom":         100,
+		"my-app-tests-1.0.0.jar":   9000,
+		"original-my-app.jar":      9000,
+	})
+
+	cfg := &Config{ArtifactID: "my-app", PomPath: "pom.xml", MaxArtifactSize: 5000, MaxUploadSize: 5000}
+	out, err := cfg.checkArtifactSize("1.0.0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.MainSize != 2000 || out.Total != 2600 {
+		t.Errorf("expected main 2000 and total 2600 bytes, got %+v", out)
+	}
+
+	cfg.MaxArtifactSize = 1000
+	if _, err := cfg.checkArtifactSize("1.0.0"); err == nil || !strings.Contains(err.Error(), "my-app-1.0.0.jar is 2.0 KB, above the limit of 1.0 KB") {
+		t.Errorf("expected a main artifact size error, got %v", err)
+	}
+
+	cfg.MaxArtifactSize = 0
+	cfg.MaxUploadSize = 2500
+	if _, err := cfg.checkArtifactSize("1.0.0"); err == nil || !strings.Contains(err.Error(), "total upload") {
+		t.Errorf("expected a total upload size error, got %v", err)
+	}
+
+	if _, err := cfg.checkArtifactSize("2.0.0"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected a missing artifact error, got %v", err)
+	}
+}
+
+func TestExecuteSizeLimit(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+	writeBuildOutput(t, dir, map[string]int{"my-app-1.0.0.jar": 2 << 20})
+
+	config := map[string]any{
+		"group_id":          "com.example",
+		"artifact_id":       "my-app",
+		"repository":        "http://localhost:8081/repository/maven-releases",
+		"max_artifact_size": "1MiB",
+	}
+
+	mockExec := &MockCommandExecutor{}
+	p := &MavenPlugin{executor: mockExec}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:    plugin.HookPostPublish,
+		Config:  config,
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Success || !strings.Contains(resp.Error, "size limit") {
+		t.Errorf("expected the size limit to fail the deploy, got %+v", resp)
+	}
+	if len(mockExec.Calls) != 0 {
+		t.Errorf("expected no deploy, got %d calls", len(mockExec.Calls))
+	}
+
+	config["size_limit_policy"] = "warn"
+	resp, err = p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:    plugin.HookPostPublish,
+		Config:  config,
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+		DryRun:  true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	warnings, _ := resp.Outputs["warnings"].([]string)
+	if !resp.Success || len(warnings) != 1 || !strings.HasPrefix(warnings[0], "size limit") {
+		t.Errorf("expected a size warning, got %+v", resp)
+	}
+
+	vresp, err := p.Validate(context.Background(), map[string]any{
+		"group_id":          "com.example",
+		"artifact_id":       "my-app",
+		"max_artifact_size": "big",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if vresp.Valid || vresp.Errors[0].Field != "max_artifact_size" {
+		t.Errorf("expected a max_artifact_size error, got %v", vresp.Errors)
+	}
+}
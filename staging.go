@@ -0,0 +1,97 @@
+// Package main implements Nexus staging workflow support for the Maven plugin.
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// maxStagingNotesSnippet is the maximum number of characters of release notes
+// included in a staging repository description.
+const maxStagingNotesSnippet = 160
+
+// stagingProfileIDPattern matches Nexus staging profile IDs (hexadecimal in practice).
+var stagingProfileIDPattern = regexp.MustCompile(`^[a-zA-Z0-9]+$`)
+
+// validateStagingProfileID validates a Nexus staging profile ID.
+func validateStagingProfileID(id string) error {
+	if id == "" {
+		return nil // Optional field.
+	}
+	if len(id) > 64 {
+		return fmt.Errorf("staging_profile_id too long (max 64 characters)")
+	}
+	if !stagingProfileIDPattern.MatchString(id) {
+		return fmt.Errorf("invalid staging_profile_id: must be alphanumeric")
+	}
+	return nil
+}
+
+// validateStagingProgressTimeout validates the staging progress timeout in minutes.
+func validateStagingProgressTimeout(minutes int) error {
+	if minutes < 0 {
+		return fmt.Errorf("staging_progress_timeout cannot be negative")
+	}
+	if minutes > 24*60 {
+		return fmt.Errorf("staging_progress_timeout too large (max 1440 minutes)")
+	}
+	return nil
+}
+
+// usesStaging reports whether the Nexus staging workflow is configured.
+func (cfg *Config) usesStaging() bool {
+	return cfg.StagingProfileID != ""
+}
+
+// stagingDescription builds a staging repository description identifying the
+// project, version, and a snippet of the release notes.
+func stagingDescription(cfg *Config, releaseCtx plugin.ReleaseContext) string {
+	desc := fmt.Sprintf("%s:%s %s", cfg.GroupID, cfg.ArtifactID, releaseCtx.Version)
+	if snippet := notesSnippet(releaseCtx.ReleaseNotes, maxStagingNotesSnippet); snippet != "" {
+		desc += " - " + snippet
+	}
+	return desc
+}
+
+// notesSnippet flattens markdown release notes into a single line of at most limit characters.
+func notesSnippet(notes string, limit int) string {
+	var parts []string
+	for _, line := range strings.Split(notes, "\n") {
+		line = strings.TrimSpace(line)
+		line = strings.TrimLeft(line, "#*->` ")
+		if line == "" {
+			continue
+		}
+		parts = append(parts, line)
+	}
+
+	snippet := strings.Join(strings.Fields(strings.Join(parts, "; ")), " ")
+	if runes := []rune(snippet); len(runes) > limit {
+		snippet = strings.TrimSpace(string(runes[:limit-3])) + "..."
+	}
+	return snippet
+}
+
+// stagingArgs returns the nexus-staging-maven-plugin properties for the configuration.
+func stagingArgs(cfg *Config, releaseCtx plugin.ReleaseContext) []string {
+	var args []string
+
+	if cfg.StagingProfileID != "" {
+		args = append(args, "-DstagingProfileId="+cfg.StagingProfileID)
+	}
+	if cfg.StagingProgressTimeout > 0 {
+		args = append(args, "-DstagingProgressTimeoutMinutes="+strconv.Itoa(cfg.StagingProgressTimeout))
+	}
+	if cfg.AutoRelease != nil {
+		args = append(args, "-DautoReleaseAfterClose="+strconv.FormatBool(*cfg.AutoRelease))
+	}
+	if cfg.usesStaging() {
+		args = append(args, "-DstagingDescription="+stagingDescription(cfg, releaseCtx))
+	}
+
+	return args
+}
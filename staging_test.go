@@ -0,0 +1,213 @@
+// Package main provides tests for Nexus staging support.
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestStagingArgs(t *testing.T) {
+	enabled := true
+	disabled := false
+
+	tests := []struct {
+		name     string
+		config   *Config
+		expected []string
+	}{
+		{
+			name:     "no staging options",
+			config:   &Config{},
+			expected: nil,
+		},
+		{
+			name: "all staging options",
+			config: &Config{
+				GroupID:                "com.example",
+				ArtifactID:             "my-lib",
+				StagingProfileID:       "12a3b4c5d6",
+				StagingProgressTimeout: 30,
+				AutoRelease:            &enabled,
+			},
+			expected: []string{
+				"-DstagingProfileId=12a3b4c5d6",
+				"-DstagingProgressTimeoutMinutes=30",
+				"-DautoReleaseAfterClose=true",
+				"-DstagingDescription=com.example:my-lib 1.0.0",
+			},
+		},
+		{
+			name: "auto release explicitly disabled",
+			config: &Config{
+				AutoRelease: &disabled,
+			},
+			expected: []string{"-DautoReleaseAfterClose=false"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := stagingArgs(tt.config, plugin.ReleaseContext{Version: "1.0.0"})
+			if strings.Join(args, " ") != strings.Join(tt.expected, " ") {
+				t.Errorf("expected args %v, got %v", tt.expected, args)
+			}
+		})
+	}
+}
+
+func TestStagingDescription(t *testing.T) {
+	cfg := &Config{GroupID: "com.example", ArtifactID: "my-lib", StagingProfileID: "12a3b4c5d6"}
+
+	tests := []struct {
+		name     string
+		notes    string
+		expected string
+	}{
+		{
+			name:     "no release notes",
+			notes:    "",
+			expected: "com.example:my-lib 1.2.0",
+		},
+		{
+			name:     "markdown notes are flattened",
+			notes:    "## What's Changed\n\n- Add streaming API\n- Fix NPE in parser\n",
+			expected: "com.example:my-lib 1.2.0 - What's Changed; Add streaming API; Fix NPE in parser",
+		},
+		{
+			name:     "long notes are truncated",
+			notes:    strings.Repeat("word ", 100),
+			expected: "com.example:my-lib 1.2.0 - " + strings.TrimSpace(strings.Repeat("word ", 100)[:maxStagingNotesSnippet-3]) + "...",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desc := stagingDescription(cfg, plugin.ReleaseContext{Version: "1.2.0", ReleaseNotes: tt.notes})
+			if desc != tt.expected {
+				t.Errorf("expected '%s', got '%s'", tt.expected, desc)
+			}
+		})
+	}
+
+	args := stagingArgs(cfg, plugin.ReleaseContext{Version: "1.2.0", ReleaseNotes: "- Add streaming API"})
+	if got := args[len(args)-1]; got != "-DstagingDescription=com.example:my-lib 1.2.0 - Add streaming API" {
+		t.Errorf("unexpected staging description argument: %s", got)
+	}
+}
+
+func TestValidateStagingOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:    "empty profile ID is valid",
+			err:     validateStagingProfileID(""),
+			wantErr: false,
+		},
+		{
+			name:    "hex profile ID",
+			err:     validateStagingProfileID("12a3b4c5d6"),
+			wantErr: false,
+		},
+		{
+			name:    "profile ID with injection",
+			err:     validateStagingProfileID("abc -Dfoo=bar"),
+			wantErr: true,
+			errMsg:  "must be alphanumeric",
+		},
+		{
+			name:    "profile ID too long",
+			err:     validateStagingProfileID(strings.Repeat("a", 65)),
+			wantErr: true,
+			errMsg:  "too long",
+		},
+		{
+			name:    "zero timeout uses default",
+			err:     validateStagingProgressTimeout(0),
+			wantErr: false,
+		},
+		{
+			name:    "negative timeout",
+			err:     validateStagingProgressTimeout(-1),
+			wantErr: true,
+			errMsg:  "cannot be negative",
+		},
+		{
+			name:    "timeout too large",
+			err:     validateStagingProgressTimeout(1441),
+			wantErr: true,
+			errMsg:  "too large",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr {
+				if tt.err == nil {
+					t.Errorf("expected error, got nil")
+				} else if !strings.Contains(tt.err.Error(), tt.errMsg) {
+					t.Errorf("expected error containing '%s', got '%s'", tt.errMsg, tt.err.Error())
+				}
+			} else if tt.err != nil {
+				t.Errorf("unexpected error: %v", tt.err)
+			}
+		})
+	}
+}
+
+func TestParseConfigStaging(t *testing.T) {
+	p := &MavenPlugin{}
+
+	cfg := p.parseConfig(map[string]any{})
+	if cfg.AutoRelease != nil {
+		t.Errorf("expected auto_release to be unset, got %v", *cfg.AutoRelease)
+	}
+
+	cfg = p.parseConfig(map[string]any{
+		"staging_profile_id":       "12a3b4c5d6",
+		"staging_progress_timeout": float64(45),
+		"auto_release":             false,
+	})
+	if cfg.StagingProfileID != "12a3b4c5d6" {
+		t.Errorf("staging_profile_id: expected '12a3b4c5d6', got '%s'", cfg.StagingProfileID)
+	}
+	if cfg.StagingProgressTimeout != 45 {
+		t.Errorf("staging_progress_timeout: expected 45, got %d", cfg.StagingProgressTimeout)
+	}
+	if cfg.AutoRelease == nil || *cfg.AutoRelease {
+		t.Errorf("auto_release: expected false, got %v", cfg.AutoRelease)
+	}
+}
+
+func TestValidateStagingConfig(t *testing.T) {
+	p := &MavenPlugin{}
+
+	resp, err := p.Validate(context.Background(), map[string]any{
+		"group_id":                 "com.example",
+		"artifact_id":              "my-artifact",
+		"staging_profile_id":       "not valid!",
+		"staging_progress_timeout": -5,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Valid {
+		t.Fatal("expected invalid config")
+	}
+
+	fields := map[string]bool{}
+	for _, e := range resp.Errors {
+		fields[e.Field] = true
+	}
+	for _, field := range []string{"staging_profile_id", "staging_progress_timeout"} {
+		if !fields[field] {
+			t.Errorf("expected error for field '%s', got %v", field, resp.Errors)
+		}
+	}
+}
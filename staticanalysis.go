@@ -0,0 +1,167 @@
+// Package main implements the static analysis gate of the release.
+package main
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+)
+
+// staticAnalyzer is a static analysis tool run by the gate.
+type staticAnalyzer struct {
+	// Goal writes the XML report without failing the build.
+	Goal string
+	// Report is the report file in the build directory of a module.
+	Report string
+	// Violation is the report element counted as a violation.
+	Violation string
+}
+
+// staticAnalyzers are the tools of the static analysis gate by name. They use
+// the configuration of the POM, or their defaults.
+var staticAnalyzers = map[string]staticAnalyzer{
+	"checkstyle": {Goal: "org.apache.maven.plugins:maven-checkstyle-plugin:3.4.0:checkstyle", Report: "checkstyle-result.xml", Violation: "error"},
+	"pmd":        {Goal: "org.apache.maven.plugins:maven-pmd-plugin:3.24.0:pmd", Report: "pmd.xml", Violation: "violation"},
+	"spotbugs":   {Goal: "com.github.spotbugs:spotbugs-maven-plugin:4.8.6.4:spotbugs", Report: "spotbugsXml.xml", Violation: "BugInstance"},
+}
+
+// staticAnalysisTools are the valid static_analysis tools.
+var staticAnalysisTools = []string{"checkstyle", "pmd", "spotbugs"}
+
+// StaticAnalysis is a tool of the static analysis gate.
+type StaticAnalysis struct {
+	// Tool is checkstyle, pmd or spotbugs.
+	Tool string
+	// MaxViolations is the number of violations tolerated across the reactor.
+	MaxViolations int
+}
+
+// staticAnalysisResult reports the violations a tool found.
+type staticAnalysisResult struct {
+	Tool          string `json:"tool"`
+	Violations    int    `json:"violations"`
+	MaxViolations int    `json:"max_violations"`
+}
+
+// parseStaticAnalysis parses the static_analysis list from the raw configuration.
+func parseStaticAnalysis(raw map[string]any) []StaticAnalysis {
+	items, ok := raw["static_analysis"].([]any)
+	if !ok {
+		return nil
+	}
+	tools := make([]StaticAnalysis, 0, len(items))
+	for _, item := range items {
+		entry, _ := item.(map[string]any)
+		parser := helpers.NewConfigParser(entry)
+		tools = append(tools, StaticAnalysis{
+			Tool:          parser.GetString("tool", "", ""),
+			MaxViolations: parser.GetInt("max_violations", 0),
+		})
+	}
+	return tools
+}
+
+// validateStaticAnalysis validates a single static_analysis entry.
+func validateStaticAnalysis(tool StaticAnalysis) (string, error) {
+	if _, ok := staticAnalyzers[tool.Tool]; !ok {
+		return "tool", fmt.Errorf("tool must be one of: %s", strings.Join(staticAnalysisTools, ", "))
+	}
+	if tool.MaxViolations < 0 {
+		return "max_violations", fmt.Errorf("max_violations must not be negative")
+	}
+	return "", nil
+}
+
+// staticAnalysisArgs returns the Maven arguments compiling the reactor and
+// writing the report of every configured tool.
+func staticAnalysisArgs(cfg *Config) []string {
+	args := []string{"-B", "-f", cfg.PomPath}
+	if path := cfg.userSettings(); path != "" {
+		args = append(args, "-s", path)
+	}
+	args = append(args, cfg.isolationArgs(true)...)
+	args = append(args, "compile")
+	for _, tool := range cfg.StaticAnalysis {
+		args = append(args, staticAnalyzers[tool.Tool].Goal)
+	}
+	return args
+}
+
+// countViolations counts the violation elements of a report.
+func countViolations(path, element string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	count := 0
+	decoder := xml.NewDecoder(io.LimitReader(f, 256<<20))
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return count, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+		}
+		if start, ok := token.(xml.StartElement); ok && start.Name.Local == element {
+			count++
+		}
+	}
+}
+
+// staticAnalysisReports returns the report files of a tool in every deployed module.
+func (cfg *Config) staticAnalysisReports(tool string) []string {
+	var reports []string
+	for _, c := range cfg.deployedModules() {
+		reports = append(reports, filepath.Join(c.artifactBuildDir(), staticAnalyzers[tool].Report))
+	}
+	return reports
+}
+
+// runStaticAnalysis runs the configured tools over the reactor and counts
+// their violations. Reports of an earlier build are removed first, and
+// modules without a report, like aggregators, count none. It returns an
+// error when a tool exceeds its threshold, along with the results.
+func (p *MavenPlugin) runStaticAnalysis(ctx context.Context, cfg *Config) ([]staticAnalysisResult, error) {
+	for _, tool := range cfg.StaticAnalysis {
+		for _, report := range cfg.staticAnalysisReports(tool.Tool) {
+			_ = os.Remove(report)
+		}
+	}
+	if output, err := p.runMavenCommand(ctx, cfg, staticAnalysisArgs(cfg)...); err != nil {
+		return nil, fmt.Errorf("analysis build failed: %s\nOutput: %s", describeExecError(cfg.mavenCommand(), err), cfg.errorOutput(output))
+	}
+
+	var results []staticAnalysisResult
+	var exceeded []string
+	for _, tool := range cfg.StaticAnalysis {
+		result := staticAnalysisResult{Tool: tool.Tool, MaxViolations: tool.MaxViolations}
+		for _, report := range cfg.staticAnalysisReports(tool.Tool) {
+			n, err := countViolations(report, staticAnalyzers[tool.Tool].Violation)
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				return results, err
+			}
+			result.Violations += n
+		}
+		results = append(results, result)
+		if result.Violations > result.MaxViolations {
+			exceeded = append(exceeded, fmt.Sprintf("%s found %s (max %d)", tool.Tool, plural(result.Violations, "violation"), tool.MaxViolations))
+		}
+	}
+	if len(exceeded) > 0 {
+		return results, errors.New(strings.Join(exceeded, "; "))
+	}
+	return results, nil
+}
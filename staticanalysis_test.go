@@ -0,0 +1,217 @@
+// Package main provides tests for the static analysis gate of the release.
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// Reports of the static analysis tools with two violations each.
+const (
+	checkstyleReport = `<?xml version="1.0" encoding="UTF-8"?>
+<checkstyle version="10.17.0">
+<file name="/work/core/src/main/java/App.java">
+<error line="1" severity="error" message="Missing a Javadoc comment." source="com.puppycrawl.tools.checkstyle.checks.javadoc.MissingJavadocTypeCheck"/>
+<error line="7" column="5" severity="warning" message="Line is longer than 100 characters." source="com.puppycrawl.tools.checkstyle.checks.sizes.LineLengthCheck"/>
+</file>
+<file name="/work/core/src/main/java/Util.java">
+</file>
+</checkstyle>`
+	pmdReport = `<?xml version="1.0" encoding="UTF-8"?>
+<pmd xmlns="http://pmd.sourceforge.net/report/2.0.0" version="7.2.0">
+<file name="/work/core/src/main/java/App.java">
+<violation beginline="3" rule="UnusedPrivateField" ruleset="Best Practices" priority="3">Avoid unused private fields.</violation>
+<violation beginline="9" rule="EmptyCatchBlock" ruleset="Error Prone" priority="3">Avoid empty catch blocks.</violation>
+</file>
+</pmd>`
+	spotbugsReport = `<?xml version="1.0" encoding="UTF-8"?>
+<BugCollection version="4.8.6" sequence="0">
+<BugInstance type="NP_NULL_ON_SOME_PATH" priority="1" rank="6" category="CORRECTNESS"><Class classname="com.example.App"/></BugInstance>
+<BugInstance type="EI_EXPOSE_REP" priority="2" rank="18" category="MALICIOUS_CODE"><Class classname="com.example.App"/></BugInstance>
+<Errors errors="0" missingClasses="0"></Errors>
+</BugCollection>`
+)
+
+func TestCountViolations(t *testing.T) {
+	dir := t.TempDir()
+	for _, tt := range []struct {
+		tool   string
+		report string
+	}{
+		{"checkstyle", checkstyleReport},
+		{"pmd", pmdReport},
+		{"spotbugs", spotbugsReport},
+	} {
+		path := filepath.Join(dir, staticAnalyzers[tt.tool].Report)
+		if err := os.WriteFile(path, []byte(tt.report), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		n, err := countViolations(path, staticAnalyzers[tt.tool].Violation)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.tool, err)
+		}
+		if n != 2 {
+			t.Errorf("%s: expected 2 violations, got %d", tt.tool, n)
+		}
+	}
+
+	path := filepath.Join(dir, "broken.xml")
+	if err := os.WriteFile(path, []byte("<pmd><file>"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := countViolations(path, "violation"); err == nil {
+		t.Error("expected error for a truncated report")
+	}
+}
+
+func TestExecuteStaticAnalysis(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+	if err := os.WriteFile("pom.xml", []byte(`<project><groupId>com.example</groupId><artifactId>parent</artifactId><version>1.0.0</version><packaging>pom</packaging><modules><module>core</module></modules></project>`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join("core", "target"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join("core", "pom.xml"), []byte(`<project><parent><groupId>com.example</groupId><artifactId>parent</artifactId><version>1.0.0</version></parent><artifactId>core</artifactId></project>`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	// A report of an earlier build must not be counted.
+	if err := os.WriteFile(filepath.Join("core", "target", "pmd.xml"), []byte(pmdReport), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		tools   []any
+		wantErr string
+		want    []staticAnalysisResult
+	}{
+		{
+			name:  "within thresholds",
+			tools: []any{map[string]any{"tool": "checkstyle", "max_violations": 5}, map[string]any{"tool": "pmd"}},
+			want:  []staticAnalysisResult{{Tool: "checkstyle", Violations: 2, MaxViolations: 5}, {Tool: "pmd", Violations: 0, MaxViolations: 0}},
+		},
+		{
+			name:    "above threshold",
+			tools:   []any{map[string]any{"tool": "checkstyle", "max_violations": 1}, map[string]any{"tool": "spotbugs"}},
+			wantErr: "static analysis: checkstyle found 2 violations (max 1); spotbugs found 2 violations (max 0)",
+			want:    []staticAnalysisResult{{Tool: "checkstyle", Violations: 2, MaxViolations: 1}, {Tool: "spotbugs", Violations: 2, MaxViolations: 0}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var analysisArgs string
+			mockExec := &MockCommandExecutor{
+				RunFunc: func(ctx context.Context, name string, args ...string) ([]byte, error) {
+					joined := strings.Join(args, " ")
+					if !strings.Contains(joined, " compile ") {
+						return []byte("[INFO] BUILD SUCCESS"), nil
+					}
+					analysisArgs = joined
+					if _, err := os.Stat(filepath.Join("core", "target", "pmd.xml")); err == nil {
+						t.Error("expected the stale pmd report to be removed")
+					}
+					// The tools write no report for the aggregator.
+					for tool, report := range map[string]string{"checkstyle": checkstyleReport, "spotbugs": spotbugsReport} {
+						if strings.Contains(joined, staticAnalyzers[tool].Goal) {
+							if err := os.WriteFile(filepath.Join("core", "target", staticAnalyzers[tool].Report), []byte(report), 0o600); err != nil {
+								t.Fatal(err)
+							}
+						}
+					}
+					return []byte("[INFO] BUILD SUCCESS"), nil
+				},
+			}
+			resp, err := (&MavenPlugin{executor: mockExec}).Execute(context.Background(), plugin.ExecuteRequest{
+				Hook: plugin.HookPostPublish,
+				Config: map[string]any{
+					"group_id":        "com.example",
+					"artifact_id":     "parent",
+					"repository":      "http://localhost:8081/repository/maven-releases",
+					"static_analysis": tt.tools,
+				},
+				Context: plugin.ReleaseContext{Version: "1.0.0"},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, tool := range tt.tools {
+				if goal := staticAnalyzers[tool.(map[string]any)["tool"].(string)].Goal; !strings.Contains(analysisArgs, goal) {
+					t.Errorf("expected %s in %q", goal, analysisArgs)
+				}
+			}
+			if tt.wantErr != "" {
+				if resp.Success || resp.Error != tt.wantErr {
+					t.Errorf("expected error %q, got %q", tt.wantErr, resp.Error)
+				}
+			} else if !resp.Success {
+				t.Fatalf("expected success, got error: %s", resp.Error)
+			}
+			if got := resp.Outputs["static_analysis"]; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected static_analysis %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestValidateStaticAnalysis(t *testing.T) {
+	tests := []struct {
+		name   string
+		config map[string]any
+		field  string
+		errMsg string
+	}{
+		{name: "valid", config: map[string]any{"static_analysis": []any{map[string]any{"tool": "checkstyle"}, map[string]any{"tool": "spotbugs", "max_violations": 3}}}},
+		{name: "not a list", config: map[string]any{"static_analysis": "checkstyle"}, field: "static_analysis", errMsg: "must be a list of objects"},
+		{name: "unknown tool", config: map[string]any{"static_analysis": []any{map[string]any{"tool": "sonar"}}}, field: "static_analysis[0].tool", errMsg: "tool must be one of: checkstyle, pmd, spotbugs"},
+		{name: "negative threshold", config: map[string]any{"static_analysis": []any{map[string]any{"tool": "pmd", "max_violations": -1}}}, field: "static_analysis[0].max_violations", errMsg: "must not be negative"},
+		{name: "duplicate tool", config: map[string]any{"static_analysis": []any{map[string]any{"tool": "pmd"}, map[string]any{"tool": "pmd"}}}, field: "static_analysis[1].tool", errMsg: `duplicate tool "pmd"`},
+		{name: "http deployer", config: map[string]any{"static_analysis": []any{map[string]any{"tool": "pmd"}}, "deployer": "http"}, field: "static_analysis", errMsg: "requires the maven deployer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]any{
+				"group_id":    "com.example",
+				"artifact_id": "my-app",
+				"repository":  "http://localhost:8081/repository/maven-releases",
+			}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+			resp, err := (&MavenPlugin{}).Validate(context.Background(), config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var messages []string
+			for _, e := range resp.Errors {
+				if strings.HasPrefix(e.Field, "static_analysis") {
+					messages = append(messages, e.Field+": "+e.Message)
+				}
+			}
+			if tt.errMsg == "" {
+				if len(messages) > 0 {
+					t.Errorf("unexpected errors: %v", messages)
+				}
+				return
+			}
+			found := false
+			for _, m := range messages {
+				if strings.HasPrefix(m, tt.field+": ") && strings.Contains(m, tt.errMsg) {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("expected %s error containing %q, got %v", tt.field, tt.errMsg, messages)
+			}
+		})
+	}
+}
@@ -0,0 +1,290 @@
+// Package main implements summarizing the Surefire and Failsafe test reports of the release build.
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// testReportSubdirs are the report directories of the Surefire and Failsafe
+// plugins in a module's build directory.
+var testReportSubdirs = []string{"surefire-reports", "failsafe-reports"}
+
+// maxRerunFailingTestsCount bounds surefire_rerun_failing_tests_count.
+const maxRerunFailingTestsCount = 10
+
+// slowestTestsLimit is the number of slowest tests listed in the summary.
+const slowestTestsLimit = 5
+
+// junitFailure is a <failure> or <error> of a test case.
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+}
+
+// junitTestCase is a <testcase> of a JUnit XML report.
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure"`
+	Error     *junitFailure `xml:"error"`
+	Skipped   *struct{}     `xml:"skipped"`
+	// FlakyFailures and FlakyErrors are the failed runs of a test that
+	// passed when Surefire reran it.
+	FlakyFailures []junitFailure `xml:"flakyFailure"`
+	FlakyErrors   []junitFailure `xml:"flakyError"`
+}
+
+// junitTestSuite is the <testsuite> of a JUnit XML report.
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+// testCaseResult is a test of the summary.
+type testCaseResult struct {
+	Class   string  `json:"class"`
+	Name    string  `json:"name"`
+	Seconds float64 `json:"seconds"`
+	Message string  `json:"message,omitempty"`
+}
+
+// testResults summarizes the test reports of the release build.
+type testResults struct {
+	Tests    int              `json:"tests"`
+	Failures int              `json:"failures"`
+	Errors   int              `json:"errors"`
+	Skipped  int              `json:"skipped"`
+	Seconds  float64          `json:"seconds"`
+	Slowest  []testCaseResult `json:"slowest,omitempty"`
+	Failed   []testCaseResult `json:"failed,omitempty"`
+	// Flaky lists the tests that passed only on rerun, with the message of
+	// their first failed run.
+	Flaky []testCaseResult `json:"flaky,omitempty"`
+}
+
+// parseReportTime parses a report time, which Surefire formats with
+// grouping separators in some locales.
+func parseReportTime(s string) float64 {
+	seconds, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(s), ",", ""), 64)
+	if err != nil {
+		return 0
+	}
+	return seconds
+}
+
+// add counts the test cases of a report.
+func (r *testResults) add(suite *junitTestSuite, all *[]testCaseResult) {
+	for _, tc := range suite.TestCases {
+		class := tc.ClassName
+		if class == "" {
+			class = suite.Name
+		}
+		result := testCaseResult{Class: class, Name: tc.Name, Seconds: parseReportTime(tc.Time)}
+		r.Tests++
+		r.Seconds += result.Seconds
+		switch {
+		case tc.Failure != nil:
+			r.Failures++
+			result.Message = failureMessage(tc.Failure)
+			r.Failed = append(r.Failed, result)
+		case tc.Error != nil:
+			r.Errors++
+			result.Message = failureMessage(tc.Error)
+			r.Failed = append(r.Failed, result)
+		case tc.Skipped != nil:
+			r.Skipped++
+		case len(tc.FlakyFailures) > 0:
+			flaky := result
+			flaky.Message = failureMessage(&tc.FlakyFailures[0])
+			r.Flaky = append(r.Flaky, flaky)
+		case len(tc.FlakyErrors) > 0:
+			flaky := result
+			flaky.Message = failureMessage(&tc.FlakyErrors[0])
+			r.Flaky = append(r.Flaky, flaky)
+		}
+		*all = append(*all, result)
+	}
+}
+
+// failureMessage returns the assertion message of a failure, or its type
+// when the exception has no message.
+func failureMessage(f *junitFailure) string {
+	if msg := strings.TrimSpace(f.Message); msg != "" {
+		return msg
+	}
+	return f.Type
+}
+
+// flakyTests returns the names of the tests that passed only on rerun.
+func (r *testResults) flakyTests() []string {
+	names := make([]string, 0, len(r.Flaky))
+	for _, tc := range r.Flaky {
+		names = append(names, tc.Class+"."+tc.Name)
+	}
+	return names
+}
+
+// rerunArgs returns the Maven arguments rerunning failed tests.
+func rerunArgs(cfg *Config) []string {
+	if cfg.RerunFailingTestsCount <= 0 {
+		return nil
+	}
+	return []string{fmt.Sprintf("-Dsurefire.rerunFailingTestsCount=%d", cfg.RerunFailingTestsCount)}
+}
+
+// validateRerunFailingTestsCount validates the number of reruns of a failed test.
+func validateRerunFailingTestsCount(count int) error {
+	if count < 0 || count > maxRerunFailingTestsCount {
+		return fmt.Errorf("surefire_rerun_failing_tests_count must be between 0 and %d", maxRerunFailingTestsCount)
+	}
+	return nil
+}
+
+// readTestReports summarizes the TEST-*.xml reports found in the given
+// directories. It returns nil when there are none.
+func readTestReports(dirs []string) (*testResults, error) {
+	results := &testResults{}
+	var all []testCaseResult
+	found := false
+	for _, dir := range dirs {
+		paths, err := filepath.Glob(filepath.Join(dir, "TEST-*.xml"))
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			suite, err := readTestSuite(path)
+			if err != nil {
+				return nil, err
+			}
+			found = true
+			results.add(suite, &all)
+		}
+	}
+	if !found {
+		return nil, nil
+	}
+
+	sort.SliceStable(all, func(i, j int) bool { return all[i].Seconds > all[j].Seconds })
+	for _, tc := range all {
+		if len(results.Slowest) == slowestTestsLimit {
+			break
+		}
+		tc.Message = ""
+		results.Slowest = append(results.Slowest, tc)
+	}
+	return results, nil
+}
+
+// readTestSuite parses a JUnit XML report.
+func readTestSuite(path string) (*junitTestSuite, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read test report: %w", err)
+	}
+	defer f.Close()
+
+	var suite junitTestSuite
+	if err := xml.NewDecoder(io.LimitReader(f, 64<<20)).Decode(&suite); err != nil {
+		return nil, fmt.Errorf("failed to parse test report %s: %w", filepath.Base(path), err)
+	}
+	return &suite, nil
+}
+
+// testReportDirs returns the test report directories of every deployed module.
+func (cfg *Config) testReportDirs() []string {
+	var dirs []string
+	for _, c := range cfg.deployedModules() {
+		for _, dir := range testReportSubdirs {
+			dirs = append(dirs, filepath.Join(c.artifactBuildDir(), dir))
+		}
+	}
+	return dirs
+}
+
+// failedTestsLimit is the number of failed tests listed in an error.
+const failedTestsLimit = 10
+
+// runAttemptPattern matches a rerun attempt listed under a failed test.
+var runAttemptPattern = regexp.MustCompile(`^Run \d+: `)
+
+// testFailurePattern matches the build log of a build failed by tests.
+var testFailurePattern = regexp.MustCompile(`There (?:are|were) test failures|Tests run: \d+, Failures: [1-9]|Tests run: \d+, Failures: \d+, Errors: [1-9]`)
+
+// failedTestsFromLog returns the entries of the "Failures:" and "Errors:"
+// sections Surefire and Failsafe log before failing the build, e.g.
+// "CalculatorTest.divides:12 expected: <2> but was: <3>".
+func failedTestsFromLog(output string) []string {
+	var failed []string
+	inSection := false
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimRight(ansiPattern.ReplaceAllString(line, ""), "\r ")
+		rest, isError := strings.CutPrefix(line, "[ERROR]")
+		switch {
+		case !isError:
+			inSection = false
+		case strings.TrimSpace(rest) == "Failures:" || strings.TrimSpace(rest) == "Errors:":
+			inSection = true
+		case inSection && strings.TrimSpace(rest) != "" && !strings.HasPrefix(strings.TrimSpace(rest), "Tests run:"):
+			entry := strings.TrimSpace(rest)
+			// Rerun attempts of a flaky test are listed under the test.
+			if !runAttemptPattern.MatchString(entry) {
+				failed = append(failed, entry)
+			}
+		default:
+			inSection = false
+		}
+	}
+	return failed
+}
+
+// testFailureSummary returns a short list of the failed tests when the build
+// failed because of them, read from the build log or else from the test
+// reports. It returns "" for other failures.
+func testFailureSummary(output string, reportDirs []string) string {
+	if !testFailurePattern.MatchString(output) {
+		return ""
+	}
+	failed := failedTestsFromLog(output)
+	if len(failed) == 0 {
+		if results, err := readTestReports(reportDirs); err == nil && results != nil {
+			for _, tc := range results.Failed {
+				entry := tc.Class + "." + tc.Name
+				if tc.Message != "" {
+					entry += " " + tc.Message
+				}
+				failed = append(failed, entry)
+			}
+		}
+	}
+	if len(failed) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	if len(failed) == 1 {
+		b.WriteString("1 test failed:")
+	} else {
+		fmt.Fprintf(&b, "%d tests failed:", len(failed))
+	}
+	for i, entry := range failed {
+		if i == failedTestsLimit {
+			fmt.Fprintf(&b, "\n  ... and %d more", len(failed)-failedTestsLimit)
+			break
+		}
+		if first, _, cut := strings.Cut(entry, "\n"); cut {
+			entry = first
+		}
+		b.WriteString("\n  - " + entry)
+	}
+	return b.String()
+}
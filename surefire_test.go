@@ -0,0 +1,340 @@
+// Package main provides tests for summarizing Surefire and Failsafe test reports.
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// calculatorReport is a Surefire report with a failure, an error and a skipped test.
+const calculatorReport = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuite name="com.example.CalculatorTest" time="3.5" tests="5" errors="1" skipped="1" failures="1">
+  <properties><property name="java.version" value="21"/></properties>
+  <testcase name="adds" classname="com.example.CalculatorTest" time="0.010"/>
+  <testcase name="divides" classname="com.example.CalculatorTest" time="1,200.5">
+    <failure message="expected: &lt;2&gt; but was: &lt;3&gt;" type="org.opentest4j.AssertionFailedError">stack trace</failure>
+  </testcase>
+  <testcase name="parses" classname="com.example.CalculatorTest" time="2.5">
+    <error type="java.lang.NullPointerException">stack trace</error>
+  </testcase>
+  <testcase name="rounds" classname="com.example.CalculatorTest" time="0">
+    <skipped message="disabled"/>
+  </testcase>
+  <testcase name="subtracts" time="0.5"/>
+</testsuite>
+`
+
+// writeTestReport writes a JUnit XML report into a report directory.
+func writeTestReport(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadTestReports(t *testing.T) {
+	dir := t.TempDir()
+	surefire := filepath.Join(dir, "surefire-reports")
+	failsafe := filepath.Join(dir, "failsafe-reports")
+	writeTestReport(t, surefire, "TEST-com.example.CalculatorTest.xml", calculatorReport)
+	writeTestReport(t, surefire, "com.example.CalculatorTest.txt", "not a report")
+	writeTestReport(t, failsafe, "TEST-com.example.ServerIT.xml",
+		`<testsuite name="com.example.ServerIT"><testcase name="starts" classname="com.example.ServerIT" time="4.0"/></testsuite>`)
+
+	results, err := readTestReports([]string{surefire, failsafe, filepath.Join(dir, "missing")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if results.Tests != 6 || results.Failures != 1 || results.Errors != 1 || results.Skipped != 1 {
+		t.Errorf("unexpected counts: %+v", results)
+	}
+	if results.Seconds != 1207.51 {
+		t.Errorf("unexpected time: %v", results.Seconds)
+	}
+	wantSlowest := []testCaseResult{
+		{Class: "com.example.CalculatorTest", Name: "divides", Seconds: 1200.5},
+		{Class: "com.example.ServerIT", Name: "starts", Seconds: 4},
+		{Class: "com.example.CalculatorTest", Name: "parses", Seconds: 2.5},
+		{Class: "com.example.CalculatorTest", Name: "subtracts", Seconds: 0.5},
+		{Class: "com.example.CalculatorTest", Name: "adds", Seconds: 0.01},
+	}
+	if !reflect.DeepEqual(results.Slowest, wantSlowest) {
+		t.Errorf("slowest = %+v, want %+v", results.Slowest, wantSlowest)
+	}
+	wantFailed := []testCaseResult{
+		{Class: "com.example.CalculatorTest", Name: "divides", Seconds: 1200.5, Message: "expected: <2> but was: <3>"},
+		{Class: "com.example.CalculatorTest", Name: "parses", Seconds: 2.5, Message: "java.lang.NullPointerException"},
+	}
+	if !reflect.DeepEqual(results.Failed, wantFailed) {
+		t.Errorf("failed = %+v, want %+v", results.Failed, wantFailed)
+	}
+}
+
+func TestReadTestReportsNone(t *testing.T) {
+	results, err := readTestReports([]string{t.TempDir()})
+	if err != nil || results != nil {
+		t.Errorf("expected no results, got %+v, %v", results, err)
+	}
+}
+
+func TestExecuteTestResultsOutput(t *testing.T) {
+	tests := []struct {
+		name      string
+		skipTests bool
+		want      bool
+	}{
+		{name: "tests run", want: true},
+		{name: "tests skipped", skipTests: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			chdir(t, dir)
+			writeTestReport(t, filepath.Join(dir, "target", "surefire-reports"), "TEST-com.example.CalculatorTest.xml", calculatorReport)
+
+			p := &MavenPlugin{executor: &MockCommandExecutor{}}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook: plugin.HookPostPublish,
+				Config: map[string]any{
+					"group_id":    "com.example",
+					"artifact_id": "my-app",
+					"repository":  "http://localhost:8081/repository/maven-releases",
+					"skip_tests":  tt.skipTests,
+				},
+				Context: plugin.ReleaseContext{Version: "1.0.0"},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !resp.Success {
+				t.Fatalf("expected success, got error: %s", resp.Error)
+			}
+			results, ok := resp.Outputs["test_results"].(*testResults)
+			if ok != tt.want {
+				t.Fatalf("unexpected test_results output: %v", resp.Outputs["test_results"])
+			}
+			if ok && results.Tests != 5 {
+				t.Errorf("unexpected test count %d", results.Tests)
+			}
+		})
+	}
+}
+
+// failedTestsLog is the end of the build log of a build failed by tests.
+const failedTestsLog = `[INFO] Results:
+[INFO]
+[ERROR] Failures:
+[ERROR]   CalculatorTest.divides:12 expected: <2> but was: <3>
+[ERROR] com.example.FlakyTest.retries
+[ERROR]   Run 1: FlakyTest.retries:8 timeout
+[ERROR]   Run 2: FlakyTest.retries:8 timeout
+[INFO]
+[ERROR] Errors:
+[ERROR]   CalculatorTest.parses:20 » NullPointer
+[INFO]
+[ERROR] Tests run: 6, Failures: 2, Errors: 1, Skipped: 1
+[INFO]
+[INFO] BUILD FAILURE
+[ERROR] Failed to execute goal org.apache.maven.plugins:maven-surefire-plugin:3.2.5:test (default-test) on project my-app: There are test failures.
+`
+
+func TestFailedTestsFromLog(t *testing.T) {
+	want := []string{
+		"CalculatorTest.divides:12 expected: <2> but was: <3>",
+		"com.example.FlakyTest.retries",
+		"CalculatorTest.parses:20 » NullPointer",
+	}
+	if got := failedTestsFromLog(failedTestsLog); !reflect.DeepEqual(got, want) {
+		t.Errorf("failedTestsFromLog() = %q, want %q", got, want)
+	}
+}
+
+func TestTestFailureSummary(t *testing.T) {
+	reports := t.TempDir()
+	writeTestReport(t, reports, "TEST-com.example.CalculatorTest.xml", calculatorReport)
+
+	tests := []struct {
+		name   string
+		output string
+		want   string
+	}{
+		{
+			name:   "from the log",
+			output: failedTestsLog,
+			want: "3 tests failed:\n" +
+				"  - CalculatorTest.divides:12 expected: <2> but was: <3>\n" +
+				"  - com.example.FlakyTest.retries\n" +
+				"  - CalculatorTest.parses:20 » NullPointer",
+		},
+		{
+			name:   "from the reports",
+			output: "[ERROR] Failed to execute goal org.apache.maven.plugins:maven-failsafe-plugin:3.2.5:verify (default) on project my-app: There are test failures.",
+			want: "2 tests failed:\n" +
+				"  - com.example.CalculatorTest.divides expected: <2> but was: <3>\n" +
+				"  - com.example.CalculatorTest.parses java.lang.NullPointerException",
+		},
+		{
+			name:   "other failure",
+			output: "[ERROR] Failed to execute goal org.apache.maven.plugins:maven-deploy-plugin:3.1.1:deploy: 401 Unauthorized",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := testFailureSummary(tt.output, []string{reports}); got != tt.want {
+				t.Errorf("testFailureSummary() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTestFailureSummaryLimit(t *testing.T) {
+	output := "[ERROR] Failures:\n"
+	for i := 0; i < failedTestsLimit+3; i++ {
+		output += "[ERROR]   FooTest.test:1 failed\n"
+	}
+	output += "[INFO]\n[ERROR] There are test failures."
+
+	summary := testFailureSummary(output, nil)
+	if !strings.HasPrefix(summary, "13 tests failed:") || !strings.HasSuffix(summary, "\n  ... and 3 more") {
+		t.Errorf("unexpected summary:\n%s", summary)
+	}
+}
+
+func TestExecuteTestFailureError(t *testing.T) {
+	chdir(t, t.TempDir())
+	mockExec := &MockCommandExecutor{
+		RunFunc: func(ctx context.Context, name string, args ...string) ([]byte, error) {
+			return []byte(failedTestsLog), errors.New("exit status 1")
+		},
+	}
+	p := &MavenPlugin{executor: mockExec}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":    "com.example",
+			"artifact_id": "my-app",
+			"repository":  "http://localhost:8081/repository/maven-releases",
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Success || !strings.HasPrefix(resp.Error, "Maven deploy failed: 3 tests failed:\n  - CalculatorTest.divides:12") {
+		t.Errorf("unexpected error:\n%s", resp.Error)
+	}
+}
+
+// flakyReport is a Surefire report of a run with rerunFailingTestsCount.
+const flakyReport = `<testsuite name="com.example.ClientTest">
+  <testcase name="connects" classname="com.example.ClientTest" time="1.5">
+    <flakyFailure message="connection refused" type="java.net.ConnectException">stack trace</flakyFailure>
+    <flakyFailure message="connection reset" type="java.net.SocketException">stack trace</flakyFailure>
+  </testcase>
+  <testcase name="reads" classname="com.example.ClientTest" time="0.5">
+    <flakyError type="java.lang.IllegalStateException">stack trace</flakyError>
+  </testcase>
+  <testcase name="writes" classname="com.example.ClientTest" time="0.1">
+    <failure message="expected: &lt;1&gt; but was: &lt;0&gt;" type="org.opentest4j.AssertionFailedError"/>
+    <rerunFailure message="expected: &lt;1&gt; but was: &lt;0&gt;" type="org.opentest4j.AssertionFailedError"/>
+  </testcase>
+</testsuite>`
+
+func TestReadTestReportsFlaky(t *testing.T) {
+	dir := t.TempDir()
+	writeTestReport(t, dir, "TEST-com.example.ClientTest.xml", flakyReport)
+
+	results, err := readTestReports([]string{dir})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantFlaky := []testCaseResult{
+		{Class: "com.example.ClientTest", Name: "connects", Seconds: 1.5, Message: "connection refused"},
+		{Class: "com.example.ClientTest", Name: "reads", Seconds: 0.5, Message: "java.lang.IllegalStateException"},
+	}
+	if !reflect.DeepEqual(results.Flaky, wantFlaky) {
+		t.Errorf("flaky = %+v, want %+v", results.Flaky, wantFlaky)
+	}
+	if results.Failures != 1 || len(results.Failed) != 1 {
+		t.Errorf("unexpected failures: %+v", results)
+	}
+	want := []string{"com.example.ClientTest.connects", "com.example.ClientTest.reads"}
+	if got := results.flakyTests(); !reflect.DeepEqual(got, want) {
+		t.Errorf("flakyTests() = %v, want %v", got, want)
+	}
+}
+
+func TestExecuteRerunFailingTests(t *testing.T) {
+	tests := []struct {
+		name      string
+		count     int
+		skipTests bool
+		wantArg   bool
+	}{
+		{name: "rerun", count: 2, wantArg: true},
+		{name: "disabled"},
+		{name: "tests skipped", count: 2, skipTests: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			chdir(t, dir)
+			writeTestReport(t, filepath.Join(dir, "target", "surefire-reports"), "TEST-com.example.ClientTest.xml", flakyReport)
+
+			mockExec := &MockCommandExecutor{}
+			p := &MavenPlugin{executor: mockExec}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook: plugin.HookPostPublish,
+				Config: map[string]any{
+					"group_id":                           "com.example",
+					"artifact_id":                        "my-app",
+					"repository":                         "http://localhost:8081/repository/maven-releases",
+					"skip_tests":                         tt.skipTests,
+					"surefire_rerun_failing_tests_count": tt.count,
+				},
+				Context: plugin.ReleaseContext{Version: "1.0.0"},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !resp.Success {
+				t.Fatalf("expected success, got error: %s", resp.Error)
+			}
+			if got := slices.Contains(mockExec.Calls[0].Args, "-Dsurefire.rerunFailingTestsCount=2"); got != tt.wantArg {
+				t.Errorf("unexpected args %v", mockExec.Calls[0].Args)
+			}
+			_, flaky := resp.Outputs["flaky_tests"]
+			if flaky == tt.skipTests {
+				t.Errorf("unexpected flaky_tests output: %v", resp.Outputs["flaky_tests"])
+			}
+		})
+	}
+}
+
+func TestValidateRerunFailingTestsCount(t *testing.T) {
+	for _, count := range []int{0, 3, maxRerunFailingTestsCount} {
+		if err := validateRerunFailingTestsCount(count); err != nil {
+			t.Errorf("validateRerunFailingTestsCount(%d) = %v", count, err)
+		}
+	}
+	for _, count := range []int{-1, maxRerunFailingTestsCount + 1} {
+		if err := validateRerunFailingTestsCount(count); err == nil {
+			t.Errorf("validateRerunFailingTestsCount(%d) succeeded", count)
+		}
+	}
+}
@@ -0,0 +1,486 @@
+// Package main implements deploying to several repositories in one release.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// RepositoryTarget is one repository of a multi-repository deploy.
+type RepositoryTarget struct {
+	// ID is the server ID used for the repository's credentials.
+	ID string
+	// URL is the repository URL; it receives both release and snapshot versions.
+	URL string
+	// Type identifies the repository manager; empty inherits repository_type.
+	Type     string
+	Username string
+	Password string
+	// UsernameEnv and PasswordEnv name the environment variables the
+	// credentials were read from, if any.
+	UsernameEnv string
+	PasswordEnv string
+}
+
+// parseRepositoryTargets parses the repositories list from the raw configuration.
+// Credentials can be given directly or read from the environment variables named
+// by username_env and password_env.
+func parseRepositoryTargets(raw map[string]any) []RepositoryTarget {
+	items, ok := raw["repositories"].([]any)
+	if !ok {
+		return nil
+	}
+
+	targets := make([]RepositoryTarget, 0, len(items))
+	for _, item := range items {
+		entry, _ := item.(map[string]any)
+		parser := helpers.NewConfigParser(entry)
+		target := RepositoryTarget{
+			ID:       parser.GetString("id", "", ""),
+			URL:      parser.GetString("url", "", ""),
+			Type:     parser.GetString("type", "", ""),
+			Username: parser.GetString("username", "", ""),
+			Password: parser.GetString("password", "", ""),
+		}
+		if target.Username == "" {
+			target.UsernameEnv = parser.GetString("username_env", "", "")
+			if target.UsernameEnv != "" {
+				target.Username = os.Getenv(target.UsernameEnv)
+			}
+		}
+		if target.Password == "" {
+			target.PasswordEnv = parser.GetString("password_env", "", "")
+			if target.PasswordEnv != "" {
+				target.Password = os.Getenv(target.PasswordEnv)
+			}
+		}
+		targets = append(targets, target)
+	}
+	return targets
+}
+
+// targetConfig returns the configuration for deploying to a single target.
+func (cfg *Config) targetConfig(target RepositoryTarget) *Config {
+	c := *cfg
+	c.Repositories = nil
+	c.RepositoryID = target.ID
+	c.Repository = target.URL
+	c.Username = target.Username
+	c.Password = target.Password
+	c.SnapshotRepositoryID = target.ID
+	c.SnapshotRepository = target.URL
+	c.SnapshotUsername = target.Username
+	c.SnapshotPassword = target.Password
+	if target.Type != "" {
+		c.RepositoryType = target.Type
+	}
+
+	c.EnvOverrides = map[string]string{}
+	if target.UsernameEnv != "" && target.Username != "" {
+		c.EnvOverrides["username"] = target.UsernameEnv
+	}
+	if target.PasswordEnv != "" && target.Password != "" {
+		c.EnvOverrides["password"] = target.PasswordEnv
+	}
+	return &c
+}
+
+// aggregateTargets combines per-repository responses into a single response.
+// message is a format string receiving the number of repositories.
+func aggregateTargets(targets []RepositoryTarget, results []*plugin.ExecuteResponse, version, message string) *plugin.ExecuteResponse {
+	entries := make([]map[string]any, 0, len(results))
+	var failures []string
+	for i, resp := range results {
+		target := targets[i]
+		entry := map[string]any{}
+		for k, v := range resp.Outputs {
+			entry[k] = v
+		}
+		entry["id"] = target.ID
+		entry["url"] = redactURL(target.URL)
+		entry["success"] = resp.Success
+		if !resp.Success {
+			entry["error"] = resp.Error
+			failures = append(failures, fmt.Sprintf("%s: %s", target.ID, resp.Error))
+		}
+		entries = append(entries, entry)
+	}
+
+	outputs := map[string]any{
+		"version":      version,
+		"repositories": entries,
+	}
+
+	if len(failures) > 0 {
+		return &plugin.ExecuteResponse{
+			Success: false,
+			Error:   fmt.Sprintf("%d of %d Maven repositories failed:\n%s", len(failures), len(results), strings.Join(failures, "\n")),
+			Outputs: outputs,
+		}
+	}
+
+	return &plugin.ExecuteResponse{
+		Success: true,
+		Message: fmt.Sprintf(message, len(results)),
+		Outputs: outputs,
+	}
+}
+
+// deployTargets deploys the release to every configured repository. A failure
+// in one repository does not prevent deploys to the others. The release is
+// built once into a stage per signing key, and every repository is deployed
+// from the staged files. With parallel the repositories left once every stage
+// is built are deployed concurrently, sharing max_parallel workers with the
+// artifact deploys of each repository.
+func (p *MavenPlugin) deployTargets(ctx context.Context, cfg *Config, releaseCtx plugin.ReleaseContext, dryRun bool) (*plugin.ExecuteResponse, error) {
+	stages := make([]*deployStage, len(cfg.Repositories))
+	if !dryRun && cfg.Deployer != deployerHTTP && cfg.ExportArchive == "" {
+		dir, err := os.MkdirTemp("", "maven-stage-")
+		if err != nil {
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   fmt.Sprintf("staging directory: %v", err),
+			}, nil
+		}
+		defer os.RemoveAll(dir)
+
+		artifacts := 0
+		if cfg.multiArtifact() {
+			artifacts = len(cfg.deployConfigs())
+		}
+		// Repositories signed with different keys cannot share the signatures.
+		byKey := map[string]*deployStage{}
+		for i, target := range cfg.Repositories {
+			key := cfg.targetConfig(target).stageKey(releaseCtx.Version)
+			if byKey[key] == nil {
+				byKey[key] = newDeployStage(filepath.Join(dir, strconv.Itoa(len(byKey))), artifacts)
+			}
+			stages[i] = byKey[key]
+		}
+	}
+
+	results := make([]*plugin.ExecuteResponse, len(cfg.Repositories))
+	deployTarget := func(i int) {
+		c := cfg.targetConfig(cfg.Repositories[i])
+		c.stage = stages[i]
+		if cfg.Parallel {
+			c.MaxParallel = splitWorkers(cfg.MaxParallel, len(cfg.Repositories))
+		}
+
+		var resp *plugin.ExecuteResponse
+		var err error
+		if c.multiArtifact() {
+			resp, err = p.deployArtifacts(ctx, c, releaseCtx, dryRun)
+		} else {
+			resp, err = p.deploy(ctx, c, releaseCtx, dryRun)
+		}
+		if err != nil {
+			resp = &plugin.ExecuteResponse{Success: false, Error: err.Error()}
+		}
+		results[i] = resp
+	}
+
+	// Deploy one repository at a time until every build is staged, so builds
+	// of the project never run concurrently.
+	next := 0
+	for next < len(cfg.Repositories) && slices.ContainsFunc(stages, func(s *deployStage) bool { return s != nil && !s.isBuilt() }) {
+		deployTarget(next)
+		next++
+	}
+	if cfg.Parallel {
+		runPool(len(cfg.Repositories)-next, cfg.MaxParallel, func(i int) { deployTarget(next + i) })
+	} else {
+		for i := next; i < len(cfg.Repositories); i++ {
+			deployTarget(i)
+		}
+	}
+
+	message := "Deployed to %d Maven repositories"
+	if dryRun {
+		message = "Would deploy to %d Maven repositories"
+	}
+	return aggregateTargets(cfg.Repositories, results, releaseCtx.Version, message), nil
+}
+
+// deployStage is the local repository the build of a multi-repository deploy
+// is deployed into once, so every repository receives identical files.
+type deployStage struct {
+	dir   string
+	built bool
+	// artifacts holds the stage of every artifact of a multi-artifact deploy.
+	artifacts []*deployStage
+}
+
+// newDeployStage returns the stage in dir, with a stage in a subdirectory for
+// each of the given number of artifacts.
+func newDeployStage(dir string, artifacts int) *deployStage {
+	stage := &deployStage{dir: dir}
+	for i := 0; i < artifacts; i++ {
+		stage.artifacts = append(stage.artifacts, &deployStage{dir: filepath.Join(dir, strconv.Itoa(i))})
+	}
+	return stage
+}
+
+// stageKey identifies the signing keys of every artifact deployed to the
+// repository. Repositories with the same keys receive the same files.
+func (cfg *Config) stageKey(version string) string {
+	var keys []string
+	for _, c := range cfg.deployConfigs() {
+		resolved, err := c.resolveSigningKey(version)
+		if err != nil {
+			return ""
+		}
+		keys = append(keys, resolved.GPGKey)
+	}
+	return strings.Join(keys, ",")
+}
+
+// isBuilt reports whether the build, or the build of every artifact, was
+// staged. It is safe to call on a nil stage.
+func (s *deployStage) isBuilt() bool {
+	if s == nil {
+		return false
+	}
+	if len(s.artifacts) > 0 {
+		for _, artifact := range s.artifacts {
+			if !artifact.built {
+				return false
+			}
+		}
+		return true
+	}
+	return s.built
+}
+
+// reset empties the stage before a build, dropping the files of a failed one.
+func (s *deployStage) reset() error {
+	if err := os.RemoveAll(s.dir); err != nil {
+		return err
+	}
+	return os.MkdirAll(s.dir, 0o700)
+}
+
+// deployStaged deploys the staged files to the repository with
+// deploy:deploy-file, one Maven run per GAV. Failed runs are retried like the
+// build. It returns a warning for each retry.
+func (p *MavenPlugin) deployStaged(ctx context.Context, cfg *Config, progress *reactorProgress, version, settingsPath string) ([]string, error) {
+	invocations, err := cfg.stagedDeployInvocations(mavenVersion(version), settingsPath)
+	if err != nil {
+		return nil, err
+	}
+	var warnings []string
+	for _, args := range invocations {
+		output, retryWarnings, err := p.runMavenWithRetries(ctx, cfg, progress, args)
+		warnings = append(warnings, retryWarnings...)
+		if err != nil {
+			reason := describeExecError(cfg.mavenCommand(), err)
+			if hint := outOfMemoryHint(cfg, string(output), err, ctx.Err() != nil); hint != "" {
+				reason = hint + "\n" + reason
+			}
+			return warnings, fmt.Errorf("%s\nOutput: %s", reason, cfg.errorOutput(output))
+		}
+	}
+	return warnings, nil
+}
+
+// stagedDeployInvocations returns the deploy:deploy-file arguments for every
+// GAV in the stage. Checksums and maven-metadata.xml are not taken from the
+// stage; Maven computes them for, and merges them with, the repository.
+func (cfg *Config) stagedDeployInvocations(version, settingsPath string) ([][]string, error) {
+	files, err := exportFiles(cfg.stage.dir)
+	if err != nil {
+		return nil, err
+	}
+	gavs := map[string][]string{}
+	var dirs []string
+	for _, f := range files {
+		name := path.Base(f)
+		if isChecksumFile(name) || strings.HasPrefix(name, "maven-metadata") {
+			continue
+		}
+		dir := path.Dir(f)
+		if _, ok := gavs[dir]; !ok {
+			dirs = append(dirs, dir)
+		}
+		gavs[dir] = append(gavs[dir], name)
+	}
+	if len(dirs) == 0 {
+		return nil, fmt.Errorf("the build staged no files to deploy")
+	}
+
+	invocations := make([][]string, 0, len(dirs))
+	for _, dir := range dirs {
+		args, err := cfg.stagedDeployArgs(version, filepath.Join(cfg.stage.dir, filepath.FromSlash(dir)), gavs[dir], settingsPath)
+		if err != nil {
+			return nil, err
+		}
+		invocations = append(invocations, args)
+	}
+	return invocations, nil
+}
+
+// stagedDeployArgs builds the deploy:deploy-file arguments deploying the
+// staged files of one GAV in dir: the POM, the main artifact, and the
+// classified artifacts and signatures as side artifacts.
+func (cfg *Config) stagedDeployArgs(version, dir string, names []string, settingsPath string) ([]string, error) {
+	pom := ""
+	for _, name := range names {
+		if strings.HasSuffix(name, ".pom") {
+			pom = name
+		}
+	}
+	if pom == "" {
+		return nil, fmt.Errorf("no POM was staged in %s", dir)
+	}
+	base := strings.TrimSuffix(pom, ".pom")
+
+	main := pom
+	var files, classifiers, types []string
+	for _, name := range names {
+		rest, ok := strings.CutPrefix(name, base)
+		if !ok {
+			return nil, fmt.Errorf("unexpected staged file %s next to %s", name, pom)
+		}
+		if name == pom {
+			continue
+		}
+		classifier, ext := "", strings.TrimPrefix(rest, ".")
+		if strings.HasPrefix(rest, "-") {
+			classifier, ext, _ = strings.Cut(rest[1:], ".")
+		}
+		if classifier == "" && main == pom && !strings.HasSuffix(ext, ".asc") && ext != "asc" {
+			main = name
+			continue
+		}
+		files = append(files, filepath.Join(dir, name))
+		classifiers = append(classifiers, classifier)
+		types = append(types, ext)
+	}
+
+	args := []string{
+		"deploy:deploy-file",
+		"-N",
+		"-DpomFile=" + filepath.Join(dir, pom),
+		"-Dfile=" + filepath.Join(dir, main),
+		"-Durl=" + cfg.targetRepository(version),
+		"-DrepositoryId=" + cfg.targetRepositoryID(version),
+	}
+	if len(files) > 0 {
+		args = append(args,
+			"-Dfiles="+strings.Join(files, ","),
+			"-Dclassifiers="+strings.Join(classifiers, ","),
+			"-Dtypes="+strings.Join(types, ","),
+		)
+	}
+	if cfg.DeployRetries > 0 {
+		args = append(args, fmt.Sprintf("-DretryFailedDeploymentCount=%d", cfg.DeployRetries))
+	}
+	if settingsPath != "" {
+		args = append(args, "-s", settingsPath)
+	}
+	return append(args, cfg.isolationArgs(true)...), nil
+}
+
+// rollbackTargets rolls back the release in every repository that supports it.
+// Repositories where rollback is not possible, such as Maven Central, are skipped.
+func (p *MavenPlugin) rollbackTargets(ctx context.Context, cfg *Config, releaseCtx plugin.ReleaseContext, dryRun bool) (*plugin.ExecuteResponse, error) {
+	results := make([]*plugin.ExecuteResponse, len(cfg.Repositories))
+	for i, target := range cfg.Repositories {
+		c := cfg.targetConfig(target)
+
+		if err := validateRollback(c.RepositoryType, c.Repository); err != nil {
+			results[i] = &plugin.ExecuteResponse{
+				Success: true,
+				Outputs: map[string]any{"rolled_back": false, "skipped": err.Error()},
+			}
+			continue
+		}
+
+		var resp *plugin.ExecuteResponse
+		var err error
+		if c.multiArtifact() {
+			resp, err = p.rollbackArtifacts(ctx, c, releaseCtx, dryRun)
+		} else {
+			resp, err = p.rollback(ctx, c, releaseCtx, dryRun)
+		}
+		if err != nil {
+			resp = &plugin.ExecuteResponse{Success: false, Error: err.Error()}
+		}
+		results[i] = resp
+	}
+
+	message := "Rolled back %d Maven repositories"
+	if dryRun {
+		message = "Would roll back %d Maven repositories"
+	}
+	return aggregateTargets(cfg.Repositories, results, releaseCtx.Version, message), nil
+}
+
+// validateRepositoryTargets validates the repositories list.
+func validateRepositoryTargets(vb *helpers.ValidationBuilder, config map[string]any, policy networkPolicy) {
+	items, ok := config["repositories"].([]any)
+	if !ok {
+		vb.AddError("repositories", "repositories must be a list of repository objects")
+		return
+	}
+	if len(items) == 0 {
+		vb.AddError("repositories", "repositories must list at least one repository")
+		return
+	}
+
+	parser := helpers.NewConfigParser(config)
+	if parser.GetString("repository", "", "") != "" || parser.GetString("snapshot_repository", "", "") != "" {
+		vb.AddError("repositories", "repositories cannot be combined with repository or snapshot_repository")
+	}
+
+	seen := map[string]int{}
+	for i, item := range items {
+		field := func(name string) string { return fmt.Sprintf("repositories[%d].%s", i, name) }
+
+		entry, ok := item.(map[string]any)
+		if !ok {
+			vb.AddError(fmt.Sprintf("repositories[%d]", i), "repository entry must be an object")
+			continue
+		}
+		entryParser := helpers.NewConfigParser(entry)
+
+		id := entryParser.GetString("id", "", "")
+		if id == "" {
+			vb.AddError(field("id"), "repository id is required")
+		} else if err := validateRepositoryID(id, "id"); err != nil {
+			vb.AddError(field("id"), err.Error())
+		} else if j, dup := seen[id]; dup {
+			vb.AddError(field("id"), fmt.Sprintf("duplicate repository id %q (also repositories[%d])", id, j))
+		} else {
+			seen[id] = i
+		}
+
+		url := entryParser.GetString("url", "", "")
+		if url == "" {
+			vb.AddError(field("url"), "repository url is required")
+		} else if err := validateRepositoryURL(url, policy); err != nil {
+			vb.AddError(field("url"), err.Error())
+		}
+
+		if repoType := entryParser.GetString("type", "", ""); repoType != "" && !slices.Contains(repositoryTypes, repoType) {
+			vb.AddError(field("type"), fmt.Sprintf("%s must be one of: %s", field("type"), strings.Join(repositoryTypes, ", ")))
+		}
+	}
+
+	if artifacts, ok := config["artifacts"].([]any); ok {
+		for i, item := range artifacts {
+			if entry, ok := item.(map[string]any); ok && entry["repository"] != nil {
+				vb.AddError(fmt.Sprintf("artifacts[%d].repository", i), "per-artifact repository cannot be combined with repositories")
+			}
+		}
+	}
+}
@@ -0,0 +1,458 @@
+// Package main provides tests for multi-repository deploys.
+package main
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func multiRepositoryConfig() map[string]any {
+	return map[string]any{
+		"group_id":    "com.example",
+		"artifact_id": "my-app",
+		"repositories": []any{
+			map[string]any{
+				"id":       "internal",
+				"url":      "http://localhost:8081/repository/maven-releases",
+				"type":     "nexus",
+				"username": "nexus-user",
+				"password": "nexus-secret",
+			},
+			map[string]any{
+				"id":           "central",
+				"url":          "http://localhost:8083/maven2",
+				"username_env": "TEST_CENTRAL_USERNAME",
+				"password_env": "TEST_CENTRAL_PASSWORD",
+			},
+		},
+	}
+}
+
+// stageRelease writes a signed release of my-app into the file repository a
+// deploy names, as Maven would.
+func stageRelease(args []string) error {
+	for _, arg := range args {
+		value, ok := strings.CutPrefix(arg, "-DaltReleaseDeploymentRepository=")
+		if !ok {
+			continue
+		}
+		_, rawURL, _ := strings.Cut(value, "::")
+		u, err := url.Parse(rawURL)
+		if err != nil || u.Scheme != "file" {
+			return err
+		}
+		dir := filepath.Join(u.Path, "com", "example", "my-app", "1.0.0")
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+		for _, name := range []string{"my-app-1.0.0.pom", "my-app-1.0.0.pom.asc", "my-app-1.0.0.jar", "my-app-1.0.0.jar.asc", "my-app-1.0.0.jar.md5", "my-app-1.0.0-sources.jar"} {
+			if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0o644); err != nil {
+				return err
+			}
+		}
+		return os.WriteFile(filepath.Join(filepath.Dir(dir), "maven-metadata.xml"), []byte("<metadata/>"), 0o644)
+	}
+	return nil
+}
+
+// deployFileArg returns the value of a -D property of a deploy:deploy-file
+// run, or "" for other runs.
+func deployFileArg(args []string, name string) string {
+	if len(args) == 0 || args[0] != "deploy:deploy-file" {
+		return ""
+	}
+	for _, arg := range args {
+		if value, ok := strings.CutPrefix(arg, "-D"+name+"="); ok {
+			return value
+		}
+	}
+	return ""
+}
+
+func TestParseRepositoryTargets(t *testing.T) {
+	t.Setenv("TEST_CENTRAL_USERNAME", "central-user")
+	t.Setenv("TEST_CENTRAL_PASSWORD", "central-secret")
+
+	targets := parseRepositoryTargets(multiRepositoryConfig())
+	if len(targets) != 2 {
+		t.Fatalf("expected 2 targets, got %d", len(targets))
+	}
+
+	want := []RepositoryTarget{
+		{ID: "internal", URL: "http://localhost:8081/repository/maven-releases", Type: "nexus", Username: "nexus-user", Password: "nexus-secret"},
+		{ID: "central", URL: "http://localhost:8083/maven2", Username: "central-user", Password: "central-secret", UsernameEnv: "TEST_CENTRAL_USERNAME", PasswordEnv: "TEST_CENTRAL_PASSWORD"},
+	}
+	for i := range want {
+		if targets[i] != want[i] {
+			t.Errorf("target %d: expected %+v, got %+v", i, want[i], targets[i])
+		}
+	}
+}
+
+func TestTargetConfig(t *testing.T) {
+	p := &MavenPlugin{}
+	cfg := p.parseConfig(map[string]any{
+		"group_id":        "com.example",
+		"artifact_id":     "my-app",
+		"username":        "top-user",
+		"repository_type": "artifactory",
+	})
+
+	c := cfg.targetConfig(RepositoryTarget{ID: "internal", URL: "http://localhost:8081/repository/maven-releases", Username: "nexus-user"})
+	if c.RepositoryID != "internal" || c.SnapshotRepositoryID != "internal" {
+		t.Errorf("expected the target ID for both repositories, got %s and %s", c.RepositoryID, c.SnapshotRepositoryID)
+	}
+	if c.Repository != c.SnapshotRepository || c.Repository != "http://localhost:8081/repository/maven-releases" {
+		t.Errorf("expected the target URL for both repositories, got %s and %s", c.Repository, c.SnapshotRepository)
+	}
+	if c.Username != "nexus-user" || c.SnapshotUsername != "nexus-user" {
+		t.Errorf("expected the target credentials, got %s", c.Username)
+	}
+	if c.RepositoryType != "artifactory" {
+		t.Errorf("expected the repository type to be inherited, got %s", c.RepositoryType)
+	}
+	if servers := c.settingsServers(); len(servers) != 1 || servers[0].ID != "internal" {
+		t.Errorf("expected a single settings server for the target, got %+v", servers)
+	}
+}
+
+func TestExecuteMultiRepository(t *testing.T) {
+	t.Setenv("TEST_CENTRAL_USERNAME", "central-user")
+	t.Setenv("TEST_CENTRAL_PASSWORD", "central-secret")
+
+	var settings []string
+	mockExec := &MockCommandExecutor{
+		RunFunc: func(_ context.Context, _ string, args ...string) ([]byte, error) {
+			if deployFileArg(args, "repositoryId") == "" {
+				return []byte("[INFO] BUILD SUCCESS"), stageRelease(args)
+			}
+			for i, arg := range args {
+				if arg == "-s" && i+1 < len(args) {
+					data, err := os.ReadFile(args[i+1])
+					if err != nil {
+						return nil, err
+					}
+					settings = append(settings, string(data))
+				}
+			}
+			if deployFileArg(args, "repositoryId") == "central" {
+				return []byte("401 Unauthorized"), errors.New("exit status 1")
+			}
+			return []byte("[INFO] BUILD SUCCESS"), nil
+		},
+	}
+	p := &MavenPlugin{executor: mockExec}
+
+	config := multiRepositoryConfig()
+	config["repositories"] = append(config["repositories"].([]any), map[string]any{
+		"id":  "mirror",
+		"url": "http://localhost:8082/repository/maven-releases",
+	})
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:    plugin.HookPostPublish,
+		Config:  config,
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Success {
+		t.Fatal("expected failure when one repository fails")
+	}
+	if !strings.Contains(resp.Error, "1 of 3 Maven repositories failed") || !strings.Contains(resp.Error, "central:") {
+		t.Errorf("unexpected error: %s", resp.Error)
+	}
+	if len(mockExec.Calls) != 4 {
+		t.Fatalf("expected one build and a deploy to every repository, got %d calls", len(mockExec.Calls))
+	}
+
+	build := strings.Join(mockExec.Calls[0].Args, " ")
+	if !strings.Contains(build, "-DaltReleaseDeploymentRepository=internal::file:") {
+		t.Errorf("expected the build to be staged, got %s", build)
+	}
+	var urls []string
+	for _, call := range mockExec.Calls[1:] {
+		urls = append(urls, deployFileArg(call.Args, "url"))
+	}
+	if strings.Join(urls, " ") != "http://localhost:8081/repository/maven-releases http://localhost:8083/maven2 http://localhost:8082/repository/maven-releases" {
+		t.Errorf("expected the staged files deployed to every repository, got %v", urls)
+	}
+	if !strings.Contains(settings[0], "<username>nexus-user</username>") || !strings.Contains(settings[1], "<username>central-user</username>") {
+		t.Errorf("expected per-repository credentials, got %v", settings)
+	}
+
+	repositories := resp.Outputs["repositories"].([]map[string]any)
+	var results []bool
+	for _, repo := range repositories {
+		results = append(results, repo["success"].(bool))
+	}
+	if len(results) != 3 || !results[0] || results[1] || !results[2] {
+		t.Errorf("expected independent results per repository, got %v", repositories)
+	}
+	for _, repo := range repositories {
+		if want := filepath.Join("target", "relicta-maven-"+repo["id"].(string)+".log"); repo["maven_log"] != want {
+			t.Errorf("expected the Maven log %s for %v, got %v", want, repo["id"], repo["maven_log"])
+		}
+	}
+}
+
+func TestExecuteMultiRepositoryParallel(t *testing.T) {
+	// The first repository builds the stage alone; the deploys to the other
+	// two wait until both have started.
+	var started sync.WaitGroup
+	started.Add(2)
+	mockExec := &MockCommandExecutor{
+		RunFunc: func(_ context.Context, _ string, args ...string) ([]byte, error) {
+			switch deployFileArg(args, "repositoryId") {
+			case "":
+				return []byte("[INFO] BUILD SUCCESS"), stageRelease(args)
+			case "internal":
+				return []byte("[INFO] BUILD SUCCESS"), nil
+			}
+			started.Done()
+			started.Wait()
+			if deployFileArg(args, "repositoryId") == "central" {
+				return []byte("401 Unauthorized"), errors.New("exit status 1")
+			}
+			return []byte("[INFO] BUILD SUCCESS"), nil
+		},
+	}
+	p := &MavenPlugin{executor: mockExec}
+
+	config := multiRepositoryConfig()
+	config["repositories"] = append(config["repositories"].([]any), map[string]any{
+		"id":  "mirror",
+		"url": "http://localhost:8082/repository/maven-releases",
+	})
+	config["parallel"] = true
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:    plugin.HookPostPublish,
+		Config:  config,
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Success || !strings.Contains(resp.Error, "1 of 3 Maven repositories failed") {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+
+	repositories := resp.Outputs["repositories"].([]map[string]any)
+	var ids []string
+	var results []bool
+	for _, repo := range repositories {
+		ids = append(ids, repo["id"].(string))
+		results = append(results, repo["success"].(bool))
+	}
+	if strings.Join(ids, " ") != "internal central mirror" || !results[0] || results[1] || !results[2] {
+		t.Errorf("expected results in repository order, got %v", repositories)
+	}
+}
+
+func TestStagedDeployArgs(t *testing.T) {
+	dir := t.TempDir()
+	if err := stageRelease([]string{"-DaltReleaseDeploymentRepository=internal::file://" + filepath.ToSlash(dir)}); err != nil {
+		t.Fatal(err)
+	}
+	cfg := (&Config{}).targetConfig(RepositoryTarget{ID: "internal", URL: "http://localhost:8081/repository/maven-releases"})
+	cfg.stage = newDeployStage(dir, 0)
+
+	invocations, err := cfg.stagedDeployInvocations("1.0.0", "settings.xml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(invocations) != 1 {
+		t.Fatalf("expected one deploy per GAV, got %v", invocations)
+	}
+	gav := filepath.Join(dir, "com", "example", "my-app", "1.0.0")
+	want := []string{
+		"deploy:deploy-file",
+		"-N",
+		"-DpomFile=" + filepath.Join(gav, "my-app-1.0.0.pom"),
+		"-Dfile=" + filepath.Join(gav, "my-app-1.0.0.jar"),
+		"-Durl=http://localhost:8081/repository/maven-releases",
+		"-DrepositoryId=internal",
+		"-Dfiles=" + filepath.Join(gav, "my-app-1.0.0-sources.jar") + "," + filepath.Join(gav, "my-app-1.0.0.jar.asc") + "," + filepath.Join(gav, "my-app-1.0.0.pom.asc"),
+		"-Dclassifiers=sources,,",
+		"-Dtypes=jar,jar.asc,pom.asc",
+		"-s", "settings.xml",
+	}
+	if strings.Join(invocations[0], " ") != strings.Join(want, " ") {
+		t.Errorf("expected %v, got %v", want, invocations[0])
+	}
+
+	cfg.stage = newDeployStage(t.TempDir(), 0)
+	if _, err := cfg.stagedDeployInvocations("1.0.0", ""); err == nil || !strings.Contains(err.Error(), "staged no files") {
+		t.Errorf("expected an error for an empty stage, got %v", err)
+	}
+}
+
+func TestExecuteMultiRepositoryDryRun(t *testing.T) {
+	mockExec := &MockCommandExecutor{}
+	p := &MavenPlugin{executor: mockExec}
+
+	config := multiRepositoryConfig()
+	config["artifacts"] = []any{
+		map[string]any{"artifact_id": "core"},
+		map[string]any{"artifact_id": "client"},
+	}
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:    plugin.HookPostPublish,
+		Config:  config,
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+		DryRun:  true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success || resp.Message != "Would deploy to 2 Maven repositories" {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	if len(mockExec.Calls) != 0 {
+		t.Errorf("expected no commands in dry run, got %d", len(mockExec.Calls))
+	}
+
+	repositories := resp.Outputs["repositories"].([]map[string]any)
+	for _, repo := range repositories {
+		artifacts, ok := repo["artifacts"].([]map[string]any)
+		if !ok || len(artifacts) != 2 {
+			t.Errorf("expected both artifacts for repository %v, got %v", repo["id"], repo["artifacts"])
+		}
+	}
+}
+
+func TestRollbackMultiRepository(t *testing.T) {
+	p := &MavenPlugin{}
+	config := multiRepositoryConfig()
+	config["rollback_on_error"] = true
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:    plugin.HookOnError,
+		Config:  config,
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+
+	repositories := resp.Outputs["repositories"].([]map[string]any)
+	if repositories[0]["rolled_back"] != false || repositories[0]["skipped"] != nil {
+		t.Errorf("expected the nexus repository to be checked for rollback, got %v", repositories[0])
+	}
+	if skipped, _ := repositories[1]["skipped"].(string); skipped == "" {
+		t.Errorf("expected the generic repository to be skipped, got %v", repositories[1])
+	}
+}
+
+func TestValidateRepositoryTargets(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    map[string]any
+		wantField string
+	}{
+		{
+			name: "valid repositories",
+			config: map[string]any{"repositories": []any{
+				map[string]any{"id": "internal", "url": "http://localhost:8081/repository/maven-releases"},
+				map[string]any{"id": "mirror", "url": "http://localhost:8082/repository/maven-releases", "type": "artifactory"},
+			}},
+		},
+		{
+			name:      "missing url",
+			config:    map[string]any{"repositories": []any{map[string]any{"id": "internal"}}},
+			wantField: "repositories[0].url",
+		},
+		{
+			name:      "missing id",
+			config:    map[string]any{"repositories": []any{map[string]any{"url": "http://localhost:8081/repository/maven-releases"}}},
+			wantField: "repositories[0].id",
+		},
+		{
+			name: "duplicate id",
+			config: map[string]any{"repositories": []any{
+				map[string]any{"id": "internal", "url": "http://localhost:8081/repository/a"},
+				map[string]any{"id": "internal", "url": "http://localhost:8081/repository/b"},
+			}},
+			wantField: "repositories[1].id",
+		},
+		{
+			name:      "insecure url",
+			config:    map[string]any{"repositories": []any{map[string]any{"id": "internal", "url": "http://example.com/maven"}}},
+			wantField: "repositories[0].url",
+		},
+		{
+			name:      "invalid type",
+			config:    map[string]any{"repositories": []any{map[string]any{"id": "internal", "url": "http://localhost:8081/repository/a", "type": "gitlab"}}},
+			wantField: "repositories[0].type",
+		},
+		{
+			name: "combined with repository",
+			config: map[string]any{
+				"repository":   "http://localhost:8081/repository/maven-releases",
+				"repositories": []any{map[string]any{"id": "internal", "url": "http://localhost:8081/repository/a"}},
+			},
+			wantField: "repositories",
+		},
+		{
+			name: "combined with per-artifact repository",
+			config: map[string]any{
+				"repositories": []any{map[string]any{"id": "internal", "url": "http://localhost:8081/repository/a"}},
+				"artifacts":    []any{map[string]any{"artifact_id": "core", "repository": "http://localhost:8081/repository/b"}},
+			},
+			wantField: "artifacts[0].repository",
+		},
+		{
+			name:      "empty list",
+			config:    map[string]any{"repositories": []any{}},
+			wantField: "repositories",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]any{
+				"group_id":    "com.example",
+				"artifact_id": "my-app",
+			}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+
+			p := &MavenPlugin{}
+			resp, err := p.Validate(context.Background(), config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantField == "" {
+				if !resp.Valid {
+					t.Errorf("expected valid config, got errors: %v", resp.Errors)
+				}
+				return
+			}
+			found := false
+			for _, e := range resp.Errors {
+				if e.Field == tt.wantField {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("expected error on field %q, got %v", tt.wantField, resp.Errors)
+			}
+		})
+	}
+}
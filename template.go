@@ -0,0 +1,130 @@
+// Package main implements release context templating in configuration values.
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// templatePattern matches {{ name }} template references.
+var templatePattern = regexp.MustCompile(`\{\{\s*([^{}\s]*)\s*\}\}`)
+
+// templatedOptions lists the options whose string values are expanded.
+var templatedOptions = []string{"properties", "extra_args"}
+
+// shortCommitLength is the length of the {{short_commit}} variable.
+const shortCommitLength = 7
+
+// templateVariables returns the template variables of a release context.
+func templateVariables(releaseCtx plugin.ReleaseContext) map[string]string {
+	short := releaseCtx.CommitSHA
+	if len(short) > shortCommitLength {
+		short = short[:shortCommitLength]
+	}
+	return map[string]string{
+		"version":          releaseCtx.Version,
+		"previous_version": releaseCtx.PreviousVersion,
+		"tag":              releaseCtx.TagName,
+		"commit":           releaseCtx.CommitSHA,
+		"short_commit":     short,
+		"branch":           releaseCtx.Branch,
+		"release_type":     releaseCtx.ReleaseType,
+	}
+}
+
+// validationVariables stands in for the release context during validation,
+// when no release is known yet.
+var validationVariables = templateVariables(plugin.ReleaseContext{
+	Version:         "0.0.0",
+	PreviousVersion: "0.0.0",
+	TagName:         "v0.0.0",
+	CommitSHA:       "0000000000000000000000000000000000000000",
+	Branch:          "main",
+	ReleaseType:     "patch",
+})
+
+// expandTemplate replaces the template references in s. Only the known
+// variables are substituted; anything else is an error.
+func expandTemplate(s string, vars map[string]string) (string, error) {
+	var unknown []string
+	expanded := templatePattern.ReplaceAllStringFunc(s, func(ref string) string {
+		name := templatePattern.FindStringSubmatch(ref)[1]
+		value, ok := vars[name]
+		if !ok {
+			unknown = append(unknown, name)
+			return ref
+		}
+		return value
+	})
+	if len(unknown) > 0 {
+		known := make([]string, 0, len(vars))
+		for name := range vars {
+			known = append(known, name)
+		}
+		sort.Strings(known)
+		return "", fmt.Errorf("unknown template variable %q (available: %s)", unknown[0], strings.Join(known, ", "))
+	}
+	return expanded, nil
+}
+
+// expandValue expands the templates in the strings of a configuration value.
+func expandValue(value any, vars map[string]string) (any, error) {
+	switch v := value.(type) {
+	case string:
+		return expandTemplate(v, vars)
+	case []any:
+		expanded := make([]any, len(v))
+		for i, item := range v {
+			var err error
+			if expanded[i], err = expandValue(item, vars); err != nil {
+				return nil, err
+			}
+		}
+		return expanded, nil
+	case []string:
+		expanded := make([]string, len(v))
+		for i, item := range v {
+			var err error
+			if expanded[i], err = expandTemplate(item, vars); err != nil {
+				return nil, err
+			}
+		}
+		return expanded, nil
+	case map[string]any:
+		expanded := make(map[string]any, len(v))
+		for k, item := range v {
+			var err error
+			if expanded[k], err = expandValue(item, vars); err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+		}
+		return expanded, nil
+	default:
+		return value, nil
+	}
+}
+
+// expandConfig returns a copy of the raw configuration with the templates of
+// the templated options expanded. The original configuration is not modified.
+func expandConfig(raw map[string]any, vars map[string]string) (map[string]any, error) {
+	expanded := make(map[string]any, len(raw))
+	for k, v := range raw {
+		expanded[k] = v
+	}
+	for _, option := range templatedOptions {
+		value, ok := raw[option]
+		if !ok {
+			continue
+		}
+		v, err := expandValue(value, vars)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", option, err)
+		}
+		expanded[option] = v
+	}
+	return expanded, nil
+}
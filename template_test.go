@@ -0,0 +1,159 @@
+// Package main provides tests for release context templating.
+package main
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestExpandTemplate(t *testing.T) {
+	vars := templateVariables(plugin.ReleaseContext{
+		Version:   "1.2.3",
+		TagName:   "v1.2.3",
+		CommitSHA: "0123456789abcdef0123456789abcdef01234567",
+	})
+
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "{{version}}", want: "1.2.3"},
+		{in: "release-{{ tag }}-{{short_commit}}", want: "release-v1.2.3-0123456"},
+		{in: "{{commit}}", want: "0123456789abcdef0123456789abcdef01234567"},
+		{in: "no templates", want: "no templates"},
+		{in: "{{env.HOME}}", wantErr: true},
+		{in: "{{ .Version }}", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := expandTemplate(tt.in, vars)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("expandTemplate(%q): expected error, got %q", tt.in, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("expandTemplate(%q): expected %q, got %q (%v)", tt.in, tt.want, got, err)
+		}
+	}
+}
+
+func TestExecuteTemplatedProperties(t *testing.T) {
+	mockExec := &MockCommandExecutor{}
+	p := &MavenPlugin{executor: mockExec}
+
+	config := map[string]any{
+		"group_id":    "com.example",
+		"artifact_id": "my-app",
+		"repository":  "http://localhost:8081/repository/maven-releases",
+		"properties": map[string]any{
+			"revision":     "{{version}}",
+			"build.commit": "{{commit}}",
+		},
+		"extra_args": []any{"-Dchangelist=-{{tag}}", "-B"},
+	}
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:    plugin.HookPostPublish,
+		Config:  config,
+		Context: plugin.ReleaseContext{Version: "1.2.3", TagName: "v1.2.3", CommitSHA: "abc123"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+
+	args := mockExec.Calls[0].Args
+	for _, want := range []string{"-Dbuild.commit=abc123", "-Drevision=1.2.3", "-Dchangelist=-v1.2.3", "-B"} {
+		if !slices.Contains(args, want) {
+			t.Errorf("expected %s in %v", want, args)
+		}
+	}
+	if config["properties"].(map[string]any)["revision"] != "{{version}}" {
+		t.Error("expected the request configuration to be left unexpanded")
+	}
+
+	config["properties"] = map[string]any{"revision": "{{unknown}}"}
+	resp, err = p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:    plugin.HookPostPublish,
+		Config:  config,
+		Context: plugin.ReleaseContext{Version: "1.2.3"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Success || !strings.Contains(resp.Error, `unknown template variable "unknown"`) {
+		t.Errorf("expected an unknown variable error, got %+v", resp)
+	}
+}
+
+func TestValidatePropertiesAndExtraArgs(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    map[string]any
+		wantField string
+	}{
+		{
+			name:   "valid",
+			config: map[string]any{"properties": map[string]any{"revision": "{{version}}"}, "extra_args": []any{"-B", "-Dx={{tag}}"}},
+		},
+		{
+			name:      "unknown variable",
+			config:    map[string]any{"properties": map[string]any{"revision": "{{nope}}"}},
+			wantField: "properties",
+		},
+		{
+			name:      "invalid property name",
+			config:    map[string]any{"properties": map[string]any{"bad name": "x"}},
+			wantField: "properties.bad name",
+		},
+		{
+			name:      "managed argument",
+			config:    map[string]any{"extra_args": []any{"-s"}},
+			wantField: "extra_args[0]",
+		},
+		{
+			name:      "not an option",
+			config:    map[string]any{"extra_args": []any{"clean"}},
+			wantField: "extra_args[0]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]any{"group_id": "com.example", "artifact_id": "my-app"}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+
+			p := &MavenPlugin{}
+			resp, err := p.Validate(context.Background(), config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantField == "" {
+				if !resp.Valid {
+					t.Errorf("expected valid config, got errors: %v", resp.Errors)
+				}
+				return
+			}
+			found := false
+			for _, e := range resp.Errors {
+				if e.Field == tt.wantField {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("expected error on field %q, got %v", tt.wantField, resp.Errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+// Package main implements rendering the JDK toolchains declared in the configuration.
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+)
+
+// Toolchain is a JDK of toolchains, rendered into the global toolchains.xml
+// of the deploy.
+type Toolchain struct {
+	// Version is the JDK version the toolchain provides, e.g. 17.
+	Version string
+	// Vendor is the JDK vendor, e.g. temurin; empty leaves it unspecified.
+	Vendor string
+	// JDKHome is the installation directory of the JDK.
+	JDKHome string
+}
+
+// toolchainVersionPattern matches the version and vendor of a toolchain.
+var toolchainVersionPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._+-]*$`)
+
+// toolchainsDocument is a Maven toolchains.xml document.
+type toolchainsDocument struct {
+	XMLName        xml.Name       `xml:"toolchains"`
+	Xmlns          string         `xml:"xmlns,attr"`
+	XmlnsXSI       string         `xml:"xmlns:xsi,attr"`
+	SchemaLocation string         `xml:"xsi:schemaLocation,attr"`
+	Toolchains     []jdkToolchain `xml:"toolchain"`
+}
+
+// jdkToolchain is a toolchain element of type jdk.
+type jdkToolchain struct {
+	Type     string `xml:"type"`
+	Provides struct {
+		Version string `xml:"version"`
+		Vendor  string `xml:"vendor,omitempty"`
+	} `xml:"provides"`
+	JDKHome string `xml:"configuration>jdkHome"`
+}
+
+// parseToolchains parses the toolchains list from the raw configuration.
+func parseToolchains(raw map[string]any) []Toolchain {
+	items, ok := raw["toolchains"].([]any)
+	if !ok {
+		return nil
+	}
+	toolchains := make([]Toolchain, 0, len(items))
+	for _, item := range items {
+		entry, _ := item.(map[string]any)
+		parser := helpers.NewConfigParser(entry)
+		toolchains = append(toolchains, Toolchain{
+			Version: parser.GetString("version", "", ""),
+			Vendor:  parser.GetString("vendor", "", ""),
+			JDKHome: parser.GetString("jdk_home", "", ""),
+		})
+	}
+	return toolchains
+}
+
+// validateToolchain validates a single toolchains entry.
+func validateToolchain(toolchain Toolchain) (string, error) {
+	if !toolchainVersionPattern.MatchString(toolchain.Version) {
+		return "version", fmt.Errorf("version must be a JDK version, e.g. 17")
+	}
+	if toolchain.Vendor != "" && !toolchainVersionPattern.MatchString(toolchain.Vendor) {
+		return "vendor", fmt.Errorf("vendor must be a JDK vendor name, e.g. temurin")
+	}
+	if !filepath.IsAbs(toolchain.JDKHome) {
+		return "jdk_home", fmt.Errorf("jdk_home must be an absolute path")
+	}
+	return "", nil
+}
+
+// renderToolchains renders a toolchains.xml document declaring the JDKs.
+func renderToolchains(toolchains []Toolchain) ([]byte, error) {
+	doc := toolchainsDocument{
+		Xmlns:          "http://maven.apache.org/TOOLCHAINS/1.1.0",
+		XmlnsXSI:       "http://www.w3.org/2001/XMLSchema-instance",
+		SchemaLocation: "http://maven.apache.org/TOOLCHAINS/1.1.0 https://maven.apache.org/xsd/toolchains-1.1.0.xsd",
+	}
+	for _, toolchain := range toolchains {
+		entry := jdkToolchain{Type: "jdk", JDKHome: toolchain.JDKHome}
+		entry.Provides.Version = toolchain.Version
+		entry.Provides.Vendor = toolchain.Vendor
+		doc.Toolchains = append(doc.Toolchains, entry)
+	}
+
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to render toolchains: %w", err)
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+// checkToolchains checks that the JDK of every toolchain is installed.
+func (cfg *Config) checkToolchains() error {
+	for _, toolchain := range cfg.Toolchains {
+		if info, err := os.Stat(toolchain.JDKHome); err != nil || !info.IsDir() {
+			return fmt.Errorf("jdk_home %s of the JDK %s toolchain is not a directory", toolchain.JDKHome, toolchain.Version)
+		}
+	}
+	return nil
+}
+
+// writeToolchains writes the toolchains.xml of the configured JDKs to a
+// temporary file and returns its path with a function removing it. Dry runs
+// only name the file.
+func (cfg *Config) writeToolchains(dryRun bool) (string, func(), error) {
+	if dryRun {
+		return filepath.Join(os.TempDir(), "relicta-maven-toolchains.xml"), func() {}, nil
+	}
+	data, err := renderToolchains(cfg.Toolchains)
+	if err != nil {
+		return "", nil, err
+	}
+	f, err := os.CreateTemp("", "relicta-maven-toolchains-*.xml")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create toolchains file: %w", err)
+	}
+	cleanup := func() { _ = os.Remove(f.Name()) }
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		cleanup()
+		return "", nil, fmt.Errorf("failed to write toolchains file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("failed to write toolchains file: %w", err)
+	}
+	return f.Name(), cleanup, nil
+}
+
+// withToolchainsFile returns the configuration passing the toolchains.xml at
+// path to every Maven run as the global toolchains.
+func (cfg *Config) withToolchainsFile(path string) *Config {
+	c := *cfg
+	c.toolchainsFile = path
+	return &c
+}
@@ -0,0 +1,186 @@
+// Package main provides tests for the JDK toolchains declared in the configuration.
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestRenderToolchains(t *testing.T) {
+	data, err := renderToolchains([]Toolchain{
+		{Version: "17", Vendor: "temurin", JDKHome: "/opt/jdk-17"},
+		{Version: "21", JDKHome: "/opt/jdk-21"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := string(data)
+	for _, want := range []string{
+		`<toolchains xmlns="http://maven.apache.org/TOOLCHAINS/1.1.0"`,
+		"<type>jdk</type>",
+		"<version>17</version>",
+		"<vendor>temurin</vendor>",
+		"<jdkHome>/opt/jdk-17</jdkHome>",
+		"<version>21</version>",
+		"<configuration>\n      <jdkHome>/opt/jdk-21</jdkHome>\n    </configuration>",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in:\n%s", want, got)
+		}
+	}
+	if strings.Count(got, "<vendor>") != 1 {
+		t.Errorf("expected no vendor for the second toolchain:\n%s", got)
+	}
+}
+
+func TestExecuteToolchains(t *testing.T) {
+	jdkHome := t.TempDir()
+
+	var toolchainsFile, content string
+	mockExec := &MockCommandExecutor{
+		RunFunc: func(ctx context.Context, name string, args ...string) ([]byte, error) {
+			for i, arg := range args {
+				if arg == "--global-toolchains" && i+1 < len(args) {
+					toolchainsFile = args[i+1]
+					data, err := os.ReadFile(toolchainsFile)
+					if err != nil {
+						t.Fatalf("expected the toolchains file during the deploy: %v", err)
+					}
+					content = string(data)
+				}
+			}
+			return []byte("[INFO] BUILD SUCCESS"), nil
+		},
+	}
+	resp, err := (&MavenPlugin{executor: mockExec}).Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":    "com.example",
+			"artifact_id": "my-app",
+			"repository":  "http://localhost:8081/repository/maven-releases",
+			"toolchains":  []any{map[string]any{"version": "17", "vendor": "temurin", "jdk_home": jdkHome}},
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+	if toolchainsFile == "" {
+		t.Fatal("expected --global-toolchains in the deploy arguments")
+	}
+	if !strings.Contains(content, "<jdkHome>"+jdkHome+"</jdkHome>") {
+		t.Errorf("expected the JDK home in the toolchains file, got:\n%s", content)
+	}
+	if _, err := os.Stat(toolchainsFile); !os.IsNotExist(err) {
+		t.Errorf("expected the toolchains file to be removed, got %v", err)
+	}
+
+	resp, err = (&MavenPlugin{executor: &MockCommandExecutor{}}).Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":    "com.example",
+			"artifact_id": "my-app",
+			"repository":  "http://localhost:8081/repository/maven-releases",
+			"toolchains":  []any{map[string]any{"version": "17", "jdk_home": filepath.Join(jdkHome, "missing")}},
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Success || !strings.Contains(resp.Error, "toolchains: jdk_home") {
+		t.Errorf("expected a missing JDK error, got %q", resp.Error)
+	}
+}
+
+func TestExecuteToolchainsDryRun(t *testing.T) {
+	resp, err := (&MavenPlugin{executor: &MockCommandExecutor{}}).Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":    "com.example",
+			"artifact_id": "my-app",
+			"repository":  "http://localhost:8081/repository/maven-releases",
+			"toolchains":  []any{map[string]any{"version": "17", "jdk_home": filepath.Join(t.TempDir(), "missing")}},
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+		DryRun:  true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+	if command, _ := resp.Outputs["command"].(string); !strings.Contains(command, "--global-toolchains") {
+		t.Errorf("expected --global-toolchains in %q", command)
+	}
+	warnings, _ := resp.Outputs["warnings"].([]string)
+	if len(warnings) == 0 || !strings.HasPrefix(warnings[0], "toolchains: jdk_home") {
+		t.Errorf("expected a missing JDK warning, got %v", warnings)
+	}
+}
+
+func TestValidateToolchains(t *testing.T) {
+	jdkHome := t.TempDir()
+
+	tests := []struct {
+		name   string
+		config map[string]any
+		field  string
+		errMsg string
+	}{
+		{name: "valid", config: map[string]any{"toolchains": []any{map[string]any{"version": "17", "vendor": "temurin", "jdk_home": jdkHome}, map[string]any{"version": "21", "jdk_home": jdkHome}}}},
+		{name: "not a list", config: map[string]any{"toolchains": "17"}, field: "toolchains", errMsg: "must be a list of objects"},
+		{name: "missing version", config: map[string]any{"toolchains": []any{map[string]any{"jdk_home": jdkHome}}}, field: "toolchains[0].version", errMsg: "version must be a JDK version"},
+		{name: "invalid vendor", config: map[string]any{"toolchains": []any{map[string]any{"version": "17", "vendor": "<oracle>", "jdk_home": jdkHome}}}, field: "toolchains[0].vendor", errMsg: "vendor must be a JDK vendor name"},
+		{name: "relative jdk home", config: map[string]any{"toolchains": []any{map[string]any{"version": "17", "jdk_home": "jdk-17"}}}, field: "toolchains[0].jdk_home", errMsg: "must be an absolute path"},
+		{name: "duplicate", config: map[string]any{"toolchains": []any{map[string]any{"version": "17", "jdk_home": jdkHome}, map[string]any{"version": "17", "jdk_home": jdkHome}}}, field: "toolchains[1].version", errMsg: "duplicate toolchain"},
+		{name: "http deployer", config: map[string]any{"toolchains": []any{map[string]any{"version": "17", "jdk_home": jdkHome}}, "deployer": "http"}, field: "toolchains", errMsg: "requires the maven deployer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]any{
+				"group_id":    "com.example",
+				"artifact_id": "my-app",
+				"repository":  "http://localhost:8081/repository/maven-releases",
+			}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+			resp, err := (&MavenPlugin{}).Validate(context.Background(), config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var messages []string
+			for _, e := range resp.Errors {
+				if strings.HasPrefix(e.Field, "toolchains") {
+					messages = append(messages, e.Field+": "+e.Message)
+				}
+			}
+			if tt.errMsg == "" {
+				if len(messages) > 0 {
+					t.Errorf("unexpected errors: %v", messages)
+				}
+				return
+			}
+			found := false
+			for _, m := range messages {
+				if strings.HasPrefix(m, tt.field+": ") && strings.Contains(m, tt.errMsg) {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("expected %s error containing %q, got %v", tt.field, tt.errMsg, messages)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// Package main implements the transport timeouts of Maven deploys.
+package main
+
+import "fmt"
+
+// maxTransportTimeout is the largest accepted timeout in seconds.
+const maxTransportTimeout = 3600
+
+// serverConfiguration is the <configuration> of a settings.xml server. It
+// carries the timeouts in milliseconds for both the Maven Resolver transport
+// (Maven 3.9+) and the Wagon HTTP transport of older Maven versions.
+type serverConfiguration struct {
+	ConnectTimeout    int                `xml:"connectTimeout,omitempty"`
+	RequestTimeout    int                `xml:"requestTimeout,omitempty"`
+	HTTPConfiguration *wagonHTTPTimeouts `xml:"httpConfiguration>all,omitempty"`
+}
+
+// wagonHTTPTimeouts are the Wagon HTTP timeouts applied to all requests.
+type wagonHTTPTimeouts struct {
+	ConnectionTimeout int `xml:"connectionTimeout,omitempty"`
+	ReadTimeout       int `xml:"readTimeout,omitempty"`
+}
+
+// serverConfiguration returns the server configuration carrying the configured
+// timeouts, or nil when none is set.
+func (cfg *Config) serverConfiguration() *serverConfiguration {
+	if cfg.ConnectTimeout == 0 && cfg.ReadTimeout == 0 {
+		return nil
+	}
+	connect, read := cfg.ConnectTimeout*1000, cfg.ReadTimeout*1000
+	return &serverConfiguration{
+		ConnectTimeout:    connect,
+		RequestTimeout:    read,
+		HTTPConfiguration: &wagonHTTPTimeouts{ConnectionTimeout: connect, ReadTimeout: read},
+	}
+}
+
+// transportArgs returns the resolver timeout properties. Unlike the server
+// configuration, they also apply to repositories without credentials.
+func transportArgs(cfg *Config) []string {
+	var args []string
+	if cfg.ConnectTimeout > 0 {
+		args = append(args, fmt.Sprintf("-Daether.connector.connectTimeout=%d", cfg.ConnectTimeout*1000))
+	}
+	if cfg.ReadTimeout > 0 {
+		args = append(args,
+			fmt.Sprintf("-Daether.connector.requestTimeout=%d", cfg.ReadTimeout*1000),
+			fmt.Sprintf("-Dmaven.wagon.rto=%d", cfg.ReadTimeout*1000))
+	}
+	return args
+}
+
+// validateTransportTimeout validates a timeout in seconds.
+func validateTransportTimeout(seconds int, fieldName string) error {
+	if seconds < 0 || seconds > maxTransportTimeout {
+		return fmt.Errorf("%s must be between 0 and %d seconds", fieldName, maxTransportTimeout)
+	}
+	return nil
+}
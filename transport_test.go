@@ -0,0 +1,57 @@
+// Package main provides tests for the transport timeouts.
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestServerConfigurationRendering(t *testing.T) {
+	cfg := &Config{RepositoryID: "releases", Username: "deployer", Password: "secret", ConnectTimeout: 30, ReadTimeout: 300}
+	data, err := renderSettings(cfg.settingsServers())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content := string(data)
+	for _, want := range []string{
+		"<connectTimeout>30000</connectTimeout>",
+		"<requestTimeout>300000</requestTimeout>",
+		"<httpConfiguration>",
+		"<all>",
+		"<connectionTimeout>30000</connectionTimeout>",
+		"<readTimeout>300000</readTimeout>",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected settings to contain %q, got:\n%s", want, content)
+		}
+	}
+
+	data, err = renderSettings((&Config{RepositoryID: "releases", Username: "deployer"}).settingsServers())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(string(data), "<configuration>") {
+		t.Errorf("expected no server configuration without timeouts, got:\n%s", data)
+	}
+}
+
+func TestBuildMavenCommandTimeouts(t *testing.T) {
+	p := &MavenPlugin{}
+	args, err := p.buildMavenCommand(&Config{PomPath: "pom.xml", ConnectTimeout: 10, ReadTimeout: 120}, plugin.ReleaseContext{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"-Daether.connector.connectTimeout=10000", "-Daether.connector.requestTimeout=120000", "-Dmaven.wagon.rto=120000"} {
+		if !slices.Contains(args, want) {
+			t.Errorf("expected %s in %v", want, args)
+		}
+	}
+
+	if _, err := p.buildMavenCommand(&Config{PomPath: "pom.xml", ReadTimeout: -1}, plugin.ReleaseContext{Version: "1.0.0"}); err == nil {
+		t.Error("expected an error for a negative timeout")
+	}
+}
@@ -0,0 +1,102 @@
+// Package main implements the report of dependency and plugin upgrades available at release time.
+package main
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// updateEntryPattern matches an available update the Versions Maven Plugin
+// lists: "[INFO]   org.slf4j:slf4j-api ........ 1.7.36 -> 2.0.13". Long
+// names put the versions on the next line.
+var updateEntryPattern = regexp.MustCompile(`^\[INFO\]\s+(?:(\S+) \.*\s*)?(\S+) -> (\S+)$`)
+
+// updateNamePattern matches the name of an update whose versions are on the next line.
+var updateNamePattern = regexp.MustCompile(`^\[INFO\]\s+(\S+) \.*$`)
+
+// updatesReport lists the upgrades available for the dependencies and build
+// plugins of the release.
+type updatesReport struct {
+	Dependencies []dependencyChange `json:"dependencies,omitempty"`
+	Plugins      []dependencyChange `json:"plugins,omitempty"`
+}
+
+// empty reports whether the report lists no upgrade.
+func (r *updatesReport) empty() bool {
+	return len(r.Dependencies)+len(r.Plugins) == 0
+}
+
+// updatesReportArgs returns the Maven arguments listing the available
+// dependency and plugin updates of every project of the reactor.
+func updatesReportArgs(cfg *Config) []string {
+	args := []string{"-B", "-f", cfg.PomPath}
+	if path := cfg.userSettings(); path != "" {
+		args = append(args, "-s", path)
+	}
+	args = append(args, cfg.isolationArgs(true)...)
+	return append(args,
+		versionsPlugin+":display-dependency-updates",
+		versionsPlugin+":display-plugin-updates",
+		"-DprocessDependencyManagement=true",
+	)
+}
+
+// parseUpdatesReport reads the updates listed by display-dependency-updates
+// and display-plugin-updates. Updates reported by several modules are listed
+// once; plugins without a groupId belong to org.apache.maven.plugins.
+func parseUpdatesReport(output string) *updatesReport {
+	report := &updatesReport{}
+	var section *[]dependencyChange
+	seen := map[string]bool{}
+	name := ""
+	for _, line := range strings.Split(ansiPattern.ReplaceAllString(output, ""), "\n") {
+		line = strings.TrimRight(line, "\r ")
+		switch {
+		case strings.Contains(line, "The following dependencies in") && strings.HasSuffix(line, "have newer versions:"):
+			section, name = &report.Dependencies, ""
+			continue
+		case strings.Contains(line, "The following plugin updates are available:"):
+			section, name = &report.Plugins, ""
+			continue
+		case section == nil:
+			continue
+		}
+
+		if m := updateEntryPattern.FindStringSubmatch(line); m != nil && (m[1] != "" || name != "") {
+			key := m[1]
+			if key == "" {
+				key = name
+			}
+			if section == &report.Plugins && !strings.Contains(key, ":") {
+				key = defaultPluginGroupID + ":" + key
+			}
+			if !seen[key+" "+m[2]] {
+				seen[key+" "+m[2]] = true
+				*section = append(*section, dependencyChange{Key: key, From: m[2], To: m[3]})
+			}
+			name = ""
+			continue
+		}
+		if m := updateNamePattern.FindStringSubmatch(line); m != nil {
+			name = m[1]
+			continue
+		}
+		section, name = nil, ""
+	}
+	return report
+}
+
+// reportUpdates lists the dependency and plugin upgrades available for the
+// release. It returns nil when none is available.
+func (p *MavenPlugin) reportUpdates(ctx context.Context, cfg *Config) (*updatesReport, error) {
+	output, err := p.runMavenCommand(ctx, cfg, updatesReportArgs(cfg)...)
+	if err != nil {
+		return nil, errors.New(describeExecError(cfg.mavenCommand(), err))
+	}
+	if report := parseUpdatesReport(string(output)); !report.empty() {
+		return report, nil
+	}
+	return nil, nil
+}
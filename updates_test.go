@@ -0,0 +1,114 @@
+// Package main provides tests for the report of dependency and plugin upgrades available at release time.
+package main
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// updatesOutput is the output of display-dependency-updates and
+// display-plugin-updates for a reactor of two modules.
+const updatesOutput = `[INFO] --- versions:2.17.1:display-dependency-updates (default-cli) @ parent ---
+[INFO] The following dependencies in Dependency Management have newer versions:
+[INFO]   com.fasterxml.jackson.core:jackson-databind ........ 2.15.2 -> 2.17.0
+[INFO]   org.apache.commons:commons-lang3-with-a-very-long-artifact-id ...
+[INFO]                                                      3.12.0 -> 3.14.0
+[INFO]
+[INFO] --- versions:2.17.1:display-plugin-updates (default-cli) @ parent ---
+[INFO]
+[INFO] The following plugin updates are available:
+[INFO]   maven-compiler-plugin .............................. 3.11.0 -> 3.13.0
+[INFO]   org.codehaus.mojo:exec-maven-plugin ................. 3.1.0 -> 3.2.0
+[INFO]
+[INFO] All plugins have a version specified.
+[INFO] --- versions:2.17.1:display-dependency-updates (default-cli) @ core ---
+[INFO] The following dependencies in Dependencies have newer versions:
+[INFO]   com.fasterxml.jackson.core:jackson-databind ........ 2.15.2 -> 2.17.0
+[INFO]   org.slf4j:slf4j-api ................................ 1.7.36 -> 2.0.13
+[INFO]
+[INFO] --- versions:2.17.1:display-plugin-updates (default-cli) @ core ---
+[INFO] All plugins with a version specified are using the latest versions.
+[INFO] BUILD SUCCESS
+`
+
+func TestParseUpdatesReport(t *testing.T) {
+	got := parseUpdatesReport(updatesOutput)
+	want := &updatesReport{
+		Dependencies: []dependencyChange{
+			{Key: "com.fasterxml.jackson.core:jackson-databind", From: "2.15.2", To: "2.17.0"},
+			{Key: "org.apache.commons:commons-lang3-with-a-very-long-artifact-id", From: "3.12.0", To: "3.14.0"},
+			{Key: "org.slf4j:slf4j-api", From: "1.7.36", To: "2.0.13"},
+		},
+		Plugins: []dependencyChange{
+			{Key: "org.apache.maven.plugins:maven-compiler-plugin", From: "3.11.0", To: "3.13.0"},
+			{Key: "org.codehaus.mojo:exec-maven-plugin", From: "3.1.0", To: "3.2.0"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseUpdatesReport() = %+v, want %+v", got, want)
+	}
+
+	if report := parseUpdatesReport("[INFO] No dependencies in Dependencies have newer versions.\n[INFO] BUILD SUCCESS"); !report.empty() {
+		t.Errorf("expected an empty report, got %+v", report)
+	}
+}
+
+func TestExecuteUpdatesReport(t *testing.T) {
+	tests := []struct {
+		name        string
+		output      string
+		err         error
+		want        *updatesReport
+		wantWarning string
+	}{
+		{
+			name:   "updates available",
+			output: "[INFO] The following dependencies in Dependencies have newer versions:\n[INFO]   org.slf4j:slf4j-api ........ 1.7.36 -> 2.0.13\n",
+			want:   &updatesReport{Dependencies: []dependencyChange{{Key: "org.slf4j:slf4j-api", From: "1.7.36", To: "2.0.13"}}},
+		},
+		{name: "up to date", output: "[INFO] BUILD SUCCESS"},
+		{name: "failed", output: "[ERROR] Could not transfer metadata", err: errors.New("exit status 1"), wantWarning: "updates report failed: "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockExec := &MockCommandExecutor{
+				RunFunc: func(ctx context.Context, name string, args ...string) ([]byte, error) {
+					if strings.Contains(strings.Join(args, " "), versionsPlugin+":display-dependency-updates "+versionsPlugin+":display-plugin-updates") {
+						return []byte(tt.output), tt.err
+					}
+					return []byte("[INFO] BUILD SUCCESS"), nil
+				},
+			}
+			resp, err := (&MavenPlugin{executor: mockExec}).Execute(context.Background(), plugin.ExecuteRequest{
+				Hook: plugin.HookPostPublish,
+				Config: map[string]any{
+					"group_id":       "com.example",
+					"artifact_id":    "my-app",
+					"repository":     "http://localhost:8081/repository/maven-releases",
+					"updates_report": true,
+				},
+				Context: plugin.ReleaseContext{Version: "1.0.0"},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !resp.Success {
+				t.Fatalf("expected success, got error: %s", resp.Error)
+			}
+			report, _ := resp.Outputs["available_updates"].(*updatesReport)
+			if tt.want == nil && report != nil || tt.want != nil && !reflect.DeepEqual(report, tt.want) {
+				t.Errorf("expected available_updates %+v, got %+v", tt.want, resp.Outputs["available_updates"])
+			}
+			warnings, _ := resp.Outputs["warnings"].([]string)
+			if tt.wantWarning != "" && (len(warnings) != 1 || !strings.HasPrefix(warnings[0], tt.wantWarning)) {
+				t.Errorf("expected warning %q, got %v", tt.wantWarning, warnings)
+			}
+		})
+	}
+}
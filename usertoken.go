@@ -0,0 +1,113 @@
+// Package main implements exchanging Sonatype credentials for a user token.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// userTokenPath is the Nexus Repository endpoint returning the user token of
+// the authenticated user.
+const userTokenPath = "/service/local/usertoken/current"
+
+// maxUserTokenResponse limits the size of a user token response.
+const maxUserTokenResponse = 64 << 10
+
+// userToken is a Sonatype user token: a name code and pass code that replace
+// the username and password.
+type userToken struct {
+	NameCode string `json:"nameCode"`
+	PassCode string `json:"passCode"`
+}
+
+// userTokenURL returns the user token endpoint. Unless user_token_url is set,
+// it is derived from the repository URL: the Nexus context path is kept when
+// the URL points below /service/local/.
+func (cfg *Config) userTokenURL() (string, error) {
+	if cfg.UserTokenURL != "" {
+		return cfg.UserTokenURL, nil
+	}
+
+	repository := cfg.Repository
+	if repository == "" {
+		repository = cfg.SnapshotRepository
+	}
+	if repository == "" {
+		return "", fmt.Errorf("user_token requires a repository or user_token_url")
+	}
+	parsedURL, err := url.Parse(repository)
+	if err != nil {
+		return "", fmt.Errorf("invalid repository URL: %w", err)
+	}
+
+	base := ""
+	if i := strings.Index(parsedURL.Path, "/service/local/"); i >= 0 {
+		base = parsedURL.Path[:i]
+	}
+	return (&url.URL{Scheme: parsedURL.Scheme, Host: parsedURL.Host, Path: base + userTokenPath}).String(), nil
+}
+
+// fetchUserToken exchanges the configured username and password for the
+// user's token.
+func (p *MavenPlugin) fetchUserToken(ctx context.Context, cfg *Config) (*userToken, error) {
+	if cfg.Username == "" || cfg.Password == "" {
+		return nil, fmt.Errorf("user_token requires a username and password")
+	}
+	tokenURL, err := cfg.userTokenURL()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.SetBasicAuth(cfg.Username, cfg.Password)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.getHTTPClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("GET %s failed: %w", redactURL(tokenURL), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &statusError{op: "user token request", status: resp.StatusCode, text: resp.Status}
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxUserTokenResponse))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read user token: %w", err)
+	}
+	// Nexus wraps some REST responses in a data element.
+	var body struct {
+		userToken
+		Data *userToken `json:"data"`
+	}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return nil, fmt.Errorf("failed to parse user token: %w", err)
+	}
+	token := body.userToken
+	if body.Data != nil {
+		token = *body.Data
+	}
+	if token.NameCode == "" || token.PassCode == "" {
+		return nil, fmt.Errorf("user token response has no name code or pass code")
+	}
+	return &token, nil
+}
+
+// withUserToken returns a copy of the configuration that authenticates with
+// the user token. Snapshot credentials that fall back to the release
+// credentials use the token as well.
+func (cfg *Config) withUserToken(token *userToken) *Config {
+	c := *cfg
+	c.Username = token.NameCode
+	c.Password = token.PassCode
+	return &c
+}
@@ -0,0 +1,168 @@
+// Package main provides tests for the Sonatype user token exchange.
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestUserTokenURL(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		want string
+	}{
+		{
+			name: "staging deploy URL",
+			cfg:  Config{Repository: "https://s01.oss.sonatype.org/service/local/staging/deploy/maven2/"},
+			want: "https://s01.oss.sonatype.org/service/local/usertoken/current",
+		},
+		{
+			name: "context path",
+			cfg:  Config{Repository: "https://repo.example.com/nexus/service/local/staging/deploy/maven2"},
+			want: "https://repo.example.com/nexus/service/local/usertoken/current",
+		},
+		{
+			name: "repository URL",
+			cfg:  Config{Repository: "https://repo.example.com/repository/maven-releases"},
+			want: "https://repo.example.com/service/local/usertoken/current",
+		},
+		{
+			name: "snapshot repository only",
+			cfg:  Config{SnapshotRepository: "https://repo.example.com/repository/maven-snapshots"},
+			want: "https://repo.example.com/service/local/usertoken/current",
+		},
+		{
+			name: "override",
+			cfg:  Config{Repository: "https://repo.example.com/repository/maven-releases", UserTokenURL: "https://tokens.example.com/current"},
+			want: "https://tokens.example.com/current",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.cfg.userTokenURL()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+
+	if _, err := (&Config{}).userTokenURL(); err == nil {
+		t.Error("expected an error without a repository")
+	}
+}
+
+// userTokenServer serves a user token to the deployer/secret account.
+func userTokenServer(t *testing.T, body string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != userTokenPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if user, pass, ok := r.BasicAuth(); !ok || user != "deployer" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Header.Get("Accept") != "application/json" {
+			w.WriteHeader(http.StatusNotAcceptable)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFetchUserToken(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		password string
+		errMsg   string
+	}{
+		{name: "plain", body: `{"nameCode":"abc","passCode":"xyz","created":"2024-01-01"}`, password: "secret"},
+		{name: "wrapped", body: `{"data":{"nameCode":"abc","passCode":"xyz"}}`, password: "secret"},
+		{name: "rejected", body: `{}`, password: "wrong", errMsg: "401"},
+		{name: "empty token", body: `{"nameCode":""}`, password: "secret", errMsg: "no name code"},
+		{name: "missing password", body: `{}`, errMsg: "requires a username and password"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := userTokenServer(t, tt.body)
+			p := &MavenPlugin{httpClient: server.Client()}
+			cfg := &Config{Repository: server.URL + "/service/local/staging/deploy/maven2", Username: "deployer", Password: tt.password}
+
+			token, err := p.fetchUserToken(context.Background(), cfg)
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Errorf("expected error containing %q, got %v", tt.errMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if token.NameCode != "abc" || token.PassCode != "xyz" {
+				t.Errorf("unexpected token: %+v", token)
+			}
+		})
+	}
+}
+
+func TestExecuteUserToken(t *testing.T) {
+	server := userTokenServer(t, `{"nameCode":"token-name","passCode":"token-pass"}`)
+
+	var settings string
+	mockExec := &MockCommandExecutor{
+		RunFunc: func(_ context.Context, _ string, args ...string) ([]byte, error) {
+			for i, arg := range args {
+				if arg == "-s" && i+1 < len(args) {
+					data, err := os.ReadFile(args[i+1])
+					if err != nil {
+						return nil, err
+					}
+					settings = string(data)
+				}
+			}
+			return []byte("[INFO] BUILD SUCCESS"), nil
+		},
+	}
+	p := &MavenPlugin{executor: mockExec, httpClient: server.Client()}
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"group_id":    "com.example",
+			"artifact_id": "my-app",
+			"repository":  server.URL + "/service/local/staging/deploy/maven2",
+			"username":    "deployer",
+			"password":    "secret",
+			"user_token":  true,
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+	if !strings.Contains(settings, "<username>token-name</username>") || !strings.Contains(settings, "<password>token-pass</password>") {
+		t.Errorf("expected the user token in settings, got %s", settings)
+	}
+	if strings.Contains(settings, "secret") {
+		t.Errorf("expected the raw password to stay out of settings, got %s", settings)
+	}
+}
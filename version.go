@@ -0,0 +1,273 @@
+// Package main implements Maven version ordering for the Maven plugin.
+package main
+
+import (
+	"strconv"
+	"strings"
+)
+
+// mavenQualifiers lists the well-known qualifiers in ascending order; "" is a release.
+var mavenQualifiers = []string{"alpha", "beta", "milestone", "rc", "snapshot", "", "sp"}
+
+// mavenQualifierAliases maps qualifier aliases to their canonical form.
+var mavenQualifierAliases = map[string]string{
+	"ga":      "",
+	"final":   "",
+	"release": "",
+	"cr":      "rc",
+}
+
+// releaseQualifierIndex is the comparable form of the release ("") qualifier.
+var releaseQualifierIndex = comparableQualifier("")
+
+// versionItem is one element of a parsed Maven version.
+type versionItem interface {
+	// compare compares the item with other, where other may be nil.
+	compare(other versionItem) int
+	isNull() bool
+}
+
+// intItem is a numeric version element, stored without leading zeros.
+type intItem string
+
+// stringItem is a qualifier version element.
+type stringItem string
+
+// listItem is a sub-list of version elements, started by '-' or a digit/letter transition.
+type listItem []versionItem
+
+func newStringItem(value string, followedByDigit bool) stringItem {
+	if followedByDigit && len(value) == 1 {
+		switch value {
+		case "a":
+			value = "alpha"
+		case "b":
+			value = "beta"
+		case "m":
+			value = "milestone"
+		}
+	}
+	if alias, ok := mavenQualifierAliases[value]; ok {
+		value = alias
+	}
+	return stringItem(value)
+}
+
+func newIntItem(digits string) intItem {
+	digits = strings.TrimLeft(digits, "0")
+	if digits == "" {
+		digits = "0"
+	}
+	return intItem(digits)
+}
+
+func (i intItem) isNull() bool { return i == "0" }
+
+func (i intItem) compare(other versionItem) int {
+	switch o := other.(type) {
+	case nil:
+		if i.isNull() {
+			return 0
+		}
+		return 1
+	case intItem:
+		if len(i) != len(o) {
+			if len(i) < len(o) {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(string(i), string(o))
+	default:
+		return 1
+	}
+}
+
+// comparableQualifier returns a string that sorts qualifiers in Maven order.
+func comparableQualifier(q string) string {
+	for i, known := range mavenQualifiers {
+		if q == known {
+			return strconv.Itoa(i)
+		}
+	}
+	return strconv.Itoa(len(mavenQualifiers)) + "-" + q
+}
+
+func (s stringItem) isNull() bool { return comparableQualifier(string(s)) == releaseQualifierIndex }
+
+func (s stringItem) compare(other versionItem) int {
+	switch o := other.(type) {
+	case nil:
+		return strings.Compare(comparableQualifier(string(s)), releaseQualifierIndex)
+	case stringItem:
+		return strings.Compare(comparableQualifier(string(s)), comparableQualifier(string(o)))
+	default:
+		return -1
+	}
+}
+
+func (l listItem) isNull() bool { return len(l) == 0 }
+
+func (l listItem) compare(other versionItem) int {
+	switch o := other.(type) {
+	case nil:
+		if len(l) == 0 {
+			return 0
+		}
+		return l[0].compare(nil)
+	case intItem:
+		return -1
+	case stringItem:
+		return 1
+	case listItem:
+		for i := 0; i < len(l) || i < len(o); i++ {
+			var left, right versionItem
+			if i < len(l) {
+				left = l[i]
+			}
+			if i < len(o) {
+				right = o[i]
+			}
+			var result int
+			switch {
+			case left == nil && right == nil:
+				result = 0
+			case left == nil:
+				result = -right.compare(nil)
+			default:
+				result = left.compare(right)
+			}
+			if result != 0 {
+				return result
+			}
+		}
+		return 0
+	}
+	return 0
+}
+
+// normalize removes trailing null items, stopping at the first non-null non-list item.
+func (l listItem) normalize() listItem {
+	for i := len(l) - 1; i >= 0; i-- {
+		if l[i].isNull() {
+			l = append(l[:i], l[i+1:]...)
+		} else if _, ok := l[i].(listItem); !ok {
+			break
+		}
+	}
+	return l
+}
+
+// parseMavenVersion parses a version string into Maven's comparable item tree.
+func parseMavenVersion(version string) listItem {
+	version = strings.ToLower(version)
+
+	// Lists are built through pointers so nested lists can be appended to after
+	// they have been attached to their parent.
+	type node struct {
+		items []versionItem
+		subs  map[int]*node
+	}
+	root := &node{subs: map[int]*node{}}
+	current := root
+
+	pushList := func() {
+		child := &node{subs: map[int]*node{}}
+		current.subs[len(current.items)] = child
+		current.items = append(current.items, nil)
+		current = child
+	}
+	parseItem := func(isDigit bool, buf string) versionItem {
+		if isDigit {
+			return newIntItem(buf)
+		}
+		return newStringItem(buf, false)
+	}
+
+	isDigit := false
+	start := 0
+	for i := 0; i < len(version); i++ {
+		c := version[i]
+		switch {
+		case c == '.':
+			if i == start {
+				current.items = append(current.items, intItem("0"))
+			} else {
+				current.items = append(current.items, parseItem(isDigit, version[start:i]))
+			}
+			start = i + 1
+		case c == '-':
+			if i == start {
+				current.items = append(current.items, intItem("0"))
+			} else {
+				current.items = append(current.items, parseItem(isDigit, version[start:i]))
+			}
+			start = i + 1
+			pushList()
+		case c >= '0' && c <= '9':
+			if !isDigit && i > start {
+				current.items = append(current.items, newStringItem(version[start:i], true))
+				start = i
+				pushList()
+			}
+			isDigit = true
+		default:
+			if isDigit && i > start {
+				current.items = append(current.items, parseItem(true, version[start:i]))
+				start = i
+				pushList()
+			}
+			isDigit = false
+		}
+	}
+	if len(version) > start {
+		current.items = append(current.items, parseItem(isDigit, version[start:]))
+	}
+
+	// Resolve nested lists bottom-up, normalizing each as Maven does.
+	var build func(n *node) listItem
+	build = func(n *node) listItem {
+		result := make(listItem, 0, len(n.items))
+		for i, item := range n.items {
+			if sub, ok := n.subs[i]; ok {
+				result = append(result, build(sub))
+				continue
+			}
+			result = append(result, item)
+		}
+		return result.normalize()
+	}
+	return build(root)
+}
+
+// compareMavenVersions compares two versions using Maven's ordering rules.
+// It returns -1 if a < b, 0 if they are equivalent, and 1 if a > b.
+func compareMavenVersions(a, b string) int {
+	result := parseMavenVersion(a).compare(parseMavenVersion(b))
+	switch {
+	case result < 0:
+		return -1
+	case result > 0:
+		return 1
+	}
+	return 0
+}
+
+// latestMavenVersion returns the highest version according to Maven ordering.
+func latestMavenVersion(versions []string) string {
+	latest := ""
+	for _, v := range versions {
+		if latest == "" || compareMavenVersions(v, latest) > 0 {
+			latest = v
+		}
+	}
+	return latest
+}
+
+// mavenVersion converts a release version to its Maven form by dropping a "v" tag prefix.
+func mavenVersion(version string) string {
+	if len(version) > 1 && (version[0] == 'v' || version[0] == 'V') && version[1] >= '0' && version[1] <= '9' {
+		return version[1:]
+	}
+	return version
+}
@@ -0,0 +1,74 @@
+// Package main provides tests for Maven version ordering.
+package main
+
+import "testing"
+
+func TestCompareMavenVersionsOrdering(t *testing.T) {
+	// Each sequence is strictly ascending, following Maven's ComparableVersion tests.
+	sequences := [][]string{
+		{"1-alpha2snapshot", "1-alpha2", "1-alpha-123", "1-beta-2", "1-beta123", "1-m2", "1-m11", "1-rc", "1-cr2", "1-rc123", "1-snapshot", "1", "1-sp", "1-sp2", "1-sp123", "1-abc", "1-def", "1-pom-1", "1-1-snapshot", "1-1", "1-2", "1-123"},
+		{"2.0-alpha-1", "2.0-alpha-2", "2.0-beta-1", "2.0-milestone-1", "2.0-rc-1", "2.0-snapshot", "2.0", "2.0-sp1"},
+		{"1.2", "1.10", "1.10.1", "2", "10"},
+	}
+
+	for _, seq := range sequences {
+		for i := 0; i+1 < len(seq); i++ {
+			low, high := seq[i], seq[i+1]
+			if got := compareMavenVersions(low, high); got != -1 {
+				t.Errorf("compareMavenVersions(%s, %s): expected -1, got %d", low, high, got)
+			}
+			if got := compareMavenVersions(high, low); got != 1 {
+				t.Errorf("compareMavenVersions(%s, %s): expected 1, got %d", high, low, got)
+			}
+		}
+	}
+}
+
+func TestCompareMavenVersionsEquality(t *testing.T) {
+	equal := [][2]string{
+		{"1", "1.0"},
+		{"1", "1.0.0"},
+		{"1.0", "1.0.0"},
+		{"1-0", "1"},
+		{"1-ga", "1"},
+		{"1-final", "1"},
+		{"1.0-release", "1"},
+		{"1-a1", "1-alpha-1"},
+		{"1-b2", "1-beta-2"},
+		{"1-m3", "1-milestone-3"},
+		{"1-cr1", "1-rc1"},
+		{"1X", "1x"},
+		{"1.0.01", "1.0.1"},
+	}
+
+	for _, pair := range equal {
+		if got := compareMavenVersions(pair[0], pair[1]); got != 0 {
+			t.Errorf("compareMavenVersions(%s, %s): expected 0, got %d", pair[0], pair[1], got)
+		}
+	}
+}
+
+func TestLatestMavenVersion(t *testing.T) {
+	versions := []string{"1.0.0", "1.10.0", "1.9.0", "2.0.0-rc1", "1.10.0-SNAPSHOT"}
+	if got := latestMavenVersion(versions); got != "2.0.0-rc1" {
+		t.Errorf("expected 2.0.0-rc1, got %s", got)
+	}
+	if got := latestMavenVersion(nil); got != "" {
+		t.Errorf("expected empty latest version, got %s", got)
+	}
+}
+
+func TestMavenVersion(t *testing.T) {
+	tests := map[string]string{
+		"v1.2.3":  "1.2.3",
+		"V2.0":    "2.0",
+		"1.2.3":   "1.2.3",
+		"vendor1": "vendor1",
+		"v":       "v",
+	}
+	for in, expected := range tests {
+		if got := mavenVersion(in); got != expected {
+			t.Errorf("mavenVersion(%s): expected %s, got %s", in, expected, got)
+		}
+	}
+}
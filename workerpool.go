@@ -0,0 +1,58 @@
+// Package main implements the bounded worker pool of concurrent deploys.
+package main
+
+import (
+	"fmt"
+	"sync"
+)
+
+// defaultMaxParallel is the number of concurrent deploys when max_parallel is not set.
+const defaultMaxParallel = 4
+
+// maxParallelLimit bounds max_parallel.
+const maxParallelLimit = 16
+
+// validateMaxParallel validates the number of concurrent deploys.
+func validateMaxParallel(workers int) error {
+	if workers < 1 || workers > maxParallelLimit {
+		return fmt.Errorf("max_parallel must be between 1 and %d", maxParallelLimit)
+	}
+	return nil
+}
+
+// runPool calls fn for the indexes 0 to n-1 on at most workers goroutines and
+// waits for every call to return.
+func runPool(n, workers int, fn func(i int)) {
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > n {
+		workers = n
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
+
+// splitWorkers divides the workers of a pool running n jobs between the jobs,
+// so nested pools stay within the bound. Every job gets at least one worker.
+func splitWorkers(workers, n int) int {
+	if n < 1 || workers <= n {
+		return 1
+	}
+	return workers / n
+}
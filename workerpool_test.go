@@ -0,0 +1,54 @@
+// Package main provides tests for the bounded worker pool of concurrent deploys.
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunPool(t *testing.T) {
+	var running, peak atomic.Int32
+	var mu sync.Mutex
+	seen := map[int]bool{}
+
+	runPool(10, 3, func(i int) {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		running.Add(-1)
+
+		mu.Lock()
+		seen[i] = true
+		mu.Unlock()
+	})
+
+	if len(seen) != 10 {
+		t.Errorf("expected every index to run, got %v", seen)
+	}
+	if peak.Load() > 3 {
+		t.Errorf("expected at most 3 concurrent calls, got %d", peak.Load())
+	}
+}
+
+func TestSplitWorkers(t *testing.T) {
+	tests := []struct {
+		workers, n, want int
+	}{
+		{workers: 4, n: 2, want: 2},
+		{workers: 4, n: 3, want: 1},
+		{workers: 4, n: 6, want: 1},
+		{workers: 16, n: 3, want: 5},
+	}
+	for _, tt := range tests {
+		if got := splitWorkers(tt.workers, tt.n); got != tt.want {
+			t.Errorf("splitWorkers(%d, %d) = %d, want %d", tt.workers, tt.n, got, tt.want)
+		}
+	}
+}